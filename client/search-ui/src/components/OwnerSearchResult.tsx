@@ -0,0 +1,66 @@
+import React from 'react'
+
+import AccountIcon from 'mdi-react/AccountIcon'
+
+import { getOwnerMatchUrl, OwnerMatch } from '@sourcegraph/shared/src/search/stream'
+import { Link } from '@sourcegraph/wildcard'
+
+import { ResultContainer } from './ResultContainer'
+
+import styles from './SearchResult.module.scss'
+
+export interface OwnerSearchResultProps {
+    result: OwnerMatch
+    onSelect: () => void
+    containerClassName?: string
+    as?: React.ElementType
+    index: number
+}
+
+const ownerTypeLabel: Record<OwnerMatch['ownerType'], string> = {
+    username: 'User',
+    team: 'Team',
+    email: 'Email',
+}
+
+export const OwnerSearchResult: React.FunctionComponent<OwnerSearchResultProps> = ({
+    result,
+    onSelect,
+    containerClassName,
+    as,
+    index,
+}) => {
+    const renderTitle = (): JSX.Element => (
+        <div className={styles.title}>
+            <span className={styles.titleInner}>
+                <Link to={getOwnerMatchUrl(result)}>{result.handle}</Link>
+            </span>
+        </div>
+    )
+
+    const renderBody = (): JSX.Element => (
+        <div data-testid="search-owner-result">
+            <div className={styles.searchResultMatch}>
+                <div className={styles.matchType}>
+                    <small>Owner match · {ownerTypeLabel[result.ownerType]}</small>
+                </div>
+            </div>
+        </div>
+    )
+
+    return (
+        <ResultContainer
+            index={index}
+            icon={AccountIcon}
+            collapsible={false}
+            defaultExpanded={true}
+            title={renderTitle()}
+            resultType={result.type}
+            onResultClicked={onSelect}
+            expandedChildren={renderBody()}
+            repoName=""
+            className={containerClassName}
+            as={as}
+        />
+    )
+}
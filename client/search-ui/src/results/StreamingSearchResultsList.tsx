@@ -10,7 +10,13 @@ import { Observable } from 'rxjs'
 import { HoverMerged } from '@sourcegraph/client-api'
 import { Hoverifier } from '@sourcegraph/codeintellify'
 import { SearchContextProps } from '@sourcegraph/search'
-import { CommitSearchResult, RepoSearchResult, FileSearchResult, FetchFileParameters } from '@sourcegraph/search-ui'
+import {
+    CommitSearchResult,
+    RepoSearchResult,
+    FileSearchResult,
+    FetchFileParameters,
+    OwnerSearchResult,
+} from '@sourcegraph/search-ui'
 import { ActionItemAction } from '@sourcegraph/shared/src/actions/ActionItem'
 import { AuthenticatedUser } from '@sourcegraph/shared/src/auth'
 import { displayRepoName } from '@sourcegraph/shared/src/components/RepoLink'
@@ -180,6 +186,16 @@ export const StreamingSearchResultsList: React.FunctionComponent<
                             as="li"
                         />
                     )
+                case 'owner':
+                    return (
+                        <OwnerSearchResult
+                            index={index}
+                            result={result}
+                            onSelect={() => logSearchResultClicked(index, 'owner')}
+                            containerClassName={resultClassName}
+                            as="li"
+                        />
+                    )
             }
         },
         [
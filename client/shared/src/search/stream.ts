@@ -29,7 +29,7 @@ export type SearchEvent =
     | { type: 'error'; data: ErrorLike }
     | { type: 'done'; data: {} }
 
-export type SearchMatch = ContentMatch | RepositoryMatch | CommitMatch | SymbolMatch | PathMatch
+export type SearchMatch = ContentMatch | RepositoryMatch | CommitMatch | SymbolMatch | PathMatch | OwnerMatch
 
 export interface PathMatch {
     type: 'path'
@@ -139,6 +139,16 @@ export interface RepositoryMatch {
     descriptionMatches?: Range[]
 }
 
+/**
+ * A code owner of the files matching a search, as selected by select:file.owners.
+ */
+export interface OwnerMatch {
+    type: 'owner'
+    // The owner as it is written in the ownership files.
+    handle: string
+    ownerType: 'username' | 'team' | 'email'
+}
+
 /**
  * An aggregate type representing a progress update.
  * Should be replaced when a new ones come in.
@@ -536,6 +546,13 @@ export function getCommitMatchUrl(commitMatch: CommitMatch): string {
     return '/' + encodeURI(commitMatch.repository) + '/-/commit/' + commitMatch.oid
 }
 
+export function getOwnerMatchUrl(ownerMatch: OwnerMatch): string {
+    if (ownerMatch.ownerType === 'email') {
+        return 'mailto:' + ownerMatch.handle
+    }
+    return '/search?q=' + encodeURIComponent(`file:has.owner(${ownerMatch.handle})`)
+}
+
 export function getMatchUrl(match: SearchMatch): string {
     switch (match.type) {
         case 'path':
@@ -546,6 +563,8 @@ export function getMatchUrl(match: SearchMatch): string {
             return getCommitMatchUrl(match)
         case 'repo':
             return getRepoMatchUrl(match)
+        case 'owner':
+            return getOwnerMatchUrl(match)
     }
 }
 
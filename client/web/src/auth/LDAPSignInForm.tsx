@@ -0,0 +1,120 @@
+import React, { useCallback, useMemo, useState } from 'react'
+
+import { uniqueId } from 'lodash'
+import { useLocation } from 'react-router-dom-v5-compat'
+
+import { Form } from '@sourcegraph/branded/src/components/Form'
+import { asError } from '@sourcegraph/common'
+import { Label, Button, LoadingSpinner, Text, Input } from '@sourcegraph/wildcard'
+
+import { AuthProvider, SourcegraphContext } from '../jscontext'
+import { eventLogger } from '../tracking/eventLogger'
+
+import { getReturnTo, PasswordInput } from './SignInSignUpCommon'
+
+interface Props {
+    provider: AuthProvider
+    onAuthError: (error: Error | null) => void
+    context: Pick<SourcegraphContext, 'xhrHeaders'>
+}
+
+/**
+ * The form for signing in with the username and password of an account in an LDAP directory.
+ */
+export const LDAPSignInForm: React.FunctionComponent<React.PropsWithChildren<Props>> = ({
+    provider,
+    onAuthError,
+    context,
+}) => {
+    const location = useLocation()
+    const [username, setUsername] = useState('')
+    const [password, setPassword] = useState('')
+    const [loading, setLoading] = useState(false)
+    // There may be multiple LDAP providers (and the builtin provider) on the sign-in page.
+    const idPrefix = useMemo(() => uniqueId('ldap-'), [])
+
+    const onUsernameFieldChange = useCallback((event: React.ChangeEvent<HTMLInputElement>): void => {
+        setUsername(event.target.value)
+    }, [])
+
+    const onPasswordFieldChange = useCallback((event: React.ChangeEvent<HTMLInputElement>): void => {
+        setPassword(event.target.value)
+    }, [])
+
+    const handleSubmit = useCallback(
+        (event: React.FormEvent<HTMLFormElement>): void => {
+            event.preventDefault()
+            if (loading || !provider.authenticationURL) {
+                return
+            }
+
+            setLoading(true)
+            eventLogger.log('InitiateSignIn')
+            fetch(provider.authenticationURL, {
+                credentials: 'same-origin',
+                method: 'POST',
+                headers: {
+                    ...context.xhrHeaders,
+                    Accept: 'application/json',
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify({ username, password }),
+            })
+                .then(async response => {
+                    if (response.status === 200) {
+                        if (new URLSearchParams(location.search).get('close') === 'true') {
+                            window.close()
+                        } else {
+                            window.location.replace(getReturnTo(location))
+                        }
+                        return
+                    }
+                    const message = (await response.text()).trim()
+                    throw new Error(message || 'Unknown Error')
+                })
+                .catch(error => {
+                    console.error('Auth error:', error)
+                    setLoading(false)
+                    onAuthError(asError(error))
+                })
+        },
+        [username, password, loading, location, onAuthError, context, provider]
+    )
+
+    return (
+        <Form onSubmit={handleSubmit}>
+            <Input
+                id={`${idPrefix}-username`}
+                label={<Text alignment="left">{provider.displayName} username</Text>}
+                onChange={onUsernameFieldChange}
+                required={true}
+                value={username}
+                disabled={loading}
+                autoCapitalize="off"
+                className="form-group"
+                autoComplete="username"
+            />
+
+            <div className="form-group d-flex flex-column align-content-start">
+                <Label htmlFor={`${idPrefix}-password`} className="align-self-start">
+                    Password
+                </Label>
+                <PasswordInput
+                    id={`${idPrefix}-password`}
+                    onChange={onPasswordFieldChange}
+                    value={password}
+                    required={true}
+                    disabled={loading}
+                    autoComplete="current-password"
+                    placeholder=" "
+                />
+            </div>
+
+            <div className="form-group">
+                <Button display="block" type="submit" disabled={loading} variant="primary">
+                    {loading ? <LoadingSpinner /> : `Sign in with ${provider.displayName}`}
+                </Button>
+            </div>
+        </Form>
+    )
+}
@@ -1,6 +1,6 @@
 import React, { useEffect, useState } from 'react'
 
-import { mdiGithub, mdiGitlab } from '@mdi/js'
+import { mdiBitbucket, mdiGithub, mdiGitlab } from '@mdi/js'
 import classNames from 'classnames'
 import { partition } from 'lodash'
 import { Navigate, useLocation } from 'react-router-dom-v5-compat'
@@ -15,6 +15,7 @@ import { SourcegraphContext } from '../jscontext'
 import { eventLogger } from '../tracking/eventLogger'
 
 import { SourcegraphIcon } from './icons'
+import { LDAPSignInForm } from './LDAPSignInForm'
 import { OrDivider } from './OrDivider'
 import { getReturnTo, maybeAddPostSignUpRedirect } from './SignInSignUpCommon'
 import { UsernamePasswordSignInForm } from './UsernamePasswordSignInForm'
@@ -44,6 +45,11 @@ export const SignInPage: React.FunctionComponent<React.PropsWithChildren<SignInP
         props.context.authProviders,
         provider => provider.isBuiltin
     )
+    // LDAP providers have their own username and password form instead of a button.
+    const [ldapAuthProviders, redirectAuthProviders] = partition(
+        thirdPartyAuthProviders,
+        provider => provider.serviceType === 'ldap'
+    )
 
     const body =
         !builtInAuthProvider && thirdPartyAuthProviders.length === 0 ? (
@@ -67,8 +73,19 @@ export const SignInPage: React.FunctionComponent<React.PropsWithChildren<SignInP
                             noThirdPartyProviders={thirdPartyAuthProviders.length === 0}
                         />
                     )}
-                    {builtInAuthProvider && thirdPartyAuthProviders.length > 0 && <OrDivider className="mb-3 py-1" />}
-                    {thirdPartyAuthProviders.map((provider, index) => (
+                    {ldapAuthProviders.map((provider, index) => (
+                        // Use index as key because display name may not be unique. This is OK
+                        // here because this list will not be updated during this component's lifetime.
+                        /* eslint-disable react/no-array-index-key */
+                        <React.Fragment key={index}>
+                            {(builtInAuthProvider || index > 0) && <OrDivider className="mb-3 py-1" />}
+                            <LDAPSignInForm provider={provider} onAuthError={setError} context={props.context} />
+                        </React.Fragment>
+                    ))}
+                    {(builtInAuthProvider || ldapAuthProviders.length > 0) && redirectAuthProviders.length > 0 && (
+                        <OrDivider className="mb-3 py-1" />
+                    )}
+                    {redirectAuthProviders.map((provider, index) => (
                         // Use index as key because display name may not be unique. This is OK
                         // here because this list will not be updated during this component's lifetime.
                         /* eslint-disable react/no-array-index-key */
@@ -89,6 +106,11 @@ export const SignInPage: React.FunctionComponent<React.PropsWithChildren<SignInP
                                         <Icon aria-hidden={true} svgPath={mdiGitlab} />{' '}
                                     </>
                                 )}
+                                {provider.serviceType === 'bitbucketCloud' && (
+                                    <>
+                                        <Icon aria-hidden={true} svgPath={mdiBitbucket} />{' '}
+                                    </>
+                                )}
                                 Continue with {provider.displayName}
                             </Button>
                         </div>
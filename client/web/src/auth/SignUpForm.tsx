@@ -1,6 +1,6 @@
 import React, { useCallback, useMemo, useState } from 'react'
 
-import { mdiHelpCircleOutline, mdiGithub, mdiGitlab } from '@mdi/js'
+import { mdiHelpCircleOutline, mdiBitbucket, mdiGithub, mdiGitlab } from '@mdi/js'
 import classNames from 'classnames'
 import cookies from 'js-cookie'
 import { Observable, of } from 'rxjs'
@@ -136,7 +136,10 @@ export const SignUpForm: React.FunctionComponent<React.PropsWithChildren<SignUpF
         setRequestedTrial(event.target.checked)
     }, [])
 
-    const externalAuthProviders = context.authProviders.filter(provider => !provider.isBuiltin)
+    // LDAP users sign up by signing in with their username and password on the sign-in page.
+    const externalAuthProviders = context.authProviders.filter(
+        provider => !provider.isBuiltin && provider.serviceType !== 'ldap'
+    )
 
     const onClickExternalAuthSignup = useCallback(
         (type: AuthProvider['serviceType']): React.MouseEventHandler<HTMLButtonElement> => () => {
@@ -286,6 +289,8 @@ export const SignUpForm: React.FunctionComponent<React.PropsWithChildren<SignUpF
                                         <Icon aria-hidden={true} svgPath={mdiGithub} />
                                     ) : provider.serviceType === 'gitlab' ? (
                                         <Icon aria-hidden={true} svgPath={mdiGitlab} />
+                                    ) : provider.serviceType === 'bitbucketCloud' ? (
+                                        <Icon aria-hidden={true} svgPath={mdiBitbucket} />
                                     ) : null}{' '}
                                     Continue with {provider.displayName}
                                 </Button>
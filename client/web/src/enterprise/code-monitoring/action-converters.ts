@@ -2,8 +2,12 @@ import { AuthenticatedUser } from '@sourcegraph/shared/src/auth'
 import {
     IMonitorEmail,
     IMonitorEmailInput,
+    IMonitorIssue,
+    IMonitorIssueInput,
     IMonitorSlackWebhook,
     IMonitorSlackWebhookInput,
+    IMonitorTeamsWebhook,
+    IMonitorTeamsWebhookInput,
     IMonitorWebhook,
     IMonitorWebhookInput,
 } from '@sourcegraph/shared/src/schema'
@@ -17,12 +21,16 @@ import {
 
 import { MonitorAction } from './components/FormActionArea'
 
-function isActionSupported(action: MonitorAction): action is IMonitorEmail | IMonitorSlackWebhook | IMonitorWebhook {
-    // We currently support email, Slack webhook, and generic webhook actions
+function isActionSupported(
+    action: MonitorAction
+): action is IMonitorEmail | IMonitorSlackWebhook | IMonitorTeamsWebhook | IMonitorWebhook | IMonitorIssue {
+    // We currently support email, Slack webhook, Microsoft Teams webhook, generic webhook, and issue actions
     return (
         action.__typename === 'MonitorEmail' ||
         action.__typename === 'MonitorSlackWebhook' ||
-        action.__typename === 'MonitorWebhook'
+        action.__typename === 'MonitorTeamsWebhook' ||
+        action.__typename === 'MonitorWebhook' ||
+        action.__typename === 'MonitorIssue'
     )
 }
 
@@ -44,11 +52,28 @@ function convertSlackWebhookAction(action: IMonitorSlackWebhook): IMonitorSlackW
     }
 }
 
+function convertTeamsWebhookAction(action: IMonitorTeamsWebhook): IMonitorTeamsWebhookInput {
+    return {
+        enabled: action.enabled,
+        includeResults: action.includeResults,
+        url: action.url,
+    }
+}
+
 function convertWebhookAction(action: IMonitorWebhook): IMonitorWebhookInput {
     return {
         enabled: action.enabled,
         includeResults: action.includeResults,
         url: action.url,
+        template: action.template,
+    }
+}
+
+function convertIssueAction(action: IMonitorIssue): IMonitorIssueInput {
+    return {
+        enabled: action.enabled,
+        includeResults: action.includeResults,
+        repository: action.repository,
     }
 }
 
@@ -66,10 +91,18 @@ export function convertActionsForCreate(
                 return {
                     slackWebhook: convertSlackWebhookAction(action),
                 }
+            case 'MonitorTeamsWebhook':
+                return {
+                    teamsWebhook: convertTeamsWebhookAction(action),
+                }
             case 'MonitorWebhook':
                 return {
                     webhook: convertWebhookAction(action),
                 }
+            case 'MonitorIssue':
+                return {
+                    issue: convertIssueAction(action),
+                }
         }
     })
 }
@@ -95,6 +128,13 @@ export function convertActionsForUpdate(
                         update: convertSlackWebhookAction(action),
                     },
                 }
+            case 'MonitorTeamsWebhook':
+                return {
+                    teamsWebhook: {
+                        id: action.id || null,
+                        update: convertTeamsWebhookAction(action),
+                    },
+                }
             case 'MonitorWebhook':
                 return {
                     webhook: {
@@ -102,6 +142,13 @@ export function convertActionsForUpdate(
                         update: convertWebhookAction(action),
                     },
                 }
+            case 'MonitorIssue':
+                return {
+                    issue: {
+                        id: action.id || null,
+                        update: convertIssueAction(action),
+                    },
+                }
         }
     })
 }
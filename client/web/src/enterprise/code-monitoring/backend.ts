@@ -56,6 +56,7 @@ const CodeMonitorFragment = gql`
                     enabled
                     includeResults
                     url
+                    template
                 }
                 ... on MonitorSlackWebhook {
                     __typename
@@ -64,6 +65,20 @@ const CodeMonitorFragment = gql`
                     includeResults
                     url
                 }
+                ... on MonitorTeamsWebhook {
+                    __typename
+                    id
+                    enabled
+                    includeResults
+                    url
+                }
+                ... on MonitorIssue {
+                    __typename
+                    id
+                    enabled
+                    includeResults
+                    repository
+                }
             }
         }
     }
@@ -203,6 +218,7 @@ export const fetchCodeMonitor = (id: string): Observable<FetchCodeMonitorResult>
                                 enabled
                                 includeResults
                                 url
+                                template
                             }
                             ... on MonitorSlackWebhook {
                                 id
@@ -210,6 +226,18 @@ export const fetchCodeMonitor = (id: string): Observable<FetchCodeMonitorResult>
                                 includeResults
                                 url
                             }
+                            ... on MonitorTeamsWebhook {
+                                id
+                                enabled
+                                includeResults
+                                url
+                            }
+                            ... on MonitorIssue {
+                                id
+                                enabled
+                                includeResults
+                                repository
+                            }
                         }
                     }
                     trigger {
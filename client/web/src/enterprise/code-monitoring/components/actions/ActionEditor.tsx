@@ -30,13 +30,13 @@ export interface ActionEditorProps {
     canDelete: boolean
     onDelete: React.FormEventHandler
 
-    // Test action
-    testState: 'called' | 'loading' | Error | undefined
+    // Test action. If onTest is undefined, the action cannot be tested and no test button is shown.
+    testState?: 'called' | 'loading' | Error | undefined
 
     testButtonDisabledReason?: string // If defined, the test button is disabled and this is the reason why
-    testButtonText: string
-    testAgainButtonText: string
-    onTest: () => void
+    testButtonText?: string
+    testAgainButtonText?: string
+    onTest?: () => void
 
     // For testing purposes only
     _testStartOpen?: boolean
@@ -137,46 +137,50 @@ export const ActionEditor: React.FunctionComponent<React.PropsWithChildren<Actio
                         </span>
                     </div>
 
-                    <div className="flex mt-1">
-                        <Button
-                            className="mr-2"
-                            variant="secondary"
-                            outline={!testButtonDisabledReason}
-                            disabled={!!testButtonDisabledReason || testState === 'loading' || testState === 'called'}
-                            onClick={onTest}
-                            size="sm"
-                            data-testid={`send-test-${idName}`}
-                        >
-                            {testButtonText}
-                        </Button>
-                        {testState === 'called' && !testButtonDisabledReason && (
+                    {onTest && (
+                        <div className="flex mt-1">
                             <Button
-                                className="p-0"
+                                className="mr-2"
+                                variant="secondary"
+                                outline={!testButtonDisabledReason}
+                                disabled={
+                                    !!testButtonDisabledReason || testState === 'loading' || testState === 'called'
+                                }
                                 onClick={onTest}
-                                variant="link"
                                 size="sm"
-                                data-testid={`send-test-${idName}-again`}
+                                data-testid={`send-test-${idName}`}
                             >
-                                {testAgainButtonText}
+                                {testButtonText}
                             </Button>
-                        )}
+                            {testState === 'called' && !testButtonDisabledReason && (
+                                <Button
+                                    className="p-0"
+                                    onClick={onTest}
+                                    variant="link"
+                                    size="sm"
+                                    data-testid={`send-test-${idName}-again`}
+                                >
+                                    {testAgainButtonText}
+                                </Button>
+                            )}
 
-                        {testButtonDisabledReason && (
-                            <div aria-live="polite" className={classNames('mt-2', styles.testActionError)}>
-                                {testButtonDisabledReason}
-                            </div>
-                        )}
+                            {testButtonDisabledReason && (
+                                <div aria-live="polite" className={classNames('mt-2', styles.testActionError)}>
+                                    {testButtonDisabledReason}
+                                </div>
+                            )}
 
-                        {isErrorLike(testState) && (
-                            <div
-                                aria-live="polite"
-                                className={classNames('mt-2', styles.testActionError)}
-                                data-testid={`test-${idName}-error`}
-                            >
-                                {testState.message}
-                            </div>
-                        )}
-                    </div>
+                            {isErrorLike(testState) && (
+                                <div
+                                    aria-live="polite"
+                                    className={classNames('mt-2', styles.testActionError)}
+                                    data-testid={`test-${idName}-error`}
+                                >
+                                    {testState.message}
+                                </div>
+                            )}
+                        </div>
+                    )}
 
                     <div className="d-flex align-items-center my-4">
                         <div>
@@ -0,0 +1,66 @@
+import { Meta, Story } from '@storybook/react'
+import sinon from 'sinon'
+
+import { H2 } from '@sourcegraph/wildcard'
+
+import { WebStory } from '../../../../components/WebStory'
+import { mockAuthenticatedUser } from '../../testing/util'
+import { ActionProps } from '../FormActionArea'
+
+import { IssueAction } from './IssueAction'
+
+const config: Meta = {
+    title: 'web/enterprise/code-monitoring/actions/IssueAction',
+    parameters: {
+        chromatic: { disableSnapshot: false },
+    },
+}
+
+export default config
+
+const defaultProps: ActionProps = {
+    action: undefined,
+    setAction: sinon.fake(),
+    disabled: false,
+    monitorName: 'Example code monitor',
+    authenticatedUser: mockAuthenticatedUser,
+}
+
+const action: ActionProps['action'] = {
+    __typename: 'MonitorIssue',
+    id: 'id1',
+    repository: 'github.com/sourcegraph/sourcegraph',
+    enabled: true,
+    includeResults: false,
+}
+
+export const IssueActionStory: Story = () => (
+    <WebStory>
+        {() => (
+            <>
+                <H2>Action card disabled</H2>
+                <IssueAction {...defaultProps} disabled={true} />
+
+                <H2>Closed, not populated</H2>
+                <IssueAction {...defaultProps} />
+
+                <H2>Open, not populated</H2>
+                <IssueAction {...defaultProps} _testStartOpen={true} />
+
+                <H2>Closed, populated, enabled</H2>
+                <IssueAction {...defaultProps} action={action} />
+
+                <H2>Open, populated, enabled</H2>
+                <IssueAction {...defaultProps} _testStartOpen={true} action={action} />
+
+                <H2>Closed, populated, disabled</H2>
+                <IssueAction {...defaultProps} action={{ ...action, enabled: false }} />
+
+                <H2>Open, populated, disabled</H2>
+                <IssueAction {...defaultProps} _testStartOpen={true} action={{ ...action, enabled: false }} />
+            </>
+        )}
+    </WebStory>
+)
+
+IssueActionStory.storyName = 'IssueAction'
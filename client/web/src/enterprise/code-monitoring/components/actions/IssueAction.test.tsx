@@ -0,0 +1,144 @@
+import { render } from '@testing-library/react'
+import userEvent from '@testing-library/user-event'
+import sinon from 'sinon'
+
+import { MockedTestProvider } from '@sourcegraph/shared/src/testing/apollo'
+
+import { mockAuthenticatedUser } from '../../testing/util'
+import { ActionProps } from '../FormActionArea'
+
+import { IssueAction } from './IssueAction'
+
+const REPOSITORY = 'github.com/sourcegraph/sourcegraph'
+
+describe('IssueAction', () => {
+    const props: ActionProps = {
+        action: undefined,
+        setAction: sinon.stub(),
+        disabled: false,
+        monitorName: 'Test',
+        authenticatedUser: mockAuthenticatedUser,
+    }
+
+    test('open and submit', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId, queryByTestId } = render(
+            <MockedTestProvider>
+                <IssueAction {...props} setAction={setActionSpy} />
+            </MockedTestProvider>
+        )
+
+        userEvent.click(getByTestId('form-action-toggle-issue'))
+
+        expect(getByTestId('submit-action-issue')).toBeDisabled()
+        expect(queryByTestId('send-test-issue')).not.toBeInTheDocument()
+
+        userEvent.type(getByTestId('issue-repository'), REPOSITORY)
+        expect(getByTestId('submit-action-issue')).toBeEnabled()
+
+        userEvent.click(getByTestId('include-results-toggle-issue'))
+
+        userEvent.click(getByTestId('submit-action-issue'))
+
+        sinon.assert.calledOnceWithExactly(setActionSpy, {
+            __typename: 'MonitorIssue',
+            enabled: true,
+            includeResults: true,
+            id: '',
+            repository: REPOSITORY,
+        })
+    })
+
+    test('open and edit', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <IssueAction
+                    {...props}
+                    setAction={setActionSpy}
+                    action={{
+                        __typename: 'MonitorIssue',
+                        enabled: true,
+                        includeResults: false,
+                        id: '1',
+                        repository: REPOSITORY,
+                    }}
+                />
+            </MockedTestProvider>
+        )
+
+        userEvent.click(getByTestId('form-action-toggle-issue'))
+        expect(getByTestId('submit-action-issue')).toBeEnabled()
+
+        userEvent.clear(getByTestId('issue-repository'))
+        expect(getByTestId('submit-action-issue')).toBeDisabled()
+
+        userEvent.type(getByTestId('issue-repository'), 'gitlab.com/sourcegraph/other')
+        expect(getByTestId('submit-action-issue')).toBeEnabled()
+
+        userEvent.click(getByTestId('submit-action-issue'))
+
+        sinon.assert.calledOnceWithExactly(setActionSpy, {
+            __typename: 'MonitorIssue',
+            enabled: true,
+            includeResults: false,
+            id: '1',
+            repository: 'gitlab.com/sourcegraph/other',
+        })
+    })
+
+    test('open and delete', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <IssueAction
+                    {...props}
+                    action={{
+                        __typename: 'MonitorIssue',
+                        enabled: true,
+                        includeResults: false,
+                        id: '2',
+                        repository: REPOSITORY,
+                    }}
+                    setAction={setActionSpy}
+                />
+            </MockedTestProvider>
+        )
+
+        userEvent.click(getByTestId('form-action-toggle-issue'))
+        userEvent.click(getByTestId('delete-action-issue'))
+
+        sinon.assert.calledOnceWithExactly(setActionSpy, undefined)
+    })
+
+    test('enable and disable', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <IssueAction
+                    {...props}
+                    action={{
+                        __typename: 'MonitorIssue',
+                        enabled: false,
+                        includeResults: false,
+                        id: '5',
+                        repository: REPOSITORY,
+                    }}
+                    setAction={setActionSpy}
+                />
+            </MockedTestProvider>
+        )
+
+        expect(getByTestId('enable-action-toggle-collapsed-issue')).not.toBeChecked()
+
+        userEvent.click(getByTestId('enable-action-toggle-collapsed-issue'))
+        expect(getByTestId('enable-action-toggle-collapsed-issue')).toBeChecked()
+        sinon.assert.calledOnceWithExactly(setActionSpy, {
+            __typename: 'MonitorIssue',
+            enabled: true,
+            includeResults: false,
+            id: '5',
+            repository: REPOSITORY,
+        })
+    })
+})
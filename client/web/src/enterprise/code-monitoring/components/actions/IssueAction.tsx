@@ -0,0 +1,109 @@
+import React, { useCallback, useState } from 'react'
+
+import { Alert, Input, Link, ProductStatusBadge, Label } from '@sourcegraph/wildcard'
+
+import { ActionProps } from '../FormActionArea'
+
+import { ActionEditor } from './ActionEditor'
+
+export const IssueAction: React.FunctionComponent<React.PropsWithChildren<ActionProps>> = ({
+    action,
+    setAction,
+    disabled,
+    _testStartOpen,
+}) => {
+    const [enabled, setEnabled] = useState(action ? action.enabled : true)
+
+    const toggleIssueEnabled: (enabled: boolean, saveImmediately: boolean) => void = useCallback(
+        (enabled, saveImmediately) => {
+            setEnabled(enabled)
+            if (action && saveImmediately) {
+                setAction({ ...action, enabled })
+            }
+        },
+        [action, setAction]
+    )
+
+    const [repository, setRepository] = useState(
+        action && action.__typename === 'MonitorIssue' ? action.repository : ''
+    )
+
+    const [includeResults, setIncludeResults] = useState(action ? action.includeResults : false)
+    const toggleIncludeResults: (includeResults: boolean) => void = useCallback(includeResults => {
+        setIncludeResults(includeResults)
+    }, [])
+
+    const onSubmit: React.FormEventHandler = useCallback(
+        event => {
+            event.preventDefault()
+            setAction({
+                __typename: 'MonitorIssue',
+                id: action ? action.id : '',
+                repository: repository.trim(),
+                enabled,
+                includeResults,
+            })
+        },
+        [action, includeResults, setAction, repository, enabled]
+    )
+
+    const onCancel: React.FormEventHandler = useCallback(() => {
+        setEnabled(action ? action.enabled : true)
+        setRepository(action && action.__typename === 'MonitorIssue' ? action.repository : '')
+        setIncludeResults(action ? action.includeResults : false)
+    }, [action])
+
+    const onDelete: React.FormEventHandler = useCallback(() => {
+        setAction(undefined)
+    }, [setAction])
+
+    return (
+        <ActionEditor
+            title={
+                <div>
+                    Open an issue on the code host <ProductStatusBadge className="ml-1 mb-1" status="beta" />{' '}
+                </div>
+            }
+            subtitle="Open an issue in a GitHub or GitLab repository, or comment on the issue if it is still open."
+            idName="issue"
+            disabled={disabled}
+            completed={!!action}
+            completedSubtitle="An issue will be opened or commented on in the specified repository."
+            actionEnabled={enabled}
+            toggleActionEnabled={toggleIssueEnabled}
+            canSubmit={!!repository.trim()}
+            includeResults={includeResults}
+            toggleIncludeResults={toggleIncludeResults}
+            onSubmit={onSubmit}
+            onCancel={onCancel}
+            canDelete={!!action}
+            onDelete={onDelete}
+            _testStartOpen={_testStartOpen}
+        >
+            <Alert aria-live="off" variant="info" className="mt-4">
+                The issue is opened with the token of the code host connection that syncs the repository, so the
+                token must be allowed to create issues. Only site admins can configure this action.
+                <br />
+                <Link to="/help/code_monitoring/how-tos/issue" target="_blank" rel="noopener">
+                    Read more about how to open issues from code monitors in the docs.
+                </Link>
+            </Alert>
+            <div className="form-group">
+                <Label htmlFor="code-monitor-issue-repository">Repository</Label>
+                <Input
+                    id="code-monitor-issue-repository"
+                    className="mb-2"
+                    data-testid="issue-repository"
+                    required={true}
+                    placeholder="github.com/sourcegraph/sourcegraph"
+                    onChange={event => {
+                        setRepository(event.target.value)
+                    }}
+                    value={repository}
+                    autoFocus={true}
+                    spellCheck={false}
+                />
+            </div>
+        </ActionEditor>
+    )
+}
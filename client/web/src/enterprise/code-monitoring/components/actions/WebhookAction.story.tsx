@@ -30,6 +30,7 @@ const action: ActionProps['action'] = {
     __typename: 'MonitorWebhook',
     id: 'id1',
     url: 'https://example.com',
+    template: null,
     enabled: true,
     includeResults: false,
 }
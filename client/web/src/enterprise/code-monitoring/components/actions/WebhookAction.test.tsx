@@ -45,6 +45,32 @@ describe('WebhookAction', () => {
             includeResults: true,
             id: '',
             url: 'https://example.com',
+            template: null,
+        })
+    })
+
+    test('open and submit with template', () => {
+        const setActionSpy = sinon.spy()
+        const { getByTestId } = render(
+            <MockedTestProvider>
+                <WebhookAction {...props} setAction={setActionSpy} />
+            </MockedTestProvider>
+        )
+
+        userEvent.click(getByTestId('form-action-toggle-webhook'))
+
+        userEvent.type(getByTestId('webhook-url'), 'https://example.com')
+        userEvent.paste(getByTestId('webhook-template'), '{"text": {{ json .Monitor.Description }}}')
+
+        userEvent.click(getByTestId('submit-action-webhook'))
+
+        sinon.assert.calledOnceWithExactly(setActionSpy, {
+            __typename: 'MonitorWebhook',
+            enabled: true,
+            includeResults: false,
+            id: '',
+            url: 'https://example.com',
+            template: '{"text": {{ json .Monitor.Description }}}',
         })
     })
 
@@ -61,6 +87,7 @@ describe('WebhookAction', () => {
                         includeResults: false,
                         id: '1',
                         url: 'https://example.com',
+                        template: null,
                     }}
                 />
             </MockedTestProvider>
@@ -83,6 +110,7 @@ describe('WebhookAction', () => {
             includeResults: false,
             id: '1',
             url: 'https://example2.com',
+            template: null,
         })
     })
 
@@ -98,6 +126,7 @@ describe('WebhookAction', () => {
                         includeResults: false,
                         id: '2',
                         url: 'https://example.com',
+                        template: null,
                     }}
                     setAction={setActionSpy}
                 />
@@ -122,6 +151,7 @@ describe('WebhookAction', () => {
                         includeResults: false,
                         id: '5',
                         url: 'https://example.com',
+                        template: null,
                     }}
                     setAction={setActionSpy}
                 />
@@ -138,6 +168,7 @@ describe('WebhookAction', () => {
             includeResults: false,
             id: '5',
             url: 'https://example.com',
+            template: null,
         })
 
         setActionSpy.resetHistory()
@@ -150,6 +181,7 @@ describe('WebhookAction', () => {
             includeResults: false,
             id: '5',
             url: 'https://example.com',
+            template: null,
         })
     })
 
@@ -165,6 +197,7 @@ describe('WebhookAction', () => {
                         includeResults: false,
                         id: '5',
                         url: 'https://example.com',
+                        template: null,
                     }}
                     setAction={setActionSpy}
                 />
@@ -195,6 +228,7 @@ describe('WebhookAction', () => {
             includeResults: false,
             id: '5',
             url: 'https://example.com',
+            template: null,
         }
 
         const mockedVariables: SendTestWebhookVariables = {
@@ -204,6 +238,7 @@ describe('WebhookAction', () => {
                 enabled: true,
                 includeResults: false,
                 url: mockAction.url,
+                template: null,
             },
         }
 
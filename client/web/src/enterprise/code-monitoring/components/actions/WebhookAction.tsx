@@ -3,7 +3,7 @@ import React, { useCallback, useMemo, useState } from 'react'
 import { gql, useMutation } from '@apollo/client'
 import { noop } from 'lodash'
 
-import { Alert, Input, Link, ProductStatusBadge, Label } from '@sourcegraph/wildcard'
+import { Alert, Input, Link, ProductStatusBadge, Label, TextArea } from '@sourcegraph/wildcard'
 
 import { SendTestWebhookResult, SendTestWebhookVariables } from '../../../../graphql-operations'
 import { ActionProps } from '../FormActionArea'
@@ -41,6 +41,10 @@ export const WebhookAction: React.FunctionComponent<React.PropsWithChildren<Acti
     const [url, setUrl] = useState(action && action.__typename === 'MonitorWebhook' ? action.url : '')
     const urlIsValid = useMemo(() => !!url.match(/^https?:\/\//), [url])
 
+    const [template, setTemplate] = useState(
+        action && action.__typename === 'MonitorWebhook' ? action.template ?? '' : ''
+    )
+
     const [includeResults, setIncludeResults] = useState(action ? action.includeResults : false)
     const toggleIncludeResults: (includeResults: boolean) => void = useCallback(includeResults => {
         setIncludeResults(includeResults)
@@ -55,14 +59,17 @@ export const WebhookAction: React.FunctionComponent<React.PropsWithChildren<Acti
                 url,
                 enabled,
                 includeResults,
+                // An empty template sends the default payload.
+                template: template || null,
             })
         },
-        [action, includeResults, setAction, url, enabled]
+        [action, includeResults, setAction, url, enabled, template]
     )
 
     const onCancel: React.FormEventHandler = useCallback(() => {
         setEnabled(action ? action.enabled : true)
         setUrl(action && action.__typename === 'MonitorWebhook' ? action.url : '')
+        setTemplate(action && action.__typename === 'MonitorWebhook' ? action.template ?? '' : '')
         setIncludeResults(action ? action.includeResults : false)
     }, [action])
 
@@ -79,10 +86,10 @@ export const WebhookAction: React.FunctionComponent<React.PropsWithChildren<Acti
             variables: {
                 namespace: authenticatedUser.id,
                 description: monitorName,
-                webhook: { url, enabled: true, includeResults },
+                webhook: { url, enabled: true, includeResults, template: template || null },
             },
         }).catch(noop) // Ignore errors, they will be handled with the error state from useMutation
-    }, [authenticatedUser.id, includeResults, monitorName, sendTestMessage, url])
+    }, [authenticatedUser.id, includeResults, monitorName, sendTestMessage, template, url])
 
     const testButtonText = loading
         ? 'Calling webhook...'
@@ -151,6 +158,26 @@ export const WebhookAction: React.FunctionComponent<React.PropsWithChildren<Acti
                     error={!urlIsValid && url && 'Enter a valid webhook URL.'}
                 />
             </div>
+            <div className="form-group">
+                <Label htmlFor="code-monitor-webhook-template">
+                    Payload template <span className="text-muted">(optional)</span>
+                </Label>
+                <TextArea
+                    id="code-monitor-webhook-template"
+                    className="mb-2 text-monospace"
+                    data-testid="webhook-template"
+                    rows={5}
+                    onChange={event => {
+                        setTemplate(event.target.value)
+                    }}
+                    value={template}
+                    spellCheck={false}
+                    placeholder='{"text": {{ json .Monitor.Description }}}'
+                />
+                <small className="text-muted">
+                    A Go template that renders the JSON body of the request. Leave empty to send the default payload.
+                </small>
+            </div>
         </ActionEditor>
     )
 }
@@ -151,7 +151,11 @@ function getActionEventTitle(action: MonitorTriggerEventWithActions['actions']['
             return 'Email'
         case 'MonitorSlackWebhook':
             return 'Slack'
+        case 'MonitorTeamsWebhook':
+            return 'Microsoft Teams'
         case 'MonitorWebhook':
             return 'Webhook'
+        case 'MonitorIssue':
+            return 'Issue'
     }
 }
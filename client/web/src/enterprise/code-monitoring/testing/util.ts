@@ -155,6 +155,7 @@ export const mockCodeMonitorNodes: ListCodeMonitors['nodes'] = [
                     enabled: true,
                     includeResults: false,
                     url: 'https://example.com/webhook',
+                    template: null,
                 },
             ],
         },
@@ -43,6 +43,9 @@ export function useFlagsOverrides(): { data: OrgFlagOverride[]; loading: boolean
                         ... on FeatureFlagRollout {
                             name
                         }
+                        ... on FeatureFlagRules {
+                            name
+                        }
                     }
                     value
                 }
@@ -160,28 +160,31 @@ export const SiteAdminFeatureFlagConfigurationPage: FunctionComponent<
         )
         actions = (
             <>
-                <Button
-                    variant="primary"
-                    disabled={updateFlagLoading || deleteFlagLoading}
-                    onClick={() =>
-                        updateFeatureFlag({
-                            variables: {
-                                name: flagName,
-                                ...flagValue,
-                            },
-                        }).then(() => {
-                            history.push(`./${flagName}`)
-                        })
-                    }
-                >
-                    {updateFlagLoading ? (
-                        <>
-                            <LoadingSpinner /> Updating...
-                        </>
-                    ) : (
-                        'Update'
-                    )}
-                </Button>
+                {/* Targeting rules can only be managed through the GraphQL API for now. */}
+                {flagType !== 'FeatureFlagRules' && (
+                    <Button
+                        variant="primary"
+                        disabled={updateFlagLoading || deleteFlagLoading}
+                        onClick={() =>
+                            updateFeatureFlag({
+                                variables: {
+                                    name: flagName,
+                                    ...flagValue,
+                                },
+                            }).then(() => {
+                                history.push(`./${flagName}`)
+                            })
+                        }
+                    >
+                        {updateFlagLoading ? (
+                            <>
+                                <LoadingSpinner /> Updating...
+                            </>
+                        ) : (
+                            'Update'
+                        )}
+                    </Button>
+                )}
                 <Button
                     variant="danger"
                     outline={true}
@@ -247,7 +250,7 @@ export const SiteAdminFeatureFlagConfigurationPage: FunctionComponent<
     )
 }
 
-type FeatureFlagType = 'FeatureFlagBoolean' | 'FeatureFlagRollout'
+type FeatureFlagType = 'FeatureFlagBoolean' | 'FeatureFlagRollout' | 'FeatureFlagRules'
 
 interface FeatureFlagOverride {
     id: string
@@ -268,6 +271,17 @@ interface FeatureFlagRolloutValue {
     rolloutBasisPoints: number
 }
 
+interface FeatureFlagRulesValue {
+    defaultValue: boolean
+    rules: {
+        type: string
+        value: boolean
+        emailDomains: string[]
+        users: { username: string }[]
+        orgs: { name: string }[]
+    }[]
+}
+
 interface CreateFeatureFlagOverrideResult {
     createFeatureFlagOverride: FeatureFlagOverride
 }
@@ -277,7 +291,7 @@ interface CreateFeatureFlagOverrideVariables {
     value: boolean
 }
 
-type FeatureFlagValue = FeatureFlagBooleanValue | FeatureFlagRolloutValue
+type FeatureFlagValue = FeatureFlagBooleanValue | FeatureFlagRolloutValue | FeatureFlagRulesValue
 type FeatureFlagOverrideType = 'User' | 'Org'
 
 const AddFeatureFlagOverride: FunctionComponent<
@@ -421,7 +435,13 @@ const FeatureFlagOverridesHeader: FunctionComponent<
         <>
             <AddFeatureFlagOverride
                 name={name}
-                value={type === 'FeatureFlagBoolean' ? (value as FeatureFlagBooleanValue).value : false}
+                value={
+                    type === 'FeatureFlagBoolean'
+                        ? (value as FeatureFlagBooleanValue).value
+                        : type === 'FeatureFlagRules'
+                        ? (value as FeatureFlagRulesValue).defaultValue
+                        : false
+                }
                 onOverrideAdded={onOverrideAdded}
             />
             <div className="mr-auto">{count}</div>
@@ -658,6 +678,11 @@ const FeatureFlagValueSettings: React.FunctionComponent<
         setFlagValue: (next: FeatureFlagValue) => void
     }>
 > = ({ type, value, setFlagValue }) => {
+    if (type === 'FeatureFlagRules') {
+        // Flags with targeting rules can't be created from the UI, so a value is always set.
+        return value && 'rules' in value ? <FeatureFlagRulesValueSettings value={value} /> : null
+    }
+
     if (type === 'FeatureFlagRollout') {
         if (!value || !('rolloutBasisPoints' in value)) {
             value = { rolloutBasisPoints: 0 }
@@ -725,6 +750,48 @@ const FeatureFlagRolloutValueSettings: React.FunctionComponent<
     </div>
 )
 
+const describeTargetingRule = (rule: FeatureFlagRulesValue['rules'][number]): string => {
+    switch (rule.type) {
+        case 'USERS':
+            return `Users ${rule.users.map(user => user.username).join(', ')}`
+        case 'ORG_MEMBERS':
+            return `Members of ${rule.orgs.map(org => org.name).join(', ')}`
+        case 'SITE_ADMINS':
+            return 'Site admins'
+        case 'EMAIL_DOMAINS':
+            return `Users with a verified email address at ${rule.emailDomains.join(', ')}`
+        case 'ANONYMOUS':
+            return 'Anonymous users'
+        default:
+            return rule.type
+    }
+}
+
+const FeatureFlagRulesValueSettings: React.FunctionComponent<
+    React.PropsWithChildren<{
+        value: FeatureFlagRulesValue
+    }>
+> = ({ value }) => (
+    <div className="form-group d-flex flex-column">
+        <H3>Rules</H3>
+        <Text className="text-muted">
+            The flag evaluates to the value of the first rule that matches the user. Rules can be managed with the{' '}
+            <Code>updateFeatureFlag</Code> GraphQL mutation.
+        </Text>
+        <ol>
+            {value.rules.map((rule, index) => (
+                // eslint-disable-next-line react/no-array-index-key
+                <li key={index}>
+                    {describeTargetingRule(rule)}: <Code>{JSON.stringify(rule.value)}</Code>
+                </li>
+            ))}
+            <li>
+                Everyone else: <Code>{JSON.stringify(value.defaultValue)}</Code>
+            </li>
+        </ol>
+    </div>
+)
+
 const FeatureFlagBooleanValueSettings: React.FunctionComponent<
     React.PropsWithChildren<{
         value: FeatureFlagBooleanValue
@@ -116,6 +116,12 @@ const filters: FilteredConnectionFilter[] = [
                 tooltip: 'Show rollout feature flags',
                 args: { type: 'FeatureFlagRollout' },
             },
+            {
+                label: 'Rules',
+                value: 'rules',
+                tooltip: 'Show feature flags with targeting rules',
+                args: { type: 'FeatureFlagRules' },
+            },
         ],
     },
 ]
@@ -261,6 +267,8 @@ const FeatureFlagNode: React.FunctionComponent<React.PropsWithChildren<FeatureFl
                     <div>
                         {node.__typename === 'FeatureFlagBoolean' && <Code>{JSON.stringify(node.value)}</Code>}
                         {node.__typename === 'FeatureFlagRollout' && node.rolloutBasisPoints}
+                        {node.__typename === 'FeatureFlagRules' &&
+                            `${node.rules.length} ${pluralize('rule', node.rules.length)}`}
                     </div>
 
                     {node.__typename === 'FeatureFlagRollout' && (
@@ -1099,6 +1099,24 @@ export function fetchFeatureFlags(): Observable<FeatureFlagFields[]> {
                         ...OverrideFields
                     }
                 }
+                ... on FeatureFlagRules {
+                    name
+                    defaultValue
+                    rules {
+                        type
+                        value
+                        emailDomains
+                        users {
+                            username
+                        }
+                        orgs {
+                            name
+                        }
+                    }
+                    overrides {
+                        ...OverrideFields
+                    }
+                }
             }
 
             fragment OverrideFields on FeatureFlagOverride {
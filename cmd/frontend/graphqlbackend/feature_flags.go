@@ -2,6 +2,7 @@ package graphqlbackend
 
 import (
 	"context"
+	"strings"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -9,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -32,6 +34,13 @@ func (f *FeatureFlagResolver) ToFeatureFlagRollout() (*FeatureFlagRolloutResolve
 	return nil, false
 }
 
+func (f *FeatureFlagResolver) ToFeatureFlagRules() (*FeatureFlagRulesResolver, bool) {
+	if f.inner.Rules != nil {
+		return &FeatureFlagRulesResolver{f.db, f.inner}, true
+	}
+	return nil, false
+}
+
 type FeatureFlagBooleanResolver struct {
 	db database.DB
 	// Invariant: inner.Bool is non-nil
@@ -64,6 +73,121 @@ func (f *FeatureFlagRolloutResolver) Overrides(ctx context.Context) ([]*FeatureF
 	return overridesToResolvers(f.db, overrides), nil
 }
 
+type FeatureFlagRulesResolver struct {
+	db database.DB
+	// Invariant: inner.Rules is non-nil
+	inner *featureflag.FeatureFlag
+}
+
+func (f *FeatureFlagRulesResolver) Name() string       { return f.inner.Name }
+func (f *FeatureFlagRulesResolver) DefaultValue() bool { return f.inner.Rules.Default }
+func (f *FeatureFlagRulesResolver) Rules() []*FeatureFlagTargetingRuleResolver {
+	res := make([]*FeatureFlagTargetingRuleResolver, 0, len(f.inner.Rules.Rules))
+	for _, rule := range f.inner.Rules.Rules {
+		res = append(res, &FeatureFlagTargetingRuleResolver{f.db, rule})
+	}
+	return res
+}
+func (f *FeatureFlagRulesResolver) Overrides(ctx context.Context) ([]*FeatureFlagOverrideResolver, error) {
+	overrides, err := f.db.FeatureFlags().GetOverridesForFlag(ctx, f.inner.Name)
+	if err != nil {
+		return nil, err
+	}
+	return overridesToResolvers(f.db, overrides), nil
+}
+
+type FeatureFlagTargetingRuleResolver struct {
+	db    database.DB
+	inner *featureflag.TargetingRule
+}
+
+func (r *FeatureFlagTargetingRuleResolver) EmailDomains() []string { return r.inner.EmailDomains }
+func (r *FeatureFlagTargetingRuleResolver) Value() bool            { return r.inner.Value }
+func (r *FeatureFlagTargetingRuleResolver) Type() string {
+	return strings.ToUpper(string(r.inner.Type))
+}
+
+// Users returns the users the rule applies to. Users that have been deleted
+// since the rule was created are omitted.
+func (r *FeatureFlagTargetingRuleResolver) Users(ctx context.Context) ([]*UserResolver, error) {
+	res := make([]*UserResolver, 0, len(r.inner.UserIDs))
+	for _, id := range r.inner.UserIDs {
+		u, err := UserByIDInt32(ctx, r.db, id)
+		if errcode.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		res = append(res, u)
+	}
+	return res, nil
+}
+
+// Orgs returns the orgs the rule applies to. Orgs that have been deleted
+// since the rule was created are omitted.
+func (r *FeatureFlagTargetingRuleResolver) Orgs(ctx context.Context) ([]*OrgResolver, error) {
+	res := make([]*OrgResolver, 0, len(r.inner.OrgIDs))
+	for _, id := range r.inner.OrgIDs {
+		o, err := OrgByIDInt32(ctx, r.db, id)
+		if errcode.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		res = append(res, o)
+	}
+	return res, nil
+}
+
+type featureFlagTargetingRuleInput struct {
+	Type         string
+	Users        *[]graphql.ID
+	Orgs         *[]graphql.ID
+	EmailDomains *[]string
+	Value        bool
+}
+
+// unmarshalFeatureFlagRules converts the targeting rules given to the feature
+// flag mutations to a FeatureFlagRules that evaluates to defaultValue if none
+// of them match.
+func unmarshalFeatureFlagRules(input []*featureFlagTargetingRuleInput, defaultValue bool) (*featureflag.FeatureFlagRules, error) {
+	rules := &featureflag.FeatureFlagRules{
+		Rules:   make([]*featureflag.TargetingRule, 0, len(input)),
+		Default: defaultValue,
+	}
+	for _, in := range input {
+		rule := &featureflag.TargetingRule{
+			Type:  featureflag.TargetingRuleType(strings.ToLower(in.Type)),
+			Value: in.Value,
+		}
+		if in.Users != nil {
+			for _, id := range *in.Users {
+				userID, err := UnmarshalUserID(id)
+				if err != nil {
+					return nil, err
+				}
+				rule.UserIDs = append(rule.UserIDs, userID)
+			}
+		}
+		if in.Orgs != nil {
+			for _, id := range *in.Orgs {
+				orgID, err := UnmarshalOrgID(id)
+				if err != nil {
+					return nil, err
+				}
+				rule.OrgIDs = append(rule.OrgIDs, orgID)
+			}
+		}
+		if in.EmailDomains != nil {
+			for _, domain := range *in.EmailDomains {
+				rule.EmailDomains = append(rule.EmailDomains, strings.ToLower(strings.TrimPrefix(domain, "@")))
+			}
+		}
+		rules.Rules = append(rules.Rules, rule)
+	}
+	return rules, rules.Validate()
+}
+
 func overridesToResolvers(db database.DB, input []*featureflag.Override) []*FeatureFlagOverrideResolver {
 	res := make([]*FeatureFlagOverrideResolver, 0, len(input))
 	for _, flag := range input {
@@ -222,6 +346,7 @@ func (r *schemaResolver) CreateFeatureFlag(ctx context.Context, args struct {
 	Name               string
 	Value              *bool
 	RolloutBasisPoints *int32
+	Rules              *[]*featureFlagTargetingRuleInput
 }) (*FeatureFlagResolver, error) {
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
 		return nil, err
@@ -231,12 +356,19 @@ func (r *schemaResolver) CreateFeatureFlag(ctx context.Context, args struct {
 
 	var res *featureflag.FeatureFlag
 	var err error
-	if args.Value != nil {
+	if args.Rules != nil {
+		var rules *featureflag.FeatureFlagRules
+		rules, err = unmarshalFeatureFlagRules(*args.Rules, args.Value != nil && *args.Value)
+		if err != nil {
+			return nil, err
+		}
+		res, err = ff.CreateRules(ctx, args.Name, rules)
+	} else if args.Value != nil {
 		res, err = ff.CreateBool(ctx, args.Name, *args.Value)
 	} else if args.RolloutBasisPoints != nil {
 		res, err = ff.CreateRollout(ctx, args.Name, *args.RolloutBasisPoints)
 	} else {
-		return nil, errors.Errorf("either 'value' or 'rolloutBasisPoints' must be set")
+		return nil, errors.Errorf("one of 'value', 'rolloutBasisPoints' or 'rules' must be set")
 	}
 
 	return &FeatureFlagResolver{r.db, res}, err
@@ -255,17 +387,24 @@ func (r *schemaResolver) UpdateFeatureFlag(ctx context.Context, args struct {
 	Name               string
 	Value              *bool
 	RolloutBasisPoints *int32
+	Rules              *[]*featureFlagTargetingRuleInput
 }) (*FeatureFlagResolver, error) {
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
 		return nil, err
 	}
 	ff := &featureflag.FeatureFlag{Name: args.Name}
-	if args.Value != nil {
+	if args.Rules != nil {
+		rules, err := unmarshalFeatureFlagRules(*args.Rules, args.Value != nil && *args.Value)
+		if err != nil {
+			return nil, err
+		}
+		ff.Rules = rules
+	} else if args.Value != nil {
 		ff.Bool = &featureflag.FeatureFlagBool{Value: *args.Value}
 	} else if args.RolloutBasisPoints != nil {
 		ff.Rollout = &featureflag.FeatureFlagRollout{Rollout: *args.RolloutBasisPoints}
 	} else {
-		return nil, errors.Errorf("either 'value' or 'rolloutBasisPoints' must be set")
+		return nil, errors.Errorf("one of 'value', 'rolloutBasisPoints' or 'rules' must be set")
 	}
 
 	res, err := r.db.FeatureFlags().UpdateFeatureFlag(ctx, ff)
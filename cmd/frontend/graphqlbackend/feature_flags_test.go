@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -295,3 +296,94 @@ func TestEvaluateFeatureFlag(t *testing.T) {
 		})
 	})
 }
+
+func TestFeatureFlagRules(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		if id == 2 {
+			return nil, database.NewUserNotFoundError(id)
+		}
+		return &types.User{ID: id, Username: "alice"}, nil
+	})
+
+	orgs := database.NewMockOrgStore()
+	orgs.GetByIDFunc.SetDefaultReturn(&types.Org{ID: 1, Name: "acme"}, nil)
+
+	flags := database.NewMockFeatureFlagStore()
+	flags.CreateRulesFunc.SetDefaultHook(func(_ context.Context, name string, rules *featureflag.FeatureFlagRules) (*featureflag.FeatureFlag, error) {
+		return &featureflag.FeatureFlag{Name: name, Rules: rules}, nil
+	})
+
+	db := database.NewMockDB()
+	db.OrgsFunc.SetDefaultReturn(orgs)
+	db.UsersFunc.SetDefaultReturn(users)
+	db.FeatureFlagsFunc.SetDefaultReturn(flags)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	RunTests(t, []*Test{
+		{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+			mutation {
+				createFeatureFlag(
+					name: "test-flag",
+					value: true,
+					rules: [
+						{type: USERS, users: ["VXNlcjox", "VXNlcjoy"], value: false},
+						{type: ORG_MEMBERS, orgs: ["T3JnOjE="], value: true},
+						{type: EMAIL_DOMAINS, emailDomains: ["@Example.com"], value: true},
+						{type: SITE_ADMINS, value: true}
+					]
+				) {
+					... on FeatureFlagRules {
+						name
+						defaultValue
+						rules {
+							type
+							users { username }
+							orgs { name }
+							emailDomains
+							value
+						}
+					}
+				}
+			}
+			`,
+			ExpectedResult: `
+				{
+					"createFeatureFlag": {
+						"name": "test-flag",
+						"defaultValue": true,
+						"rules": [
+							{"type": "USERS", "users": [{"username": "alice"}], "orgs": [], "emailDomains": [], "value": false},
+							{"type": "ORG_MEMBERS", "users": [], "orgs": [{"name": "acme"}], "emailDomains": [], "value": true},
+							{"type": "EMAIL_DOMAINS", "users": [], "orgs": [], "emailDomains": ["example.com"], "value": true},
+							{"type": "SITE_ADMINS", "users": [], "orgs": [], "emailDomains": [], "value": true}
+						]
+					}
+				}
+			`,
+		},
+		{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+			mutation {
+				createFeatureFlag(name: "test-flag", rules: [{type: ORG_MEMBERS, value: true}]) {
+					__typename
+				}
+			}
+			`,
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message: "rule 1: org_members rule must specify at least one of the orgs it applies to",
+					Path:    []any{"createFeatureFlag"},
+				},
+			},
+		},
+	})
+}
@@ -171,7 +171,16 @@ func matchesToResolvers(db database.DB, matches []result.Match) []SearchResultRe
 }
 
 func (sr *SearchResultsResolver) MatchCount() int32 {
-	return int32(sr.Matches.ResultCount())
+	count := 0
+	for _, match := range sr.Matches {
+		// Owner matches are only returned by the streaming API, so they are
+		// left out of the count to stay consistent with Results.
+		if _, ok := match.(*result.OwnerMatch); ok {
+			continue
+		}
+		count += match.ResultCount()
+	}
+	return int32(count)
 }
 
 // Deprecated. Prefer MatchCount.
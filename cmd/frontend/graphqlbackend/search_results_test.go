@@ -431,6 +431,17 @@ func TestSearchResultsResolver_ApproximateResultCount(t *testing.T) {
 			},
 			want: "2+",
 		},
+
+		{
+			name: "owner matches are not counted",
+			fields: fields{
+				results: []result.Match{
+					&result.FileMatch{},
+					&result.OwnerMatch{Handle: "@alice", Type: "username"},
+				},
+			},
+			want: "1",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/log/logtest"
 	oce "github.com/sourcegraph/sourcegraph/cmd/frontend/oneclickexport"
@@ -37,13 +38,19 @@ func TestOneClickExportHandler(t *testing.T) {
 			oce.GlobalExporter = nil
 		})
 
+		since := time.Now().Add(-24 * time.Hour)
 		request := oce.ExportRequest{
 			IncludeSiteConfig:     true,
 			IncludeCodeHostConfig: true,
-			DBQueries: []*oce.DBQueryRequest{{
-				TableName: "external_services",
-				Count:     10,
-			}},
+			DBQueries: []*oce.DBQueryRequest{
+				{TableName: "external_services", Count: 10},
+				{TableName: "external_service_sync_jobs", Count: 10},
+				{TableName: "gitserver_repos", Count: 10},
+				{TableName: "worker_queues"},
+				{TableName: "out_of_band_migrations"},
+				{TableName: "webhook_logs", Count: 10},
+			},
+			TimeWindow: oce.TimeWindow{Since: &since},
 		}
 		data, err := json.Marshal(request)
 		if err != nil {
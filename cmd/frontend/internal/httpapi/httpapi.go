@@ -83,6 +83,24 @@ func NewHandler(
 		ExternalServices: db.ExternalServices(),
 	}
 
+	// The GitLab and Bitbucket routers handle push events themselves and pass
+	// all other events on to the batch changes handlers.
+	gitlabWebhook := webhooks.GitLabWebhook{
+		ExternalServices: db.ExternalServices(),
+		Next:             handlers.GitLabWebhook,
+	}
+	bbsWebhook := webhooks.BitbucketServerWebhook{
+		ExternalServices: db.ExternalServices(),
+		Next:             handlers.BitbucketServerWebhook,
+	}
+	bbcWebhook := webhooks.BitbucketCloudWebhook{
+		ExternalServices: db.ExternalServices(),
+		Next:             handlers.BitbucketCloudWebhook,
+	}
+	giteaWebhook := webhooks.GiteaWebhook{
+		ExternalServices: db.ExternalServices(),
+	}
+
 	webhookhandlers.Init(db, &gh)
 	webhookMiddleware := webhooks.NewLogMiddleware(
 		db.WebhookLogs(keyring.Default().WebhookLogKey),
@@ -91,15 +109,28 @@ func NewHandler(
 	handlers.GitHubWebhook.Register(&gh)
 
 	m.Get(apirouter.GitHubWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&gh)))
-	m.Get(apirouter.GitLabWebhooks).Handler(trace.Route(webhookMiddleware.Logger(handlers.GitLabWebhook)))
-	m.Get(apirouter.BitbucketServerWebhooks).Handler(trace.Route(webhookMiddleware.Logger(handlers.BitbucketServerWebhook)))
-	m.Get(apirouter.BitbucketCloudWebhooks).Handler(trace.Route(webhookMiddleware.Logger(handlers.BitbucketCloudWebhook)))
+	m.Get(apirouter.GitLabWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&gitlabWebhook)))
+	m.Get(apirouter.BitbucketServerWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&bbsWebhook)))
+	m.Get(apirouter.BitbucketCloudWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&bbcWebhook)))
+	m.Get(apirouter.GiteaWebhooks).Handler(trace.Route(webhookMiddleware.Logger(&giteaWebhook)))
 	m.Get(apirouter.LSIFUpload).Handler(trace.Route(handlers.NewCodeIntelUploadHandler(false)))
 	m.Get(apirouter.ComputeStream).Handler(trace.Route(handlers.NewComputeStreamHandler()))
 
 	ghSync := repos.GitHubWebhookHandler{}
 	ghSync.Register(&gh)
 
+	gitlabSync := repos.GitLabWebhookHandler{}
+	gitlabSync.Register(&gitlabWebhook)
+
+	bbsSync := repos.BitbucketServerWebhookHandler{}
+	bbsSync.Register(&bbsWebhook)
+
+	bbcSync := repos.BitbucketCloudWebhookHandler{}
+	bbcSync.Register(&bbcWebhook)
+
+	giteaSync := repos.GiteaWebhookHandler{}
+	giteaSync.Register(&giteaWebhook)
+
 	if envvar.SourcegraphDotComMode() {
 		m.Path("/updates").Methods("GET", "POST").Name("updatecheck").Handler(trace.Route(http.HandlerFunc(updatecheck.Handler)))
 	}
@@ -0,0 +1,448 @@
+package scim
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// filter is a parsed SCIM filter expression (RFC 7644, section 3.4.2.2) that can be evaluated
+// against a resource in its JSON representation.
+type filter interface {
+	match(resource map[string]any) bool
+}
+
+type logicalFilter struct {
+	and         bool // otherwise "or"
+	left, right filter
+}
+
+func (f logicalFilter) match(resource map[string]any) bool {
+	if f.and {
+		return f.left.match(resource) && f.right.match(resource)
+	}
+	return f.left.match(resource) || f.right.match(resource)
+}
+
+type notFilter struct {
+	filter filter
+}
+
+func (f notFilter) match(resource map[string]any) bool { return !f.filter.match(resource) }
+
+// attributeFilter compares the values of an attribute, e.g. `userName eq "alice"` or `title pr`.
+type attributeFilter struct {
+	path  attributePath
+	op    string
+	value any // string, float64, bool or nil
+}
+
+func (f attributeFilter) match(resource map[string]any) bool {
+	values := f.path.values(resource)
+	switch f.op {
+	case "pr":
+		for _, v := range values {
+			if v != nil && v != "" {
+				return true
+			}
+		}
+		return false
+	case "ne":
+		return !(attributeFilter{path: f.path, op: "eq", value: f.value}).match(resource)
+	}
+
+	if f.value == nil && f.op == "eq" {
+		return !(attributeFilter{path: f.path, op: "pr"}).match(resource)
+	}
+	for _, v := range values {
+		if compare(v, f.op, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// valuePathFilter matches resources where at least one value of a multi-valued attribute matches a
+// filter, e.g. `emails[type eq "work" and value ew "@example.com"]`.
+type valuePathFilter struct {
+	path   attributePath
+	filter filter
+}
+
+func (f valuePathFilter) match(resource map[string]any) bool {
+	for _, v := range f.path.values(resource) {
+		if m, ok := v.(map[string]any); ok && f.filter.match(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// compare reports whether the attribute value v compares to the filter value with the given
+// operator. Strings are compared case-insensitively.
+func compare(v any, op string, value any) bool {
+	switch value := value.(type) {
+	case string:
+		s, ok := v.(string)
+		if !ok {
+			return false
+		}
+		s, value = strings.ToLower(s), strings.ToLower(value)
+		switch op {
+		case "eq":
+			return s == value
+		case "co":
+			return strings.Contains(s, value)
+		case "sw":
+			return strings.HasPrefix(s, value)
+		case "ew":
+			return strings.HasSuffix(s, value)
+		case "gt":
+			return s > value
+		case "ge":
+			return s >= value
+		case "lt":
+			return s < value
+		case "le":
+			return s <= value
+		}
+	case float64:
+		n, ok := v.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return n == value
+		case "gt":
+			return n > value
+		case "ge":
+			return n >= value
+		case "lt":
+			return n < value
+		case "le":
+			return n <= value
+		}
+	case bool:
+		b, ok := parseBool(v)
+		return ok && op == "eq" && b == value
+	}
+	return false
+}
+
+// attributePath is a reference to a (sub-)attribute such as "userName", "name.givenName" or
+// "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department".
+type attributePath struct {
+	// schema is the URN of a schema extension. It is empty for attributes of the core schemas,
+	// which live at the top level of a resource.
+	schema string
+	names  []string
+}
+
+func parseAttributePath(s string) (attributePath, error) {
+	var p attributePath
+	if strings.HasPrefix(strings.ToLower(s), "urn:") {
+		i := strings.LastIndex(s, ":")
+		p.schema, s = s[:i], s[i+1:]
+		if isCoreSchema(p.schema) {
+			p.schema = ""
+		}
+	}
+	for _, name := range strings.Split(s, ".") {
+		if !isAttributeName(name) {
+			return p, errors.Errorf("invalid attribute path %q", s)
+		}
+		p.names = append(p.names, name)
+	}
+	if len(p.names) > 2 {
+		return p, errors.Errorf("invalid attribute path %q: too many sub-attributes", s)
+	}
+	return p, nil
+}
+
+func isAttributeName(s string) bool {
+	if s == "" || !unicode.IsLetter(rune(s[0])) && s[0] != '$' {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '$' {
+			return false
+		}
+	}
+	return true
+}
+
+func isCoreSchema(urn string) bool {
+	return strings.EqualFold(urn, userSchema) || strings.EqualFold(urn, groupSchema)
+}
+
+// container returns the object that holds the top-level attribute of the path, which is either the
+// resource itself or the object of a schema extension. If create is true, missing schema extension
+// objects are added to the resource.
+func (p attributePath) container(resource map[string]any, create bool) map[string]any {
+	if p.schema == "" {
+		return resource
+	}
+	key, v := lookup(resource, p.schema)
+	if m, ok := v.(map[string]any); ok {
+		return m
+	}
+	if !create {
+		return nil
+	}
+	m := map[string]any{}
+	if key == "" {
+		key = p.schema
+	}
+	resource[key] = m
+	return m
+}
+
+// values returns all values of the attribute in the resource. Multi-valued attributes are
+// flattened.
+func (p attributePath) values(resource map[string]any) []any {
+	current := []any{p.container(resource, false)}
+	for _, name := range p.names {
+		var next []any
+		for _, c := range current {
+			m, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			_, v := lookup(m, name)
+			if vs, ok := v.([]any); ok {
+				next = append(next, vs...)
+			} else if v != nil {
+				next = append(next, v)
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+// lookup finds an attribute by its case-insensitive name and returns the key it is stored under.
+func lookup(m map[string]any, name string) (string, any) {
+	if v, ok := m[name]; ok {
+		return name, v
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, name) {
+			return k, v
+		}
+	}
+	return "", nil
+}
+
+// parseBool accepts booleans as well as the strings "true" and "false" in any case, which some
+// identity providers (e.g. Azure AD) send.
+func parseBool(v any) (value, ok bool) {
+	switch v := v.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(strings.ToLower(v))
+		return b, err == nil
+	}
+	return false, false
+}
+
+func parseFilter(s string) (filter, error) {
+	tokens, err := tokenizeFilter(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != "" {
+		return nil, errors.Errorf("unexpected %q in filter", t)
+	}
+	return f, nil
+}
+
+type filterParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *filterParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *filterParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *filterParser) expect(want string) error {
+	if t := p.next(); t != want {
+		if t == "" {
+			return errors.Errorf("expected %q at end of filter", want)
+		}
+		return errors.Errorf("expected %q in filter, got %q", want, t)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filter, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "and") {
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalFilter{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseTerm() (filter, error) {
+	t := p.next()
+	switch {
+	case t == "":
+		return nil, errors.New("unexpected end of filter")
+	case t == "(":
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return f, p.expect(")")
+	case strings.EqualFold(t, "not"):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return notFilter{filter: f}, p.expect(")")
+	}
+
+	path, err := parseAttributePath(t)
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() == "[" {
+		p.next()
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return valuePathFilter{path: path, filter: f}, p.expect("]")
+	}
+
+	op := strings.ToLower(p.next())
+	switch op {
+	case "pr":
+		return attributeFilter{path: path, op: op}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, errors.Errorf("invalid operator %q in filter", op)
+	}
+
+	value, err := parseFilterValue(p.next())
+	if err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	case bool, nil:
+		if op != "eq" && op != "ne" {
+			return nil, errors.Errorf("operator %q cannot be used with %v", op, value)
+		}
+	case float64:
+		if op == "co" || op == "sw" || op == "ew" {
+			return nil, errors.Errorf("operator %q cannot be used with a number", op)
+		}
+	}
+	return attributeFilter{path: path, op: op, value: value}, nil
+}
+
+func parseFilterValue(t string) (any, error) {
+	switch strings.ToLower(t) {
+	case "":
+		return nil, errors.New("expected a value at end of filter")
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if t[0] == '"' {
+		var s string
+		if err := json.Unmarshal([]byte(t), &s); err != nil {
+			return nil, errors.Errorf("invalid string %s in filter", t)
+		}
+		return s, nil
+	}
+	n, err := strconv.ParseFloat(t, 64)
+	if err != nil {
+		return nil, errors.Errorf("invalid value %q in filter", t)
+	}
+	return n, nil
+}
+
+// tokenizeFilter splits a filter into parentheses, brackets, strings (including their quotes) and
+// words.
+func tokenizeFilter(s string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			tokens = append(tokens, s[i:i+1])
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, errors.New("unterminated string in filter")
+			}
+			tokens = append(tokens, s[i:j+1])
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n\r()[]\"", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,108 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	var alice map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"id": "1",
+		"userName": "alice@example.com",
+		"externalId": "00u1",
+		"active": true,
+		"name": {"givenName": "Alice", "familyName": "Liddell"},
+		"emails": [
+			{"value": "alice@example.com", "type": "work", "primary": true},
+			{"value": "alice@home.example", "type": "home"}
+		],
+		"loginCount": 3,
+		"meta": {"lastModified": "2022-08-01T12:00:00Z"},
+		"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "Engineering"}
+	}`), &alice))
+
+	for filter, want := range map[string]bool{
+		`userName eq "alice@example.com"`:                                true,
+		`USERNAME EQ "ALICE@EXAMPLE.COM"`:                                true,
+		`userName eq "bob@example.com"`:                                  false,
+		`userName ne "bob@example.com"`:                                  true,
+		`userName co "example"`:                                          true,
+		`userName sw "alice"`:                                            true,
+		`userName ew ".com"`:                                             true,
+		`externalId eq "00u1" and active eq true`:                        true,
+		`externalId eq "00u2" or active eq "true"`:                       false,
+		`externalId eq "00u2" or active eq true`:                         true,
+		`not (active eq true)`:                                           false,
+		`name.familyName eq "Liddell"`:                                   true,
+		`title pr`:                                                       false,
+		`name pr`:                                                        true,
+		`title eq null`:                                                  true,
+		`emails.value eq "alice@home.example"`:                           true,
+		`emails[type eq "work" and value co "example.com"]`:              true,
+		`emails[type eq "work" and value co "home"]`:                     false,
+		`loginCount gt 2 and loginCount le 3`:                            true,
+		`loginCount lt 3`:                                                false,
+		`meta.lastModified gt "2022-07-01T00:00:00Z"`:                    true,
+		`urn:ietf:params:scim:schemas:core:2.0:User:userName sw "alice"`: true,
+		`userName eq "x" or userName eq "y" and active eq true`:          false,
+		`(userName eq "x" or userName eq "alice@example.com") and active eq true`:                true,
+		`urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department eq "engineering"`: true,
+	} {
+		f, err := parseFilter(filter)
+		require.NoError(t, err, filter)
+		assert.Equal(t, want, f.match(alice), filter)
+	}
+
+	for _, filter := range []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName is "alice"`,
+		`userName eq "alice`,
+		`(userName eq "alice"`,
+		`userName eq "alice")`,
+		`emails[type eq "work"`,
+		`active gt true`,
+		`loginCount co 3`,
+		`userName eq alice`,
+		`not userName eq "alice"`,
+		`a.b.c eq "x"`,
+	} {
+		_, err := parseFilter(filter)
+		assert.Error(t, err, filter)
+	}
+}
+
+func TestAccountDataFilter(t *testing.T) {
+	for filter, want := range map[string]map[string]string{
+		`userName eq "alice@example.com"`:                                            {"userName": "alice@example.com"},
+		`USERNAME eq "alice@example.com" and externalId eq "00u1"`:                   {"userName": "alice@example.com", "externalId": "00u1"},
+		`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "alice@example.com"`: {"userName": "alice@example.com"},
+		`userName eq "alice@example.com" or externalId eq "00u1"`:                    nil,
+		`userName eq "alice@example.com" and userName eq "bob"`:                      nil,
+		`userName ne "alice@example.com"`:                                            nil,
+		`displayName eq "Alice"`:                                                     nil,
+		`not (userName eq "alice@example.com")`:                                      nil,
+	} {
+		t.Run(filter, func(t *testing.T) {
+			f, err := parseFilter(filter)
+			require.NoError(t, err)
+			got, ok := accountDataFilter(f)
+			if want == nil {
+				assert.False(t, ok)
+			} else {
+				require.True(t, ok)
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+
+	got, ok := accountDataFilter(nil)
+	require.True(t, ok)
+	assert.Empty(t, got)
+}
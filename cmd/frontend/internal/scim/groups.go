@@ -0,0 +1,282 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// group holds the attributes of a SCIM group resource that are synced to the organization.
+type group struct {
+	displayName string
+	members     []int32
+}
+
+func parseGroup(resource map[string]any) (*group, error) {
+	var g group
+	_, v := lookup(resource, "displayName")
+	if g.displayName, _ = v.(string); g.displayName == "" {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "displayName is required")
+	}
+
+	_, v = lookup(resource, "members")
+	seen := map[int32]bool{}
+	for _, m := range toSlice(v) {
+		if m == nil {
+			continue
+		}
+		member, _ := m.(map[string]any)
+		_, value := lookup(member, "value")
+		s, _ := value.(string)
+		id, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid member %v", value)
+		}
+		if !seen[int32(id)] {
+			seen[int32(id)] = true
+			g.members = append(g.members, int32(id))
+		}
+	}
+	return &g, nil
+}
+
+// groupResource returns the SCIM representation of the organization. Deactivated users are not
+// listed as members.
+func groupResource(ctx context.Context, db database.DB, org *types.Org) (map[string]any, error) {
+	memberships, err := db.OrgMembers().GetByOrgID(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]int32, 0, len(memberships))
+	for _, m := range memberships {
+		userIDs = append(userIDs, m.UserID)
+	}
+	users, err := db.Users().List(ctx, &database.UsersListOptions{UserIDs: userIDs})
+	if err != nil {
+		return nil, err
+	}
+	members := make([]any, 0, len(users))
+	for _, u := range users {
+		id := strconv.Itoa(int(u.ID))
+		members = append(members, map[string]any{
+			"value":   id,
+			"display": u.Username,
+			"$ref":    meta("User", id, u.CreatedAt, u.UpdatedAt)["location"],
+		})
+	}
+
+	displayName := org.Name
+	if org.DisplayName != nil && *org.DisplayName != "" {
+		displayName = *org.DisplayName
+	}
+	id := strconv.Itoa(int(org.ID))
+	return map[string]any{
+		"schemas":     []any{groupSchema},
+		"id":          id,
+		"displayName": displayName,
+		"members":     members,
+		"meta":        meta("Group", id, org.CreatedAt, org.UpdatedAt),
+	}, nil
+}
+
+func (h *handler) listGroups(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	q, err := parseListQuery(r)
+	if err != nil {
+		return err
+	}
+
+	orgs, err := h.db.Orgs().List(ctx, nil)
+	if err != nil {
+		return err
+	}
+	resources := make([]map[string]any, 0, len(orgs))
+	for _, org := range orgs {
+		resource, err := groupResource(ctx, h.db, org)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, resource)
+	}
+	writeList(w, q, resources)
+	return nil
+}
+
+func (h *handler) getGroup(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseID(r)
+	if err != nil {
+		return err
+	}
+	return h.writeGroup(w, r, h.db, http.StatusOK, id)
+}
+
+func (h *handler) writeGroup(w http.ResponseWriter, r *http.Request, db database.DB, status int, id int32) error {
+	org, err := db.Orgs().GetByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	resource, err := groupResource(r.Context(), db, org)
+	if err != nil {
+		return err
+	}
+	writeResource(w, r, status, resource)
+	return nil
+}
+
+func (h *handler) createGroup(w http.ResponseWriter, r *http.Request) (err error) {
+	ctx := r.Context()
+	resource, err := readResource(r)
+	if err != nil {
+		return err
+	}
+	g, err := parseGroup(resource)
+	if err != nil {
+		return err
+	}
+	// Organization names share the namespace of usernames.
+	name, err := auth.NormalizeUsername(g.displayName)
+	if err != nil {
+		return newError(http.StatusBadRequest, scimTypeInvalidValue, "%s", err)
+	}
+
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	org, err := tx.Orgs().Create(ctx, name, &g.displayName)
+	if err != nil {
+		if database.IsOrgNameAlreadyExists(err) {
+			return newError(http.StatusConflict, scimTypeUniqueness, "organization %q already exists", name)
+		}
+		return err
+	}
+	if err := syncMembers(ctx, tx, org.ID, g.members); err != nil {
+		return err
+	}
+	return h.writeGroup(w, r, tx, http.StatusCreated, org.ID)
+}
+
+func (h *handler) replaceGroup(w http.ResponseWriter, r *http.Request) error {
+	resource, err := readResource(r)
+	if err != nil {
+		return err
+	}
+	return h.updateGroup(w, r, func(map[string]any) (map[string]any, error) {
+		return resource, nil
+	})
+}
+
+func (h *handler) patchGroup(w http.ResponseWriter, r *http.Request) error {
+	ops, err := readPatch(r)
+	if err != nil {
+		return err
+	}
+	return h.updateGroup(w, r, func(resource map[string]any) (map[string]any, error) {
+		return resource, applyPatch(resource, ops)
+	})
+}
+
+// updateGroup updates the organization to the resource returned by update, which is passed the
+// current resource. The name of the organization is not changed.
+func (h *handler) updateGroup(w http.ResponseWriter, r *http.Request, update func(map[string]any) (map[string]any, error)) (err error) {
+	ctx := r.Context()
+	id, err := parseID(r)
+	if err != nil {
+		return err
+	}
+
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	org, err := tx.Orgs().GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	resource, err := groupResource(ctx, tx, org)
+	if err != nil {
+		return err
+	}
+	resource, err = update(resource)
+	if err != nil {
+		return err
+	}
+	g, err := parseGroup(resource)
+	if err != nil {
+		return err
+	}
+
+	if org.DisplayName == nil || *org.DisplayName != g.displayName {
+		if _, err := tx.Orgs().Update(ctx, id, &g.displayName); err != nil {
+			return err
+		}
+	}
+	if err := syncMembers(ctx, tx, id, g.members); err != nil {
+		return err
+	}
+	return h.writeGroup(w, r, tx, http.StatusOK, id)
+}
+
+// syncMembers adds and removes members of the organization so that its members are exactly the
+// given users.
+func syncMembers(ctx context.Context, tx database.DB, orgID int32, userIDs []int32) error {
+	memberships, err := tx.OrgMembers().GetByOrgID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	current := make(map[int32]bool, len(memberships))
+	for _, m := range memberships {
+		current[m.UserID] = true
+	}
+
+	// Deactivated users are not listed as members, so we can't tell whether they already are.
+	// Their memberships are left as they are.
+	users, err := tx.Users().List(ctx, &database.UsersListOptions{UserIDs: userIDs})
+	if err != nil {
+		return err
+	}
+	active := make(map[int32]bool, len(users))
+	for _, u := range users {
+		active[u.ID] = true
+	}
+
+	desired := make(map[int32]bool, len(userIDs))
+	for _, id := range userIDs {
+		desired[id] = true
+		if current[id] || !active[id] {
+			continue
+		}
+		if _, err := tx.OrgMembers().Create(ctx, orgID, id); err != nil {
+			return err
+		}
+	}
+	for id := range current {
+		if desired[id] {
+			continue
+		}
+		if err := tx.OrgMembers().Remove(ctx, orgID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *handler) deleteGroup(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseID(r)
+	if err != nil {
+		return err
+	}
+	if err := h.db.Orgs().Delete(r.Context(), id); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
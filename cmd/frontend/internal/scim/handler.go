@@ -0,0 +1,398 @@
+// Package scim implements the SCIM 2.0 protocol (RFC 7643 and RFC 7644), which identity providers
+// such as Okta and Azure AD use to provision users and groups. Users are backed by Sourcegraph users
+// with an external account of type "scim", and groups are backed by organizations.
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgconn"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// PathPrefix is the path under which the SCIM API is served.
+const PathPrefix = "/.scim/v2"
+
+const (
+	userSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	groupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	listResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	errorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	serviceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	resourceTypeSchema          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+
+	contentType = "application/scim+json"
+
+	// maxResults is the maximum number of resources returned by a list request.
+	maxResults = 1000
+)
+
+// NewHandler returns the handler for the SCIM API, which is mounted at PathPrefix.
+//
+// 🚨 SECURITY: Requests must carry the bearer token from the "scim.authToken" site configuration.
+// The API is not available if it is not set.
+func NewHandler(db database.DB) http.Handler {
+	h := &handler{db: db, logger: log.Scoped("scim", "SCIM user and group provisioning API")}
+
+	r := mux.NewRouter().PathPrefix(PathPrefix).Subrouter()
+	r.Path("/ServiceProviderConfig").Methods("GET").Handler(h.handle(h.serveServiceProviderConfig))
+	r.Path("/ResourceTypes").Methods("GET").Handler(h.handle(h.serveResourceTypes))
+	r.Path("/Users").Methods("GET").Handler(h.handle(h.listUsers))
+	r.Path("/Users").Methods("POST").Handler(h.handle(h.createUser))
+	r.Path("/Users/{id}").Methods("GET").Handler(h.handle(h.getUser))
+	r.Path("/Users/{id}").Methods("PUT").Handler(h.handle(h.replaceUser))
+	r.Path("/Users/{id}").Methods("PATCH").Handler(h.handle(h.patchUser))
+	r.Path("/Users/{id}").Methods("DELETE").Handler(h.handle(h.deleteUser))
+	r.Path("/Groups").Methods("GET").Handler(h.handle(h.listGroups))
+	r.Path("/Groups").Methods("POST").Handler(h.handle(h.createGroup))
+	r.Path("/Groups/{id}").Methods("GET").Handler(h.handle(h.getGroup))
+	r.Path("/Groups/{id}").Methods("PUT").Handler(h.handle(h.replaceGroup))
+	r.Path("/Groups/{id}").Methods("PATCH").Handler(h.handle(h.patchGroup))
+	r.Path("/Groups/{id}").Methods("DELETE").Handler(h.handle(h.deleteGroup))
+	r.NotFoundHandler = h.handle(func(w http.ResponseWriter, r *http.Request) error {
+		return newError(http.StatusNotFound, "", "no such endpoint: %s", r.URL.Path)
+	})
+	r.MethodNotAllowedHandler = h.handle(func(w http.ResponseWriter, r *http.Request) error {
+		return newError(http.StatusMethodNotAllowed, "", "method %s is not supported for %s", r.Method, r.URL.Path)
+	})
+
+	return h.authenticate(r)
+}
+
+type handler struct {
+	db     database.DB
+	logger log.Logger
+}
+
+func (h *handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := conf.Get().ScimAuthToken
+		if token == "" {
+			writeError(w, newError(http.StatusNotFound, "", "SCIM provisioning is not enabled on this Sourcegraph instance"))
+			return
+		}
+
+		scheme, credentials, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !strings.EqualFold(scheme, "Bearer") || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(credentials)), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="SCIM"`)
+			writeError(w, newError(http.StatusUnauthorized, "", "invalid bearer token"))
+			return
+		}
+
+		// 🚨 SECURITY: The identity provider is trusted to manage all users and organizations.
+		next.ServeHTTP(w, r.WithContext(actor.WithInternalActor(r.Context())))
+	})
+}
+
+// handle turns a function that returns an error into an http.Handler that responds with a SCIM
+// error.
+func (h *handler) handle(f func(w http.ResponseWriter, r *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := f(w, r)
+		if err == nil {
+			return
+		}
+
+		var e *scimError
+		switch {
+		case errors.As(err, &e):
+		case errcode.IsNotFound(err):
+			e = newError(http.StatusNotFound, "", "resource not found")
+		case database.IsUsernameExists(err), database.IsEmailExists(err), isConstraintViolation(err, "23505"):
+			e = newError(http.StatusConflict, scimTypeUniqueness, "%s", err)
+		default:
+			h.logger.Error("SCIM request failed", log.String("method", r.Method), log.String("path", r.URL.Path), log.Error(err))
+			e = newError(http.StatusInternalServerError, "", "internal error")
+		}
+		writeError(w, e)
+	})
+}
+
+// isConstraintViolation reports whether err is a Postgres error with the given code.
+func isConstraintViolation(err error, code string) bool {
+	var e *pgconn.PgError
+	return errors.As(err, &e) && e.Code == code
+}
+
+const (
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeNoTarget      = "noTarget"
+	scimTypeMutability    = "mutability"
+	scimTypeUniqueness    = "uniqueness"
+)
+
+// scimError is an error that is returned to the client as a SCIM error response (RFC 7644,
+// section 3.12).
+type scimError struct {
+	Status   int
+	ScimType string
+	Detail   string
+}
+
+func newError(status int, scimType, format string, args ...any) *scimError {
+	return &scimError{Status: status, ScimType: scimType, Detail: fmt.Sprintf(format, args...)}
+}
+
+func (e *scimError) Error() string {
+	return fmt.Sprintf("SCIM error %d: %s", e.Status, e.Detail)
+}
+
+func writeError(w http.ResponseWriter, e *scimError) {
+	writeResponse(w, e.Status, map[string]any{
+		"schemas":  []string{errorSchema},
+		"status":   strconv.Itoa(e.Status),
+		"scimType": e.ScimType,
+		"detail":   e.Detail,
+	})
+}
+
+func writeResponse(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// readJSON decodes the request body, which must be JSON.
+func readJSON(r *http.Request, v any) error {
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && mediaType != contentType && mediaType != "application/json" {
+		return newError(http.StatusUnsupportedMediaType, "", "unsupported content type %q", mediaType)
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return newError(http.StatusBadRequest, scimTypeInvalidSyntax, "invalid JSON: %s", err)
+	}
+	return nil
+}
+
+// readResource decodes a resource from the request body. Attributes that are assigned by the
+// service provider are dropped.
+func readResource(r *http.Request) (map[string]any, error) {
+	var resource map[string]any
+	if err := readJSON(r, &resource); err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidSyntax, "the request body must be an object")
+	}
+	for _, name := range []string{"id", "meta"} {
+		if key, _ := lookup(resource, name); key != "" {
+			delete(resource, key)
+		}
+	}
+	return resource, nil
+}
+
+func readPatch(r *http.Request) ([]patchOperation, error) {
+	var req patchRequest
+	if err := readJSON(r, &req); err != nil {
+		return nil, err
+	}
+	if len(req.Operations) == 0 {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "the request has no operations")
+	}
+	return req.Operations, nil
+}
+
+// parseID parses the ID of a user or group, which are the database IDs of users and organizations.
+func parseID(r *http.Request) (int32, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		return 0, newError(http.StatusNotFound, "", "resource not found")
+	}
+	return int32(id), nil
+}
+
+// meta returns the "meta" attribute of a resource.
+func meta(resourceType, id string, created, lastModified time.Time) map[string]any {
+	return map[string]any{
+		"resourceType": resourceType,
+		"created":      created.UTC().Format(time.RFC3339),
+		"lastModified": lastModified.UTC().Format(time.RFC3339),
+		"location":     strings.TrimSuffix(conf.ExternalURL(), "/") + PathPrefix + "/" + resourceType + "s/" + id,
+	}
+}
+
+// listQuery holds the query parameters of a list request.
+type listQuery struct {
+	filter     filter
+	startIndex int // 1-based
+	count      int
+	attributes attributeSelection
+}
+
+func parseListQuery(r *http.Request) (*listQuery, error) {
+	q := &listQuery{startIndex: 1, count: maxResults, attributes: parseAttributeSelection(r)}
+	params := r.URL.Query()
+
+	if s := params.Get("filter"); s != "" {
+		f, err := parseFilter(s)
+		if err != nil {
+			return nil, newError(http.StatusBadRequest, scimTypeInvalidFilter, "%s", err)
+		}
+		q.filter = f
+	}
+	// Invalid values are interpreted as the defaults (RFC 7644, section 3.4.2.4).
+	if n, err := strconv.Atoi(params.Get("startIndex")); err == nil && n > 1 {
+		q.startIndex = n
+	}
+	if n, err := strconv.Atoi(params.Get("count")); err == nil && n >= 0 && n < maxResults {
+		q.count = n
+	}
+	return q, nil
+}
+
+// writeList filters and paginates the resources and writes them as a list response. Resources are
+// sorted by ID.
+func writeList(w http.ResponseWriter, q *listQuery, resources []map[string]any) {
+	var matches []map[string]any
+	for _, resource := range resources {
+		if q.filter == nil || q.filter.match(resource) {
+			matches = append(matches, resource)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, _ := strconv.Atoi(matches[i]["id"].(string))
+		b, _ := strconv.Atoi(matches[j]["id"].(string))
+		return a < b
+	})
+
+	var page []map[string]any
+	for i := q.startIndex - 1; i < len(matches) && len(page) < q.count; i++ {
+		page = append(page, matches[i])
+	}
+	writePage(w, q, len(matches), page)
+}
+
+// writePage writes a page of resources that were already filtered and paginated as a list
+// response. total is the number of resources that match the filter.
+func writePage(w http.ResponseWriter, q *listQuery, total int, resources []map[string]any) {
+	page := make([]map[string]any, 0, len(resources))
+	for _, resource := range resources {
+		page = append(page, q.attributes.apply(resource))
+	}
+	writeResponse(w, http.StatusOK, map[string]any{
+		"schemas":      []string{listResponseSchema},
+		"totalResults": total,
+		"startIndex":   q.startIndex,
+		"itemsPerPage": len(page),
+		"Resources":    page,
+	})
+}
+
+// attributeSelection implements the "attributes" and "excludedAttributes" query parameters for
+// top-level attributes.
+type attributeSelection struct {
+	attributes, excludedAttributes []string
+}
+
+func parseAttributeSelection(r *http.Request) attributeSelection {
+	split := func(s string) (names []string) {
+		for _, name := range strings.Split(s, ",") {
+			// Sub-attributes select their complete parent attribute.
+			name, _, _ = strings.Cut(strings.TrimSpace(name), ".")
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	params := r.URL.Query()
+	return attributeSelection{
+		attributes:         split(params.Get("attributes")),
+		excludedAttributes: split(params.Get("excludedAttributes")),
+	}
+}
+
+func (s attributeSelection) apply(resource map[string]any) map[string]any {
+	if len(s.attributes) == 0 && len(s.excludedAttributes) == 0 {
+		return resource
+	}
+	selected := make(map[string]any, len(resource))
+	for k, v := range resource {
+		switch {
+		case k == "id" || k == "schemas" || k == "meta":
+			// Always returned.
+		case len(s.attributes) > 0 && !containsFold(s.attributes, k):
+			continue
+		case containsFold(s.excludedAttributes, k):
+			continue
+		}
+		selected[k] = v
+	}
+	return selected
+}
+
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeResource writes a single resource, e.g. in response to a GET or PATCH request.
+func writeResource(w http.ResponseWriter, r *http.Request, status int, resource map[string]any) {
+	if m, ok := resource["meta"].(map[string]any); ok {
+		w.Header().Set("Location", m["location"].(string))
+	}
+	writeResponse(w, status, parseAttributeSelection(r).apply(resource))
+}
+
+func (h *handler) serveServiceProviderConfig(w http.ResponseWriter, r *http.Request) error {
+	writeResponse(w, http.StatusOK, map[string]any{
+		"schemas":          []string{serviceProviderConfigSchema},
+		"documentationUri": "https://docs.sourcegraph.com/admin/auth/scim",
+		"patch":            map[string]any{"supported": true},
+		"bulk":             map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":           map[string]any{"supported": true, "maxResults": maxResults},
+		"changePassword":   map[string]any{"supported": false},
+		"sort":             map[string]any{"supported": false},
+		"etag":             map[string]any{"supported": false},
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": `Authentication with the token from the "scim.authToken" site configuration`,
+			"primary":     true,
+		}},
+	})
+	return nil
+}
+
+func (h *handler) serveResourceTypes(w http.ResponseWriter, r *http.Request) error {
+	resourceType := func(name, schema string) map[string]any {
+		return map[string]any{
+			"schemas":  []string{resourceTypeSchema},
+			"id":       name,
+			"name":     name,
+			"endpoint": "/" + name + "s",
+			"schema":   schema,
+		}
+	}
+	writeResponse(w, http.StatusOK, map[string]any{
+		"schemas":      []string{listResponseSchema},
+		"totalResults": 2,
+		"startIndex":   1,
+		"itemsPerPage": 2,
+		"Resources":    []map[string]any{resourceType("User", userSchema), resourceType("Group", groupSchema)},
+	})
+	return nil
+}
@@ -0,0 +1,596 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const testToken = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// fakeDB is an in-memory implementation of the database methods used by the SCIM API.
+type fakeDB struct {
+	users       map[int32]*types.User
+	deleted     map[int32]bool
+	emails      map[int32][]*database.UserEmail
+	accounts    []*extsvc.Account
+	orgs        map[int32]*types.Org
+	memberships map[int32]map[int32]bool // org ID -> user IDs
+	nextID      int32
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{
+		users:       map[int32]*types.User{},
+		deleted:     map[int32]bool{},
+		emails:      map[int32][]*database.UserEmail{},
+		orgs:        map[int32]*types.Org{},
+		memberships: map[int32]map[int32]bool{},
+	}
+}
+
+func (f *fakeDB) createUser(username string) *types.User {
+	f.nextID++
+	u := &types.User{ID: f.nextID, Username: username, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	f.users[u.ID] = u
+	return u
+}
+
+func (f *fakeDB) addEmail(userID int32, email string) {
+	f.emails[userID] = append(f.emails[userID], &database.UserEmail{UserID: userID, Email: email})
+}
+
+func (f *fakeDB) findEmail(userID int32, email string) (*database.UserEmail, error) {
+	for _, e := range f.emails[userID] {
+		if e.Email == email {
+			return e, nil
+		}
+	}
+	return nil, errors.New("user email not found")
+}
+
+func (f *fakeDB) getUser(id int32) (*types.User, error) {
+	u, ok := f.users[id]
+	if !ok || f.deleted[id] {
+		return nil, database.NewUserNotFoundError(id)
+	}
+	return u, nil
+}
+
+func (f *fakeDB) db() database.DB {
+	users := database.NewStrictMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		return f.getUser(id)
+	})
+	users.GetByUsernameFunc.SetDefaultHook(func(_ context.Context, username string) (*types.User, error) {
+		for id, u := range f.users {
+			if u.Username == username && !f.deleted[id] {
+				return u, nil
+			}
+		}
+		return nil, database.NewUserNotFoundError(0)
+	})
+	users.ListFunc.SetDefaultHook(func(_ context.Context, opt *database.UsersListOptions) ([]*types.User, error) {
+		var list []*types.User
+		for _, id := range opt.UserIDs {
+			if u, err := f.getUser(id); err == nil {
+				list = append(list, u)
+			}
+		}
+		return list, nil
+	})
+	users.UpdateFunc.SetDefaultHook(func(_ context.Context, id int32, update database.UserUpdate) error {
+		u, err := f.getUser(id)
+		if err != nil {
+			return err
+		}
+		if update.Username != "" {
+			u.Username = update.Username
+		}
+		if update.DisplayName != nil {
+			u.DisplayName = *update.DisplayName
+		}
+		return nil
+	})
+	users.DeleteFunc.SetDefaultHook(func(_ context.Context, id int32) error {
+		if _, err := f.getUser(id); err != nil {
+			return err
+		}
+		f.deleted[id] = true
+		delete(f.emails, id)
+		return nil
+	})
+	users.RecoverUsersListFunc.SetDefaultHook(func(_ context.Context, ids []int32) error {
+		for _, id := range ids {
+			if !f.deleted[id] {
+				return database.NewUserNotFoundError(id)
+			}
+			delete(f.deleted, id)
+		}
+		return nil
+	})
+	users.HardDeleteFunc.SetDefaultHook(func(_ context.Context, id int32) error {
+		delete(f.users, id)
+		delete(f.deleted, id)
+		delete(f.emails, id)
+		var accounts []*extsvc.Account
+		for _, a := range f.accounts {
+			if a.UserID != id {
+				accounts = append(accounts, a)
+			}
+		}
+		f.accounts = accounts
+		for _, members := range f.memberships {
+			delete(members, id)
+		}
+		return nil
+	})
+
+	emails := database.NewStrictMockUserEmailsStore()
+	emails.ListByUserFunc.SetDefaultHook(func(_ context.Context, opt database.UserEmailsListOptions) ([]*database.UserEmail, error) {
+		var list []*database.UserEmail
+		for _, e := range f.emails[opt.UserID] {
+			copy := *e
+			list = append(list, &copy)
+		}
+		return list, nil
+	})
+	emails.AddFunc.SetDefaultHook(func(_ context.Context, userID int32, email string, _ *string) error {
+		f.addEmail(userID, email)
+		return nil
+	})
+	emails.SetVerifiedFunc.SetDefaultHook(func(_ context.Context, userID int32, email string, verified bool) error {
+		e, err := f.findEmail(userID, email)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		e.VerifiedAt = &now
+		return nil
+	})
+	emails.SetPrimaryEmailFunc.SetDefaultHook(func(_ context.Context, userID int32, email string) error {
+		e, err := f.findEmail(userID, email)
+		if err != nil {
+			return err
+		}
+		if e.VerifiedAt == nil {
+			return errors.New("primary email must be verified")
+		}
+		for _, other := range f.emails[userID] {
+			other.Primary = other == e
+		}
+		return nil
+	})
+	emails.RemoveFunc.SetDefaultHook(func(_ context.Context, userID int32, email string) error {
+		e, err := f.findEmail(userID, email)
+		if err != nil {
+			return err
+		}
+		if e.Primary {
+			return errors.New("can't delete primary email address")
+		}
+		var kept []*database.UserEmail
+		for _, other := range f.emails[userID] {
+			if other != e {
+				kept = append(kept, other)
+			}
+		}
+		f.emails[userID] = kept
+		return nil
+	})
+
+	accounts := database.NewStrictMockUserExternalAccountsStore()
+	listAccounts := func(opt database.ExternalAccountsListOptions) []*extsvc.Account {
+		var list []*extsvc.Account
+		latest := map[int32]*extsvc.Account{}
+		for _, a := range f.accounts {
+			if (opt.UserID != 0 && a.UserID != opt.UserID) ||
+				(opt.ServiceType != "" && a.ServiceType != opt.ServiceType) ||
+				(opt.ServiceID != "" && a.ServiceID != opt.ServiceID) ||
+				(opt.AccountIDLike != "" && escapeLike(a.AccountID) != opt.AccountIDLike) ||
+				(!opt.IncludeDeleted && f.deleted[a.UserID]) {
+				continue
+			}
+			list = append(list, a)
+			latest[a.UserID] = a
+		}
+		if !opt.LatestPerUser {
+			return list
+		}
+
+		list = list[:0]
+		for _, a := range latest {
+			data, _ := a.Data.Decrypt(context.Background())
+			m, _ := data.(map[string]any)
+			matches := true
+			for key, value := range opt.AccountData {
+				v, _ := m[key].(string)
+				matches = matches && strings.EqualFold(v, value)
+			}
+			if matches {
+				list = append(list, a)
+			}
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].UserID < list[j].UserID })
+		if opt.LimitOffset != nil {
+			if opt.Offset > len(list) {
+				return nil
+			}
+			list = list[opt.Offset:]
+			if opt.Limit < len(list) {
+				list = list[:opt.Limit]
+			}
+		}
+		return list
+	}
+	accounts.ListFunc.SetDefaultHook(func(_ context.Context, opt database.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+		return listAccounts(opt), nil
+	})
+	accounts.CountFunc.SetDefaultHook(func(_ context.Context, opt database.ExternalAccountsListOptions) (int, error) {
+		return len(listAccounts(opt)), nil
+	})
+	accounts.CreateUserAndSaveFunc.SetDefaultHook(func(_ context.Context, newUser database.NewUser, spec extsvc.AccountSpec, data extsvc.AccountData) (int32, error) {
+		for _, u := range f.users {
+			if u.Username == newUser.Username {
+				return 0, errors.New("username exists")
+			}
+		}
+		u := f.createUser(newUser.Username)
+		u.DisplayName = newUser.DisplayName
+		if newUser.Email != "" {
+			now := time.Now()
+			f.emails[u.ID] = []*database.UserEmail{{UserID: u.ID, Email: newUser.Email, VerifiedAt: &now, Primary: true}}
+		}
+		f.nextID++
+		f.accounts = append(f.accounts, &extsvc.Account{ID: f.nextID, UserID: u.ID, AccountSpec: spec, AccountData: data})
+		return u.ID, nil
+	})
+	accounts.AssociateUserAndSaveFunc.SetDefaultHook(func(_ context.Context, userID int32, spec extsvc.AccountSpec, data extsvc.AccountData) error {
+		for _, a := range f.accounts {
+			if a.AccountSpec == spec {
+				if a.UserID != userID {
+					return errors.New("account belongs to another user")
+				}
+				a.AccountData = data
+				a.UpdatedAt = time.Now()
+				return nil
+			}
+		}
+		f.nextID++
+		f.accounts = append(f.accounts, &extsvc.Account{ID: f.nextID, UserID: userID, AccountSpec: spec, AccountData: data})
+		return nil
+	})
+
+	orgs := database.NewStrictMockOrgStore()
+	orgs.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.Org, error) {
+		org, ok := f.orgs[id]
+		if !ok {
+			return nil, &database.OrgNotFoundError{}
+		}
+		return org, nil
+	})
+	orgs.ListFunc.SetDefaultHook(func(context.Context, *database.OrgsListOptions) ([]*types.Org, error) {
+		var list []*types.Org
+		for _, org := range f.orgs {
+			list = append(list, org)
+		}
+		return list, nil
+	})
+	orgs.CreateFunc.SetDefaultHook(func(_ context.Context, name string, displayName *string) (*types.Org, error) {
+		f.nextID++
+		org := &types.Org{ID: f.nextID, Name: name, DisplayName: displayName}
+		f.orgs[org.ID] = org
+		f.memberships[org.ID] = map[int32]bool{}
+		return org, nil
+	})
+	orgs.UpdateFunc.SetDefaultHook(func(_ context.Context, id int32, displayName *string) (*types.Org, error) {
+		f.orgs[id].DisplayName = displayName
+		return f.orgs[id], nil
+	})
+	orgs.DeleteFunc.SetDefaultHook(func(_ context.Context, id int32) error {
+		if _, ok := f.orgs[id]; !ok {
+			return &database.OrgNotFoundError{}
+		}
+		delete(f.orgs, id)
+		return nil
+	})
+
+	members := database.NewStrictMockOrgMemberStore()
+	members.GetByOrgIDFunc.SetDefaultHook(func(_ context.Context, orgID int32) ([]*types.OrgMembership, error) {
+		var list []*types.OrgMembership
+		for userID := range f.memberships[orgID] {
+			if !f.deleted[userID] {
+				list = append(list, &types.OrgMembership{OrgID: orgID, UserID: userID})
+			}
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].UserID < list[j].UserID })
+		return list, nil
+	})
+	members.CreateFunc.SetDefaultHook(func(_ context.Context, orgID, userID int32) (*types.OrgMembership, error) {
+		if f.memberships[orgID][userID] {
+			return nil, errors.New("user is already a member of the organization")
+		}
+		f.memberships[orgID][userID] = true
+		return &types.OrgMembership{OrgID: orgID, UserID: userID}, nil
+	})
+	members.RemoveFunc.SetDefaultHook(func(_ context.Context, orgID, userID int32) error {
+		delete(f.memberships[orgID], userID)
+		return nil
+	})
+
+	db := database.NewStrictMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserEmailsFunc.SetDefaultReturn(emails)
+	db.UserExternalAccountsFunc.SetDefaultReturn(accounts)
+	db.OrgsFunc.SetDefaultReturn(orgs)
+	db.OrgMembersFunc.SetDefaultReturn(members)
+	db.TransactFunc.SetDefaultReturn(db, nil)
+	db.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	return db
+}
+
+func newTestHandler(t *testing.T, f *fakeDB) func(method, path, body string) (int, map[string]any) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{ScimAuthToken: testToken}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	h := NewHandler(f.db())
+	return func(method, path, body string) (int, map[string]any) {
+		t.Helper()
+		req := httptest.NewRequest(method, PathPrefix+path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+testToken)
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		var resp map[string]any
+		if rec.Code != http.StatusNoContent {
+			assert.Equal(t, contentType+"; charset=utf-8", rec.Header().Get("Content-Type"))
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp), rec.Body.String())
+		}
+		return rec.Code, resp
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	h := NewHandler(newFakeDB().db())
+	doRequest := func(authorization string) int {
+		req := httptest.NewRequest("GET", PathPrefix+"/ServiceProviderConfig", nil)
+		req.Header.Set("Authorization", authorization)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	conf.Mock(&conf.Unified{})
+	t.Cleanup(func() { conf.Mock(nil) })
+	assert.Equal(t, http.StatusNotFound, doRequest("Bearer "))
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{ScimAuthToken: testToken}})
+	assert.Equal(t, http.StatusOK, doRequest("Bearer "+testToken))
+	assert.Equal(t, http.StatusOK, doRequest("bearer "+testToken))
+	assert.Equal(t, http.StatusUnauthorized, doRequest(""))
+	assert.Equal(t, http.StatusUnauthorized, doRequest("Bearer wrong"))
+	assert.Equal(t, http.StatusUnauthorized, doRequest("token "+testToken))
+}
+
+func TestUsers(t *testing.T) {
+	f := newFakeDB()
+	do := newTestHandler(t, f)
+
+	// Okta looks up users before creating them.
+	code, resp := do("GET", `/Users?filter=userName+eq+"alice@example.com"&startIndex=1&count=100`, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(0), resp["totalResults"])
+
+	code, resp = do("POST", "/Users", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "alice@example.com",
+		"externalId": "00u1",
+		"name": {"givenName": "Alice", "familyName": "Liddell"},
+		"emails": [{"primary": true, "value": "alice@example.com", "type": "work"}],
+		"password": "secret",
+		"active": true
+	}`)
+	require.Equal(t, http.StatusCreated, code, resp)
+	id := resp["id"].(string)
+	assert.Equal(t, "alice@example.com", resp["userName"])
+	assert.Equal(t, true, resp["active"])
+	assert.Nil(t, resp["password"])
+	assert.Equal(t, "User", resp["meta"].(map[string]any)["resourceType"])
+
+	alice := f.users[1]
+	assert.Equal(t, "alice", alice.Username)
+	assert.Equal(t, "Alice Liddell", alice.DisplayName)
+	require.Len(t, f.accounts, 1)
+	assert.Equal(t, extsvc.AccountSpec{ServiceType: "scim", ServiceID: "scim", AccountID: "00u1"}, f.accounts[0].AccountSpec)
+
+	t.Run("conflict", func(t *testing.T) {
+		code, resp := do("POST", "/Users", `{"userName": "alice@example.com", "externalId": "00u1"}`)
+		assert.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, scimTypeUniqueness, resp["scimType"])
+	})
+
+	t.Run("get", func(t *testing.T) {
+		code, resp := do("GET", "/Users/"+id+"?excludedAttributes=emails", "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "00u1", resp["externalId"])
+		assert.Nil(t, resp["emails"])
+
+		code, _ = do("GET", "/Users/1234", "")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("link existing user", func(t *testing.T) {
+		bob := f.createUser("bob")
+		code, resp := do("POST", "/Users", `{"userName": "bob", "displayName": "Bob", "emails": [{"value": "bob@example.com"}]}`)
+		require.Equal(t, http.StatusCreated, code, resp)
+		assert.Equal(t, bob.ID, f.accounts[1].UserID)
+		assert.Equal(t, "bob", f.accounts[1].AccountID)
+		assert.Equal(t, "Bob", bob.DisplayName)
+		require.Len(t, f.emails[bob.ID], 1)
+		assert.True(t, f.emails[bob.ID][0].Primary)
+		assert.NotNil(t, f.emails[bob.ID][0].VerifiedAt)
+
+		code, resp = do("GET", `/Users?filter=emails[value+eq+"bob@example.com"]`, "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, float64(1), resp["totalResults"])
+	})
+
+	t.Run("change email", func(t *testing.T) {
+		code, resp := do("PATCH", "/Users/"+id, `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "alice@example.org"}]
+		}`)
+		require.Equal(t, http.StatusOK, code, resp)
+		require.Len(t, f.emails[alice.ID], 1)
+		assert.Equal(t, "alice@example.org", f.emails[alice.ID][0].Email)
+		assert.True(t, f.emails[alice.ID][0].Primary)
+	})
+
+	t.Run("deactivate and reactivate", func(t *testing.T) {
+		code, resp := do("PATCH", "/Users/"+id, `{"Operations": [{"op": "Replace", "path": "active", "value": "False"}]}`)
+		require.Equal(t, http.StatusOK, code, resp)
+		assert.Equal(t, false, resp["active"])
+		assert.True(t, f.deleted[alice.ID])
+
+		code, resp = do("GET", `/Users?filter=active+eq+false`, "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, float64(1), resp["totalResults"])
+
+		// Updates of inactive users are kept.
+		code, resp = do("PUT", "/Users/"+id, `{
+			"userName": "alice.liddell@example.com",
+			"externalId": "00u1",
+			"displayName": "Alice L.",
+			"emails": [{"primary": true, "value": "alice@example.org", "type": "work"}],
+			"active": false
+		}`)
+		require.Equal(t, http.StatusOK, code, resp)
+		assert.Equal(t, "alice.liddell@example.com", resp["userName"])
+		assert.True(t, f.deleted[alice.ID])
+
+		code, resp = do("PATCH", "/Users/"+id, `{"Operations": [{"op": "replace", "value": {"active": true}}]}`)
+		require.Equal(t, http.StatusOK, code, resp)
+		assert.Equal(t, true, resp["active"])
+		assert.False(t, f.deleted[alice.ID])
+		assert.Equal(t, "alice.liddell", alice.Username)
+		assert.Equal(t, "Alice L.", alice.DisplayName)
+		require.Len(t, f.emails[alice.ID], 1)
+		assert.Equal(t, "alice@example.org", f.emails[alice.ID][0].Email)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		code, resp := do("GET", "/Users?startIndex=2&count=1", "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, float64(2), resp["totalResults"])
+		assert.Equal(t, float64(2), resp["startIndex"])
+		assert.Equal(t, float64(1), resp["itemsPerPage"])
+		assert.Equal(t, "bob", resp["Resources"].([]any)[0].(map[string]any)["userName"])
+
+		code, resp = do("GET", `/Users?filter=userName+eq+"ALICE.LIDDELL@example.com"`, "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, float64(1), resp["totalResults"])
+		assert.Equal(t, id, resp["Resources"].([]any)[0].(map[string]any)["id"])
+
+		code, resp = do("GET", `/Users?filter=externalId+eq+"00u1"+and+userName+eq+"bob"`, "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, float64(0), resp["totalResults"])
+
+		code, resp = do("GET", "/Users?filter=userName+eq", "")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, scimTypeInvalidFilter, resp["scimType"])
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		code, _ := do("DELETE", "/Users/"+id, "")
+		require.Equal(t, http.StatusNoContent, code)
+		assert.Nil(t, f.users[alice.ID])
+
+		code, _ = do("GET", "/Users/"+id, "")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
+
+func TestGroups(t *testing.T) {
+	f := newFakeDB()
+	do := newTestHandler(t, f)
+	alice, bob, carol := f.createUser("alice"), f.createUser("bob"), f.createUser("carol")
+
+	code, resp := do("POST", "/Groups", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+		"displayName": "Engineering Team",
+		"members": [{"value": "1"}, {"value": "2"}]
+	}`)
+	require.Equal(t, http.StatusCreated, code, resp)
+	id := resp["id"].(string)
+	org := f.orgs[4]
+	assert.Equal(t, "Engineering-Team", org.Name)
+	assert.Equal(t, map[int32]bool{alice.ID: true, bob.ID: true}, f.memberships[org.ID])
+	assert.Len(t, resp["members"], 2)
+
+	t.Run("patch members", func(t *testing.T) {
+		code, resp := do("PATCH", "/Groups/"+id, `{"Operations": [
+			{"op": "add", "path": "members", "value": [{"value": "3"}]},
+			{"op": "remove", "path": "members[value eq \"1\"]"}
+		]}`)
+		require.Equal(t, http.StatusOK, code, resp)
+		assert.Equal(t, map[int32]bool{bob.ID: true, carol.ID: true}, f.memberships[org.ID])
+
+		// Azure AD passes the members to remove as the value.
+		code, resp = do("PATCH", "/Groups/"+id, `{"Operations": [{"op": "Remove", "path": "members", "value": [{"value": "2"}]}]}`)
+		require.Equal(t, http.StatusOK, code, resp)
+		assert.Equal(t, map[int32]bool{carol.ID: true}, f.memberships[org.ID])
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		code, resp := do("PATCH", "/Groups/"+id, `{"Operations": [{"op": "replace", "path": "displayName", "value": "Platform"}]}`)
+		require.Equal(t, http.StatusOK, code, resp)
+		assert.Equal(t, "Platform", *org.DisplayName)
+		assert.Equal(t, "Engineering-Team", org.Name)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		code, resp := do("PUT", "/Groups/"+id, `{"displayName": "Platform", "members": [{"value": "1"}, {"value": "2"}]}`)
+		require.Equal(t, http.StatusOK, code, resp)
+		assert.Equal(t, map[int32]bool{alice.ID: true, bob.ID: true}, f.memberships[org.ID])
+
+		code, resp = do("PUT", "/Groups/"+id, `{"displayName": "Platform", "members": [{"value": "alice"}]}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, scimTypeInvalidValue, resp["scimType"])
+	})
+
+	t.Run("list", func(t *testing.T) {
+		code, resp := do("GET", `/Groups?filter=displayName+eq+"platform"&excludedAttributes=members`, "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, float64(1), resp["totalResults"])
+		group := resp["Resources"].([]any)[0].(map[string]any)
+		assert.Equal(t, id, group["id"])
+		assert.Nil(t, group["members"])
+
+		code, resp = do("GET", `/Groups?filter=members[value+eq+"3"]`, "")
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, float64(0), resp["totalResults"])
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		code, _ := do("DELETE", "/Groups/"+id, "")
+		require.Equal(t, http.StatusNoContent, code)
+		code, _ = do("GET", "/Groups/"+id, "")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
@@ -0,0 +1,306 @@
+package scim
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// patchOperation is a single operation of a PATCH request (RFC 7644, section 3.5.2).
+type patchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+// patchPath is the target of a patch operation, e.g. `displayName`, `name.givenName`, `members` or
+// `emails[type eq "work"].value`.
+type patchPath struct {
+	attributePath
+	filter       filter // selects values of a multi-valued attribute, if not nil
+	subAttribute string // the sub-attribute of the values selected by filter
+}
+
+func parsePatchPath(s string) (patchPath, error) {
+	var p patchPath
+	rest := ""
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		j := strings.LastIndexByte(s, ']')
+		if j < i {
+			return p, errors.Errorf("invalid path %q", s)
+		}
+		f, err := parseFilter(s[i+1 : j])
+		if err != nil {
+			return p, err
+		}
+		p.filter = f
+		s, rest = s[:i], s[j+1:]
+	}
+
+	var err error
+	p.attributePath, err = parseAttributePath(s)
+	if err != nil {
+		return p, err
+	}
+	if p.filter != nil {
+		if len(p.names) != 1 {
+			return p, errors.Errorf("invalid path %q: filters are only allowed on top-level attributes", s)
+		}
+		if rest != "" {
+			if rest[0] != '.' || !isAttributeName(rest[1:]) {
+				return p, errors.Errorf("invalid sub-attribute %q in path", rest)
+			}
+			p.subAttribute = rest[1:]
+		}
+	}
+	return p, nil
+}
+
+// applyPatch applies the operations to the JSON representation of a resource in place. Errors are
+// of type *scimError.
+func applyPatch(resource map[string]any, ops []patchOperation) error {
+	for _, op := range ops {
+		if err := applyPatchOperation(resource, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyPatchOperation(resource map[string]any, op patchOperation) error {
+	// Azure AD capitalizes operations.
+	kind := strings.ToLower(op.Op)
+	if kind != "add" && kind != "replace" && kind != "remove" {
+		return newError(400, scimTypeInvalidSyntax, "invalid patch operation %q", op.Op)
+	}
+
+	if op.Path == "" {
+		if kind == "remove" {
+			return newError(400, scimTypeNoTarget, "remove operations require a path")
+		}
+		values, ok := op.Value.(map[string]any)
+		if !ok {
+			return newError(400, scimTypeInvalidValue, "the value of a patch operation without a path must be an object")
+		}
+		for k, v := range values {
+			// Schema extensions are objects keyed by their URN, whose attributes are addressed
+			// as "<urn>:<attribute>".
+			if m, ok := v.(map[string]any); ok && strings.HasPrefix(strings.ToLower(k), "urn:") {
+				for name, v := range m {
+					if err := applyPatchOperation(resource, patchOperation{Op: kind, Path: k + ":" + name, Value: v}); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			// The keys may be paths as well, e.g. "name.givenName" (Azure AD does this).
+			if err := applyPatchOperation(resource, patchOperation{Op: kind, Path: k, Value: v}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path, err := parsePatchPath(op.Path)
+	if err != nil {
+		return newError(400, scimTypeInvalidPath, "%s", err)
+	}
+	if kind != "remove" && op.Value == nil {
+		return newError(400, scimTypeInvalidValue, "%s operations require a value", op.Op)
+	}
+	if isReadOnly(path) {
+		return newError(400, scimTypeMutability, "attribute %q is read-only", op.Path)
+	}
+
+	container := path.container(resource, kind != "remove")
+	if container == nil {
+		return nil // nothing to remove
+	}
+	if len(path.names) == 2 {
+		// A sub-attribute of a complex attribute, e.g. "name.givenName".
+		key, v := lookup(container, path.names[0])
+		parent, ok := v.(map[string]any)
+		if !ok {
+			if kind == "remove" {
+				return nil
+			}
+			parent = map[string]any{}
+			if key == "" {
+				key = path.names[0]
+			}
+			container[key] = parent
+		}
+		return patchAttribute(parent, path.names[1], kind, op.Value)
+	}
+	if path.filter != nil {
+		return patchFilteredValues(container, path, kind, op.Value)
+	}
+	return patchAttribute(container, path.names[0], kind, op.Value)
+}
+
+// patchAttribute adds, replaces or removes a top-level attribute of the object.
+func patchAttribute(object map[string]any, name, kind string, value any) error {
+	key, current := lookup(object, name)
+	if key == "" {
+		key = name
+	}
+
+	switch kind {
+	case "remove":
+		// Azure AD removes members of a group by passing them as the value.
+		if values, ok := current.([]any); ok && value != nil {
+			object[key] = removeValues(values, toSlice(value))
+			return nil
+		}
+		delete(object, key)
+
+	case "add":
+		// Values are added to multi-valued attributes, unless they are already present.
+		if values, ok := current.([]any); ok {
+			for _, v := range toSlice(value) {
+				if !containsValue(values, v) {
+					values = append(values, v)
+				}
+			}
+			object[key] = values
+			return nil
+		}
+		// Complex attributes are merged.
+		if m, ok := current.(map[string]any); ok {
+			if add, ok := value.(map[string]any); ok {
+				for k, v := range add {
+					if err := patchAttribute(m, k, kind, v); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+		object[key] = value
+
+	case "replace":
+		object[key] = value
+	}
+	return nil
+}
+
+// patchFilteredValues adds, replaces or removes the values of a multi-valued attribute that match
+// the path's filter, or their sub-attribute.
+func patchFilteredValues(container map[string]any, path patchPath, kind string, value any) error {
+	key, current := lookup(container, path.names[0])
+	if key == "" {
+		key = path.names[0]
+	}
+	values, _ := current.([]any)
+
+	var kept []any
+	matched := false
+	for _, v := range values {
+		m, ok := v.(map[string]any)
+		if !ok || !path.filter.match(m) {
+			kept = append(kept, v)
+			continue
+		}
+		matched = true
+
+		switch {
+		case kind == "remove" && path.subAttribute == "":
+			continue // drop the value
+		case path.subAttribute == "":
+			// Replace or merge the whole value.
+			replacement, ok := value.(map[string]any)
+			if !ok {
+				return newError(400, scimTypeInvalidValue, "the value for %q must be an object", path.names[0])
+			}
+			if kind == "replace" {
+				m = map[string]any{}
+			}
+			for k, v := range replacement {
+				m[k] = v
+			}
+		default:
+			if err := patchAttribute(m, path.subAttribute, kind, value); err != nil {
+				return err
+			}
+		}
+		kept = append(kept, m)
+	}
+
+	if !matched && kind != "remove" {
+		// Azure AD sets e.g. `emails[type eq "work"].value` on users that have no work email yet,
+		// so we create the value if the filter describes it unambiguously.
+		f, ok := path.filter.(attributeFilter)
+		if !ok || f.op != "eq" || len(f.path.names) != 1 || path.subAttribute == "" {
+			return newError(400, scimTypeNoTarget, "no values match the filter of path %q", path.names[0])
+		}
+		kept = append(kept, map[string]any{f.path.names[0]: f.value, path.subAttribute: value})
+	}
+
+	if kept == nil {
+		delete(container, key)
+	} else {
+		container[key] = kept
+	}
+	return nil
+}
+
+// isReadOnly reports whether the path refers to an attribute that clients cannot change.
+func isReadOnly(p patchPath) bool {
+	if p.schema != "" {
+		return false
+	}
+	name := p.names[0]
+	return strings.EqualFold(name, "id") || strings.EqualFold(name, "meta") || strings.EqualFold(name, "schemas")
+}
+
+func toSlice(v any) []any {
+	if vs, ok := v.([]any); ok {
+		return vs
+	}
+	return []any{v}
+}
+
+func containsValue(values []any, v any) bool {
+	for _, existing := range values {
+		if sameValue(existing, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func removeValues(values, remove []any) []any {
+	var kept []any
+	for _, v := range values {
+		if !containsValue(remove, v) {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// sameValue reports whether two values of a multi-valued attribute are the same. Complex values
+// with a "value" sub-attribute (such as emails and members) are identified by it.
+func sameValue(a, b any) bool {
+	am, aok := a.(map[string]any)
+	bm, bok := b.(map[string]any)
+	if aok && bok {
+		_, av := lookup(am, "value")
+		_, bv := lookup(bm, "value")
+		if av != nil || bv != nil {
+			as, aok := av.(string)
+			bs, bok := bv.(string)
+			if aok && bok {
+				return strings.EqualFold(as, bs)
+			}
+			return reflect.DeepEqual(av, bv)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,153 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPatch(t *testing.T) {
+	const resource = `{
+		"userName": "alice",
+		"active": true,
+		"name": {"givenName": "Alice"},
+		"emails": [{"value": "alice@example.com", "type": "work", "primary": true}],
+		"members": [{"value": "1"}, {"value": "2"}]
+	}`
+
+	for name, tc := range map[string]struct {
+		ops  string
+		want string
+	}{
+		"replace without path": {
+			ops: `[{"op": "replace", "value": {"active": false, "name.familyName": "Liddell"}}]`,
+			want: `{
+				"userName": "alice",
+				"active": false,
+				"name": {"givenName": "Alice", "familyName": "Liddell"},
+				"emails": [{"value": "alice@example.com", "type": "work", "primary": true}],
+				"members": [{"value": "1"}, {"value": "2"}]
+			}`,
+		},
+		"Azure AD capitalization": {
+			ops: `[{"op": "Replace", "path": "active", "value": "False"}]`,
+			want: `{
+				"userName": "alice",
+				"active": "False",
+				"name": {"givenName": "Alice"},
+				"emails": [{"value": "alice@example.com", "type": "work", "primary": true}],
+				"members": [{"value": "1"}, {"value": "2"}]
+			}`,
+		},
+		"add to multi-valued attribute": {
+			ops: `[{"op": "add", "path": "members", "value": [{"value": "2"}, {"value": "3"}]}]`,
+			want: `{
+				"userName": "alice",
+				"active": true,
+				"name": {"givenName": "Alice"},
+				"emails": [{"value": "alice@example.com", "type": "work", "primary": true}],
+				"members": [{"value": "1"}, {"value": "2"}, {"value": "3"}]
+			}`,
+		},
+		"remove with filter": {
+			ops: `[{"op": "remove", "path": "members[value eq \"1\"]"}]`,
+			want: `{
+				"userName": "alice",
+				"active": true,
+				"name": {"givenName": "Alice"},
+				"emails": [{"value": "alice@example.com", "type": "work", "primary": true}],
+				"members": [{"value": "2"}]
+			}`,
+		},
+		"remove with value": {
+			ops: `[{"op": "Remove", "path": "members", "value": [{"value": "2"}]}]`,
+			want: `{
+				"userName": "alice",
+				"active": true,
+				"name": {"givenName": "Alice"},
+				"emails": [{"value": "alice@example.com", "type": "work", "primary": true}],
+				"members": [{"value": "1"}]
+			}`,
+		},
+		"replace sub-attribute of filtered values": {
+			ops: `[{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "alice@example.org"}]`,
+			want: `{
+				"userName": "alice",
+				"active": true,
+				"name": {"givenName": "Alice"},
+				"emails": [{"value": "alice@example.org", "type": "work", "primary": true}],
+				"members": [{"value": "1"}, {"value": "2"}]
+			}`,
+		},
+		"add value for filter without match": {
+			ops: `[{"op": "add", "path": "emails[type eq \"home\"].value", "value": "alice@home.example"}]`,
+			want: `{
+				"userName": "alice",
+				"active": true,
+				"name": {"givenName": "Alice"},
+				"emails": [
+					{"value": "alice@example.com", "type": "work", "primary": true},
+					{"value": "alice@home.example", "type": "home"}
+				],
+				"members": [{"value": "1"}, {"value": "2"}]
+			}`,
+		},
+		"remove attribute": {
+			ops: `[{"op": "remove", "path": "name.givenName"}, {"op": "remove", "path": "members"}]`,
+			want: `{
+				"userName": "alice",
+				"active": true,
+				"name": {},
+				"emails": [{"value": "alice@example.com", "type": "work", "primary": true}]
+			}`,
+		},
+		"schema extension": {
+			ops: `[{"op": "add", "value": {"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "Engineering"}}}]`,
+			want: `{
+				"userName": "alice",
+				"active": true,
+				"name": {"givenName": "Alice"},
+				"emails": [{"value": "alice@example.com", "type": "work", "primary": true}],
+				"members": [{"value": "1"}, {"value": "2"}],
+				"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "Engineering"}
+			}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var r map[string]any
+			require.NoError(t, json.Unmarshal([]byte(resource), &r))
+			var ops []patchOperation
+			require.NoError(t, json.Unmarshal([]byte(tc.ops), &ops))
+
+			require.NoError(t, applyPatch(r, ops))
+			got, err := json.Marshal(r)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+
+	for name, ops := range map[string]string{
+		"invalid op":              `[{"op": "move", "path": "userName"}]`,
+		"remove without path":     `[{"op": "remove"}]`,
+		"read-only attribute":     `[{"op": "replace", "path": "id", "value": "2"}]`,
+		"invalid path":            `[{"op": "replace", "path": "emails[type eq]", "value": "x"}]`,
+		"no match":                `[{"op": "replace", "path": "emails[type co \"home\"].value", "value": "x"}]`,
+		"value without path":      `[{"op": "add", "value": "alice"}]`,
+		"replace without value":   `[{"op": "replace", "path": "userName"}]`,
+		"filter on sub-attribute": `[{"op": "replace", "path": "name.givenName[value eq \"x\"]", "value": "x"}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var r map[string]any
+			require.NoError(t, json.Unmarshal([]byte(resource), &r))
+			var patch []patchOperation
+			require.NoError(t, json.Unmarshal([]byte(ops), &patch))
+
+			err := applyPatch(r, patch)
+			var e *scimError
+			require.ErrorAs(t, err, &e)
+			assert.Equal(t, 400, e.Status)
+		})
+	}
+}
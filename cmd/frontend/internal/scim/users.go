@@ -0,0 +1,557 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// Users provisioned through SCIM have an external account with this service type and ID. The
+// account ID is the user's externalId (or userName, if the identity provider doesn't send an
+// externalId), and the account data is the SCIM resource as last sent by the identity provider.
+const (
+	serviceType = "scim"
+	serviceID   = "scim"
+)
+
+// user holds the attributes of a SCIM user resource that are synced to the Sourcegraph user.
+type user struct {
+	userName    string
+	externalID  string
+	displayName string
+	emails      []string // the primary email first
+	active      bool
+}
+
+func parseUser(resource map[string]any) (*user, error) {
+	var u user
+	var ok bool
+	_, v := lookup(resource, "userName")
+	if u.userName, ok = v.(string); !ok || u.userName == "" {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "userName is required")
+	}
+	_, v = lookup(resource, "externalId")
+	u.externalID, _ = v.(string)
+
+	_, v = lookup(resource, "displayName")
+	u.displayName, _ = v.(string)
+	if _, v := lookup(resource, "name"); u.displayName == "" && v != nil {
+		name, _ := v.(map[string]any)
+		_, formatted := lookup(name, "formatted")
+		_, givenName := lookup(name, "givenName")
+		_, familyName := lookup(name, "familyName")
+		if u.displayName, _ = formatted.(string); u.displayName == "" {
+			given, _ := givenName.(string)
+			family, _ := familyName.(string)
+			u.displayName = strings.TrimSpace(given + " " + family)
+		}
+	}
+
+	_, v = lookup(resource, "emails")
+	for _, e := range toSlice(v) {
+		email, _ := e.(map[string]any)
+		_, value := lookup(email, "value")
+		address, _ := value.(string)
+		if address == "" {
+			continue
+		}
+		_, primary := lookup(email, "primary")
+		if isPrimary, _ := parseBool(primary); isPrimary {
+			u.emails = append([]string{address}, u.emails...)
+		} else {
+			u.emails = append(u.emails, address)
+		}
+	}
+
+	u.active = true
+	if _, v = lookup(resource, "active"); v != nil {
+		if u.active, ok = parseBool(v); !ok {
+			return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "active must be a boolean")
+		}
+	}
+	return &u, nil
+}
+
+// accountData returns the external account data for a SCIM resource. The service provider's
+// attributes and the password, which we don't use, are not stored.
+func accountData(resource map[string]any) (extsvc.AccountData, error) {
+	stored := make(map[string]any, len(resource))
+	for k, v := range resource {
+		switch strings.ToLower(k) {
+		case "id", "meta", "active", "password":
+			continue
+		case "username":
+			// Stored under their canonical names, so that accountDataFilter can look them up.
+			k = "userName"
+		case "externalid":
+			k = "externalId"
+		}
+		stored[k] = v
+	}
+	serialized, err := json.Marshal(stored)
+	if err != nil {
+		return extsvc.AccountData{}, err
+	}
+	return extsvc.AccountData{Data: extsvc.NewUnencryptedData(serialized)}, nil
+}
+
+// userResource returns the SCIM representation of the user with the external account.
+func userResource(ctx context.Context, account *extsvc.Account, active bool) (map[string]any, error) {
+	resource := map[string]any{}
+	if account.Data != nil {
+		data, err := account.Data.Decrypt(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if m, ok := data.(map[string]any); ok {
+			resource = m
+		}
+	}
+	id := strconv.Itoa(int(account.UserID))
+	resource["schemas"] = []any{userSchema}
+	resource["id"] = id
+	resource["active"] = active
+	resource["meta"] = meta("User", id, account.CreatedAt, account.UpdatedAt)
+	return resource, nil
+}
+
+// getAccount returns the SCIM external account of the user and whether the user is active, i.e.
+// not soft-deleted.
+func (h *handler) getAccount(ctx context.Context, db database.DB, userID int32) (*extsvc.Account, bool, error) {
+	accounts, err := db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		UserID:         userID,
+		ServiceType:    serviceType,
+		ServiceID:      serviceID,
+		IncludeDeleted: true,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if len(accounts) == 0 {
+		return nil, false, newError(http.StatusNotFound, "", "user %d not found", userID)
+	}
+
+	_, err = db.Users().GetByID(ctx, userID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return nil, false, err
+	}
+	// The most recent account is the one in use.
+	return accounts[len(accounts)-1], err == nil, nil
+}
+
+func (h *handler) listUsers(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	q, err := parseListQuery(r)
+	if err != nil {
+		return err
+	}
+
+	opt := database.ExternalAccountsListOptions{
+		ServiceType:    serviceType,
+		ServiceID:      serviceID,
+		IncludeDeleted: true,
+		LatestPerUser:  true,
+	}
+
+	// Identity providers look up users by userName or externalId, so the database evaluates
+	// these filters and the pagination, unless it can't read the encrypted account data. Other
+	// filters are evaluated against all users.
+	if data, ok := accountDataFilter(q.filter); ok && keyring.Default().UserExternalAccountKey == nil {
+		opt.AccountData = data
+		total, err := h.db.UserExternalAccounts().Count(ctx, opt)
+		if err != nil {
+			return err
+		}
+		var resources []map[string]any
+		if q.count > 0 {
+			opt.LimitOffset = &database.LimitOffset{Limit: q.count, Offset: q.startIndex - 1}
+			accounts, err := h.db.UserExternalAccounts().List(ctx, opt)
+			if err != nil {
+				return err
+			}
+			if resources, err = h.userResources(ctx, accounts); err != nil {
+				return err
+			}
+		}
+		writePage(w, q, total, resources)
+		return nil
+	}
+
+	accounts, err := h.db.UserExternalAccounts().List(ctx, opt)
+	if err != nil {
+		return err
+	}
+	resources, err := h.userResources(ctx, accounts)
+	if err != nil {
+		return err
+	}
+	writeList(w, q, resources)
+	return nil
+}
+
+// accountDataFilter returns the account data values that the filter matches, if the filter only
+// compares userName and externalId for equality.
+func accountDataFilter(f filter) (map[string]string, bool) {
+	data := map[string]string{}
+	var collect func(f filter) bool
+	collect = func(f filter) bool {
+		switch f := f.(type) {
+		case nil:
+			return true
+		case logicalFilter:
+			return f.and && collect(f.left) && collect(f.right)
+		case attributeFilter:
+			value, ok := f.value.(string)
+			if !ok || f.op != "eq" || f.path.schema != "" || len(f.path.names) != 1 {
+				return false
+			}
+			var key string
+			switch strings.ToLower(f.path.names[0]) {
+			case "username":
+				key = "userName"
+			case "externalid":
+				key = "externalId"
+			default:
+				return false
+			}
+			if _, ok := data[key]; ok {
+				return false
+			}
+			data[key] = value
+			return true
+		}
+		return false
+	}
+	return data, collect(f)
+}
+
+// userResources returns the SCIM representations of the users with the external accounts, which
+// must be the most recent accounts of the users.
+func (h *handler) userResources(ctx context.Context, accounts []*extsvc.Account) ([]map[string]any, error) {
+	if len(accounts) == 0 {
+		return nil, nil
+	}
+	userIDs := make([]int32, 0, len(accounts))
+	for _, account := range accounts {
+		userIDs = append(userIDs, account.UserID)
+	}
+	activeUsers, err := h.db.Users().List(ctx, &database.UsersListOptions{UserIDs: userIDs})
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[int32]bool, len(activeUsers))
+	for _, u := range activeUsers {
+		active[u.ID] = true
+	}
+
+	resources := make([]map[string]any, 0, len(accounts))
+	for _, account := range accounts {
+		resource, err := userResource(ctx, account, active[account.UserID])
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+func (h *handler) getUser(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseID(r)
+	if err != nil {
+		return err
+	}
+	return h.writeUser(w, r, h.db, http.StatusOK, id)
+}
+
+func (h *handler) writeUser(w http.ResponseWriter, r *http.Request, db database.DB, status int, id int32) error {
+	account, active, err := h.getAccount(r.Context(), db, id)
+	if err != nil {
+		return err
+	}
+	resource, err := userResource(r.Context(), account, active)
+	if err != nil {
+		return err
+	}
+	writeResource(w, r, status, resource)
+	return nil
+}
+
+func (h *handler) createUser(w http.ResponseWriter, r *http.Request) (err error) {
+	ctx := r.Context()
+	resource, err := readResource(r)
+	if err != nil {
+		return err
+	}
+	u, err := parseUser(resource)
+	if err != nil {
+		return err
+	}
+	username, err := auth.NormalizeUsername(u.userName)
+	if err != nil {
+		return newError(http.StatusBadRequest, scimTypeInvalidValue, "%s", err)
+	}
+	spec := extsvc.AccountSpec{ServiceType: serviceType, ServiceID: serviceID, AccountID: u.externalID}
+	if spec.AccountID == "" {
+		spec.AccountID = u.userName
+	}
+
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	existing, err := tx.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		ServiceType:    serviceType,
+		ServiceID:      serviceID,
+		AccountIDLike:  escapeLike(spec.AccountID),
+		IncludeDeleted: true,
+	})
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return newError(http.StatusConflict, scimTypeUniqueness, "user %q already exists with id %d", spec.AccountID, existing[len(existing)-1].UserID)
+	}
+
+	// Users who signed in before they were provisioned are linked to the resource.
+	var userID int32
+	switch existingUser, err := tx.Users().GetByUsername(ctx, username); {
+	case err == nil:
+		accounts, err := tx.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+			UserID:      existingUser.ID,
+			ServiceType: serviceType,
+			ServiceID:   serviceID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(accounts) > 0 {
+			return newError(http.StatusConflict, scimTypeUniqueness, "username %q is already provisioned with id %d", username, existingUser.ID)
+		}
+		userID = existingUser.ID
+
+	case errcode.IsNotFound(err):
+		data, err := accountData(resource)
+		if err != nil {
+			return err
+		}
+		var email string
+		if len(u.emails) > 0 {
+			email = u.emails[0]
+		}
+		// 🚨 SECURITY: The identity provider is trusted to verify email addresses.
+		userID, err = tx.UserExternalAccounts().CreateUserAndSave(ctx, database.NewUser{
+			Username:        username,
+			Email:           email,
+			EmailIsVerified: email != "",
+			DisplayName:     u.displayName,
+		}, spec, data)
+		if err != nil {
+			return err
+		}
+
+	default:
+		return err
+	}
+
+	if err := saveUser(ctx, tx, userID, true, spec, nil, u, resource); err != nil {
+		return err
+	}
+	return h.writeUser(w, r, tx, http.StatusCreated, userID)
+}
+
+func (h *handler) replaceUser(w http.ResponseWriter, r *http.Request) error {
+	resource, err := readResource(r)
+	if err != nil {
+		return err
+	}
+	return h.updateUser(w, r, func(map[string]any) (map[string]any, error) {
+		return resource, nil
+	})
+}
+
+func (h *handler) patchUser(w http.ResponseWriter, r *http.Request) error {
+	ops, err := readPatch(r)
+	if err != nil {
+		return err
+	}
+	return h.updateUser(w, r, func(resource map[string]any) (map[string]any, error) {
+		return resource, applyPatch(resource, ops)
+	})
+}
+
+// updateUser updates the user to the resource returned by update, which is passed the current
+// resource.
+func (h *handler) updateUser(w http.ResponseWriter, r *http.Request, update func(map[string]any) (map[string]any, error)) (err error) {
+	ctx := r.Context()
+	id, err := parseID(r)
+	if err != nil {
+		return err
+	}
+
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	account, active, err := h.getAccount(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	resource, err := userResource(ctx, account, active)
+	if err != nil {
+		return err
+	}
+	old, err := parseUser(resource)
+	if err != nil {
+		return err
+	}
+
+	resource, err = update(resource)
+	if err != nil {
+		return err
+	}
+	u, err := parseUser(resource)
+	if err != nil {
+		return err
+	}
+
+	if err := saveUser(ctx, tx, id, active, account.AccountSpec, old, u, resource); err != nil {
+		return err
+	}
+	return h.writeUser(w, r, tx, http.StatusOK, id)
+}
+
+// saveUser updates the user to match the SCIM resource. A user that becomes inactive is
+// soft-deleted, and an inactive user that becomes active is recovered. old is the previous
+// resource, if any.
+func saveUser(ctx context.Context, tx database.DB, userID int32, wasActive bool, spec extsvc.AccountSpec, old, u *user, resource map[string]any) error {
+	// Inactive users are recovered for the update and deleted again afterwards, so that their
+	// data is kept up to date.
+	if !wasActive {
+		if err := tx.Users().RecoverUsersList(ctx, []int32{userID}); err != nil {
+			return err
+		}
+	}
+
+	update := database.UserUpdate{DisplayName: &u.displayName}
+	if old != nil && old.userName != u.userName {
+		username, err := auth.NormalizeUsername(u.userName)
+		if err != nil {
+			return newError(http.StatusBadRequest, scimTypeInvalidValue, "%s", err)
+		}
+		update.Username = username
+	}
+	if err := tx.Users().Update(ctx, userID, update); err != nil {
+		return err
+	}
+
+	var oldEmails []string
+	if old != nil {
+		oldEmails = old.emails
+	}
+	if err := syncEmails(ctx, tx, userID, oldEmails, u.emails); err != nil {
+		return err
+	}
+
+	data, err := accountData(resource)
+	if err != nil {
+		return err
+	}
+	if err := tx.UserExternalAccounts().AssociateUserAndSave(ctx, userID, spec, data); err != nil {
+		return err
+	}
+
+	if !u.active {
+		if err := tx.Users().Delete(ctx, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncEmails adds the email addresses of the user resource to the user as verified addresses, and
+// removes the ones that were removed from the resource. Addresses that the user added themselves
+// are kept.
+func syncEmails(ctx context.Context, tx database.DB, userID int32, old, emails []string) error {
+	current, err := tx.UserEmails().ListByUser(ctx, database.UserEmailsListOptions{UserID: userID})
+	if err != nil {
+		return err
+	}
+	byAddress := make(map[string]*database.UserEmail, len(current))
+	for _, e := range current {
+		byAddress[strings.ToLower(e.Email)] = e
+	}
+
+	keep := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		keep[strings.ToLower(email)] = true
+		existing, ok := byAddress[strings.ToLower(email)]
+		if !ok {
+			if err := tx.UserEmails().Add(ctx, userID, email, nil); err != nil {
+				return err
+			}
+		} else {
+			email = existing.Email
+		}
+		// 🚨 SECURITY: The identity provider is trusted to verify email addresses.
+		if !ok || existing.VerifiedAt == nil {
+			if err := tx.UserEmails().SetVerified(ctx, userID, email, true); err != nil {
+				return err
+			}
+		}
+	}
+	if len(emails) > 0 {
+		if existing, ok := byAddress[strings.ToLower(emails[0])]; !ok || !existing.Primary {
+			if err := tx.UserEmails().SetPrimaryEmail(ctx, userID, emails[0]); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, email := range old {
+		existing, ok := byAddress[strings.ToLower(email)]
+		if !ok || keep[strings.ToLower(email)] {
+			continue
+		}
+		if existing.Primary && len(emails) == 0 {
+			// Users must have a primary email address if they have any.
+			continue
+		}
+		if err := tx.UserEmails().Remove(ctx, userID, existing.Email); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *handler) deleteUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	id, err := parseID(r)
+	if err != nil {
+		return err
+	}
+	if _, _, err := h.getAccount(ctx, h.db, id); err != nil {
+		return err
+	}
+	if err := h.db.Users().HardDelete(ctx, id); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// escapeLike escapes s for use as a LIKE pattern that matches s exactly.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -15,7 +15,7 @@ import (
 
 const DefaultLimit = 1000
 
-var _ Processor[Limit] = &ExtSvcQueryProcessor{}
+var _ Processor[DBQueryOptions] = &ExtSvcQueryProcessor{}
 
 type ExtSvcQueryProcessor struct {
 	db     database.DB
@@ -23,10 +23,10 @@ type ExtSvcQueryProcessor struct {
 	Type   string
 }
 
-func (d ExtSvcQueryProcessor) Process(ctx context.Context, payload Limit, dir string) {
+func (d ExtSvcQueryProcessor) Process(ctx context.Context, payload DBQueryOptions, dir string) {
 	externalServices, err := d.db.ExternalServices().List(
 		ctx,
-		database.ExternalServicesListOptions{LimitOffset: &database.LimitOffset{Limit: payload.getOrDefault(DefaultLimit)}},
+		database.ExternalServicesListOptions{LimitOffset: &database.LimitOffset{Limit: payload.Count.getOrDefault(DefaultLimit)}},
 	)
 	if err != nil {
 		d.logger.Error("error during fetching external services from the DB", log.Error(err))
@@ -104,6 +104,8 @@ func convertExtSvcToRedacted(ctx context.Context, extSvc *types.ExternalService)
 	}, nil
 }
 
+var _ Processor[DBQueryOptions] = &ExtSvcReposQueryProcessor{}
+
 // ExtSvcReposQueryProcessor is the query processor for the
 // external_service_repos table.
 type ExtSvcReposQueryProcessor struct {
@@ -112,10 +114,10 @@ type ExtSvcReposQueryProcessor struct {
 	Type   string
 }
 
-func (e ExtSvcReposQueryProcessor) Process(ctx context.Context, payload Limit, dir string) {
+func (e ExtSvcReposQueryProcessor) Process(ctx context.Context, payload DBQueryOptions, dir string) {
 	externalServiceRepos, err := e.db.ExternalServices().ListRepos(
 		ctx,
-		database.ExternalServiceReposListOptions{LimitOffset: &database.LimitOffset{Limit: payload.getOrDefault(DefaultLimit)}},
+		database.ExternalServiceReposListOptions{LimitOffset: &database.LimitOffset{Limit: payload.Count.getOrDefault(DefaultLimit)}},
 	)
 	if err != nil {
 		e.logger.Error("error during fetching external service repos from the DB", log.Error(err))
@@ -0,0 +1,436 @@
+package oneclickexport
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/oobmigration"
+)
+
+// The processors in this file export diagnostic data which is helpful when
+// troubleshooting an instance. None of them export secrets: credentials in
+// URLs found in error messages are redacted, and webhook payloads and headers
+// are not exported at all.
+
+// urlCredentialsPattern matches the user info of URLs up to the last "@" of
+// their authority, as unescaped passwords may contain "@" themselves.
+var urlCredentialsPattern = lazyregexp.New(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^/?#\s]+@`)
+
+// redactURLCredentials replaces the user info of all the URLs in the given
+// string with "REDACTED".
+func redactURLCredentials(s string) string {
+	return urlCredentialsPattern.ReplaceAllString(s, "${1}REDACTED@")
+}
+
+// timeWindowConds returns the conditions restricting the given column to the
+// time window.
+func timeWindowConds(column string, window TimeWindow) []*sqlf.Query {
+	var conds []*sqlf.Query
+	if window.Since != nil {
+		conds = append(conds, sqlf.Sprintf(column+" >= %s", *window.Since))
+	}
+	if window.Until != nil {
+		conds = append(conds, sqlf.Sprintf(column+" <= %s", *window.Until))
+	}
+	if len(conds) == 0 {
+		conds = append(conds, sqlf.Sprintf("TRUE"))
+	}
+	return conds
+}
+
+func (w TimeWindow) contains(t time.Time) bool {
+	return (w.Since == nil || !t.Before(*w.Since)) && (w.Until == nil || !t.After(*w.Until))
+}
+
+func writeJSON(logger log.Logger, dir, fileName string, v any) {
+	bytes, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		logger.Error("error during marshalling the result", log.Error(err))
+		return
+	}
+
+	outputFile := path.Join(dir, fileName)
+	err = ioutil.WriteFile(outputFile, bytes, 0644)
+	if err != nil {
+		logger.Error("error writing to file", log.Error(err), log.String("filePath", outputFile))
+	}
+}
+
+var _ Processor[DBQueryOptions] = &ExtSvcSyncJobsQueryProcessor{}
+
+// ExtSvcSyncJobsQueryProcessor is the query processor for the failed jobs of
+// the external_service_sync_jobs table. Jobs are filtered by the time they
+// finished.
+type ExtSvcSyncJobsQueryProcessor struct {
+	db     database.DB
+	logger log.Logger
+	Type   string
+}
+
+type FailedSyncJob struct {
+	ID                  int64
+	State               string
+	FailureMessage      string
+	QueuedAt            time.Time
+	StartedAt           time.Time
+	FinishedAt          time.Time
+	NumFailures         int
+	ExternalServiceID   int64
+	ExternalServiceKind string
+}
+
+const failedSyncJobsQueryFmtstr = `
+SELECT j.id, j.state, j.failure_message, j.queued_at, j.started_at, j.finished_at, j.num_failures, j.external_service_id, es.kind
+FROM external_service_sync_jobs j
+JOIN external_services es ON es.id = j.external_service_id
+WHERE j.state IN ('errored', 'failed') AND %s
+ORDER BY j.finished_at DESC NULLS LAST, j.id DESC
+LIMIT %s
+`
+
+func (e ExtSvcSyncJobsQueryProcessor) Process(ctx context.Context, payload DBQueryOptions, dir string) {
+	jobs, err := e.failedSyncJobs(ctx, payload)
+	if err != nil {
+		e.logger.Error("error during fetching failed external service sync jobs from the DB", log.Error(err))
+		return
+	}
+
+	writeJSON(e.logger, dir, "db-external-service-sync-jobs.txt", jobs)
+}
+
+func (e ExtSvcSyncJobsQueryProcessor) failedSyncJobs(ctx context.Context, payload DBQueryOptions) (_ []*FailedSyncJob, err error) {
+	q := sqlf.Sprintf(
+		failedSyncJobsQueryFmtstr,
+		sqlf.Join(timeWindowConds("j.finished_at", payload.TimeWindow), "AND"),
+		payload.Count.getOrDefault(DefaultLimit),
+	)
+	rows, err := basestore.NewWithHandle(e.db.Handle()).Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	jobs := make([]*FailedSyncJob, 0)
+	for rows.Next() {
+		var job FailedSyncJob
+		if err := rows.Scan(
+			&job.ID,
+			&job.State,
+			&dbutil.NullString{S: &job.FailureMessage},
+			&dbutil.NullTime{Time: &job.QueuedAt},
+			&dbutil.NullTime{Time: &job.StartedAt},
+			&dbutil.NullTime{Time: &job.FinishedAt},
+			&job.NumFailures,
+			&job.ExternalServiceID,
+			&job.ExternalServiceKind,
+		); err != nil {
+			return nil, err
+		}
+		job.FailureMessage = redactURLCredentials(job.FailureMessage)
+		jobs = append(jobs, &job)
+	}
+	return jobs, nil
+}
+
+func (e ExtSvcSyncJobsQueryProcessor) ProcessorType() string {
+	return e.Type
+}
+
+var _ Processor[DBQueryOptions] = &GitserverReposQueryProcessor{}
+
+// GitserverReposQueryProcessor is the query processor for the gitserver_repos
+// table. It exports the number of repos in each clone status and the repos
+// which have an error, filtered by the time they were last updated.
+type GitserverReposQueryProcessor struct {
+	db     database.DB
+	logger log.Logger
+	Type   string
+}
+
+type GitserverReposSummary struct {
+	CloneStatusCounts map[string]int
+	ErroredRepos      []*ErroredGitserverRepo
+}
+
+type ErroredGitserverRepo struct {
+	Name        string
+	ShardID     string
+	CloneStatus string
+	LastError   string
+	LastFetched time.Time
+	UpdatedAt   time.Time
+}
+
+const gitserverCloneStatusCountsQuery = `
+SELECT gr.clone_status, COUNT(*)
+FROM gitserver_repos gr
+JOIN repo r ON r.id = gr.repo_id
+WHERE r.deleted_at IS NULL
+GROUP BY gr.clone_status
+`
+
+const erroredGitserverReposQueryFmtstr = `
+SELECT r.name, gr.shard_id, gr.clone_status, gr.last_error, gr.last_fetched, gr.updated_at
+FROM gitserver_repos gr
+JOIN repo r ON r.id = gr.repo_id
+WHERE r.deleted_at IS NULL AND gr.last_error IS NOT NULL AND gr.last_error != '' AND %s
+ORDER BY gr.updated_at DESC, r.id
+LIMIT %s
+`
+
+func (g GitserverReposQueryProcessor) Process(ctx context.Context, payload DBQueryOptions, dir string) {
+	summary, err := g.summary(ctx, payload)
+	if err != nil {
+		g.logger.Error("error during fetching gitserver repos from the DB", log.Error(err))
+		return
+	}
+
+	writeJSON(g.logger, dir, "db-gitserver-repos.txt", summary)
+}
+
+func (g GitserverReposQueryProcessor) summary(ctx context.Context, payload DBQueryOptions) (_ *GitserverReposSummary, err error) {
+	store := basestore.NewWithHandle(g.db.Handle())
+
+	counts, err := countByKey(ctx, store, sqlf.Sprintf(gitserverCloneStatusCountsQuery))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := store.Query(ctx, sqlf.Sprintf(
+		erroredGitserverReposQueryFmtstr,
+		sqlf.Join(timeWindowConds("gr.updated_at", payload.TimeWindow), "AND"),
+		payload.Count.getOrDefault(DefaultLimit),
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	summary := &GitserverReposSummary{CloneStatusCounts: counts, ErroredRepos: make([]*ErroredGitserverRepo, 0)}
+	for rows.Next() {
+		var repo ErroredGitserverRepo
+		if err := rows.Scan(
+			&repo.Name,
+			&repo.ShardID,
+			&repo.CloneStatus,
+			&dbutil.NullString{S: &repo.LastError},
+			&repo.LastFetched,
+			&repo.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		repo.LastError = redactURLCredentials(repo.LastError)
+		summary.ErroredRepos = append(summary.ErroredRepos, &repo)
+	}
+	return summary, nil
+}
+
+func (g GitserverReposQueryProcessor) ProcessorType() string {
+	return g.Type
+}
+
+var _ Processor[DBQueryOptions] = &WorkerQueuesQueryProcessor{}
+
+// WorkerQueuesQueryProcessor exports the number of records in each state of
+// the queues of the background workers. The count and the time window are
+// ignored.
+type WorkerQueuesQueryProcessor struct {
+	db     database.DB
+	logger log.Logger
+	Type   string
+}
+
+// workerQueueTables are the tables of the worker queues whose depths are
+// exported.
+var workerQueueTables = []string{
+	"lsif_uploads",
+	"changeset_jobs",
+	"insights_query_runner_jobs",
+}
+
+func (w WorkerQueuesQueryProcessor) Process(ctx context.Context, _ DBQueryOptions, dir string) {
+	store := basestore.NewWithHandle(w.db.Handle())
+
+	depths := make(map[string]map[string]int, len(workerQueueTables))
+	for _, table := range workerQueueTables {
+		counts, err := countByKey(ctx, store, sqlf.Sprintf("SELECT state, COUNT(*) FROM "+table+" GROUP BY state"))
+		if err != nil {
+			w.logger.Error("error during fetching worker queue depth from the DB", log.Error(err), log.String("table", table))
+			continue
+		}
+		depths[table] = counts
+	}
+
+	writeJSON(w.logger, dir, "db-worker-queues.txt", depths)
+}
+
+func (w WorkerQueuesQueryProcessor) ProcessorType() string {
+	return w.Type
+}
+
+// countByKey runs the given query, which must return a key and a count in
+// each row, and returns the counts by key. NULL keys are counted as "".
+func countByKey(ctx context.Context, store *basestore.Store, q *sqlf.Query) (_ map[string]int, err error) {
+	rows, err := store.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&dbutil.NullString{S: &key}, &count); err != nil {
+			return nil, err
+		}
+		counts[key] += count
+	}
+	return counts, nil
+}
+
+var _ Processor[DBQueryOptions] = &OutOfBandMigrationsQueryProcessor{}
+
+// OutOfBandMigrationsQueryProcessor is the query processor for the
+// out_of_band_migrations table. Errors of the migrations are filtered by the
+// time they occurred.
+type OutOfBandMigrationsQueryProcessor struct {
+	db     database.DB
+	logger log.Logger
+	Type   string
+}
+
+type OutOfBandMigrationSummary struct {
+	ID           int
+	Team         string
+	Component    string
+	Description  string
+	Introduced   string
+	Deprecated   string
+	Progress     float64
+	Created      time.Time
+	LastUpdated  *time.Time
+	ApplyReverse bool
+	Errors       []oobmigration.MigrationError
+}
+
+func (o OutOfBandMigrationsQueryProcessor) Process(ctx context.Context, payload DBQueryOptions, dir string) {
+	migrations, err := oobmigration.NewStoreWithDB(o.db).List(ctx)
+	if err != nil {
+		o.logger.Error("error during fetching out of band migrations from the DB", log.Error(err))
+		return
+	}
+
+	limit := payload.Count.getOrDefault(DefaultLimit)
+	if len(migrations) > limit {
+		migrations = migrations[:limit]
+	}
+
+	summaries := make([]*OutOfBandMigrationSummary, len(migrations))
+	for idx, m := range migrations {
+		summary := &OutOfBandMigrationSummary{
+			ID:           m.ID,
+			Team:         m.Team,
+			Component:    m.Component,
+			Description:  m.Description,
+			Introduced:   m.Introduced.String(),
+			Progress:     m.Progress,
+			Created:      m.Created,
+			LastUpdated:  m.LastUpdated,
+			ApplyReverse: m.ApplyReverse,
+			Errors:       make([]oobmigration.MigrationError, 0, len(m.Errors)),
+		}
+		if m.Deprecated != nil {
+			summary.Deprecated = m.Deprecated.String()
+		}
+		for _, migrationErr := range m.Errors {
+			if !payload.TimeWindow.contains(migrationErr.Created) {
+				continue
+			}
+			summary.Errors = append(summary.Errors, oobmigration.MigrationError{
+				Message: redactURLCredentials(migrationErr.Message),
+				Created: migrationErr.Created,
+			})
+		}
+		summaries[idx] = summary
+	}
+
+	writeJSON(o.logger, dir, "db-out-of-band-migrations.txt", summaries)
+}
+
+func (o OutOfBandMigrationsQueryProcessor) ProcessorType() string {
+	return o.Type
+}
+
+var _ Processor[DBQueryOptions] = &WebhookLogsQueryProcessor{}
+
+// WebhookLogsQueryProcessor is the query processor for the webhook_logs table.
+// Only the metadata of the webhooks is exported: headers and bodies of the
+// requests and responses may contain secrets.
+type WebhookLogsQueryProcessor struct {
+	db     database.DB
+	logger log.Logger
+	Type   string
+}
+
+type RedactedWebhookLog struct {
+	ID                int64
+	ReceivedAt        time.Time
+	ExternalServiceID *int64
+	StatusCode        int
+	Method            string
+	// Path is the path of the request URL. The query is omitted.
+	Path string
+}
+
+func (w WebhookLogsQueryProcessor) Process(ctx context.Context, payload DBQueryOptions, dir string) {
+	webhookLogs, _, err := w.db.WebhookLogs(keyring.Default().WebhookLogKey).List(ctx, database.WebhookLogListOpts{
+		Limit: payload.Count.getOrDefault(DefaultLimit),
+		Since: payload.TimeWindow.Since,
+		Until: payload.TimeWindow.Until,
+	})
+	if err != nil {
+		w.logger.Error("error during fetching webhook logs from the DB", log.Error(err))
+		return
+	}
+
+	redactedLogs := make([]*RedactedWebhookLog, 0, len(webhookLogs))
+	for _, webhookLog := range webhookLogs {
+		redacted := &RedactedWebhookLog{
+			ID:                webhookLog.ID,
+			ReceivedAt:        webhookLog.ReceivedAt,
+			ExternalServiceID: webhookLog.ExternalServiceID,
+			StatusCode:        webhookLog.StatusCode,
+		}
+		if webhookLog.Request != nil {
+			request, err := webhookLog.Request.Decrypt(ctx)
+			if err != nil {
+				w.logger.Error("error during decrypting webhook log request", log.Error(err), log.Int64("webhookLogID", webhookLog.ID))
+				continue
+			}
+			redacted.Method = request.Method
+			if u, err := url.Parse(request.URL); err == nil {
+				redacted.Path = u.Path
+			}
+		}
+		redactedLogs = append(redactedLogs, redacted)
+	}
+
+	writeJSON(w.logger, dir, "db-webhook-logs.txt", redactedLogs)
+}
+
+func (w WebhookLogsQueryProcessor) ProcessorType() string {
+	return w.Type
+}
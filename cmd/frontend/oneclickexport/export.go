@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -25,7 +26,7 @@ var GlobalExporter Exporter
 type DataExporter struct {
 	logger           log.Logger
 	configProcessors map[string]Processor[ConfigRequest]
-	dbProcessors     map[string]Processor[Limit]
+	dbProcessors     map[string]Processor[DBQueryOptions]
 }
 
 type ConfigRequest struct {
@@ -36,6 +37,19 @@ type DBQueryRequest struct {
 	Count     Limit  `json:"count"`
 }
 
+// DBQueryOptions is the payload passed to the DB query processors.
+type DBQueryOptions struct {
+	Count      Limit
+	TimeWindow TimeWindow
+}
+
+// TimeWindow restricts the exported time-based data (sync jobs, webhook logs,
+// etc.) to the given period. Both bounds are optional.
+type TimeWindow struct {
+	Since *time.Time `json:"since,omitempty"`
+	Until *time.Time `json:"until,omitempty"`
+}
+
 type Limit int
 
 func (l Limit) getOrDefault(defaultValue int) int {
@@ -59,16 +73,41 @@ func NewDataExporter(db database.DB, logger log.Logger) Exporter {
 				Type:   "codeHostConfig",
 			},
 		},
-		dbProcessors: map[string]Processor[Limit]{
+		dbProcessors: map[string]Processor[DBQueryOptions]{
 			"external_services": ExtSvcQueryProcessor{
 				db:     db,
 				logger: logger,
 				Type:   "external_services",
 			},
-			"external_service_repos": ExtSvcQueryProcessor{
+			"external_service_repos": ExtSvcReposQueryProcessor{
 				db:     db,
 				logger: logger,
-				Type:   "external_services",
+				Type:   "external_service_repos",
+			},
+			"external_service_sync_jobs": ExtSvcSyncJobsQueryProcessor{
+				db:     db,
+				logger: logger,
+				Type:   "external_service_sync_jobs",
+			},
+			"gitserver_repos": GitserverReposQueryProcessor{
+				db:     db,
+				logger: logger,
+				Type:   "gitserver_repos",
+			},
+			"worker_queues": WorkerQueuesQueryProcessor{
+				db:     db,
+				logger: logger,
+				Type:   "worker_queues",
+			},
+			"out_of_band_migrations": OutOfBandMigrationsQueryProcessor{
+				db:     db,
+				logger: logger,
+				Type:   "out_of_band_migrations",
+			},
+			"webhook_logs": WebhookLogsQueryProcessor{
+				db:     db,
+				logger: logger,
+				Type:   "webhook_logs",
 			},
 		},
 	}
@@ -78,6 +117,9 @@ type ExportRequest struct {
 	IncludeSiteConfig     bool              `json:"includeSiteConfig"`
 	IncludeCodeHostConfig bool              `json:"includeCodeHostConfig"`
 	DBQueries             []*DBQueryRequest `json:"dbQueries"`
+	// TimeWindow is applied to all the DB queries which export time-based
+	// data.
+	TimeWindow TimeWindow `json:"timeWindow"`
 }
 
 // Export generates and returns a ZIP archive with the data, specified in request.
@@ -102,7 +144,12 @@ func (e *DataExporter) Export(ctx context.Context, request ExportRequest) (io.Re
 		e.configProcessors["codeHostConfig"].Process(ctx, ConfigRequest{}, dir)
 	}
 	for _, dbQuery := range request.DBQueries {
-		e.dbProcessors[dbQuery.TableName].Process(ctx, dbQuery.Count, dir)
+		processor, ok := e.dbProcessors[dbQuery.TableName]
+		if !ok {
+			e.logger.Warn("unknown DB query table name", log.String("tableName", dbQuery.TableName))
+			continue
+		}
+		processor.Process(ctx, DBQueryOptions{Count: dbQuery.Count, TimeWindow: request.TimeWindow}, dir)
 	}
 
 	// 3) after all request parts are processed, zip the tmp dir and return its bytes
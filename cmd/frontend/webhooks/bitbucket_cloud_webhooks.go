@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"crypto/subtle"
+	"io"
+	"net/http"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// bitbucketCloudPushEventType is the event type of push events.
+const bitbucketCloudPushEventType = "repo:push"
+
+// BitbucketCloudWebhook is responsible for handling incoming http requests
+// for Bitbucket Cloud push webhooks and routing them to any registered
+// WebhookHandlers under the "repo:push" event type. All other events are
+// passed on to Next unchanged.
+type BitbucketCloudWebhook struct {
+	ExternalServices database.ExternalServiceStore
+
+	// Next handles all events other than push events.
+	Next http.Handler
+
+	eventRouter
+}
+
+func (h *BitbucketCloudWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	eventType := r.Header.Get("X-Event-Key")
+	if eventType != bitbucketCloudPushEventType {
+		h.Next.ServeHTTP(w, r)
+		return
+	}
+
+	// get external service and validate the shared secret
+	extSvc, err := h.getExternalService(r)
+	if err != nil {
+		log15.Error("Could not find valid external service for webhook", "error", err)
+		http.Error(w, "External service not found", http.StatusUnauthorized)
+		return
+	}
+
+	SetExternalServiceID(r.Context(), extSvc.ID)
+
+	// 🚨 SECURITY: now that the shared secret has been validated, we can use
+	// an internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log15.Error("Error parsing bitbucket cloud webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	e, err := bitbucketcloud.ParseWebhookEvent(eventType, body)
+	if err != nil {
+		log15.Error("Error parsing bitbucket cloud webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(ctx, eventType, extSvc, e); err != nil {
+		log15.Error("Error handling bitbucket cloud webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *BitbucketCloudWebhook) getExternalService(r *http.Request) (*types.ExternalService, error) {
+	e, c, err := externalServiceFromRequest(r, h.ExternalServices, extsvc.KindBitbucketCloud)
+	if err != nil {
+		return nil, err
+	}
+	bc, ok := c.(*schema.BitbucketCloudConnection)
+	if !ok {
+		return nil, errors.Errorf("invalid configuration, received bitbucket cloud webhook for non-bitbucket cloud external service: %v", e.ID)
+	}
+
+	// 🚨 SECURITY: Bitbucket Cloud webhooks don't support shared secrets, so
+	// the secret is part of the webhook URL instead.
+	if bc.WebhookSecret != "" && subtle.ConstantTimeCompare([]byte(bc.WebhookSecret), []byte(r.FormValue("secret"))) == 1 {
+		return e, nil
+	}
+	return nil, errors.Errorf("couldn't validate the secret of the webhook for external service: %v", e.ID)
+}
@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestBitbucketCloudWebhook_ServeHTTP(t *testing.T) {
+	extSvc := &types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindBitbucketCloud,
+		Config: extsvc.NewUnencryptedConfig(`{"url": "https://bitbucket.org", "username": "admin", "appPassword": "abc", "webhookSecret": "secret"}`),
+	}
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultReturn(extSvc, nil)
+
+	var nextCalled int
+	var events []any
+	h := BitbucketCloudWebhook{
+		ExternalServices: externalServices,
+		Next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nextCalled++
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	}
+	h.Register(func(ctx context.Context, svc *types.ExternalService, payload any) error {
+		assert.Equal(t, extSvc, svc)
+		events = append(events, payload)
+		return nil
+	}, "repo:push")
+
+	payload := []byte(`{"repository": {"full_name": "alice/dotfiles"}, "push": {"changes": [{"new": {"type": "branch", "name": "main"}}]}}`)
+	serve := func(eventType, secret string) int {
+		req, err := http.NewRequest("POST", "/.api/bitbucket-cloud-webhooks?externalServiceID=1&secret="+secret, bytes.NewReader(payload))
+		require.Nil(t, err)
+		req.Header.Set("X-Event-Key", eventType)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("repo:push", "secret"))
+	require.Len(t, events, 1)
+	assert.Equal(t, "alice/dotfiles", events[0].(*bitbucketcloud.RepoPushEvent).Repository.FullName)
+
+	assert.Equal(t, http.StatusUnauthorized, serve("repo:push", "wrong"))
+	assert.Len(t, events, 1)
+
+	assert.Equal(t, http.StatusNoContent, serve("pullrequest:created", "secret"))
+	assert.Equal(t, 1, nextCalled)
+}
@@ -0,0 +1,95 @@
+package webhooks
+
+import (
+	"io"
+	"net/http"
+
+	gh "github.com/google/go-github/v43/github"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// bitbucketServerPushEventType is the event type of push events.
+const bitbucketServerPushEventType = "repo:refs_changed"
+
+// BitbucketServerWebhook is responsible for handling incoming http requests
+// for Bitbucket Server push webhooks and routing them to any registered
+// WebhookHandlers under the "repo:refs_changed" event type. All other events
+// are passed on to Next unchanged.
+type BitbucketServerWebhook struct {
+	ExternalServices database.ExternalServiceStore
+
+	// Next handles all events other than push events.
+	Next http.Handler
+
+	eventRouter
+}
+
+func (h *BitbucketServerWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	eventType := bitbucketserver.WebhookEventType(r)
+	if eventType != bitbucketServerPushEventType {
+		h.Next.ServeHTTP(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log15.Error("Error parsing bitbucket server webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// get external service and validate webhook payload signature
+	extSvc, err := h.getExternalService(r, body)
+	if err != nil {
+		log15.Error("Could not find valid external service for webhook", "error", err)
+		http.Error(w, "External service not found", http.StatusUnauthorized)
+		return
+	}
+
+	SetExternalServiceID(r.Context(), extSvc.ID)
+
+	// 🚨 SECURITY: now that the payload and shared secret have been validated,
+	// we can use an internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	e, err := bitbucketserver.ParseWebhookEvent(eventType, body)
+	if err != nil {
+		log15.Error("Error parsing bitbucket server webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(ctx, eventType, extSvc, e); err != nil {
+		log15.Error("Error handling bitbucket server webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *BitbucketServerWebhook) getExternalService(r *http.Request, body []byte) (*types.ExternalService, error) {
+	e, c, err := externalServiceFromRequest(r, h.ExternalServices, extsvc.KindBitbucketServer)
+	if err != nil {
+		return nil, err
+	}
+	bc, ok := c.(*schema.BitbucketServerConnection)
+	if !ok {
+		return nil, errors.Errorf("invalid configuration, received bitbucket server webhook for non-bitbucket server external service: %v", e.ID)
+	}
+
+	// 🚨 SECURITY: Bitbucket Server signs the payload with the secret of the
+	// webhook in the same way as GitHub does.
+	if secret := bc.WebhookSecret(); secret != "" {
+		if err := gh.ValidateSignature(r.Header.Get("X-Hub-Signature"), body, []byte(secret)); err == nil {
+			return e, nil
+		}
+	}
+	return nil, errors.Errorf("couldn't validate the signature of the webhook for external service: %v", e.ID)
+}
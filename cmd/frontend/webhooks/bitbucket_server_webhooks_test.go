@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestBitbucketServerWebhook_ServeHTTP(t *testing.T) {
+	extSvc := &types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindBitbucketServer,
+		Config: extsvc.NewUnencryptedConfig(`{"url": "https://bitbucket.example.com", "username": "admin", "token": "abc", "repositoryQuery": ["none"], "webhooks": {"secret": "secret"}}`),
+	}
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultReturn(extSvc, nil)
+
+	var nextCalled int
+	var events []any
+	h := BitbucketServerWebhook{
+		ExternalServices: externalServices,
+		Next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nextCalled++
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	}
+	h.Register(func(ctx context.Context, svc *types.ExternalService, payload any) error {
+		assert.Equal(t, extSvc, svc)
+		events = append(events, payload)
+		return nil
+	}, "repo:refs_changed")
+
+	payload := []byte(`{"repository": {"slug": "repo", "project": {"key": "PROJ"}}, "changes": [{"refId": "refs/heads/main", "type": "UPDATE"}]}`)
+	sign := func(secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(payload)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	serve := func(eventType, signature string) int {
+		req, err := http.NewRequest("POST", "/.api/bitbucket-server-webhooks?externalServiceID=1", bytes.NewReader(payload))
+		require.Nil(t, err)
+		req.Header.Set("X-Event-Key", eventType)
+		req.Header.Set("X-Hub-Signature", signature)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("repo:refs_changed", sign("secret")))
+	require.Len(t, events, 1)
+	e := events[0].(*bitbucketserver.RepoRefsChangedEvent)
+	assert.Equal(t, "PROJ", e.Repository.Project.Key)
+	assert.Equal(t, "refs/heads/main", e.Changes[0].RefID)
+
+	assert.Equal(t, http.StatusUnauthorized, serve("repo:refs_changed", sign("wrong")))
+	assert.Len(t, events, 1)
+
+	assert.Equal(t, http.StatusNoContent, serve("pr:opened", sign("secret")))
+	assert.Equal(t, 1, nextCalled)
+}
@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GiteaWebhook is responsible for handling incoming http requests for Gitea
+// (and Forgejo) webhooks and routing to any registered WebhookHandlers. Events
+// are routed by their event type, passed in the X-Gitea-Event header.
+type GiteaWebhook struct {
+	ExternalServices database.ExternalServiceStore
+
+	eventRouter
+}
+
+func (h *GiteaWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log15.Error("Error parsing gitea webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// get external service and validate webhook payload signature
+	extSvc, err := h.getExternalService(r, body)
+	if err != nil {
+		log15.Error("Could not find valid external service for webhook", "error", err)
+		http.Error(w, "External service not found", http.StatusUnauthorized)
+		return
+	}
+
+	SetExternalServiceID(r.Context(), extSvc.ID)
+
+	// 🚨 SECURITY: now that the payload and shared secret have been validated,
+	// we can use an internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	// parse event
+	eventType := r.Header.Get(gitea.EventTypeHeader)
+	e, err := gitea.ParseWebhookEvent(eventType, body)
+	if err != nil {
+		if errors.Is(err, gitea.ErrUnknownEventType) {
+			// Gitea sends all events a webhook is configured for, so we
+			// ignore the ones we don't handle.
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		log15.Error("Error parsing gitea webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// match event handlers
+	err = h.Dispatch(ctx, eventType, extSvc, e)
+	if err != nil {
+		log15.Error("Error handling gitea webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *GiteaWebhook) getExternalService(r *http.Request, body []byte) (*types.ExternalService, error) {
+	e, c, err := externalServiceFromRequest(r, h.ExternalServices, extsvc.KindGitea)
+	if err != nil {
+		return nil, err
+	}
+	gc, ok := c.(*schema.GiteaConnection)
+	if !ok {
+		return nil, errors.Errorf("invalid configuration, received gitea webhook for non-gitea external service: %v", e.ID)
+	}
+
+	// 🚨 SECURITY: Try to authenticate the request with any of the stored secrets
+	// If there are no secrets or no secret managed to authenticate the request,
+	// we return an error to the client.
+	sig := r.Header.Get(gitea.SignatureHeader)
+	for _, hook := range gc.Webhooks {
+		if hook.Secret == "" {
+			continue
+		}
+
+		if err := gitea.ValidateSignature(sig, body, []byte(hook.Secret)); err == nil {
+			return e, nil
+		}
+	}
+	return nil, errors.Errorf("couldn't validate the signature of the webhook for external service: %v", e.ID)
+}
@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestGiteaWebhook_ServeHTTP(t *testing.T) {
+	extSvc := &types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindGitea,
+		Config: extsvc.NewUnencryptedConfig(`{"url": "https://gitea.example.com", "token": "abc", "webhooks": [{"secret": "other"}, {"secret": "secret"}]}`),
+	}
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultReturn(extSvc, nil)
+
+	var events []any
+	h := GiteaWebhook{ExternalServices: externalServices}
+	h.Register(func(ctx context.Context, svc *types.ExternalService, payload any) error {
+		assert.Equal(t, extSvc, svc)
+		events = append(events, payload)
+		return nil
+	}, "push")
+
+	payload := []byte(`{"ref": "refs/heads/main", "repository": {"id": 1, "full_name": "alice/dotfiles"}}`)
+	sign := func(secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(payload)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	serve := func(eventType, signature string) int {
+		req, err := http.NewRequest("POST", "/.api/gitea-webhooks?externalServiceID=1", bytes.NewReader(payload))
+		require.Nil(t, err)
+		req.Header.Set(gitea.EventTypeHeader, eventType)
+		req.Header.Set(gitea.SignatureHeader, signature)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("push", sign("secret")))
+	require.Len(t, events, 1)
+	assert.Equal(t, "alice/dotfiles", events[0].(*gitea.PushEvent).Repository.FullName)
+
+	assert.Equal(t, http.StatusUnauthorized, serve("push", sign("wrong")))
+	assert.Equal(t, http.StatusNoContent, serve("issues", sign("secret")))
+	assert.Len(t, events, 1)
+}
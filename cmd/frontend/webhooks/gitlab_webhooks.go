@@ -0,0 +1,99 @@
+package webhooks
+
+import (
+	"crypto/subtle"
+	"io"
+	"net/http"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// gitlabPushEventHeader is the value of the X-Gitlab-Event header of push
+// events.
+const gitlabPushEventHeader = "Push Hook"
+
+// GitLabWebhook is responsible for handling incoming http requests for GitLab
+// push webhooks and routing them to any registered WebhookHandlers under the
+// "push" event type. All other events are passed on to Next unchanged.
+type GitLabWebhook struct {
+	ExternalServices database.ExternalServiceStore
+
+	// Next handles all events other than push events.
+	Next http.Handler
+
+	eventRouter
+}
+
+func (h *GitLabWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Gitlab-Event") != gitlabPushEventHeader {
+		h.Next.ServeHTTP(w, r)
+		return
+	}
+
+	// get external service and validate the shared secret
+	extSvc, err := h.getExternalService(r)
+	if err != nil {
+		log15.Error("Could not find valid external service for webhook", "error", err)
+		http.Error(w, "External service not found", http.StatusUnauthorized)
+		return
+	}
+
+	SetExternalServiceID(r.Context(), extSvc.ID)
+
+	// 🚨 SECURITY: now that the shared secret has been validated, we can use
+	// an internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log15.Error("Error parsing gitlab webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	e, err := gitlabwebhooks.UnmarshalEvent(body)
+	if err != nil {
+		log15.Error("Error parsing gitlab webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(ctx, "push", extSvc, e); err != nil {
+		log15.Error("Error handling gitlab webhook event", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *GitLabWebhook) getExternalService(r *http.Request) (*types.ExternalService, error) {
+	e, c, err := externalServiceFromRequest(r, h.ExternalServices, extsvc.KindGitLab)
+	if err != nil {
+		return nil, err
+	}
+	gc, ok := c.(*schema.GitLabConnection)
+	if !ok {
+		return nil, errors.Errorf("invalid configuration, received gitlab webhook for non-gitlab external service: %v", e.ID)
+	}
+
+	// 🚨 SECURITY: GitLab sends the secret of the webhook as is, so we check
+	// whether it matches any of the stored secrets. An empty secret never
+	// succeeds.
+	secret := r.Header.Get(gitlabwebhooks.TokenHeaderName)
+	if secret == "" {
+		return nil, errors.New("missing webhook secret")
+	}
+	for _, hook := range gc.Webhooks {
+		if subtle.ConstantTimeCompare([]byte(hook.Secret), []byte(secret)) == 1 {
+			return e, nil
+		}
+	}
+	return nil, errors.Errorf("couldn't validate the secret of the webhook for external service: %v", e.ID)
+}
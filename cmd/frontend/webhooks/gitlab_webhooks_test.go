@@ -0,0 +1,65 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestGitLabWebhook_ServeHTTP(t *testing.T) {
+	extSvc := &types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindGitLab,
+		Config: extsvc.NewUnencryptedConfig(`{"url": "https://gitlab.example.com", "token": "abc", "projectQuery": ["none"], "webhooks": [{"secret": "other"}, {"secret": "secret"}]}`),
+	}
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultReturn(extSvc, nil)
+
+	var nextCalled int
+	var events []any
+	h := GitLabWebhook{
+		ExternalServices: externalServices,
+		Next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nextCalled++
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	}
+	h.Register(func(ctx context.Context, svc *types.ExternalService, payload any) error {
+		assert.Equal(t, extSvc, svc)
+		events = append(events, payload)
+		return nil
+	}, "push")
+
+	payload := []byte(`{"object_kind": "push", "ref": "refs/heads/main", "project": {"id": 1, "path_with_namespace": "group/project"}}`)
+	serve := func(eventType, secret string) int {
+		req, err := http.NewRequest("POST", "/.api/gitlab-webhooks?externalServiceID=1", bytes.NewReader(payload))
+		require.Nil(t, err)
+		req.Header.Set("X-Gitlab-Event", eventType)
+		req.Header.Set(gitlabwebhooks.TokenHeaderName, secret)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("Push Hook", "secret"))
+	require.Len(t, events, 1)
+	assert.Equal(t, "group/project", events[0].(*gitlabwebhooks.PushEvent).Project.PathWithNamespace)
+
+	assert.Equal(t, http.StatusUnauthorized, serve("Push Hook", "wrong"))
+	assert.Equal(t, http.StatusUnauthorized, serve("Push Hook", ""))
+	assert.Len(t, events, 1)
+
+	assert.Equal(t, http.StatusNoContent, serve("Merge Request Hook", "secret"))
+	assert.Equal(t, 1, nextCalled)
+}
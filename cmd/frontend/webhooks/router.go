@@ -0,0 +1,79 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// eventRouter routes parsed webhook events to the WebhookHandlers registered
+// for their event type. It is embedded by the webhook handlers of code hosts.
+type eventRouter struct {
+	mu       sync.RWMutex
+	handlers map[string][]WebhookHandler
+}
+
+// Dispatch accepts an event for a particular event type and dispatches it
+// to the appropriate stack of handlers, if any are configured.
+func (h *eventRouter) Dispatch(ctx context.Context, eventType string, extSvc *types.ExternalService, e any) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	g := errgroup.Group{}
+	for _, handler := range h.handlers[eventType] {
+		// capture the handler variable within this loop
+		handler := handler
+		g.Go(func() error {
+			return handler(ctx, extSvc, e)
+		})
+	}
+	return g.Wait()
+}
+
+// Register associates a given event type(s) with the specified handler.
+func (h *eventRouter) Register(handler WebhookHandler, eventTypes ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.handlers == nil {
+		h.handlers = make(map[string][]WebhookHandler)
+	}
+	for _, eventType := range eventTypes {
+		h.handlers[eventType] = append(h.handlers[eventType], handler)
+	}
+}
+
+// externalServiceFromRequest returns the external service of the given kind
+// identified by the externalServiceID parameter of the webhook request, along
+// with its parsed configuration.
+func externalServiceFromRequest(r *http.Request, externalServices database.ExternalServiceStore, kind string) (*types.ExternalService, any, error) {
+	rawID := r.FormValue(extsvc.IDParam)
+	if rawID == "" {
+		return nil, nil, errors.Errorf("missing %s query parameter", extsvc.IDParam)
+	}
+
+	externalServiceID, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return nil, nil, err
+	}
+	e, err := externalServices.GetByID(r.Context(), externalServiceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !strings.EqualFold(e.Kind, kind) {
+		return nil, nil, errors.Errorf("received %s webhook for %s external service: %v", strings.ToLower(kind), strings.ToLower(e.Kind), externalServiceID)
+	}
+
+	c, err := e.Configuration(r.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+	return e, c, nil
+}
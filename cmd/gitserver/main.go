@@ -45,7 +45,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodproxy"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/npm"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/nuget"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/pypi"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/rubygems"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/hostname"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
@@ -470,6 +472,22 @@ func getVCSSyncer(
 			Client:       c.P4Client,
 			FusionConfig: configureFusionClient(c),
 		}, nil
+	case extsvc.TypeMercurial:
+		return &server.MercurialSyncer{}, nil
+	case extsvc.TypeSubversion:
+		var c schema.SubversionConnection
+		if _, err := extractOptions(&c); err != nil {
+			return nil, err
+		}
+		layout := c.Layout
+		if layout == nil {
+			layout = &schema.SubversionLayout{Trunk: "trunk", Branches: "branches", Tags: "tags"}
+		}
+		return &server.SubversionSyncer{
+			Trunk:    layout.Trunk,
+			Branches: layout.Branches,
+			Tags:     layout.Tags,
+		}, nil
 	case extsvc.TypeJVMPackages:
 		var c schema.JVMPackagesConnection
 		if _, err := extractOptions(&c); err != nil {
@@ -500,6 +518,22 @@ func getVCSSyncer(
 		}
 		cli := pypi.NewClient(urn, c.Urls, httpcli.ExternalDoer)
 		return server.NewPythonPackagesSyncer(&c, depsSvc, cli), nil
+	case extsvc.TypeNuGetPackages:
+		var c schema.NuGetPackagesConnection
+		urn, err := extractOptions(&c)
+		if err != nil {
+			return nil, err
+		}
+		cli := nuget.NewClient(urn, c.Urls, httpcli.ExternalDoer)
+		return server.NewNuGetPackagesSyncer(&c, depsSvc, cli), nil
+	case extsvc.TypeRubyPackages:
+		var c schema.RubyPackagesConnection
+		urn, err := extractOptions(&c)
+		if err != nil {
+			return nil, err
+		}
+		cli := rubygems.NewClient(urn, c.Repository, httpcli.ExternalDoer)
+		return server.NewRubyPackagesSyncer(&c, depsSvc, cli), nil
 	case extsvc.TypeRustPackages:
 		var c schema.RustPackagesConnection
 		urn, err := extractOptions(&c)
@@ -509,6 +543,20 @@ func getVCSSyncer(
 		cli := crates.NewClient(urn, httpcli.ExternalDoer)
 		return server.NewRustPackagesSyncer(&c, depsSvc, cli), nil
 	}
+
+	// All code hosts of Git repositories that support Git LFS share the same
+	// setting, so we don't need to know the type of the connection.
+	var c struct {
+		GitLFS bool `json:"gitLFS"`
+	}
+	if len(r.Sources) > 0 {
+		if _, err := extractOptions(&c); err != nil {
+			return nil, err
+		}
+	}
+	if c.GitLFS {
+		return &server.GitRepoSyncer{LFS: server.NewLFSFetcher(httpcli.ExternalDoer)}, nil
+	}
 	return &server.GitRepoSyncer{}, nil
 }
 
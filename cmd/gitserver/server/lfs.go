@@ -0,0 +1,657 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Git LFS objects are stored in the layout used by git-lfs, so that the git-lfs
+// filter configured by enableGitLFS serves their contents in place of their
+// pointer files:
+//
+//	<repo>/lfs/objects/<oid[0:2]>/<oid[2:4]>/<oid>
+//
+// Objects that are not present are served as their pointer files. The filter
+// only applies to the paths that the .gitattributes files of the repository
+// track with Git LFS, which readLFSAttributes copies to
+//
+//	<repo>/lfs/attributes
+//
+// because git does not read attributes from the tree of bare repositories.
+
+const (
+	// lfsPointerMaxSize is the maximum size of a Git LFS pointer file, as
+	// defined by the Git LFS specification.
+	lfsPointerMaxSize = 1024
+	// lfsBatchSize is the number of objects requested per batch API call.
+	lfsBatchSize = 100
+	lfsMediaType = "application/vnd.git-lfs+json"
+)
+
+// lfsPointer identifies a Git LFS object.
+type lfsPointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// LFSFetcher fetches the Git LFS objects of a repository from the Git LFS
+// server of its code host, using the batch API of the basic transfer adapter.
+// See https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md.
+type LFSFetcher struct {
+	cli httpcli.Doer
+}
+
+// NewLFSFetcher returns a new LFSFetcher which uses cli for requests to the Git
+// LFS server.
+func NewLFSFetcher(cli httpcli.Doer) *LFSFetcher {
+	return &LFSFetcher{cli: cli}
+}
+
+// Fetch makes the objects referenced by HEAD of the repository in dir that are
+// not larger than conf.GitLFSMaxFileSize available to the git-lfs filter, and
+// removes all other objects.
+func (f *LFSFetcher) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir) error {
+	endpoint, err := lfsEndpoint(remoteURL)
+	if err != nil {
+		return err
+	}
+
+	pointers, err := listLFSPointers(ctx, dir, "HEAD")
+	if err != nil {
+		return errors.Wrap(err, "failed to list Git LFS pointers")
+	}
+
+	maxSize := conf.GitLFSMaxFileSize()
+	want := make(map[string]struct{}, len(pointers))
+	var missing []lfsPointer
+	for _, p := range pointers {
+		if p.Size > maxSize {
+			continue
+		}
+		want[p.OID] = struct{}{}
+		if _, err := os.Stat(lfsObjectPath(dir, p.OID)); os.IsNotExist(err) {
+			missing = append(missing, p)
+		}
+	}
+
+	// We keep going if objects fail to download, so that a single broken
+	// object doesn't prevent the others from being served.
+	var errs error
+	for len(missing) > 0 {
+		batch := missing
+		if len(batch) > lfsBatchSize {
+			batch = batch[:lfsBatchSize]
+		}
+		missing = missing[len(batch):]
+
+		if err := f.fetchBatch(ctx, endpoint, remoteURL, dir, batch); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+
+	if err := pruneLFSObjects(dir, want); err != nil {
+		errs = errors.Append(errs, err)
+	}
+	if attributes, err := readLFSAttributes(ctx, dir, "HEAD"); err != nil {
+		errs = errors.Append(errs, errors.Wrap(err, "failed to read Git LFS attributes"))
+	} else if err := os.WriteFile(dir.Path("lfs", "attributes"), []byte(attributes), 0644); err != nil {
+		errs = errors.Append(errs, err)
+	}
+	if err := enableGitLFS(dir); err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return errs
+}
+
+type lfsBatchRequest struct {
+	Operation string       `json:"operation"`
+	Transfers []string     `json:"transfers"`
+	Objects   []lfsPointer `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []lfsBatchObject `json:"objects"`
+}
+
+type lfsBatchObject struct {
+	lfsPointer
+	Actions struct {
+		Download *lfsAction `json:"download"`
+	} `json:"actions"`
+	Error *lfsObjectError `json:"error"`
+}
+
+type lfsObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lfsAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header"`
+}
+
+// fetchBatch requests the download actions for the objects in batch from the
+// batch API at endpoint and downloads the objects.
+func (f *LFSFetcher) fetchBatch(ctx context.Context, endpoint string, remoteURL *vcs.URL, dir GitDir, batch []lfsPointer) error {
+	body, err := json.Marshal(lfsBatchRequest{
+		Operation: "download",
+		Transfers: []string{"basic"},
+		Objects:   batch,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/objects/batch", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+	setLFSBasicAuth(req, remoteURL)
+
+	resp, err := f.cli.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Git LFS batch request failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("Git LFS batch request failed with status %d", resp.StatusCode)
+	}
+
+	var res lfsBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return errors.Wrap(err, "failed to decode Git LFS batch response")
+	}
+
+	var errs error
+	for _, o := range res.Objects {
+		switch {
+		case o.Error != nil:
+			errs = errors.Append(errs, errors.Errorf("Git LFS object %s: %s (code %d)", o.OID, o.Error.Message, o.Error.Code))
+		case o.Actions.Download == nil:
+			// The server has no download action for objects it doesn't have.
+			errs = errors.Append(errs, errors.Errorf("Git LFS object %s: no download action", o.OID))
+		default:
+			if err := f.download(ctx, endpoint, remoteURL, dir, o.lfsPointer, o.Actions.Download); err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "Git LFS object %s", o.OID))
+			}
+		}
+	}
+	return errs
+}
+
+// download downloads the object p using action and stores it in dir after
+// verifying its content.
+func (f *LFSFetcher) download(ctx context.Context, endpoint string, remoteURL *vcs.URL, dir GitDir, p lfsPointer, action *lfsAction) error {
+	if !isValidLFSOID(p.OID) {
+		return errors.New("invalid object ID")
+	}
+	if p.Size > conf.GitLFSMaxFileSize() {
+		return errors.Errorf("object is larger than %d bytes", conf.GitLFSMaxFileSize())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, action.Href, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range action.Header {
+		req.Header.Set(k, v)
+	}
+	// Objects are often served from a different host, such as a blob store, in
+	// which case the action carries its own credentials.
+	if req.Header.Get("Authorization") == "" && sameHost(req.URL.String(), endpoint) {
+		setLFSBasicAuth(req, remoteURL)
+	}
+
+	resp, err := f.cli.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "download failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	tmpDir := dir.Path("lfs", "tmp")
+	if err := os.MkdirAll(tmpDir, os.ModePerm); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(tmpDir, p.OID+"-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(resp.Body, p.Size+1))
+	if err != nil {
+		return errors.Wrap(err, "download failed")
+	}
+	if n != p.Size {
+		return errors.Errorf("expected %d bytes but got %d", p.Size, n)
+	}
+	if oid := hex.EncodeToString(h.Sum(nil)); oid != p.OID {
+		return errors.Errorf("content has object ID %s", oid)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	path := lfsObjectPath(dir, p.OID)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// lfsEndpoint returns the URL of the Git LFS server for remoteURL without
+// credentials, following the defaults of git-lfs.
+func lfsEndpoint(remoteURL *vcs.URL) (string, error) {
+	if remoteURL.Scheme != "http" && remoteURL.Scheme != "https" {
+		return "", errors.Errorf("Git LFS is only supported for HTTP(S) clone URLs, not %q", remoteURL.Scheme)
+	}
+
+	u := remoteURL.URL
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(u.Path, ".git") {
+		u.Path += ".git"
+	}
+	u.Path += "/info/lfs"
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// setLFSBasicAuth sets the credentials of remoteURL, if any, on req.
+func setLFSBasicAuth(req *http.Request, remoteURL *vcs.URL) {
+	if remoteURL.User == nil {
+		return
+	}
+	password, _ := remoteURL.User.Password()
+	req.SetBasicAuth(remoteURL.User.Username(), password)
+}
+
+func sameHost(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return ua.Scheme == ub.Scheme && ua.Host == ub.Host
+}
+
+// treeExists reports whether treeish exists in the repository in dir, which is
+// not the case for HEAD of an empty repository.
+func treeExists(ctx context.Context, dir GitDir, treeish string) bool {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", treeish+"^{tree}")
+	dir.Set(cmd)
+	return cmd.Run() == nil
+}
+
+// listLFSPointers returns the Git LFS objects referenced by pointer files in the
+// tree of treeish. It returns no pointers if treeish does not exist.
+func listLFSPointers(ctx context.Context, dir GitDir, treeish string) ([]lfsPointer, error) {
+	if !treeExists(ctx, dir, treeish) {
+		return nil, nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "ls-tree", "-r", "-l", "-z", "--full-tree", treeish)
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(wrapCmdError(cmd, err), "failed to list files")
+	}
+
+	// Pointer files are small, so we only need to read the content of small
+	// blobs. Each entry has the form "<mode> <type> <object> <size>\t<path>".
+	var candidates []string
+	for _, entry := range bytes.Split(out, []byte{0}) {
+		info, _, ok := bytes.Cut(entry, []byte{'\t'})
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(string(info))
+		if len(fields) != 4 || fields[1] != "blob" {
+			continue
+		}
+		if size, err := strconv.Atoi(fields[3]); err != nil || size >= lfsPointerMaxSize {
+			continue
+		}
+		candidates = append(candidates, fields[2])
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "cat-file", "--batch")
+	dir.Set(cmd)
+	cmd.Stdin = strings.NewReader(strings.Join(candidates, "\n") + "\n")
+	out, err = cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(wrapCmdError(cmd, err), "failed to read files")
+	}
+
+	// The output consists of "<object> <type> <size>\n<content>\n" for each
+	// candidate.
+	seen := map[string]struct{}{}
+	var pointers []lfsPointer
+	r := bufio.NewReader(bytes.NewReader(out))
+	for {
+		header, err := r.ReadString('\n')
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, errors.Errorf("unexpected cat-file output %q", header)
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, errors.Errorf("unexpected cat-file output %q", header)
+		}
+		content := make([]byte, size+1)
+		if _, err := io.ReadFull(r, content); err != nil {
+			return nil, err
+		}
+
+		p, ok := parseLFSPointer(content[:size])
+		if !ok {
+			continue
+		}
+		if _, ok := seen[p.OID]; ok {
+			continue
+		}
+		seen[p.OID] = struct{}{}
+		pointers = append(pointers, p)
+	}
+	return pointers, nil
+}
+
+// readLFSAttributes returns the attributes of the .gitattributes files in the
+// tree of treeish that enable or disable the git-lfs filter, in the format of
+// the info/attributes file. Patterns of nested .gitattributes files are made
+// relative to the root of the repository. It returns no attributes if treeish
+// does not exist.
+func readLFSAttributes(ctx context.Context, dir GitDir, treeish string) (string, error) {
+	if !treeExists(ctx, dir, treeish) {
+		return "", nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "ls-tree", "-r", "-z", "--name-only", "--full-tree", treeish)
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrap(wrapCmdError(cmd, err), "failed to list files")
+	}
+
+	var files []string
+	for _, name := range bytes.Split(out, []byte{0}) {
+		if path.Base(string(name)) == ".gitattributes" {
+			files = append(files, string(name))
+		}
+	}
+	// Later lines take precedence, and so do the attributes of deeper
+	// directories.
+	sort.SliceStable(files, func(i, j int) bool {
+		return strings.Count(files[i], "/") < strings.Count(files[j], "/")
+	})
+
+	var b strings.Builder
+	for _, file := range files {
+		cmd := exec.CommandContext(ctx, "git", "cat-file", "blob", treeish+":"+file)
+		dir.Set(cmd)
+		content, err := cmd.Output()
+		if err != nil {
+			return "", errors.Wrapf(wrapCmdError(cmd, err), "failed to read %s", file)
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			if attr, ok := lfsAttribute(path.Dir(file), line); ok {
+				b.WriteString(attr)
+				b.WriteByte('\n')
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+// lfsAttribute returns the line of a .gitattributes file in dir with only the
+// attributes that enable or disable the git-lfs filter, and its pattern made
+// relative to the root of the repository. It returns false if the line has no
+// such attributes.
+func lfsAttribute(dir, line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	// We skip comments, macros and quoted patterns, which can't contain the
+	// attributes of Git LFS.
+	if line == "" || line[0] == '#' || line[0] == '"' || strings.HasPrefix(line, "[attr]") {
+		return "", false
+	}
+
+	fields := strings.Fields(line)
+	var attrs []string
+	for _, attr := range fields[1:] {
+		switch attr {
+		case "filter=lfs", "-filter", "!filter":
+			attrs = append(attrs, attr)
+		}
+	}
+	if len(attrs) == 0 {
+		return "", false
+	}
+
+	pattern := fields[0]
+	if dir != "." {
+		if strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+			// Patterns with a slash are relative to the directory of the
+			// .gitattributes file.
+			pattern = dir + "/" + strings.TrimPrefix(pattern, "/")
+		} else {
+			// Other patterns match at any level below it.
+			pattern = dir + "/**/" + pattern
+		}
+	}
+	return pattern + " " + strings.Join(attrs, " "), true
+}
+
+// parseLFSPointer parses the content of a Git LFS pointer file. See
+// https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md.
+func parseLFSPointer(content []byte) (lfsPointer, bool) {
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) < 3 {
+		return lfsPointer{}, false
+	}
+	switch lines[0] {
+	case "version https://git-lfs.github.com/spec/v1", "version https://hawser.github.com/spec/v1":
+	default:
+		return lfsPointer{}, false
+	}
+
+	var p lfsPointer
+	var hasSize bool
+	for _, line := range lines[1:] {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			return lfsPointer{}, false
+		}
+		switch key {
+		case "oid":
+			p.OID = strings.TrimPrefix(value, "sha256:")
+			if p.OID == value || !isValidLFSOID(p.OID) {
+				return lfsPointer{}, false
+			}
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || size < 0 {
+				return lfsPointer{}, false
+			}
+			p.Size = size
+			hasSize = true
+		}
+	}
+	if p.OID == "" || !hasSize {
+		return lfsPointer{}, false
+	}
+	return p, true
+}
+
+// isValidLFSOID reports whether oid is a SHA-256 object ID, which makes it safe
+// to use in paths.
+func isValidLFSOID(oid string) bool {
+	if len(oid) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range oid {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func lfsObjectPath(dir GitDir, oid string) string {
+	return dir.Path("lfs", "objects", oid[0:2], oid[2:4], oid)
+}
+
+// pruneLFSObjects removes the objects that are not in want.
+func pruneLFSObjects(dir GitDir, want map[string]struct{}) error {
+	root := dir.Path("lfs", "objects")
+	if err := os.MkdirAll(root, os.ModePerm); err != nil {
+		return err
+	}
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		if _, ok := want[info.Name()]; ok && path == lfsObjectPath(dir, info.Name()) {
+			return nil
+		}
+		return os.Remove(path)
+	})
+}
+
+// gitLFSEnabled reports whether Git LFS objects are served for the repository
+// in dir.
+func gitLFSEnabled(dir GitDir) bool {
+	_, err := os.Stat(dir.Path("lfs"))
+	return err == nil
+}
+
+// lfsGitAttributes returns the attributes that apply the git-lfs filter to the
+// paths the repository in dir tracks with Git LFS, or an empty string if Git LFS
+// is not enabled for it.
+func lfsGitAttributes(dir GitDir) (string, error) {
+	if !gitLFSEnabled(dir) {
+		return "", nil
+	}
+	attributes, err := os.ReadFile(dir.Path("lfs", "attributes"))
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	return string(attributes), nil
+}
+
+// lfsReadFileArgs returns the arguments of the git command that serves an exec
+// request with args. A `git show <commit>:<path>` request for a file of a
+// repository with Git LFS enabled is served with `git cat-file --filters`, which
+// returns the contents of Git LFS objects in place of their pointer files. All
+// other requests, including those for directories, are served as is.
+func lfsReadFileArgs(ctx context.Context, dir GitDir, args []string) []string {
+	if len(args) != 2 || args[0] != "show" || strings.HasPrefix(args[1], "-") || !strings.Contains(args[1], ":") {
+		return args
+	}
+	if !gitLFSEnabled(dir) {
+		return args
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "-t", args[1])
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil || strings.TrimSpace(string(out)) != "blob" {
+		return args
+	}
+	return []string{"cat-file", "--filters", args[1]}
+}
+
+// enableGitLFS configures the git-lfs filter for the repository in dir, which
+// makes git archive and git cat-file --filters return the contents of the Git
+// LFS objects that are present for the paths tracked with Git LFS.
+func enableGitLFS(dir GitDir) error {
+	for _, kv := range [][2]string{
+		// --skip makes the filter return pointer files for objects that are
+		// not present instead of downloading them.
+		{"filter.lfs.process", "git-lfs filter-process --skip"},
+		{"filter.lfs.smudge", "git-lfs smudge --skip -- %f"},
+		{"filter.lfs.required", "false"},
+	} {
+		if err := gitConfigSet(dir, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(dir.Path("lfs", "objects"), os.ModePerm); err != nil {
+		return err
+	}
+	return setGitAttributes(dir)
+}
+
+// removeGitLFS removes the Git LFS objects and configuration of the repository
+// in dir, if any.
+func removeGitLFS(dir GitDir) error {
+	if !gitLFSEnabled(dir) {
+		return nil
+	}
+	if err := os.RemoveAll(dir.Path("lfs")); err != nil {
+		return err
+	}
+	for _, key := range []string{"filter.lfs.process", "filter.lfs.smudge", "filter.lfs.required"} {
+		if err := gitConfigUnset(dir, key); err != nil {
+			return err
+		}
+	}
+	return setGitAttributes(dir)
+}
+
+// syncGitLFS fetches the Git LFS objects of the repository in dir if Git LFS is
+// enabled for its syncer, and removes them otherwise. Failures are only logged,
+// since the repository is usable without its Git LFS objects.
+func (s *Server) syncGitLFS(ctx context.Context, repo api.RepoName, dir GitDir, syncer VCSSyncer, remoteURL *vcs.URL) {
+	var err error
+	if gs, ok := syncer.(*GitRepoSyncer); ok && gs.LFS != nil {
+		err = gs.LFS.Fetch(ctx, remoteURL, dir)
+	} else {
+		err = removeGitLFS(dir)
+	}
+	if err != nil {
+		s.Logger.Warn("failed to sync Git LFS objects", log.String("repo", string(repo)), log.Error(err))
+	}
+}
@@ -1673,8 +1673,12 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	stdoutW := &writeCounter{w: w}
 	stderrW := &writeCounter{w: &limitWriter{W: &stderrBuf, N: 1024}}
 
+	// ReadFile requests for repositories with Git LFS enabled are served
+	// through the git-lfs filter.
+	args := lfsReadFileArgs(ctx, dir, req.Args)
+
 	cmdStart = time.Now()
-	cmd := exec.CommandContext(ctx, "git", req.Args...)
+	cmd := exec.CommandContext(ctx, "git", args...)
 	dir.Set(cmd)
 	cmd.Stdout = stdoutW
 	cmd.Stderr = stderrW
@@ -1920,13 +1924,23 @@ func setGitAttributes(dir GitDir) error {
 		return errors.Wrap(err, "failed to set git attributes")
 	}
 
-	_, err := fileutil.UpdateFileIfDifferent(
-		filepath.Join(infoDir, "attributes"),
-		[]byte(`# Managed by Sourcegraph gitserver.
+	attributes := `# Managed by Sourcegraph gitserver.
 
 # We want every file to be present in git archive.
 * -export-ignore
-`))
+`
+	lfsAttributes, err := lfsGitAttributes(dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to set git attributes")
+	}
+	if lfsAttributes != "" {
+		attributes += `
+# Serve the contents of Git LFS objects in place of their pointer files for the
+# paths tracked with Git LFS.
+` + lfsAttributes
+	}
+
+	_, err = fileutil.UpdateFileIfDifferent(filepath.Join(infoDir, "attributes"), []byte(attributes))
 	if err != nil {
 		return errors.Wrap(err, "failed to set git attributes")
 	}
@@ -2152,6 +2166,8 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 		return err
 	}
 
+	s.syncGitLFS(ctx, repo, tmp, syncer, remoteURL)
+
 	// Set gc.auto depending on gitGCMode.
 	if err := gitSetAutoGC(tmp); err != nil {
 		return err
@@ -2500,6 +2516,8 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 		return errors.Wrap(err, `git config set "sourcegraph.type"`)
 	}
 
+	s.syncGitLFS(ctx, repo, dir, syncer, remoteURL)
+
 	// Update the last-changed stamp on disk.
 	if err := setLastChanged(dir); err != nil {
 		s.Logger.Warn("Failed to update last changed time", log.String("repo", string(repo)), log.Error(err))
@@ -11,7 +11,11 @@ import (
 )
 
 // GitRepoSyncer is a syncer for Git repositories.
-type GitRepoSyncer struct{}
+type GitRepoSyncer struct {
+	// LFS, if non-nil, fetches the Git LFS objects of the repository after it
+	// is cloned or fetched.
+	LFS *LFSFetcher
+}
 
 func (s *GitRepoSyncer) Type() string {
 	return "git"
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// MercurialSyncer is a syncer for Mercurial repositories. The history of the
+// repository is converted into Git incrementally by the git-remote-hg remote
+// helper, which maps
+//
+//	the tip of the default branch -> refs/heads/master
+//	bookmarks                     -> refs/heads/<bookmark>
+//	named branches                -> refs/heads/branches/<branch>
+//	tags                          -> refs/tags/<tag>
+//
+// git-remote-hg keeps the state of the conversion in the hg directory of the
+// Git repository, so fetches only convert new changesets.
+type MercurialSyncer struct{}
+
+// mercurialRemote is the name under which the remote is passed to
+// git-remote-hg. It names the directory in which git-remote-hg stores the
+// state of the conversion, so it must not change between fetches.
+const mercurialRemote = "hg"
+
+func (s *MercurialSyncer) Type() string {
+	return "mercurial"
+}
+
+// IsCloneable checks to see if the Mercurial repository can be accessed.
+func (s *MercurialSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Example: hg identify --noninteractive https://hg.example.com/repos/project1
+	cmd := exec.CommandContext(ctx, "hg", "identify", "--noninteractive",
+		// Suppress asking to add SSH host key to known_hosts, like we do for git.
+		"--config", "ui.ssh=ssh -o BatchMode=yes -o ConnectTimeout=30",
+		remoteURL.String())
+	cmd.Env = append(os.Environ(), "HGPLAIN=1")
+
+	out, err := runWith(ctx, cmd, false, nil)
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Errorf("%s (output follows)\n\n%s", err, newURLRedactor(remoteURL).redact(string(out)))
+		}
+		return err
+	}
+	return nil
+}
+
+// CloneCommand returns the command to be executed for cloning a Mercurial
+// repository as a Git repository.
+func (s *MercurialSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (cmd *exec.Cmd, err error) {
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "clone setup failed")
+	}
+
+	cmd = s.fetchCommand(ctx, remoteURL)
+	dir := GitDir(tmpPath)
+	dir.Set(cmd)
+	return cmd, nil
+}
+
+// Fetch tries to fetch updates of a Mercurial repository as a Git repository.
+func (s *MercurialSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, _ string) error {
+	cmd := s.fetchCommand(ctx, remoteURL)
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, true, nil); err != nil {
+		return errors.Wrapf(err, "failed to update with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
+
+// RemoteShowCommand returns the command to be executed for showing Git remote
+// of a Mercurial repository.
+func (s *MercurialSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
+	// Remote info is encoded as in the current repository. git-remote-hg
+	// always creates master, which is the default branch.
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+func (s *MercurialSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) *exec.Cmd {
+	// The remote is configured on the command line rather than in the
+	// repository, so that credentials are never written to disk. Fetching from
+	// a URL directly would make git-remote-hg throw away its state after every
+	// fetch.
+	cmd := exec.CommandContext(ctx, "git",
+		"-c", "remote."+mercurialRemote+".url=hg::"+remoteURL.String(),
+		"fetch",
+		// We already have janitor jobs that run git gc. We disable git gc here to avoid
+		// a possible corruption of repositories by competing gc processes.
+		"--no-auto-gc",
+		"--progress", "--prune", mercurialRemote,
+		"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*")
+	cmd.Env = append(os.Environ(), "HGPLAIN=1")
+	return cmd
+}
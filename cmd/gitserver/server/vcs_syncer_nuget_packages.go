@@ -0,0 +1,128 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/nuget"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func assertNuGetParsesPlaceholder() *reposource.NuGetVersionedPackage {
+	placeholder, err := reposource.ParseNuGetVersionedPackage("sourcegraph.com-placeholder@0.0.0")
+	if err != nil {
+		panic(fmt.Sprintf("expected placeholder dependency to parse but got %v", err))
+	}
+
+	return placeholder
+}
+
+func NewNuGetPackagesSyncer(
+	connection *schema.NuGetPackagesConnection,
+	svc *dependencies.Service,
+	client *nuget.Client,
+) VCSSyncer {
+	placeholder := assertNuGetParsesPlaceholder()
+
+	return &vcsPackagesSyncer{
+		logger:      log.Scoped("NuGetPackagesSyncer", "sync NuGet packages"),
+		typ:         "nuget_packages",
+		scheme:      dependencies.NuGetPackagesScheme,
+		placeholder: placeholder,
+		svc:         svc,
+		configDeps:  connection.Dependencies,
+		source:      &nugetDependencySource{client: client},
+	}
+}
+
+// nugetDependencySource implements packagesSource
+type nugetDependencySource struct {
+	client *nuget.Client
+}
+
+func (nugetDependencySource) ParseVersionedPackageFromNameAndVersion(name reposource.PackageName, version string) (reposource.VersionedPackage, error) {
+	return reposource.ParseNuGetVersionedPackage(string(name) + "@" + version)
+}
+
+func (nugetDependencySource) ParseVersionedPackageFromConfiguration(dep string) (reposource.VersionedPackage, error) {
+	return reposource.ParseNuGetVersionedPackage(dep)
+}
+
+func (nugetDependencySource) ParsePackageFromName(name reposource.PackageName) (reposource.Package, error) {
+	return reposource.ParseNuGetPackageFromName(name)
+}
+
+func (nugetDependencySource) ParsePackageFromRepoName(repoName api.RepoName) (reposource.Package, error) {
+	return reposource.ParseNuGetPackageFromRepoName(repoName)
+}
+
+func (s *nugetDependencySource) Download(ctx context.Context, dir string, dep reposource.VersionedPackage) error {
+	nugetDep := dep.(*reposource.NuGetVersionedPackage)
+	pkg, err := s.client.GetPackageContents(ctx, nugetDep)
+	if err != nil {
+		return errors.Wrapf(err, "error downloading NuGet package %q", nugetDep.VersionedPackageSyntax())
+	}
+
+	if err = unpackNuGetPackage(pkg, dir); err != nil {
+		return errors.Wrap(err, "failed to unpack NuGet package")
+	}
+
+	return nil
+}
+
+// unpackNuGetPackage unpacks the given .nupkg archive into workDir, skipping
+// any files that aren't valid or that are potentially malicious.
+//
+// A .nupkg file is a zip archive containing the package's .nuspec manifest, its
+// contents (sources for source packages, assemblies and symbols otherwise) and
+// OPC packaging metadata, which is skipped. Like gems, the files of a NuGet
+// package aren't nested in a single top-level directory, so none is stripped.
+func unpackNuGetPackage(pkg []byte, workDir string) error {
+	opts := unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			if isNuGetPackagingMetadata(path) {
+				return false
+			}
+
+			size := file.Size()
+
+			const sizeLimit = 15 * 1024 * 1024
+			if size >= sizeLimit {
+				return false
+			}
+
+			_, malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+			return !malicious
+		},
+	}
+
+	r := bytes.NewReader(pkg)
+	return unpack.Zip(r, int64(len(pkg)), workDir, opts)
+}
+
+// isNuGetPackagingMetadata returns true for the files that NuGet adds to every
+// package archive, which aren't part of the package itself.
+func isNuGetPackagingMetadata(filepath string) bool {
+	switch {
+	case filepath == "[Content_Types].xml", filepath == ".signature.p7s":
+		return true
+	case strings.HasPrefix(filepath, "_rels/"), strings.HasPrefix(filepath, "package/"):
+		return true
+	case path.Ext(filepath) == ".psmdcp":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackNuGetPackage(t *testing.T) {
+	pkg := createNupkg(t, []fileInfo{
+		{path: "Newtonsoft.Json.nuspec", contents: []byte("<package/>")},
+		{path: "src/Newtonsoft.Json/JsonConvert.cs", contents: []byte("namespace Newtonsoft.Json {}")},
+		{path: "lib/net6.0/Newtonsoft.Json.pdb", contents: []byte("pdb")},
+		{path: "[Content_Types].xml", contents: []byte("filter me")},
+		{path: "_rels/.rels", contents: []byte("filter me")},
+		{path: "package/services/metadata/core-properties/0123.psmdcp", contents: []byte("filter me")},
+		{path: ".signature.p7s", contents: []byte("filter me")},
+		{path: "../escape.cs", contents: []byte("filter me")},
+		{path: ".git/index", contents: []byte("filter me")},
+	})
+
+	tmp := t.TempDir()
+	require.NoError(t, unpackNuGetPackage(pkg, tmp))
+
+	var got []string
+	require.NoError(t, filepath.Walk(tmp, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			got = append(got, strings.TrimPrefix(path, tmp))
+		}
+		return nil
+	}))
+	sort.Strings(got)
+
+	want := []string{
+		"/Newtonsoft.Json.nuspec",
+		"/lib/net6.0/Newtonsoft.Json.pdb",
+		"/src/Newtonsoft.Json/JsonConvert.cs",
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("-want,+got\n%s", d)
+	}
+}
+
+// createNupkg returns a zip archive of the given files, which is the format of
+// .nupkg files.
+func createNupkg(t *testing.T, fileInfos []fileInfo) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, fileinfo := range fileInfos {
+		w, err := zw.Create(fileinfo.path)
+		require.NoError(t, err)
+		_, err = w.Write(fileinfo.contents)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
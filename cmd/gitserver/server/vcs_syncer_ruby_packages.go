@@ -0,0 +1,123 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/rubygems"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func assertRubyParsesPlaceholder() *reposource.RubyVersionedPackage {
+	placeholder, err := reposource.ParseRubyVersionedPackage("sourcegraph.com-placeholder@0.0.0")
+	if err != nil {
+		panic(fmt.Sprintf("expected placeholder dependency to parse but got %v", err))
+	}
+
+	return placeholder
+}
+
+func NewRubyPackagesSyncer(
+	connection *schema.RubyPackagesConnection,
+	svc *dependencies.Service,
+	client *rubygems.Client,
+) VCSSyncer {
+	placeholder := assertRubyParsesPlaceholder()
+
+	return &vcsPackagesSyncer{
+		logger:      log.Scoped("RubyPackagesSyncer", "sync Ruby packages"),
+		typ:         "ruby_packages",
+		scheme:      dependencies.RubyPackagesScheme,
+		placeholder: placeholder,
+		svc:         svc,
+		configDeps:  connection.Dependencies,
+		source:      &rubyDependencySource{client: client},
+	}
+}
+
+// rubyDependencySource implements packagesSource
+type rubyDependencySource struct {
+	client *rubygems.Client
+}
+
+func (rubyDependencySource) ParseVersionedPackageFromNameAndVersion(name reposource.PackageName, version string) (reposource.VersionedPackage, error) {
+	return reposource.ParseRubyVersionedPackage(string(name) + "@" + version)
+}
+
+func (rubyDependencySource) ParseVersionedPackageFromConfiguration(dep string) (reposource.VersionedPackage, error) {
+	return reposource.ParseRubyVersionedPackage(dep)
+}
+
+func (rubyDependencySource) ParsePackageFromName(name reposource.PackageName) (reposource.Package, error) {
+	return reposource.ParseRubyPackageFromName(name)
+}
+
+func (rubyDependencySource) ParsePackageFromRepoName(repoName api.RepoName) (reposource.Package, error) {
+	return reposource.ParseRubyPackageFromRepoName(repoName)
+}
+
+func (s *rubyDependencySource) Download(ctx context.Context, dir string, dep reposource.VersionedPackage) error {
+	rubyDep := dep.(*reposource.RubyVersionedPackage)
+	pkg, err := s.client.GetPackageContents(ctx, rubyDep)
+	if err != nil {
+		return errors.Wrapf(err, "error downloading gem %q", rubyDep.VersionedPackageSyntax())
+	}
+
+	if err = unpackRubyPackage(pkg, dir); err != nil {
+		return errors.Wrap(err, "failed to unpack ruby gem")
+	}
+
+	return nil
+}
+
+// unpackRubyPackage unpacks the sources of the given .gem archive into workDir,
+// skipping any files that aren't valid or that are potentially malicious.
+//
+// A .gem file is an uncompressed tar archive containing the gem's metadata and
+// a data.tar.gz archive with the files of the gem, which is the only part we
+// unpack. Unlike other package archives, the files of a gem aren't nested in a
+// single top-level directory, so none is stripped.
+func unpackRubyPackage(pkg []byte, workDir string) error {
+	tr := tar.NewReader(bytes.NewReader(pkg))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return errors.New("gem does not contain data.tar.gz")
+		}
+		if err != nil {
+			return err
+		}
+		if header.Name != "data.tar.gz" {
+			continue
+		}
+
+		opts := unpack.Opts{
+			SkipInvalid:    true,
+			SkipDuplicates: true,
+			Filter: func(path string, file fs.FileInfo) bool {
+				size := file.Size()
+
+				const sizeLimit = 15 * 1024 * 1024
+				if size >= sizeLimit {
+					return false
+				}
+
+				_, malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+				return !malicious
+			},
+		}
+
+		return unpack.Tgz(tr, workDir, opts)
+	}
+}
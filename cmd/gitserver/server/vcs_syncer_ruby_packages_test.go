@@ -0,0 +1,81 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackRubyPackage(t *testing.T) {
+	data := createTgz(t, []fileInfo{
+		{
+			path:     "lib/rails.rb",
+			contents: []byte("module Rails; end"),
+		},
+		{
+			path:     "README.md",
+			contents: []byte("# Rails"),
+		},
+		{
+			path:     ".git/index",
+			contents: []byte("filter me"),
+		},
+		{
+			path:     "/absolute/path/are/filtered",
+			contents: []byte("filter me"),
+		},
+	})
+	gem := createGem(t, []fileInfo{
+		{path: "metadata.gz", contents: []byte("metadata")},
+		{path: "data.tar.gz", contents: data},
+		{path: "checksums.yaml.gz", contents: []byte("checksums")},
+	})
+
+	tmp := t.TempDir()
+	require.NoError(t, unpackRubyPackage(gem, tmp))
+
+	var got []string
+	require.NoError(t, filepath.Walk(tmp, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			got = append(got, strings.TrimPrefix(path, tmp))
+		}
+		return nil
+	}))
+	sort.Strings(got)
+
+	// The single lib directory must not be stripped.
+	want := []string{"/README.md", "/lib/rails.rb"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("-want,+got\n%s", d)
+	}
+}
+
+func TestUnpackRubyPackage_MissingData(t *testing.T) {
+	gem := createGem(t, []fileInfo{{path: "metadata.gz", contents: []byte("metadata")}})
+	require.Error(t, unpackRubyPackage(gem, t.TempDir()))
+}
+
+// createGem returns an uncompressed tar archive of the given files, which is
+// the format of .gem files.
+func createGem(t *testing.T, fileInfos []fileInfo) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tarWriter := tar.NewWriter(&buf)
+	for _, fileinfo := range fileInfos {
+		require.NoError(t, addFileToTarball(t, tarWriter, fileinfo))
+	}
+	require.NoError(t, tarWriter.Close())
+
+	return buf.Bytes()
+}
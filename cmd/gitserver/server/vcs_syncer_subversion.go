@@ -0,0 +1,281 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SubversionSyncer is a syncer for Subversion repositories. The history of the
+// repository is converted into Git incrementally by git svn.
+type SubversionSyncer struct {
+	// Trunk, Branches and Tags are the directories of the Subversion repository
+	// that are mapped to the default branch, branches and tags. Empty
+	// directories are not mapped. If Trunk is empty, the root of the repository
+	// is mapped to the default branch.
+	Trunk    string
+	Branches string
+	Tags     string
+}
+
+const (
+	// subversionRemotePrefix is the prefix of the refs git svn fetches into.
+	subversionRemotePrefix = "refs/remotes/svn/"
+	// subversionPasswordEnv is the environment variable used to pass the
+	// password to the askpass script, so that it doesn't show up in the
+	// arguments of a process.
+	subversionPasswordEnv = "SRC_SUBVERSION_PASSWORD"
+)
+
+func (s *SubversionSyncer) Type() string {
+	return "subversion"
+}
+
+// IsCloneable checks to see if the Subversion repository can be accessed.
+func (s *SubversionSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	username, password, repoURL := decomposeSubversionRemoteURL(remoteURL)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	args := []string{"info", "--non-interactive", "--no-auth-cache"}
+	if username != "" {
+		args = append(args, "--username", username, "--password-from-stdin")
+	}
+	args = append(args, repoURL)
+
+	cmd := exec.CommandContext(ctx, "svn", args...)
+	cmd.Env = append(os.Environ(), "SVN_SSH=ssh -o BatchMode=yes -o ConnectTimeout=30")
+	if username != "" {
+		cmd.Stdin = strings.NewReader(password)
+	}
+
+	out, err := runWith(ctx, cmd, false, nil)
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Errorf("%s (output follows)\n\n%s", err, newURLRedactor(remoteURL).redact(string(out)))
+		}
+		return err
+	}
+	return nil
+}
+
+// CloneCommand initializes a bare Git repository for the Subversion repository
+// and converts its history.
+func (s *SubversionSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (*exec.Cmd, error) {
+	username, _, repoURL := decomposeSubversionRemoteURL(remoteURL)
+
+	if err := os.MkdirAll(tmpPath, 0755); err != nil {
+		return nil, err
+	}
+	dir := GitDir(tmpPath)
+
+	cmd := exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return nil, errors.Wrapf(err, "failed to init repository with output %q", string(output))
+	}
+
+	// Example: git svn init --prefix=svn/ --trunk=trunk --branches=branches --tags=tags https://svn.example.com/repos/project1
+	args := []string{"svn", "init", "--prefix=svn/"}
+	if s.Trunk != "" {
+		args = append(args, "--trunk="+s.Trunk)
+	}
+	if s.Branches != "" {
+		args = append(args, "--branches="+s.Branches)
+	}
+	if s.Tags != "" {
+		args = append(args, "--tags="+s.Tags)
+	}
+	if username != "" {
+		args = append(args, "--username="+username)
+	}
+	args = append(args, repoURL)
+
+	cmd = exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return nil, errors.Wrapf(err, "failed to init git svn with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+
+	if err := s.Fetch(ctx, remoteURL, dir, ""); err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch repo for %s", repoURL)
+	}
+
+	// The history was converted by Fetch above, so we return a no-op command to
+	// satisfy the VCSSyncer interface.
+	return exec.CommandContext(ctx, "git", "--version"), nil
+}
+
+// Fetch converts the Subversion revisions that were added since the last fetch
+// and updates the branches and tags of the repository.
+func (s *SubversionSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, _ string) error {
+	username, password, _ := decomposeSubversionRemoteURL(remoteURL)
+
+	askpass, err := writeSubversionAskpass()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(filepath.Dir(askpass))
+
+	// Example: git svn fetch --username=admin --no-auth-cache
+	args := []string{"svn", "fetch", "--no-auth-cache"}
+	if username != "" {
+		args = append(args, "--username="+username)
+	}
+
+	// We don't use the remote options of runWith, because they disable the
+	// askpass program that provides the password.
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(),
+		"GIT_ASKPASS="+askpass,
+		subversionPasswordEnv+"="+password,
+		"SVN_SSH=ssh -o BatchMode=yes -o ConnectTimeout=30",
+	)
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return errors.Wrapf(err, "failed to fetch with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+
+	return s.updateRefs(ctx, dir)
+}
+
+// RemoteShowCommand returns the command to be executed for showing Git remote
+// of a Subversion repository.
+func (s *SubversionSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
+	// Remote info is encoded as in the current repository
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+// updateRefs makes the branches and tags of the repository mirror the refs
+// fetched by git svn.
+func (s *SubversionSyncer) updateRefs(ctx context.Context, dir GitDir) error {
+	cmd := exec.CommandContext(ctx, "git", "for-each-ref", "--format=%(objectname) %(refname)",
+		subversionRemotePrefix, "refs/heads/", "refs/tags/")
+	dir.Set(cmd)
+	output, err := runWith(ctx, cmd, false, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list refs with output %q", string(output))
+	}
+
+	refs := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		objectName, refName, ok := strings.Cut(scanner.Text(), " ")
+		if ok {
+			refs[refName] = objectName
+		}
+	}
+
+	commands := s.refUpdates(refs)
+	if len(commands) == 0 {
+		return nil
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "update-ref", "--stdin")
+	cmd.Stdin = strings.NewReader(strings.Join(commands, "\n") + "\n")
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return errors.Wrapf(err, "failed to update refs with output %q", string(output))
+	}
+	return nil
+}
+
+// refUpdates returns the commands for git update-ref --stdin that make the
+// branches and tags in refs mirror the refs fetched by git svn:
+//
+//	refs/remotes/svn/trunk      -> refs/heads/master
+//	refs/remotes/svn/<branch>   -> refs/heads/<branch>
+//	refs/remotes/svn/tags/<tag> -> refs/tags/<tag>
+func (s *SubversionSyncer) refUpdates(refs map[string]string) []string {
+	// Without a trunk, git svn fetches the root of the repository into
+	// refs/remotes/<prefix>git-svn.
+	trunk := subversionRemotePrefix + "git-svn"
+	if s.Trunk != "" {
+		trunk = subversionRemotePrefix + "trunk"
+	}
+
+	want := map[string]string{}
+	for refName, objectName := range refs {
+		name := strings.TrimPrefix(refName, subversionRemotePrefix)
+		switch {
+		case name == refName || refName == trunk:
+			continue
+		case strings.Contains(name, "@"):
+			// git svn keeps the history of deleted and replaced branches
+			// in refs such as "branch@1234", which aren't branches anymore.
+			continue
+		case strings.HasPrefix(name, "tags/"):
+			if s.Tags != "" {
+				want["refs/"+name] = objectName
+			}
+		default:
+			if s.Branches != "" {
+				want["refs/heads/"+name] = objectName
+			}
+		}
+	}
+	// The trunk wins over a branch that happens to be called master.
+	if objectName, ok := refs[trunk]; ok {
+		want["refs/heads/master"] = objectName
+	}
+
+	var commands []string
+	for refName, objectName := range want {
+		if refs[refName] != objectName {
+			commands = append(commands, fmt.Sprintf("update %s %s", refName, objectName))
+		}
+	}
+	for refName := range refs {
+		if strings.HasPrefix(refName, subversionRemotePrefix) {
+			continue
+		}
+		if _, ok := want[refName]; !ok {
+			commands = append(commands, "delete "+refName)
+		}
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// decomposeSubversionRemoteURL returns the credentials and the URL without
+// credentials of a Subversion repository.
+func decomposeSubversionRemoteURL(remoteURL *vcs.URL) (username, password, repoURL string) {
+	u := *remoteURL
+	if u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+		u.User = nil
+	}
+	return username, password, u.String()
+}
+
+// writeSubversionAskpass writes a program for GIT_ASKPASS to a new temporary
+// directory, which answers the password prompts of git svn with the content of
+// subversionPasswordEnv.
+func writeSubversionAskpass() (string, error) {
+	dir, err := os.MkdirTemp("", "svn-askpass-")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "askpass")
+	script := "#!/bin/sh\ncase \"$1\" in\n*assword*) printf '%s\\n' \"$" + subversionPasswordEnv + "\" ;;\nesac\n"
+	if err := os.WriteFile(path, []byte(script), 0700); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return path, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/google/uuid"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient"
@@ -28,8 +29,18 @@ type Config struct {
 	VMStartupScriptPath           string
 	VMPrefix                      string
 	KeepWorkspaces                bool
+	WorkspaceCacheDir             string
+	WorkspaceCacheMaxSize         string
 	DockerHostMountPath           string
 	UseFirecracker                bool
+	UsePodman                     bool
+	PodmanUserNamespace           string
+	UseKubernetes                 bool
+	KubernetesNamespace           string
+	KubernetesVolumeClaimName     string
+	KubernetesMountPath           string
+	KubernetesPollInterval        time.Duration
+	KubernetesPendingTimeout      time.Duration
 	JobNumCPUs                    int
 	JobMemory                     string
 	FirecrackerDiskSpace          string
@@ -49,11 +60,21 @@ func (c *Config) Load() {
 	c.QueuePollInterval = c.GetInterval("EXECUTOR_QUEUE_POLL_INTERVAL", "1s", "Interval between dequeue requests.")
 	c.MaximumNumJobs = c.GetInt("EXECUTOR_MAXIMUM_NUM_JOBS", "1", "Number of virtual machines or containers that can be running at once.")
 	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", "true", "Whether to isolate commands in virtual machines.")
+	c.UsePodman = c.GetBool("EXECUTOR_USE_PODMAN", "false", "Whether to run commands in rootless Podman containers instead of Docker containers. Requires EXECUTOR_USE_FIRECRACKER=false.")
+	c.PodmanUserNamespace = c.Get("EXECUTOR_PODMAN_USERNS", "auto", "The user namespace mode of Podman containers. The default, auto, runs every container in its own user namespace.")
+	c.UseKubernetes = c.GetBool("EXECUTOR_USE_KUBERNETES", "false", "Whether to run commands in Kubernetes jobs instead of Docker containers. Requires EXECUTOR_USE_FIRECRACKER=false.")
+	c.KubernetesNamespace = c.Get("EXECUTOR_KUBERNETES_NAMESPACE", "default", "The namespace in which Kubernetes jobs are created.")
+	c.KubernetesVolumeClaimName = c.GetOptional("EXECUTOR_KUBERNETES_PERSISTENT_VOLUME_CLAIM_NAME", "The name of the persistent volume claim shared by the executor and its Kubernetes jobs.")
+	c.KubernetesMountPath = c.GetOptional("EXECUTOR_KUBERNETES_MOUNT_PATH", "The path at which the persistent volume claim is mounted in the executor. Workspaces must be created below this path (e.g. by setting TMPDIR).")
+	c.KubernetesPollInterval = c.GetInterval("EXECUTOR_KUBERNETES_POLL_INTERVAL", "1s", "Interval between checks of the status of a Kubernetes job.")
+	c.KubernetesPendingTimeout = c.GetInterval("EXECUTOR_KUBERNETES_POD_PENDING_TIMEOUT", "5m", "The time after which a Kubernetes job fails if its pod has not started.")
 	c.FirecrackerImage = c.Get("EXECUTOR_FIRECRACKER_IMAGE", "sourcegraph/ignite-ubuntu:insiders", "The base image to use for virtual machines.")
 	c.FirecrackerKernelImage = c.Get("EXECUTOR_FIRECRACKER_KERNEL_IMAGE", "sourcegraph/ignite-kernel:5.10.135-amd64", "The base image containing the kernel binary to use for virtual machines.")
 	c.VMStartupScriptPath = c.GetOptional("EXECUTOR_VM_STARTUP_SCRIPT_PATH", "A path to a file on the host that is loaded into a fresh virtual machine and executed on startup.")
 	c.VMPrefix = c.Get("EXECUTOR_VM_PREFIX", "executor", "A name prefix for virtual machines controlled by this instance.")
 	c.KeepWorkspaces = c.GetBool("EXECUTOR_KEEP_WORKSPACES", "false", "Whether to skip deletion of workspaces after a job completes (or fails). Note that when Firecracker is enabled that the workspace is initially copied into the VM, so modifications will not be observed.")
+	c.WorkspaceCacheDir = c.GetOptional("EXECUTOR_WORKSPACE_CACHE_DIR", "A directory on the host in which repositories are cached between jobs. Repositories are not cached if unset.")
+	c.WorkspaceCacheMaxSize = c.Get("EXECUTOR_WORKSPACE_CACHE_MAX_SIZE", "20G", "The size above which the least recently used repositories are evicted from the workspace cache.")
 	c.DockerHostMountPath = c.GetOptional("EXECUTOR_DOCKER_HOST_MOUNT_PATH", "The target workspace as it resides on the Docker host (used to enable Docker-in-Docker).")
 	c.JobNumCPUs = c.GetInt(env.ChooseFallbackVariableName("EXECUTOR_JOB_NUM_CPUS", "EXECUTOR_FIRECRACKER_NUM_CPUS"), "4", "How many CPUs to allocate to each virtual machine or container. A value of zero sets no resource bound (in Docker, but not VMs).")
 	c.JobMemory = c.Get(env.ChooseFallbackVariableName("EXECUTOR_JOB_MEMORY", "EXECUTOR_FIRECRACKER_MEMORY"), "12G", "How much memory to allocate to each virtual machine or container. A value of zero sets no resource bound (in Docker, but not VMs).")
@@ -75,6 +96,26 @@ func (c *Config) Validate() error {
 		// Required by Firecracker: The vCPU number is invalid! The vCPU number can only be 1 or an even number when hyperthreading is enabled
 		c.AddError(errors.Newf("EXECUTOR_JOB_NUM_CPUS must be 1 or an even number"))
 	}
+	if c.UsePodman && c.UseFirecracker {
+		c.AddError(errors.Newf("EXECUTOR_USE_PODMAN requires EXECUTOR_USE_FIRECRACKER to be false"))
+	}
+	if _, err := humanize.ParseBytes(c.WorkspaceCacheMaxSize); err != nil {
+		c.AddError(errors.Wrap(err, "invalid EXECUTOR_WORKSPACE_CACHE_MAX_SIZE"))
+	}
+	if c.UseKubernetes {
+		if c.UseFirecracker {
+			c.AddError(errors.Newf("EXECUTOR_USE_KUBERNETES requires EXECUTOR_USE_FIRECRACKER to be false"))
+		}
+		if c.UsePodman {
+			c.AddError(errors.Newf("EXECUTOR_USE_KUBERNETES and EXECUTOR_USE_PODMAN cannot both be enabled"))
+		}
+		if c.KubernetesVolumeClaimName == "" {
+			c.AddError(errors.Newf("EXECUTOR_KUBERNETES_PERSISTENT_VOLUME_CLAIM_NAME is required when EXECUTOR_USE_KUBERNETES is enabled"))
+		}
+		if c.KubernetesMountPath == "" {
+			c.AddError(errors.Newf("EXECUTOR_KUBERNETES_MOUNT_PATH is required when EXECUTOR_USE_KUBERNETES is enabled"))
+		}
+	}
 
 	return c.BaseConfig.Validate()
 }
@@ -83,9 +124,12 @@ func (c *Config) APIWorkerOptions(telemetryOptions apiclient.TelemetryOptions) a
 	return apiworker.Options{
 		VMPrefix:           c.VMPrefix,
 		KeepWorkspaces:     c.KeepWorkspaces,
+		WorkspaceCacheDir:  c.WorkspaceCacheDir,
 		QueueName:          c.QueueName,
 		WorkerOptions:      c.WorkerOptions(),
 		FirecrackerOptions: c.FirecrackerOptions(),
+		PodmanOptions:      c.PodmanOptions(),
+		KubernetesOptions:  c.KubernetesOptions(),
 		ResourceOptions:    c.ResourceOptions(),
 		GitServicePath:     "/.executors/git",
 		ClientOptions:      c.ClientOptions(telemetryOptions),
@@ -95,11 +139,18 @@ func (c *Config) APIWorkerOptions(telemetryOptions apiclient.TelemetryOptions) a
 			c.FrontendAuthorizationToken: "SECRET_REMOVED",
 		},
 
+		WorkspaceCacheMaxSize:              c.workspaceCacheMaxSize(),
 		NodeExporterEndpoint:               c.NodeExporterURL,
 		DockerRegistryNodeExporterEndpoint: c.DockerRegistryNodeExporterURL,
 	}
 }
 
+func (c *Config) workspaceCacheMaxSize() int64 {
+	// The size has been validated in Validate.
+	size, _ := humanize.ParseBytes(c.WorkspaceCacheMaxSize)
+	return int64(size)
+}
+
 func (c *Config) WorkerOptions() workerutil.WorkerOptions {
 	return workerutil.WorkerOptions{
 		Name:                 fmt.Sprintf("executor_%s_worker", c.QueueName),
@@ -124,6 +175,24 @@ func (c *Config) FirecrackerOptions() command.FirecrackerOptions {
 	}
 }
 
+func (c *Config) PodmanOptions() command.PodmanOptions {
+	return command.PodmanOptions{
+		Enabled:       c.UsePodman,
+		UserNamespace: c.PodmanUserNamespace,
+	}
+}
+
+func (c *Config) KubernetesOptions() command.KubernetesOptions {
+	return command.KubernetesOptions{
+		Enabled:                   c.UseKubernetes,
+		Namespace:                 c.KubernetesNamespace,
+		PersistentVolumeClaimName: c.KubernetesVolumeClaimName,
+		MountPath:                 c.KubernetesMountPath,
+		PollInterval:              c.KubernetesPollInterval,
+		PendingTimeout:            c.KubernetesPendingTimeout,
+	}
+}
+
 func (c *Config) ResourceOptions() command.ResourceOptions {
 	return command.ResourceOptions{
 		NumCPUs:             c.JobNumCPUs,
@@ -0,0 +1,295 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// KubernetesExecutorNameLabel is the label attached to every Kubernetes job (and its pod)
+// created by the Kubernetes runtime. Its value is the name of the executor job the
+// Kubernetes job was created for.
+const KubernetesExecutorNameLabel = "executor.sourcegraph.com/name"
+
+const (
+	kubernetesContainerName  = "step"
+	kubernetesVolumeName     = "workspace"
+	kubernetesJobNameMaxSize = 63
+
+	// kubernetesJobTTL is the time after which Kubernetes removes a finished job that
+	// has not been deleted by the executor, e.g. because the executor was restarted
+	// in the middle of the job.
+	kubernetesJobTTL = int32(10 * time.Minute / time.Second)
+)
+
+// NewKubernetesClientset returns a client for the Kubernetes cluster the executor is
+// running in.
+func NewKubernetesClientset() (kubernetes.Interface, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+// newKubernetesJob constructs a Kubernetes job that invokes the given spec in a one-shot
+// pod subject to the resource limits specified in the given options. The workspace dir
+// must reside on the persistent volume shared by the executor and the job pods, which is
+// mounted into the pod at the same location as the workspace in docker containers.
+func newKubernetesJob(spec CommandSpec, name, dir string, options Options) (*batchv1.Job, error) {
+	subPath, err := filepath.Rel(options.KubernetesOptions.MountPath, dir)
+	if err != nil || subPath == ".." || strings.HasPrefix(subPath, "../") {
+		return nil, errors.Newf("workspace %q is not on the volume mounted at %q", dir, options.KubernetesOptions.MountPath)
+	}
+
+	resources, err := kubernetesResources(options.ResourceOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	env := make([]corev1.EnvVar, 0, len(spec.Env))
+	for _, e := range spec.Env {
+		// Env vars are not passed through a shell, so they must not be quoted.
+		k, v, _ := strings.Cut(e, "=")
+		env = append(env, corev1.EnvVar{Name: k, Value: v})
+	}
+
+	labels := map[string]string{KubernetesExecutorNameLabel: name}
+	backoffLimit := int32(0)
+	ttl := kubernetesJobTTL
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   kubernetesJobName(name, spec.Key),
+			Labels: labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:       kubernetesContainerName,
+						Image:      spec.Image,
+						Command:    []string{"/bin/sh", filepath.Join("/data", ScriptsPath, spec.ScriptPath)},
+						WorkingDir: filepath.Join("/data", spec.Dir),
+						Env:        env,
+						Resources:  resources,
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      kubernetesVolumeName,
+							MountPath: "/data",
+							SubPath:   subPath,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: kubernetesVolumeName,
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: options.KubernetesOptions.PersistentVolumeClaimName,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}, nil
+}
+
+func kubernetesResources(options ResourceOptions) (corev1.ResourceRequirements, error) {
+	limits := corev1.ResourceList{}
+	if options.NumCPUs != 0 {
+		limits[corev1.ResourceCPU] = resource.MustParse(strconv.Itoa(options.NumCPUs))
+	}
+	if options.Memory != "0" && options.Memory != "" {
+		memory, err := resource.ParseQuantity(options.Memory)
+		if err != nil {
+			return corev1.ResourceRequirements{}, errors.Wrapf(err, "invalid memory limit %q", options.Memory)
+		}
+		limits[corev1.ResourceMemory] = memory
+	}
+
+	return corev1.ResourceRequirements{Limits: limits}, nil
+}
+
+var invalidKubernetesNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// kubernetesJobName returns a valid Kubernetes object name for the step with the given key
+// of the executor job with the given name. Names that are too long are truncated and end
+// in a hash of the full name, so that the steps of a job keep distinct names.
+func kubernetesJobName(name, key string) string {
+	jobName := strings.Trim(invalidKubernetesNameCharacters.ReplaceAllString(strings.ToLower(name+"-"+key), "-"), "-")
+	if len(jobName) > kubernetesJobNameMaxSize {
+		sum := sha256.Sum256([]byte(jobName))
+		hash := hex.EncodeToString(sum[:])[:8]
+		jobName = strings.TrimRight(jobName[:kubernetesJobNameMaxSize-len(hash)-1], "-") + "-" + hash
+	}
+	return jobName
+}
+
+// runKubernetesJob creates the given Kubernetes job and waits for its pod to finish. The
+// output of the pod is written to the given logger. The job is deleted once this function
+// returns, including when the given context is canceled.
+func runKubernetesJob(ctx context.Context, options KubernetesOptions, job *batchv1.Job, operation *observation.Operation, key string, logger Logger) (err error) {
+	ctx, _, endObservation := operation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	container := job.Spec.Template.Spec.Containers[0]
+	log15.Info(fmt.Sprintf("Running kubernetes job %s: %s", job.Name, strings.Join(container.Command, " ")))
+
+	jobs := options.Clientset.BatchV1().Jobs(options.Namespace)
+	if _, err := jobs.Create(ctx, job, metav1.CreateOptions{}); err != nil {
+		return errors.Wrap(err, "creating kubernetes job")
+	}
+	defer func() {
+		// Perform this outside of the task execution context. If there is a timeout or
+		// cancellation error we don't want to leave the job (and its pod) running.
+		propagation := metav1.DeletePropagationBackground
+		deleteErr := jobs.Delete(context.Background(), job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+		if deleteErr != nil && !kerrors.IsNotFound(deleteErr) {
+			log15.Error("Failed to delete kubernetes job", "name", job.Name, "err", deleteErr)
+		}
+	}()
+
+	handle := logger.Log(key, flatten(container.Image, container.Command))
+	defer handle.Close()
+
+	exitCode, err := watchKubernetesJob(ctx, options, job.Name, handle)
+	handle.Finalize(exitCode)
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		// If is context cancelation, forward the ctx.Err().
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		return errors.New("command failed")
+	}
+	return nil
+}
+
+// watchKubernetesJob waits for the pod of the Kubernetes job with the given name to start,
+// streams its output into the given writer, and returns the exit code of its container.
+func watchKubernetesJob(ctx context.Context, options KubernetesOptions, name string, w io.Writer) (int, error) {
+	pendingCtx := ctx
+	if options.PendingTimeout > 0 {
+		var cancel context.CancelFunc
+		pendingCtx, cancel = context.WithTimeout(ctx, options.PendingTimeout)
+		defer cancel()
+	}
+	pod, err := waitForKubernetesPod(pendingCtx, options, name, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase != corev1.PodPending
+	})
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			return 0, errors.Newf("pod of kubernetes job %s did not start within %s", name, options.PendingTimeout)
+		}
+		return 0, err
+	}
+
+	stream, err := options.Clientset.CoreV1().Pods(options.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: kubernetesContainerName,
+		Follow:    true,
+	}).Stream(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "streaming pod logs")
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 4*1024), 100*1024*1024)
+	for scanner.Scan() {
+		if _, err := fmt.Fprintf(w, "stdout: %s\n", scanner.Text()); err != nil {
+			return 0, err
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return 0, errors.Wrap(err, "reading pod logs")
+	}
+
+	pod, err = waitForKubernetesPod(ctx, options, name, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == kubernetesContainerName && status.State.Terminated != nil {
+			return int(status.State.Terminated.ExitCode), nil
+		}
+	}
+	if pod.Status.Phase == corev1.PodFailed {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// kubernetesPodFailureReasons are the reasons for which a container waits to start that
+// are not resolved by waiting, e.g. because the image does not exist.
+var kubernetesPodFailureReasons = map[string]struct{}{
+	"ErrImagePull":               {},
+	"ImagePullBackOff":           {},
+	"InvalidImageName":           {},
+	"CreateContainerConfigError": {},
+	"CreateContainerError":       {},
+}
+
+// waitForKubernetesPod polls the pods of the Kubernetes job with the given name until one
+// of them satisfies the given condition. It fails if the container of a pod cannot start.
+func waitForKubernetesPod(ctx context.Context, options KubernetesOptions, name string, condition func(pod *corev1.Pod) bool) (*corev1.Pod, error) {
+	ticker := time.NewTicker(options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		pods, err := options.Clientset.CoreV1().Pods(options.Namespace).List(ctx, metav1.ListOptions{
+			// The job-name label is set on pods by the job controller.
+			LabelSelector: "job-name=" + name,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "listing pods")
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			for _, status := range pod.Status.ContainerStatuses {
+				if waiting := status.State.Waiting; waiting != nil {
+					if _, ok := kubernetesPodFailureReasons[waiting.Reason]; ok {
+						return nil, errors.Newf("pod %s cannot start: %s: %s", pod.Name, waiting.Reason, waiting.Message)
+					}
+				}
+			}
+			if condition(pod) {
+				return pod, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
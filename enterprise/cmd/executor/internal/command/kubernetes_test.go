@@ -0,0 +1,296 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestNewKubernetesJob(t *testing.T) {
+	job, err := newKubernetesJob(
+		CommandSpec{
+			Key:        "step.docker.0",
+			Image:      "alpine:latest",
+			ScriptPath: "myscript.sh",
+			Dir:        "subdir",
+			Env: []string{
+				`TEST=true`,
+				`CONTAINS_WHITESPACE=yes it does`,
+			},
+			Operation: makeTestOperation(),
+		},
+		"executor-deadbeef",
+		"/workspaces/1234",
+		Options{
+			KubernetesOptions: KubernetesOptions{
+				Enabled:                   true,
+				PersistentVolumeClaimName: "executor-workspaces",
+				MountPath:                 "/workspaces",
+			},
+			ResourceOptions: ResourceOptions{
+				NumCPUs: 4,
+				Memory:  "20G",
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if job.Name != "executor-deadbeef-step-docker-0" {
+		t.Errorf("unexpected job name %q", job.Name)
+	}
+	if *job.Spec.BackoffLimit != 0 {
+		t.Errorf("unexpected backoff limit %d", *job.Spec.BackoffLimit)
+	}
+
+	expectedContainer := corev1.Container{
+		Name:       "step",
+		Image:      "alpine:latest",
+		Command:    []string{"/bin/sh", "/data/.sourcegraph-executor/myscript.sh"},
+		WorkingDir: "/data/subdir",
+		Env: []corev1.EnvVar{
+			{Name: "TEST", Value: "true"},
+			{Name: "CONTAINS_WHITESPACE", Value: "yes it does"},
+		},
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("20G"),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{{Name: "workspace", MountPath: "/data", SubPath: "1234"}},
+	}
+	if diff := cmp.Diff([]corev1.Container{expectedContainer}, job.Spec.Template.Spec.Containers); diff != "" {
+		t.Errorf("unexpected containers (-want +got):\n%s", diff)
+	}
+
+	if claim := job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName; claim != "executor-workspaces" {
+		t.Errorf("unexpected claim name %q", claim)
+	}
+	if name := job.Spec.Template.Labels[KubernetesExecutorNameLabel]; name != "executor-deadbeef" {
+		t.Errorf("unexpected executor name label %q", name)
+	}
+}
+
+func TestNewKubernetesJobOutsideOfVolume(t *testing.T) {
+	_, err := newKubernetesJob(
+		CommandSpec{Image: "alpine:latest", ScriptPath: "myscript.sh"},
+		"executor-deadbeef",
+		"/tmp/1234",
+		Options{KubernetesOptions: KubernetesOptions{Enabled: true, MountPath: "/workspaces"}},
+	)
+	if err == nil {
+		t.Fatal("expected an error for a workspace outside of the volume")
+	}
+}
+
+func TestKubernetesJobName(t *testing.T) {
+	testCases := map[string]string{
+		"step.docker.0":                          "executor-deadbeef-step-docker-0",
+		"STEP_SRC_1":                             "executor-deadbeef-step-src-1",
+		"step." + strings.Repeat("x", 60) + ".0": "executor-deadbeef-step-" + strings.Repeat("x", 31) + "-8363d235",
+	}
+	for key, expected := range testCases {
+		if name := kubernetesJobName("executor-deadbeef", key); name != expected {
+			t.Errorf("unexpected job name for key %q. want=%q have=%q", key, expected, name)
+		}
+	}
+
+	// Steps whose names only differ after the maximum length must not share a job name.
+	long := "step." + strings.Repeat("x", 60)
+	if a, b := kubernetesJobName("executor-deadbeef", long+".0"), kubernetesJobName("executor-deadbeef", long+".1"); a == b || len(a) > kubernetesJobNameMaxSize {
+		t.Errorf("unexpected job names %q and %q", a, b)
+	}
+}
+
+func TestRunKubernetesJob(t *testing.T) {
+	clientset, options := newFakeKubernetes(t, func(job *batchv1.Job) *corev1.Pod {
+		return newFakeKubernetesPod(job, corev1.PodSucceeded, 0)
+	})
+	logger, entry, output := newKubernetesTestLogger()
+
+	job := newTestKubernetesJob(t, options)
+	if err := runKubernetesJob(context.Background(), options.KubernetesOptions, job, makeTestOperation(), "step.docker.0", logger); err != nil {
+		t.Fatalf("unexpected error running job: %s", err)
+	}
+
+	if out := output(); out != "stdout: fake logs\n" {
+		t.Errorf("unexpected output %q", out)
+	}
+	if calls := entry.FinalizeFunc.History(); len(calls) != 1 || calls[0].Arg0 != 0 {
+		t.Errorf("unexpected finalize calls %v", calls)
+	}
+	assertKubernetesJobDeleted(t, clientset, options, job.Name)
+}
+
+func TestRunKubernetesJobFailure(t *testing.T) {
+	clientset, options := newFakeKubernetes(t, func(job *batchv1.Job) *corev1.Pod {
+		return newFakeKubernetesPod(job, corev1.PodFailed, 3)
+	})
+	logger, entry, _ := newKubernetesTestLogger()
+
+	job := newTestKubernetesJob(t, options)
+	if err := runKubernetesJob(context.Background(), options.KubernetesOptions, job, makeTestOperation(), "step.docker.0", logger); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if calls := entry.FinalizeFunc.History(); len(calls) != 1 || calls[0].Arg0 != 3 {
+		t.Errorf("unexpected finalize calls %v", calls)
+	}
+	assertKubernetesJobDeleted(t, clientset, options, job.Name)
+}
+
+func TestRunKubernetesJobCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientset, options := newFakeKubernetes(t, func(job *batchv1.Job) *corev1.Pod {
+		// The pod never starts, so the job only finishes when it is canceled.
+		cancel()
+		return newFakeKubernetesPod(job, corev1.PodPending, 0)
+	})
+	logger, _, _ := newKubernetesTestLogger()
+
+	job := newTestKubernetesJob(t, options)
+	if err := runKubernetesJob(ctx, options.KubernetesOptions, job, makeTestOperation(), "step.docker.0", logger); !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error. want=%q have=%q", context.Canceled, err)
+	}
+
+	assertKubernetesJobDeleted(t, clientset, options, job.Name)
+}
+
+func TestRunKubernetesJobImagePullFailure(t *testing.T) {
+	clientset, options := newFakeKubernetes(t, func(job *batchv1.Job) *corev1.Pod {
+		pod := newFakeKubernetesPod(job, corev1.PodPending, 0)
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  "step",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}},
+		}}
+		return pod
+	})
+	logger, _, _ := newKubernetesTestLogger()
+
+	job := newTestKubernetesJob(t, options)
+	err := runKubernetesJob(context.Background(), options.KubernetesOptions, job, makeTestOperation(), "step.docker.0", logger)
+	if err == nil || !strings.Contains(err.Error(), "ImagePullBackOff") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	assertKubernetesJobDeleted(t, clientset, options, job.Name)
+}
+
+func TestRunKubernetesJobPendingTimeout(t *testing.T) {
+	clientset, options := newFakeKubernetes(t, func(job *batchv1.Job) *corev1.Pod {
+		return newFakeKubernetesPod(job, corev1.PodPending, 0)
+	})
+	options.KubernetesOptions.PendingTimeout = 10 * time.Millisecond
+	logger, _, _ := newKubernetesTestLogger()
+
+	job := newTestKubernetesJob(t, options)
+	err := runKubernetesJob(context.Background(), options.KubernetesOptions, job, makeTestOperation(), "step.docker.0", logger)
+	if err == nil || !strings.Contains(err.Error(), "did not start within") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	assertKubernetesJobDeleted(t, clientset, options, job.Name)
+}
+
+// newFakeKubernetes returns a fake Kubernetes API server in which the creation of a job
+// creates the pod returned by the given function, standing in for the job controller.
+func newFakeKubernetes(t *testing.T, newPod func(job *batchv1.Job) *corev1.Pod) (*fake.Clientset, Options) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		job := action.(k8stesting.CreateAction).GetObject().(*batchv1.Job)
+		if err := clientset.Tracker().Add(newPod(job)); err != nil {
+			t.Fatalf("unexpected error creating pod: %s", err)
+		}
+		return false, nil, nil
+	})
+
+	return clientset, Options{
+		ExecutorName: "executor-deadbeef",
+		KubernetesOptions: KubernetesOptions{
+			Enabled:                   true,
+			Namespace:                 "executors",
+			PersistentVolumeClaimName: "executor-workspaces",
+			MountPath:                 "/workspaces",
+			PollInterval:              time.Millisecond,
+			Clientset:                 clientset,
+		},
+	}
+}
+
+func newFakeKubernetesPod(job *batchv1.Job, phase corev1.PodPhase, exitCode int32) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      job.Name + "-abcde",
+			Namespace: "executors",
+			Labels:    map[string]string{"job-name": job.Name},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+	if phase == corev1.PodSucceeded || phase == corev1.PodFailed {
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  "step",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+		}}
+	}
+	return pod
+}
+
+func newTestKubernetesJob(t *testing.T, options Options) *batchv1.Job {
+	job, err := newKubernetesJob(
+		CommandSpec{Key: "step.docker.0", Image: "alpine:latest", ScriptPath: "myscript.sh"},
+		options.ExecutorName,
+		"/workspaces/1234",
+		options,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return job
+}
+
+func newKubernetesTestLogger() (*MockLogger, *MockLogEntry, func() string) {
+	var mu sync.Mutex
+	var output strings.Builder
+
+	entry := NewMockLogEntry()
+	entry.WriteFunc.SetDefaultHook(func(p []byte) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return output.Write(p)
+	})
+	logger := NewMockLogger()
+	logger.LogFunc.SetDefaultReturn(entry)
+
+	return logger, entry, func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return output.String()
+	}
+}
+
+func assertKubernetesJobDeleted(t *testing.T, clientset *fake.Clientset, options Options, name string) {
+	t.Helper()
+
+	_, err := clientset.BatchV1().Jobs(options.KubernetesOptions.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if !kerrors.IsNotFound(err) {
+		t.Errorf("expected job %q to be deleted, got error %v", name, err)
+	}
+}
@@ -18,9 +18,14 @@ type Operations struct {
 	SetupGitSparseCheckoutSet    *observation.Operation
 	SetupGitCheckout             *observation.Operation
 	SetupGitSetRemoteUrl         *observation.Operation
+	SetupGitCacheInit            *observation.Operation
+	SetupGitCacheFetch           *observation.Operation
+	SetupGitCacheCopy            *observation.Operation
+	SetupGitDisableBare          *observation.Operation
 	SetupFirecrackerStart        *observation.Operation
 	SetupStartupScript           *observation.Operation
 	TeardownFirecrackerRemove    *observation.Operation
+	TeardownPodmanChown          *observation.Operation
 	Exec                         *observation.Operation
 
 	RunLockWaitTotal prometheus.Counter
@@ -64,9 +69,14 @@ func NewOperations(observationContext *observation.Context) *Operations {
 		SetupGitSparseCheckoutSet:    op("setup.git.sparse-checkout-set"),
 		SetupGitCheckout:             op("setup.git.checkout"),
 		SetupGitSetRemoteUrl:         op("setup.git.set-remote"),
+		SetupGitCacheInit:            op("setup.git.cache-init"),
+		SetupGitCacheFetch:           op("setup.git.cache-fetch"),
+		SetupGitCacheCopy:            op("setup.git.cache-copy"),
+		SetupGitDisableBare:          op("setup.git.disable-bare"),
 		SetupFirecrackerStart:        op("setup.firecracker.start"),
 		SetupStartupScript:           op("setup.startup-script"),
 		TeardownFirecrackerRemove:    op("teardown.firecracker.remove"),
+		TeardownPodmanChown:          op("teardown.podman.chown"),
 		Exec:                         op("exec"),
 
 		RunLockWaitTotal: runLockWaitTotal,
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/podman"
+)
+
+// podmanAutoUserNamespace is the user namespace mode in which Podman allocates a
+// fresh, unused range of subordinate IDs for every container.
+const podmanAutoUserNamespace = "auto"
+
+// formatRawOrPodmanCommand constructs the command to run on the host in order to
+// invoke the given spec. If the spec does not specify an image, then the command
+// will be run _directly_ on the host. Otherwise, the command will be run inside
+// of a one-shot rootless Podman container subject to the same resource limits as
+// Docker containers. The container is labeled with the given name so that the
+// janitor can remove it if it outlives the job.
+func formatRawOrPodmanCommand(spec CommandSpec, dir, name string, options Options) command {
+	if spec.Image == "" {
+		return formatRawOrDockerCommand(spec, dir, options)
+	}
+
+	return command{
+		Key: spec.Key,
+		Command: flatten(
+			"podman", "run", "--rm",
+			podmanUserNamespaceFlags(options.PodmanOptions),
+			podmanLabelFlags(name),
+			dockerResourceFlags(options.ResourceOptions),
+			podmanVolumeFlags(dir, options.PodmanOptions),
+			dockerWorkingdirectoryFlags(spec.Dir),
+			// If the env vars will be part of the command line args, we need to quote them
+			dockerEnvFlags(quoteEnv(spec.Env)),
+			dockerEntrypointFlags(),
+			spec.Image,
+			filepath.Join("/data", ScriptsPath, spec.ScriptPath),
+		),
+		Operation: spec.Operation,
+	}
+}
+
+// teardownPodman returns ownership of the workspace to the executor user. Files
+// written by a container running in its own user namespace are owned by one of the
+// subordinate IDs of the executor user, which the executor cannot remove directly.
+func teardownPodman(ctx context.Context, runner commandRunner, logger Logger, dir string, options Options, operations *Operations) error {
+	if options.PodmanOptions.UserNamespace != podmanAutoUserNamespace {
+		return nil
+	}
+
+	// Within `podman unshare`, the executor user is root and its subordinate IDs
+	// are mapped, so this hands the workspace back to the executor user.
+	chownCommand := command{
+		Key:       "teardown.podman.chown",
+		Command:   flatten("podman", "unshare", "chown", "-R", "0:0", dir),
+		Operation: operations.TeardownPodmanChown,
+	}
+	if err := runner.RunCommand(ctx, chownCommand, logger); err != nil {
+		log15.Error("Failed to reclaim workspace from podman user namespace", "dir", dir, "err", err)
+	}
+
+	return nil
+}
+
+func podmanUserNamespaceFlags(options PodmanOptions) []string {
+	if options.UserNamespace == "" {
+		return nil
+	}
+	return []string{"--userns", options.UserNamespace}
+}
+
+func podmanLabelFlags(name string) []string {
+	return []string{"--label", podman.ExecutorNameLabel + "=" + name}
+}
+
+func podmanVolumeFlags(wd string, options PodmanOptions) []string {
+	if options.UserNamespace == podmanAutoUserNamespace {
+		// The container root is not the executor user in an automatic user namespace,
+		// so the workspace is chowned to the container root (the U option) in order to
+		// stay writable.
+		return []string{"-v", wd + ":/data:U"}
+	}
+	return dockerVolumeFlags(wd)
+}
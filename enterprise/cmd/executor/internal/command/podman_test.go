@@ -0,0 +1,158 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestFormatRawOrPodmanCommandRaw(t *testing.T) {
+	actual := formatRawOrPodmanCommand(
+		CommandSpec{
+			Command:   []string{"ls", "-a"},
+			Dir:       "subdir",
+			Env:       []string{`TEST=true`},
+			Operation: makeTestOperation(),
+		},
+		"/proj/src",
+		"deadbeef",
+		Options{PodmanOptions: PodmanOptions{Enabled: true, UserNamespace: "auto"}},
+	)
+
+	expected := command{
+		Command: []string{"ls", "-a"},
+		Dir:     "/proj/src/subdir",
+		Env:     []string{"TEST=true"},
+	}
+	if diff := cmp.Diff(expected, actual, commandComparer); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormatRawOrPodmanCommandPodmanScript(t *testing.T) {
+	actual := formatRawOrPodmanCommand(
+		CommandSpec{
+			Image:      "alpine:latest",
+			ScriptPath: "myscript.sh",
+			Dir:        "subdir",
+			Env: []string{
+				`TEST=true`,
+				`CONTAINS_WHITESPACE=yes it does`,
+			},
+			Operation: makeTestOperation(),
+		},
+		"/proj/src",
+		"deadbeef",
+		Options{
+			PodmanOptions: PodmanOptions{
+				Enabled:       true,
+				UserNamespace: "auto",
+			},
+			ResourceOptions: ResourceOptions{
+				NumCPUs: 4,
+				Memory:  "20G",
+			},
+		},
+	)
+
+	expected := command{
+		Command: []string{
+			"podman", "run", "--rm",
+			"--userns", "auto",
+			"--label", "sourcegraph.executor.name=deadbeef",
+			"--cpus", "4",
+			"--memory", "20G",
+			"-v", "/proj/src:/data:U",
+			"-w", "/data/subdir",
+			"-e", "TEST=true",
+			"-e", `CONTAINS_WHITESPACE="yes it does"`,
+			"--entrypoint",
+			"/bin/sh",
+			"alpine:latest",
+			"/data/.sourcegraph-executor/myscript.sh",
+		},
+	}
+	if diff := cmp.Diff(expected, actual, commandComparer); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormatRawOrPodmanCommandPodmanScriptKeepID(t *testing.T) {
+	actual := formatRawOrPodmanCommand(
+		CommandSpec{
+			Image:      "alpine:latest",
+			ScriptPath: "myscript.sh",
+			Dir:        "subdir",
+			Operation:  makeTestOperation(),
+		},
+		"/proj/src",
+		"deadbeef",
+		Options{
+			PodmanOptions: PodmanOptions{
+				Enabled:       true,
+				UserNamespace: "keep-id",
+			},
+			ResourceOptions: ResourceOptions{
+				NumCPUs: 0,
+				Memory:  "0",
+			},
+		},
+	)
+
+	expected := command{
+		Command: []string{
+			"podman", "run", "--rm",
+			"--userns", "keep-id",
+			"--label", "sourcegraph.executor.name=deadbeef",
+			"-v", "/proj/src:/data",
+			"-w", "/data/subdir",
+			"--entrypoint",
+			"/bin/sh",
+			"alpine:latest",
+			"/data/.sourcegraph-executor/myscript.sh",
+		},
+	}
+	if diff := cmp.Diff(expected, actual, commandComparer); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+}
+
+func TestTeardownPodman(t *testing.T) {
+	runner := NewMockCommandRunner()
+	operations := NewOperations(&observation.TestContext)
+
+	options := Options{PodmanOptions: PodmanOptions{Enabled: true, UserNamespace: "auto"}}
+	if err := teardownPodman(context.Background(), runner, nil, "/proj/src", options, operations); err != nil {
+		t.Fatalf("unexpected error tearing down podman runner: %s", err)
+	}
+
+	var actual []string
+	for _, call := range runner.RunCommandFunc.History() {
+		actual = append(actual, strings.Join(call.Arg1.Command, " "))
+	}
+
+	expected := []string{
+		"podman unshare chown -R 0:0 /proj/src",
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected commands (-want +got):\n%s", diff)
+	}
+}
+
+func TestTeardownPodmanKeepID(t *testing.T) {
+	runner := NewMockCommandRunner()
+	operations := NewOperations(&observation.TestContext)
+
+	options := Options{PodmanOptions: PodmanOptions{Enabled: true, UserNamespace: "keep-id"}}
+	if err := teardownPodman(context.Background(), runner, nil, "/proj/src", options, operations); err != nil {
+		t.Fatalf("unexpected error tearing down podman runner: %s", err)
+	}
+
+	if calls := runner.RunCommandFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected commands: %d", len(calls))
+	}
+}
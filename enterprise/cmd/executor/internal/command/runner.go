@@ -2,6 +2,9 @@ package command
 
 import (
 	"context"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -9,7 +12,7 @@ import (
 // Runner is the interface between an executor and the host on which commands
 // are invoked. Having this interface at this level allows us to use the same
 // code paths for local development (via shell + docker) as well as production
-// usage (via Firecracker).
+// usage (via Firecracker, rootless Podman, or Kubernetes jobs).
 type Runner interface {
 	// Setup prepares the runner to invoke a series of commands.
 	Setup(ctx context.Context) error
@@ -41,6 +44,12 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions FirecrackerOptions
 
+	// PodmanOptions configures the behavior of Podman container creation.
+	PodmanOptions PodmanOptions
+
+	// KubernetesOptions configures the behavior of Kubernetes job creation.
+	KubernetesOptions KubernetesOptions
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions ResourceOptions
@@ -62,6 +71,44 @@ type FirecrackerOptions struct {
 	VMStartupScriptPath string
 }
 
+type PodmanOptions struct {
+	// Enabled determines if commands will be run in rootless Podman containers instead
+	// of Docker containers. This option is ignored if Firecracker is enabled.
+	Enabled bool
+
+	// UserNamespace is the user namespace mode passed to podman run (e.g. auto or
+	// keep-id). With auto, every container runs in its own user namespace.
+	UserNamespace string
+}
+
+type KubernetesOptions struct {
+	// Enabled determines if commands will be run in Kubernetes jobs instead of Docker
+	// containers. This option is ignored if Firecracker is enabled.
+	Enabled bool
+
+	// Namespace is the namespace in which Kubernetes jobs are created.
+	Namespace string
+
+	// PersistentVolumeClaimName is the name of the claim of the persistent volume shared
+	// by the executor and the pods of Kubernetes jobs.
+	PersistentVolumeClaimName string
+
+	// MountPath is the path at which the shared persistent volume is mounted in the
+	// executor. Workspaces must be created below this path.
+	MountPath string
+
+	// PollInterval is the interval at which the status of the pod of a Kubernetes job
+	// is checked.
+	PollInterval time.Duration
+
+	// PendingTimeout is the time after which a Kubernetes job fails if its pod has not
+	// started, e.g. because it cannot be scheduled. Zero means no timeout.
+	PendingTimeout time.Duration
+
+	// Clientset is the client used to create Kubernetes jobs.
+	Clientset kubernetes.Interface
+}
+
 type ResourceOptions struct {
 	// NumCPUs is the number of virtual CPUs a container or VM can use.
 	NumCPUs int
@@ -82,6 +129,20 @@ type ResourceOptions struct {
 // NewRunner creates a new runner with the given options.
 func NewRunner(dir string, logger Logger, options Options, operations *Operations) Runner {
 	if !options.FirecrackerOptions.Enabled {
+		if options.KubernetesOptions.Enabled {
+			return &kubernetesRunner{name: options.ExecutorName, dir: dir, logger: logger, options: options}
+		}
+
+		if options.PodmanOptions.Enabled {
+			return &podmanRunner{
+				name:       options.ExecutorName,
+				dir:        dir,
+				logger:     logger,
+				options:    options,
+				operations: operations,
+			}
+		}
+
 		return &dockerRunner{dir: dir, logger: logger, options: options}
 	}
 
@@ -114,6 +175,61 @@ func (r *dockerRunner) Run(ctx context.Context, command CommandSpec) error {
 	return runCommand(ctx, formatRawOrDockerCommand(command, r.dir, r.options), r.logger)
 }
 
+type podmanRunner struct {
+	name       string
+	dir        string
+	logger     Logger
+	options    Options
+	operations *Operations
+}
+
+var _ Runner = &podmanRunner{}
+
+func (r *podmanRunner) Setup(ctx context.Context) error {
+	return nil
+}
+
+func (r *podmanRunner) Teardown(ctx context.Context) error {
+	if r.dir == "" {
+		return nil
+	}
+	return teardownPodman(ctx, defaultRunner, r.logger, r.dir, r.options, r.operations)
+}
+
+func (r *podmanRunner) Run(ctx context.Context, command CommandSpec) error {
+	return runCommand(ctx, formatRawOrPodmanCommand(command, r.dir, r.name, r.options), r.logger)
+}
+
+type kubernetesRunner struct {
+	name    string
+	dir     string
+	logger  Logger
+	options Options
+}
+
+var _ Runner = &kubernetesRunner{}
+
+func (r *kubernetesRunner) Setup(ctx context.Context) error {
+	return nil
+}
+
+func (r *kubernetesRunner) Teardown(ctx context.Context) error {
+	return nil
+}
+
+func (r *kubernetesRunner) Run(ctx context.Context, command CommandSpec) error {
+	// Commands without an image (such as src-cli steps) are run in the executor.
+	if command.Image == "" {
+		return runCommand(ctx, formatRawOrDockerCommand(command, r.dir, r.options), r.logger)
+	}
+
+	job, err := newKubernetesJob(command, r.name, r.dir, r.options)
+	if err != nil {
+		return err
+	}
+	return runKubernetesJob(ctx, r.options.KubernetesOptions, job, command.Operation, command.Key, r.logger)
+}
+
 type firecrackerRunner struct {
 	name       string
 	dir        string
@@ -0,0 +1,97 @@
+package janitor
+
+import (
+	"context"
+	"os/exec"
+	"sort"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/podman"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type orphanedContainerJanitor struct {
+	prefix  string
+	names   *NameSet
+	metrics *metrics
+}
+
+var _ goroutine.Handler = &orphanedContainerJanitor{}
+var _ goroutine.ErrorHandler = &orphanedContainerJanitor{}
+
+// NewOrphanedContainerJanitor returns a background routine that periodically removes all
+// Podman containers on the host that belong to a job not known by the worker running
+// within this executor instance.
+func NewOrphanedContainerJanitor(
+	prefix string,
+	names *NameSet,
+	interval time.Duration,
+	metrics *metrics,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, newOrphanedContainerJanitor(
+		prefix,
+		names,
+		metrics,
+	))
+}
+
+func newOrphanedContainerJanitor(
+	prefix string,
+	names *NameSet,
+	metrics *metrics,
+) *orphanedContainerJanitor {
+	return &orphanedContainerJanitor{
+		prefix:  prefix,
+		names:   names,
+		metrics: metrics,
+	}
+}
+
+func (j *orphanedContainerJanitor) Handle(ctx context.Context) (err error) {
+	containersByID, err := podman.ActiveContainersByID(ctx, j.prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range findOrphanedContainers(containersByID, j.names.Slice()) {
+		log15.Info("Removing orphaned container", "id", id)
+
+		if removeErr := exec.CommandContext(ctx, "podman", "rm", "-f", id).Run(); removeErr != nil {
+			err = errors.Append(err, removeErr)
+		} else {
+			j.metrics.numContainersRemoved.Inc()
+		}
+	}
+
+	return err
+}
+
+func (j *orphanedContainerJanitor) HandleError(err error) {
+	j.metrics.numErrors.Inc()
+	log15.Error("Failed to remove orphaned containers", "error", err)
+}
+
+// findOrphanedContainers returns the set of container identifiers of running containers
+// whose job name is absent from expected names. The runningContainers argument is expected
+// to be a map from container identifiers to job names.
+func findOrphanedContainers(runningContainers map[string]string, expectedNames []string) []string {
+	expectedMap := make(map[string]struct{}, len(expectedNames))
+	for _, name := range expectedNames {
+		expectedMap[name] = struct{}{}
+	}
+
+	ids := make([]string, 0, len(runningContainers))
+	for id, name := range runningContainers {
+		if _, ok := expectedMap[name]; ok {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
@@ -0,0 +1,43 @@
+package podman
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ExecutorNameLabel is the label attached to every container started by the Podman
+// runtime. Its value is the name of the job (shared with the name-set used by the
+// janitor) that the container was started for.
+const ExecutorNameLabel = "sourcegraph.executor.name"
+
+// ActiveContainersByID returns the set of containers existant on the host that were
+// started by the Podman runtime as a map from container identifiers to job names.
+// Containers whose job name starts with a prefix distinct from the given prefix are
+// ignored.
+func ActiveContainersByID(ctx context.Context, prefix string) (map[string]string, error) {
+	cmd := exec.CommandContext(ctx, "podman", "ps", "-a",
+		"--filter", "label="+ExecutorNameLabel,
+		"--format", fmt.Sprintf(`{{ .ID }}:{{ index .Labels %q }}`, ExecutorNameLabel),
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePodmanList(prefix, string(out)), nil
+}
+
+// parsePodmanList parses the output from the `podman ps` invocation in ActiveContainersByID.
+// Containers whose job name starts with a prefix distinct from the given prefix are ignored.
+func parsePodmanList(prefix, out string) map[string]string {
+	activeContainersMap := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		if parts := strings.Split(line, ":"); len(parts) == 2 && strings.HasPrefix(parts[1], prefix) {
+			activeContainersMap[parts[0]] = parts[1]
+		}
+	}
+
+	return activeContainersMap
+}
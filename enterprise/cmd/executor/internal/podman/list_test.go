@@ -0,0 +1,36 @@
+package podman
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var testPodmanOut = `
+100:xa
+101:yb
+102:xa
+103:yd
+104:xe
+105:
+time="2022-08-19T10:00:00Z" level=warning msg="Test that we ignore annoying log/stderr text"
+`
+
+func TestParsePodmanList(t *testing.T) {
+	expectedForX := map[string]string{
+		"100": "xa",
+		"102": "xa",
+		"104": "xe",
+	}
+	if diff := cmp.Diff(expectedForX, parsePodmanList("x", testPodmanOut)); diff != "" {
+		t.Fatalf("unexpected active containers (-want +got):\n%s", diff)
+	}
+
+	expectedForY := map[string]string{
+		"101": "yb",
+		"103": "yd",
+	}
+	if diff := cmp.Diff(expectedForY, parsePodmanList("y", testPodmanOut)); diff != "" {
+		t.Fatalf("unexpected active containers (-want +got):\n%s", diff)
+	}
+}
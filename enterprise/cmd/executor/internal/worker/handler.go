@@ -27,6 +27,9 @@ type handler struct {
 	options       Options
 	operations    *command.Operations
 	runnerFactory func(dir string, logger command.Logger, options command.Options, operations *command.Operations) command.Runner
+
+	// workspaceCache is nil if repositories are not cached between jobs.
+	workspaceCache *workspaceCache
 }
 
 var (
@@ -141,6 +144,8 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record workerut
 	options := command.Options{
 		ExecutorName:       name,
 		FirecrackerOptions: h.options.FirecrackerOptions,
+		PodmanOptions:      h.options.PodmanOptions,
+		KubernetesOptions:  h.options.KubernetesOptions,
 		ResourceOptions:    h.options.ResourceOptions,
 	}
 	runner := h.runnerFactory(workspaceRoot, commandLogger, options, h.operations)
@@ -15,8 +15,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/janitor"
-	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/metrics"
+	executormetrics "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -61,10 +62,24 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions command.FirecrackerOptions
 
+	// PodmanOptions configures the behavior of Podman container creation.
+	PodmanOptions command.PodmanOptions
+
+	// KubernetesOptions configures the behavior of Kubernetes job creation.
+	KubernetesOptions command.KubernetesOptions
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions command.ResourceOptions
 
+	// WorkspaceCacheDir is the directory in which repositories are cached between jobs.
+	// Repositories are not cached if it is empty.
+	WorkspaceCacheDir string
+
+	// WorkspaceCacheMaxSize is the size in bytes above which the least recently used
+	// repositories are evicted from the workspace cache.
+	WorkspaceCacheMaxSize int64
+
 	// NodeExporterEndpoint is the URL of the local node_exporter endpoint, without
 	// the /metrics path.
 	NodeExporterEndpoint string
@@ -80,7 +95,7 @@ type Options struct {
 // currently being performed, which is necessary so the job queue API doesn't hand out jobs
 // it thinks may have been dropped.
 func NewWorker(nameSet *janitor.NameSet, options Options, observationContext *observation.Context) goroutine.WaitableBackgroundRoutine {
-	gatherer := metrics.MakeExecutorMetricsGatherer(log.Scoped("executor-worker.metrics-gatherer", ""), prometheus.DefaultGatherer, options.NodeExporterEndpoint, options.DockerRegistryNodeExporterEndpoint)
+	gatherer := executormetrics.MakeExecutorMetricsGatherer(log.Scoped("executor-worker.metrics-gatherer", ""), prometheus.DefaultGatherer, options.NodeExporterEndpoint, options.DockerRegistryNodeExporterEndpoint)
 	queueStore := apiclient.New(options.ClientOptions, gatherer, observationContext)
 	store := &storeShim{queueName: options.QueueName, queueStore: queueStore}
 
@@ -95,6 +110,9 @@ func NewWorker(nameSet *janitor.NameSet, options Options, observationContext *ob
 		operations:    command.NewOperations(observationContext),
 		runnerFactory: command.NewRunner,
 	}
+	if options.WorkspaceCacheDir != "" {
+		handler.workspaceCache = newWorkspaceCache(options.WorkspaceCacheDir, options.WorkspaceCacheMaxSize, metrics.NewWorkspaceCacheMetrics(observationContext.Registerer))
+	}
 
 	ctx := context.Background()
 
@@ -97,12 +97,26 @@ func (h *handler) prepareWorkspace(ctx context.Context, commandRunner command.Ru
 			appendFetchArg("--filter=blob:none")
 		}
 
-		gitCommands := []command.CommandSpec{
-			{Key: "setup.git.init", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "init"}, Operation: h.operations.SetupGitInit},
-			{Key: "setup.git.add-remote", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "remote", "add", "origin", cloneURL.String()}, Operation: h.operations.SetupAddRemote},
-			// Disable gc, this can improve performance and should never run for executor clones.
-			{Key: "setup.git.disable-gc", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "config", "--local", "gc.auto", "0"}, Operation: h.operations.SetupGitDisableGC},
-			{Key: "setup.git.fetch", Env: gitStdEnv, Command: fetchCommand, Operation: h.operations.SetupGitFetch},
+		var gitCommands []command.CommandSpec
+		if h.workspaceCache != nil && !shallowClone && len(sparseCheckout) == 0 {
+			// Shallow and sparse clones fetch only a small part of the repository, and
+			// would turn the cached repository into a shallow or partial clone.
+			if err := h.prepareCachedRepository(ctx, commandRunner, repositoryName, repoPath, cloneURL.String(), authorizationOption, commit, fetchTags); err != nil {
+				return "", err
+			}
+
+			gitCommands = []command.CommandSpec{
+				{Key: "setup.git.disable-bare", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "config", "--local", "core.bare", "false"}, Operation: h.operations.SetupGitDisableBare},
+				{Key: "setup.git.add-remote", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "remote", "add", "origin", cloneURL.String()}, Operation: h.operations.SetupAddRemote},
+			}
+		} else {
+			gitCommands = []command.CommandSpec{
+				{Key: "setup.git.init", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "init"}, Operation: h.operations.SetupGitInit},
+				{Key: "setup.git.add-remote", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "remote", "add", "origin", cloneURL.String()}, Operation: h.operations.SetupAddRemote},
+				// Disable gc, this can improve performance and should never run for executor clones.
+				{Key: "setup.git.disable-gc", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "config", "--local", "gc.auto", "0"}, Operation: h.operations.SetupGitDisableGC},
+				{Key: "setup.git.fetch", Env: gitStdEnv, Command: fetchCommand, Operation: h.operations.SetupGitFetch},
+			}
 		}
 
 		if len(sparseCheckout) > 0 {
@@ -181,6 +195,78 @@ func (h *handler) prepareWorkspace(ctx context.Context, commandRunner command.Ru
 	return tempDir, nil
 }
 
+// prepareCachedRepository fetches the given commit into the cached bare repository for the
+// given repository name, then copies the cached repository into the .git directory of the
+// workspace repository path. The copy uses reflinks on filesystems that support them, and
+// is a full copy otherwise. The workspace repository is self-contained, so it can be
+// copied into a virtual machine or mounted into a container.
+func (h *handler) prepareCachedRepository(ctx context.Context, commandRunner command.Runner, repositoryName, repoPath, cloneURL, authorizationOption, commit string, fetchTags bool) (err error) {
+	cachePath, cached, release, err := h.workspaceCache.acquire(repositoryName)
+	if err != nil {
+		return err
+	}
+	defer func() { release(err) }()
+
+	var gitCommands []command.CommandSpec
+	if !cached {
+		gitCommands = append(gitCommands,
+			command.CommandSpec{Key: "setup.git.cache-init", Env: gitStdEnv, Command: []string{"git", "init", "--bare", cachePath}, Operation: h.operations.SetupGitCacheInit},
+			// Disable gc, so that it never runs in the background while the repository is copied.
+			command.CommandSpec{Key: "setup.git.cache-disable-gc", Env: gitStdEnv, Command: []string{"git", "-C", cachePath, "config", "--local", "gc.auto", "0"}, Operation: h.operations.SetupGitDisableGC},
+		)
+	}
+
+	// Every fetched commit is kept in its own ref, so that later fetches can advertise it
+	// and only fetch the objects that are missing from the cached repository. A commit that
+	// was fetched before does not need to be fetched again.
+	ref := "refs/executor/" + commit
+	if _, statErr := os.Stat(filepath.Join(cachePath, ref)); statErr != nil || fetchTags {
+		fetchCommand := []string{
+			"git",
+			"-C", cachePath,
+			"-c", "protocol.version=2",
+			"-c", authorizationOption,
+			"-c", "http.extraHeader=X-Sourcegraph-Actor-UID: internal",
+			"fetch",
+			"--progress",
+			"--no-recurse-submodules",
+		}
+		if fetchTags {
+			fetchCommand = append(fetchCommand, "--tags")
+		}
+		fetchCommand = append(fetchCommand, cloneURL, "+"+commit+":"+ref)
+
+		gitCommands = append(gitCommands, command.CommandSpec{Key: "setup.git.cache-fetch", Env: gitStdEnv, Command: fetchCommand, Operation: h.operations.SetupGitCacheFetch})
+	}
+
+	for _, spec := range gitCommands {
+		if err := commandRunner.Run(ctx, spec); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed %s", spec.Key))
+		}
+	}
+
+	if err := pruneCachedRefs(filepath.Join(cachePath, "refs", "executor"), commit, maxCachedRefs); err != nil {
+		return errors.Wrap(err, "pruning cached refs")
+	}
+
+	copySpec := command.CommandSpec{
+		Key:       "setup.git.cache-copy",
+		Command:   []string{"cp", "-R", "--reflink=auto", cachePath, filepath.Join(repoPath, ".git")},
+		Operation: h.operations.SetupGitCacheCopy,
+	}
+	if err := commandRunner.Run(ctx, copySpec); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed %s", copySpec.Key))
+	}
+
+	// The refs of the cache are an implementation detail of the cache, which jobs should
+	// not see.
+	if err := os.RemoveAll(filepath.Join(repoPath, ".git", "refs", "executor")); err != nil {
+		return errors.Wrap(err, "removing cached refs from workspace")
+	}
+
+	return nil
+}
+
 func makeRelativeURL(base string, path ...string) (*url.URL, error) {
 	baseURL, err := url.Parse(base)
 	if err != nil {
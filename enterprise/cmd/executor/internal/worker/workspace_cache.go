@@ -0,0 +1,228 @@
+package worker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// workspaceCache is an on-disk cache of bare repositories from which job workspaces are
+// created. Jobs for a repository that is already cached only fetch the objects missing
+// from the cached repository. Once the cache exceeds its maximum size, the least recently
+// used repositories that are not in use by a job are evicted.
+//
+// The cache assumes that it is the only user of its directory, so multiple executors on
+// one host must not share it.
+type workspaceCache struct {
+	dir     string
+	maxSize int64
+	metrics *metrics.WorkspaceCacheMetrics
+
+	// mu protects entries and serializes evictions.
+	mu      sync.Mutex
+	entries map[string]*workspaceCacheEntry
+}
+
+type workspaceCacheEntry struct {
+	// mu is held while a job fetches into or copies from the cached repository.
+	mu sync.Mutex
+	// inUse is the number of jobs holding or waiting for mu. Entries in use are never
+	// evicted.
+	inUse int
+}
+
+func newWorkspaceCache(dir string, maxSize int64, metrics *metrics.WorkspaceCacheMetrics) *workspaceCache {
+	return &workspaceCache{
+		dir:     dir,
+		maxSize: maxSize,
+		metrics: metrics,
+		entries: map[string]*workspaceCacheEntry{},
+	}
+}
+
+// acquire locks the cached repository for the given repository name and returns its path
+// and whether it already exists. The caller must call the returned release function with
+// the error (or nil) of the operations it performed on the cached repository once it no
+// longer needs it. A repository that did not exist before is removed again on error, so
+// that a partially initialized repository is never reused.
+func (c *workspaceCache) acquire(repositoryName string) (path string, cached bool, release func(err error), err error) {
+	if err := os.MkdirAll(c.dir, os.ModePerm); err != nil {
+		return "", false, nil, errors.Wrap(err, "creating workspace cache directory")
+	}
+	path = filepath.Join(c.dir, workspaceCacheKey(repositoryName))
+
+	c.mu.Lock()
+	entry, ok := c.entries[path]
+	if !ok {
+		entry = &workspaceCacheEntry{}
+		c.entries[path] = entry
+	}
+	entry.inUse++
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+
+	if _, err := os.Stat(path); err == nil {
+		cached = true
+		c.metrics.Hits.Inc()
+	} else {
+		c.metrics.Misses.Inc()
+	}
+
+	release = func(err error) {
+		if err != nil && !cached {
+			if err := os.RemoveAll(path); err != nil {
+				c.metrics.Errors.Inc()
+				log15.Error("Failed to remove cached repository", "path", path, "error", err)
+			}
+		} else {
+			// Bump the modification time, which orders repositories for eviction.
+			now := time.Now()
+			_ = os.Chtimes(path, now, now)
+		}
+		entry.mu.Unlock()
+
+		c.mu.Lock()
+		entry.inUse--
+		c.mu.Unlock()
+
+		c.evict()
+	}
+
+	return path, cached, release, nil
+}
+
+// evict removes the least recently used repositories that are not in use until the total
+// size of the cache is no larger than its maximum size.
+func (c *workspaceCache) evict() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		c.metrics.Errors.Inc()
+		log15.Error("Failed to read workspace cache directory", "dir", c.dir, "error", err)
+		return
+	}
+
+	type candidate struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	candidates := make([]candidate, 0, len(dirEntries))
+	var totalSize int64
+	for _, dirEntry := range dirEntries {
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(c.dir, dirEntry.Name())
+		size := diskUsage(path)
+		totalSize += size
+		candidates = append(candidates, candidate{path: path, size: size, modTime: info.ModTime()})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].modTime.Before(candidates[j].modTime)
+	})
+
+	for _, candidate := range candidates {
+		if totalSize <= c.maxSize {
+			break
+		}
+		if entry, ok := c.entries[candidate.path]; ok && entry.inUse > 0 {
+			continue
+		}
+
+		if err := os.RemoveAll(candidate.path); err != nil {
+			c.metrics.Errors.Inc()
+			log15.Error("Failed to evict cached repository", "path", candidate.path, "error", err)
+			continue
+		}
+		delete(c.entries, candidate.path)
+		totalSize -= candidate.size
+		c.metrics.Evictions.Inc()
+	}
+
+	c.metrics.SizeBytes.Set(float64(totalSize))
+}
+
+// maxCachedRefs is the number of refs/executor refs that are kept in each cached
+// repository. Every job adds a ref for its commit, and each of them is advertised by every
+// later fetch, so they are pruned to the most recently used ones.
+const maxCachedRefs = 100
+
+// pruneCachedRefs marks the ref of the given commit in the given refs directory as used,
+// then removes the least recently used refs until at most max of them are left. The
+// objects of removed refs stay in the cached repository until it is evicted.
+func pruneCachedRefs(dir, commit string, max int) error {
+	now := time.Now()
+	if err := os.Chtimes(filepath.Join(dir, commit), now, now); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if len(dirEntries) <= max {
+		return nil
+	}
+
+	type ref struct {
+		path    string
+		modTime time.Time
+	}
+	refs := make([]ref, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		refs = append(refs, ref{path: filepath.Join(dir, dirEntry.Name()), modTime: info.ModTime()})
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].modTime.After(refs[j].modTime)
+	})
+
+	for _, ref := range refs[max:] {
+		if err := os.Remove(ref.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// workspaceCacheKey returns the name of the directory of the cached repository for the
+// given repository name.
+func workspaceCacheKey(repositoryName string) string {
+	sum := sha256.Sum256([]byte(repositoryName))
+	return hex.EncodeToString(sum[:]) + ".git"
+}
+
+// diskUsage returns the total size of the regular files below the given path.
+func diskUsage(path string) (size int64) {
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,151 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestWorkspaceCacheAcquire(t *testing.T) {
+	cache := newWorkspaceCache(t.TempDir(), 1024, metrics.NewWorkspaceCacheMetrics(metrics.TestRegisterer))
+
+	path, cached, release, err := cache.acquire("github.com/sourcegraph/sourcegraph")
+	if err != nil {
+		t.Fatalf("unexpected error acquiring repository: %s", err)
+	}
+	if cached {
+		t.Fatalf("expected repository not to be cached")
+	}
+	writeCachedRepository(t, path, 10)
+	release(nil)
+
+	path2, cached, release, err := cache.acquire("github.com/sourcegraph/sourcegraph")
+	if err != nil {
+		t.Fatalf("unexpected error acquiring repository: %s", err)
+	}
+	release(nil)
+	if path2 != path {
+		t.Errorf("unexpected path. want=%q have=%q", path, path2)
+	}
+	if !cached {
+		t.Errorf("expected repository to be cached")
+	}
+}
+
+func TestWorkspaceCacheAcquireError(t *testing.T) {
+	cache := newWorkspaceCache(t.TempDir(), 1024, metrics.NewWorkspaceCacheMetrics(metrics.TestRegisterer))
+
+	path, _, release, err := cache.acquire("github.com/sourcegraph/sourcegraph")
+	if err != nil {
+		t.Fatalf("unexpected error acquiring repository: %s", err)
+	}
+	writeCachedRepository(t, path, 10)
+	release(errors.New("fetch failed"))
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected partially initialized repository to be removed, got %v", err)
+	}
+
+	// A failure on an existing repository keeps it in the cache.
+	path, _, release, err = cache.acquire("github.com/sourcegraph/sourcegraph")
+	if err != nil {
+		t.Fatalf("unexpected error acquiring repository: %s", err)
+	}
+	writeCachedRepository(t, path, 10)
+	release(nil)
+	_, _, release, err = cache.acquire("github.com/sourcegraph/sourcegraph")
+	if err != nil {
+		t.Fatalf("unexpected error acquiring repository: %s", err)
+	}
+	release(errors.New("fetch failed"))
+
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected cached repository to be kept, got %v", err)
+	}
+}
+
+func TestWorkspaceCacheEvict(t *testing.T) {
+	cache := newWorkspaceCache(t.TempDir(), 300, metrics.NewWorkspaceCacheMetrics(metrics.TestRegisterer))
+
+	add := func(repositoryName string, size int, modTime time.Time) string {
+		path, _, release, err := cache.acquire(repositoryName)
+		if err != nil {
+			t.Fatalf("unexpected error acquiring repository: %s", err)
+		}
+		writeCachedRepository(t, path, size)
+		release(nil)
+
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("unexpected error setting modification time: %s", err)
+		}
+		return path
+	}
+
+	now := time.Now()
+	oldest := add("github.com/sourcegraph/a", 100, now.Add(-3*time.Hour))
+	inUse := add("github.com/sourcegraph/b", 100, now.Add(-2*time.Hour))
+	newer := add("github.com/sourcegraph/c", 100, now.Add(-1*time.Hour))
+
+	// Hold on to the second oldest repository while the cache exceeds its size.
+	_, _, releaseInUse, err := cache.acquire("github.com/sourcegraph/b")
+	if err != nil {
+		t.Fatalf("unexpected error acquiring repository: %s", err)
+	}
+	newest := add("github.com/sourcegraph/d", 200, now)
+
+	// The cache exceeds its size by 200 bytes, so the two least recently used repositories
+	// not in use are evicted.
+	for path, expected := range map[string]bool{oldest: false, inUse: true, newer: false, newest: true} {
+		_, err := os.Stat(path)
+		if exists := err == nil; exists != expected {
+			t.Errorf("unexpected existence of %s. want=%v have=%v", filepath.Base(path), expected, exists)
+		}
+	}
+
+	releaseInUse(nil)
+}
+
+func TestPruneCachedRefs(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	for i, commit := range []string{"a", "b", "c", "d"} {
+		path := filepath.Join(dir, commit)
+		if err := os.WriteFile(path, []byte(commit+"\n"), os.ModePerm); err != nil {
+			t.Fatalf("unexpected error writing ref: %s", err)
+		}
+		modTime := now.Add(time.Duration(i-4) * time.Hour)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("unexpected error setting modification time: %s", err)
+		}
+	}
+
+	// The oldest ref is used by the current job, so the next two oldest refs are removed.
+	if err := pruneCachedRefs(dir, "a", 2); err != nil {
+		t.Fatalf("unexpected error pruning refs: %s", err)
+	}
+	for commit, expected := range map[string]bool{"a": true, "b": false, "c": false, "d": true} {
+		_, err := os.Stat(filepath.Join(dir, commit))
+		if exists := err == nil; exists != expected {
+			t.Errorf("unexpected existence of ref %s. want=%v have=%v", commit, expected, exists)
+		}
+	}
+
+	if err := pruneCachedRefs(filepath.Join(dir, "missing"), "a", 2); err != nil {
+		t.Errorf("unexpected error pruning missing refs directory: %s", err)
+	}
+}
+
+func writeCachedRepository(t *testing.T, path string, size int) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Join(path, "objects"), os.ModePerm); err != nil {
+		t.Fatalf("unexpected error creating repository: %s", err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "objects", "pack"), make([]byte, size), os.ModePerm); err != nil {
+		t.Fatalf("unexpected error writing repository: %s", err)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
@@ -193,6 +195,97 @@ func TestPrepareWorkspace_SparseCheckout(t *testing.T) {
 	}
 }
 
+func TestPrepareWorkspace_Cache(t *testing.T) {
+	options := Options{
+		ClientOptions: apiclient.Options{
+			EndpointOptions: apiclient.EndpointOptions{
+				URL:   "https://test.io",
+				Token: "hunter2",
+			},
+		},
+		GitServicePath: "/internal/git",
+	}
+	runner := NewMockRunner()
+	cacheDir := t.TempDir()
+	handler := &handler{
+		options:        options,
+		operations:     command.NewOperations(&observation.TestContext),
+		workspaceCache: newWorkspaceCache(cacheDir, 1024*1024, metrics.NewWorkspaceCacheMetrics(metrics.TestRegisterer)),
+	}
+	cachePath := filepath.Join(cacheDir, workspaceCacheKey("torvalds/linux"))
+
+	// Simulate git init, fetch and the copy, which the mock runner does not perform.
+	runner.RunFunc.SetDefaultHook(func(_ context.Context, spec command.CommandSpec) error {
+		switch spec.Key {
+		case "setup.git.cache-init":
+			return os.MkdirAll(filepath.Join(cachePath, "refs", "executor"), os.ModePerm)
+		case "setup.git.cache-fetch":
+			return os.WriteFile(filepath.Join(cachePath, "refs", "executor", "deadbeef"), []byte("deadbeef\n"), os.ModePerm)
+		case "setup.git.cache-copy":
+			dst := filepath.Join(spec.Command[len(spec.Command)-1], "refs", "executor")
+			if err := os.MkdirAll(dst, os.ModePerm); err != nil {
+				return err
+			}
+			return os.WriteFile(filepath.Join(dst, "deadbeef"), []byte("deadbeef\n"), os.ModePerm)
+		}
+		return nil
+	})
+
+	prepare := func() [][]string {
+		start := len(runner.RunFunc.History())
+		dir, err := handler.prepareWorkspace(context.Background(), runner, "torvalds/linux", "", "deadbeef", false, false, []string{})
+		if err != nil {
+			t.Fatalf("unexpected error preparing workspace: %s", err)
+		}
+		defer os.RemoveAll(dir)
+
+		if _, err := os.Stat(filepath.Join(dir, ".git", "refs", "executor")); !os.IsNotExist(err) {
+			t.Errorf("expected cached refs to be removed from the workspace, got %v", err)
+		}
+
+		var commands [][]string
+		for _, call := range runner.RunFunc.History()[start:] {
+			commands = append(commands, replaceDir(call.Arg1.Command, dir))
+		}
+		return commands
+	}
+
+	expectedCommands := [][]string{
+		{"git", "init", "--bare", cachePath},
+		{"git", "-C", cachePath, "config", "--local", "gc.auto", "0"},
+		{"git", "-C", cachePath, "-c", "protocol.version=2", "-c", "http.extraHeader=Authorization: token-executor hunter2", "-c", "http.extraHeader=X-Sourcegraph-Actor-UID: internal", "fetch", "--progress", "--no-recurse-submodules", "https://executor@test.io/internal/git/torvalds/linux", "+deadbeef:refs/executor/deadbeef"},
+		{"cp", "-R", "--reflink=auto", cachePath, "$DIR/.git"},
+		{"git", "-C", "$DIR", "config", "--local", "core.bare", "false"},
+		{"git", "-C", "$DIR", "remote", "add", "origin", "https://executor@test.io/internal/git/torvalds/linux"},
+		{"git", "-C", "$DIR", "checkout", "--progress", "--force", "deadbeef"},
+		{"git", "-C", "$DIR", "remote", "set-url", "origin", "torvalds/linux"},
+	}
+	if diff := cmp.Diff(expectedCommands, prepare()); diff != "" {
+		t.Errorf("unexpected commands (-want +got):\n%s", diff)
+	}
+
+	// The second job for the same commit copies the cached repository without fetching.
+	expectedCommands = [][]string{
+		{"cp", "-R", "--reflink=auto", cachePath, "$DIR/.git"},
+		{"git", "-C", "$DIR", "config", "--local", "core.bare", "false"},
+		{"git", "-C", "$DIR", "remote", "add", "origin", "https://executor@test.io/internal/git/torvalds/linux"},
+		{"git", "-C", "$DIR", "checkout", "--progress", "--force", "deadbeef"},
+		{"git", "-C", "$DIR", "remote", "set-url", "origin", "torvalds/linux"},
+	}
+	if diff := cmp.Diff(expectedCommands, prepare()); diff != "" {
+		t.Errorf("unexpected commands (-want +got):\n%s", diff)
+	}
+}
+
+// replaceDir replaces the workspace directory in the given command with $DIR.
+func replaceDir(command []string, dir string) []string {
+	replaced := make([]string, 0, len(command))
+	for _, arg := range command {
+		replaced = append(replaced, strings.ReplaceAll(arg, dir, "$DIR"))
+	}
+	return replaced
+}
+
 func TestPrepareWorkspace_NoRepository(t *testing.T) {
 	options := Options{}
 	runner := NewMockRunner()
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/ignite"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/janitor"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker"
@@ -77,7 +78,16 @@ func main() {
 
 	nameSet := janitor.NewNameSet()
 	ctx, cancel := context.WithCancel(context.Background())
-	worker := worker.NewWorker(nameSet, config.APIWorkerOptions(telemetryOptions), observationContext)
+	options := config.APIWorkerOptions(telemetryOptions)
+	if config.UseKubernetes {
+		clientset, err := command.NewKubernetesClientset()
+		if err != nil {
+			logger.Error("failed to create Kubernetes client", log.Error(err))
+			os.Exit(1)
+		}
+		options.KubernetesOptions.Clientset = clientset
+	}
+	worker := worker.NewWorker(nameSet, options, observationContext)
 
 	routines := []goroutine.BackgroundRoutine{
 		worker,
@@ -92,6 +102,13 @@ func main() {
 		))
 
 		mustRegisterVMCountMetric(observationContext, config.VMPrefix)
+	} else if config.UsePodman {
+		routines = append(routines, janitor.NewOrphanedContainerJanitor(
+			config.VMPrefix,
+			nameSet,
+			config.CleanupTaskInterval,
+			janitor.NewMetrics(observationContext),
+		))
 	}
 
 	go func() {
@@ -0,0 +1,62 @@
+package bitbucketcloudoauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/oauth"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestParseConfig(t *testing.T) {
+	db := database.NewMockDB()
+	authProvider := &schema.BitbucketCloudAuthProvider{
+		Type:         "bitbucketcloud",
+		ClientKey:    "my-client-key",
+		ClientSecret: "my-client-secret",
+		DisplayName:  "Bitbucket Cloud",
+	}
+
+	t.Run("no configs", func(t *testing.T) {
+		ps, problems := parseConfig(&conf.Unified{}, db)
+		assert.Empty(t, ps)
+		assert.Empty(t, problems)
+	})
+
+	t.Run("no external URL", func(t *testing.T) {
+		ps, problems := parseConfig(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			AuthProviders: []schema.AuthProviders{{Bitbucketcloud: authProvider}},
+		}}, db)
+		assert.Empty(t, ps)
+		assert.Len(t, problems, 1)
+	})
+
+	t.Run("Bitbucket Cloud config", func(t *testing.T) {
+		ps, problems := parseConfig(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			ExternalURL: "https://sourcegraph.example.com",
+			AuthProviders: []schema.AuthProviders{
+				{Bitbucketcloud: authProvider},
+				{Builtin: &schema.BuiltinAuthProvider{Type: "builtin"}},
+			},
+		}}, db)
+		assert.Empty(t, problems)
+		require.Len(t, ps, 1)
+		assert.Equal(t, authProvider, ps[0].BitbucketCloudAuthProvider)
+
+		p := ps[0].Provider.(*oauth.Provider)
+		assert.Equal(t, extsvc.TypeBitbucketCloud, p.ServiceType)
+		assert.Equal(t, "https://bitbucket.org/", p.ServiceID)
+		assert.Equal(t, "Bitbucket Cloud", p.CachedInfo().DisplayName)
+
+		cfg := p.OAuth2Config()
+		assert.Equal(t, "https://sourcegraph.example.com/.auth/bitbucketcloud/callback", cfg.RedirectURL)
+		assert.Equal(t, "my-client-key", cfg.ClientID)
+		assert.Equal(t, "https://bitbucket.org/site/oauth2/authorize", cfg.Endpoint.AuthURL)
+		assert.Equal(t, "https://bitbucket.org/site/oauth2/access_token", cfg.Endpoint.TokenURL)
+	})
+}
@@ -0,0 +1,139 @@
+package bitbucketcloudoauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/oauth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	esauth "github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type sessionIssuerHelper struct {
+	*extsvc.CodeHost
+	db          database.DB
+	client      bitbucketcloud.Client
+	clientKey   string
+	allowSignup *bool
+}
+
+func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2.Token, anonymousUserID, firstSourceURL, lastSourceURL string) (actr *actor.Actor, safeErrMsg string, err error) {
+	bbUser, err := UserFromContext(ctx)
+	if err != nil {
+		return nil, "Could not read Bitbucket Cloud user from callback request.", errors.Wrap(err, "could not read user from context")
+	}
+
+	login, err := auth.NormalizeUsername(bbUser.Username)
+	if err != nil {
+		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
+	}
+
+	// 🚨 SECURITY: Ensure that the user email is verified
+	emails, err := s.client.WithAuthenticator(&esauth.OAuthBearerToken{Token: token.AccessToken}).CurrentUserEmails(ctx)
+	if err != nil {
+		return nil, "Could not get email addresses of Bitbucket Cloud user.", err
+	}
+	verifiedEmails := getVerifiedEmails(emails)
+	if len(verifiedEmails) == 0 {
+		return nil, "Could not get verified email for Bitbucket Cloud user. Check that your Bitbucket Cloud account has a confirmed email that matches one of your Sourcegraph verified emails.", errors.New("no verified email")
+	}
+
+	var data extsvc.AccountData
+	if err := bitbucketcloud.SetExternalAccountData(&data, bbUser, token); err != nil {
+		return nil, "", err
+	}
+
+	// We will first attempt to connect one of the verified emails with an existing
+	// account in Sourcegraph. If that fails and signup is allowed, we create an
+	// account with the primary email address.
+	type attemptConfig struct {
+		email            string
+		createIfNotExist bool
+	}
+	var attempts []attemptConfig
+	for _, email := range verifiedEmails {
+		attempts = append(attempts, attemptConfig{email: email})
+	}
+	// AllowSignup defaults to true when not set.
+	if s.allowSignup == nil || *s.allowSignup {
+		attempts = append(attempts, attemptConfig{email: verifiedEmails[0], createIfNotExist: true})
+	}
+
+	var (
+		firstSafeErrMsg string
+		firstErr        error
+	)
+	for _, attempt := range attempts {
+		userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, s.db, auth.GetAndSaveUserOp{
+			UserProps: database.NewUser{
+				Username:        login,
+				Email:           attempt.email,
+				EmailIsVerified: true,
+				DisplayName:     bbUser.DisplayName,
+				AvatarURL:       bbUser.Links["avatar"].Href,
+			},
+			ExternalAccount: extsvc.AccountSpec{
+				ServiceType: s.ServiceType,
+				ServiceID:   s.ServiceID,
+				ClientID:    s.clientKey,
+				AccountID:   bbUser.UUID,
+			},
+			ExternalAccountData: data,
+			CreateIfNotExist:    attempt.createIfNotExist,
+		})
+		if err == nil {
+			return actor.FromUser(userID), "", nil
+		}
+		if firstErr == nil {
+			firstSafeErrMsg, firstErr = safeErrMsg, err
+		}
+	}
+	return nil, firstSafeErrMsg, firstErr
+}
+
+// getVerifiedEmails returns the confirmed email addresses, with the primary
+// email address first.
+func getVerifiedEmails(emails []*bitbucketcloud.UserEmail) (verifiedEmails []string) {
+	for _, email := range emails {
+		if !email.IsConfirmed {
+			continue
+		}
+		if email.IsPrimary {
+			verifiedEmails = append([]string{email.Email}, verifiedEmails...)
+		} else {
+			verifiedEmails = append(verifiedEmails, email.Email)
+		}
+	}
+	return verifiedEmails
+}
+
+func (s *sessionIssuerHelper) CreateCodeHostConnection(context.Context, *oauth2.Token, string) (*types.ExternalService, string, error) {
+	return nil, "Creating code host connections is not supported for Bitbucket Cloud.", errors.New("creating code host connections is not supported for Bitbucket Cloud")
+}
+
+func (s *sessionIssuerHelper) DeleteStateCookie(w http.ResponseWriter) {
+	stateConfig := getStateConfig()
+	stateConfig.MaxAge = -1
+	http.SetCookie(w, oauth.NewCookie(stateConfig, ""))
+}
+
+func (s *sessionIssuerHelper) SessionData(token *oauth2.Token) oauth.SessionData {
+	return oauth.SessionData{
+		ID: providers.ConfigID{
+			ID:   s.ServiceID,
+			Type: s.ServiceType,
+		},
+		AccessToken: token.AccessToken,
+		TokenType:   token.Type(),
+	}
+}
@@ -0,0 +1,128 @@
+package bitbucketcloudoauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSessionIssuerHelper_GetOrCreateUser(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2.0/user/emails" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"values": [
+			{"email": "alice@work.example.com", "is_primary": false, "is_confirmed": true},
+			{"email": "alice@unconfirmed.example.com", "is_primary": false, "is_confirmed": false},
+			{"email": "alice@example.com", "is_primary": true, "is_confirmed": true}
+		]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := bitbucketcloud.NewClient("urn", &schema.BitbucketCloudConnection{ApiURL: srv.URL}, http.DefaultClient)
+	require.NoError(t, err)
+
+	bbURL, _ := url.Parse("https://bitbucket.org")
+	bbUser := &bitbucketcloud.User{Account: bitbucketcloud.Account{
+		Username:    "alice",
+		DisplayName: "Alice",
+		UUID:        "{alice}",
+		Links:       bitbucketcloud.Links{"avatar": {Href: "https://bitbucket.org/alice.png"}},
+	}}
+	allowSignup := false
+
+	for _, tc := range []struct {
+		name         string
+		allowSignup  *bool
+		existing     map[string]int32
+		wantActor    *actor.Actor
+		wantAttempts []string
+	}{
+		{
+			name:         "existing user with secondary email",
+			existing:     map[string]int32{"alice@work.example.com": 1},
+			wantActor:    actor.FromUser(1),
+			wantAttempts: []string{"alice@example.com", "alice@work.example.com"},
+		},
+		{
+			name:         "new user",
+			wantActor:    actor.FromUser(2),
+			wantAttempts: []string{"alice@example.com", "alice@work.example.com", "alice@example.com"},
+		},
+		{
+			name:         "signup not allowed",
+			allowSignup:  &allowSignup,
+			wantAttempts: []string{"alice@example.com", "alice@work.example.com"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts []string
+			auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (int32, string, error) {
+				attempts = append(attempts, op.UserProps.Email)
+				assert.Equal(t, "alice", op.UserProps.Username)
+				assert.True(t, op.UserProps.EmailIsVerified)
+				assert.Equal(t, "https://bitbucket.org/alice.png", op.UserProps.AvatarURL)
+				assert.Equal(t, extsvc.AccountSpec{
+					ServiceType: extsvc.TypeBitbucketCloud,
+					ServiceID:   "https://bitbucket.org/",
+					ClientID:    "key",
+					AccountID:   "{alice}",
+				}, op.ExternalAccount)
+
+				if id, ok := tc.existing[op.UserProps.Email]; ok {
+					return id, "", nil
+				}
+				if op.CreateIfNotExist {
+					return 2, "", nil
+				}
+				return 0, "user not found", errors.New("user not found")
+			}
+			t.Cleanup(func() { auth.MockGetAndSaveUser = nil })
+
+			s := &sessionIssuerHelper{
+				CodeHost:    extsvc.NewCodeHost(bbURL, extsvc.TypeBitbucketCloud),
+				db:          database.NewMockDB(),
+				client:      client,
+				clientKey:   "key",
+				allowSignup: tc.allowSignup,
+			}
+			ctx := WithUser(context.Background(), bbUser)
+			actr, _, err := s.GetOrCreateUser(ctx, &oauth2.Token{AccessToken: "token"}, "", "", "")
+			if tc.wantActor == nil {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantActor, actr)
+			assert.Equal(t, tc.wantAttempts, attempts)
+		})
+	}
+
+	t.Run("no user in context", func(t *testing.T) {
+		s := &sessionIssuerHelper{CodeHost: extsvc.NewCodeHost(bbURL, extsvc.TypeBitbucketCloud), client: client}
+		_, _, err := s.GetOrCreateUser(context.Background(), &oauth2.Token{AccessToken: "token"}, "", "", "")
+		assert.Error(t, err)
+	})
+
+	t.Run("emails request fails", func(t *testing.T) {
+		s := &sessionIssuerHelper{CodeHost: extsvc.NewCodeHost(bbURL, extsvc.TypeBitbucketCloud), client: client}
+		ctx := WithUser(context.Background(), bbUser)
+		_, _, err := s.GetOrCreateUser(ctx, &oauth2.Token{AccessToken: "invalid"}, "", "", "")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,144 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// defaultTimeout bounds connecting to the server and every request after it, unless the
+// context has an earlier deadline.
+const defaultTimeout = 30 * time.Second
+
+// isInvalidCredentials reports whether err is an LDAP result that rejected the credentials
+// of a bind request.
+func isInvalidCredentials(err error) bool {
+	var e *ldap.Error
+	return errors.As(err, &e) && e.ResultCode == ldap.LDAPResultInvalidCredentials
+}
+
+// entry is an entry returned by a search request. Attribute names are lowercased, because
+// they are case-insensitive.
+type entry struct {
+	DN string
+	// ID is the value of the first of the requested ID attributes that the entry has, or ""
+	// if it has none of them. See accountID.
+	ID         string
+	Attributes map[string][]string
+}
+
+// newEntry converts an entry returned by the LDAP client. The ID attributes are only used
+// to set the ID of the entry, because they may hold binary values.
+func newEntry(e *ldap.Entry, idAttributes []string) *entry {
+	out := &entry{DN: e.DN, Attributes: make(map[string][]string)}
+	for _, name := range idAttributes {
+		if v := e.GetEqualFoldRawAttributeValue(name); len(v) > 0 {
+			out.ID = accountID(name, v)
+			break
+		}
+	}
+	for _, attr := range e.Attributes {
+		if containsFold(idAttributes, attr.Name) {
+			continue
+		}
+		name := strings.ToLower(attr.Name)
+		out.Attributes[name] = append(out.Attributes[name], attr.Values...)
+	}
+	return out
+}
+
+// get returns the first value of the named attribute, or "" if the entry has no such
+// attribute.
+func (e *entry) get(name string) string {
+	if vs := e.Attributes[strings.ToLower(name)]; len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
+
+// accountID formats the value of an ID attribute. The objectGUID attribute of Active
+// Directory is formatted like Active Directory displays it, other binary values are
+// hex-encoded.
+func accountID(attribute string, value []byte) string {
+	if strings.EqualFold(attribute, "objectGUID") && len(value) == 16 {
+		// The first three fields of a GUID are little-endian.
+		return fmt.Sprintf("%02x%02x%02x%02x-%02x%02x-%02x%02x-%x-%x",
+			value[3], value[2], value[1], value[0], value[5], value[4], value[7], value[6], value[8:10], value[10:])
+	}
+	if !utf8.Valid(value) {
+		return hex.EncodeToString(value)
+	}
+	return string(value)
+}
+
+// dial connects to the LDAP server of the given auth provider. The connection uses TLS if
+// the URL has the ldaps scheme, or if StartTLS is enabled.
+func dial(ctx context.Context, pc *schema.LDAPAuthProvider) (*ldap.Conn, error) {
+	u, err := url.Parse(pc.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse LDAP URL")
+	}
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return nil, errors.Errorf("unsupported LDAP URL scheme %q", u.Scheme)
+	}
+
+	tlsConfig := &tls.Config{ServerName: u.Hostname()}
+	if pc.Certificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(pc.Certificate)) {
+			return nil, errors.New("invalid LDAP server certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+	dialer := &net.Dialer{Deadline: deadline}
+	c, err := ldap.DialURL(pc.Url, ldap.DialWithDialer(dialer), ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, errors.Wrap(err, "connect to LDAP server")
+	}
+	c.SetTimeout(time.Until(deadline))
+
+	if u.Scheme == "ldap" && pc.StartTLS {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			c.Close()
+			return nil, errors.Wrap(err, "StartTLS")
+		}
+	}
+	return c, nil
+}
+
+// search performs a search request and returns the entries that it found. Reaching the size
+// limit is not an error.
+func search(c *ldap.Conn, baseDN string, scope int, filter string, attributes []string, sizeLimit int) ([]*ldap.Entry, error) {
+	req := ldap.NewSearchRequest(baseDN, scope, ldap.NeverDerefAliases, sizeLimit, int(defaultTimeout/time.Second), false, filter, attributes, nil)
+	res, err := c.Search(req)
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, err
+	}
+	return res.Entries, nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+)
+
+var mockGetProviderValue *provider
+
+// getProvider looks up the registered LDAP auth provider with the given ID.
+func getProvider(id string) *provider {
+	if mockGetProviderValue != nil {
+		return mockGetProviderValue
+	}
+	p, _ := providers.GetProviderByConfigID(providers.ConfigID{Type: providerType, ID: id}).(*provider)
+	return p
+}
+
+func init() {
+	conf.ContributeValidator(validateConfig)
+}
+
+func validateConfig(c conftypes.SiteConfigQuerier) (problems conf.Problems) {
+	seen := map[string]int{}
+	for i, p := range c.SiteConfig().AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+
+		id := providerConfigID(p.Ldap)
+		if j, ok := seen[id]; ok {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d is duplicate of index %d, ignoring", i, j)))
+			continue
+		}
+		seen[id] = i
+
+		if p.Ldap.StartTLS && strings.HasPrefix(p.Ldap.Url, "ldaps://") {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: `startTLS` can't be used with an ldaps:// URL, which already uses TLS", i)))
+		}
+		if p.Ldap.BindPassword != "" && p.Ldap.BindDN == "" {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: `bindPassword` is set but `bindDN` is not", i)))
+		}
+
+		pr := &provider{config: *p.Ldap}
+		if !strings.Contains(pr.userSearchFilter(), "{username}") {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: `userSearchFilter` must contain {username}", i)))
+		}
+		if _, err := ldap.CompileFilter(strings.ReplaceAll(pr.userSearchFilter(), "{username}", "alice")); err != nil {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: invalid `userSearchFilter`: %s", i, err)))
+		}
+		if p.Ldap.GroupFilter != "" {
+			if _, err := ldap.CompileFilter(p.Ldap.GroupFilter); err != nil {
+				problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: invalid `groupFilter`: %s", i, err)))
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,76 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestValidateConfig(t *testing.T) {
+	valid := schema.LDAPAuthProvider{
+		Type:             providerType,
+		Url:              "ldap://ldap.example.com",
+		StartTLS:         true,
+		BindDN:           "cn=sourcegraph,dc=example,dc=com",
+		BindPassword:     "secret",
+		UserSearchBaseDN: "dc=example,dc=com",
+	}
+
+	for name, tc := range map[string]struct {
+		configs      []schema.LDAPAuthProvider
+		wantProblems int
+	}{
+		"valid": {
+			configs: []schema.LDAPAuthProvider{valid},
+		},
+		"duplicate": {
+			configs:      []schema.LDAPAuthProvider{valid, valid},
+			wantProblems: 1,
+		},
+		"StartTLS with ldaps": {
+			configs: []schema.LDAPAuthProvider{func() schema.LDAPAuthProvider {
+				c := valid
+				c.Url = "ldaps://ldap.example.com"
+				return c
+			}()},
+			wantProblems: 1,
+		},
+		"bindPassword without bindDN": {
+			configs: []schema.LDAPAuthProvider{func() schema.LDAPAuthProvider {
+				c := valid
+				c.BindDN = ""
+				return c
+			}()},
+			wantProblems: 1,
+		},
+		"userSearchFilter without username": {
+			configs: []schema.LDAPAuthProvider{func() schema.LDAPAuthProvider {
+				c := valid
+				c.UserSearchFilter = "(uid=alice)"
+				return c
+			}()},
+			wantProblems: 1,
+		},
+		"invalid filters": {
+			configs: []schema.LDAPAuthProvider{func() schema.LDAPAuthProvider {
+				c := valid
+				c.UserSearchFilter = "(uid={username}"
+				c.GroupFilter = "memberOf=cn=users"
+				return c
+			}()},
+			wantProblems: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var providers []schema.AuthProviders
+			for i := range tc.configs {
+				providers = append(providers, schema.AuthProviders{Ldap: &tc.configs[i]})
+			}
+			problems := validateConfig(conf.Unified{SiteConfiguration: schema.SiteConfiguration{AuthProviders: providers}})
+			assert.Len(t, problems, tc.wantProblems, problems.Messages())
+		})
+	}
+}
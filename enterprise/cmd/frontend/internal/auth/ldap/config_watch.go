@@ -0,0 +1,30 @@
+package ldap
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func getProviders() []providers.Provider {
+	var cfgs []*schema.LDAPAuthProvider
+	for _, p := range conf.Get().AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+		cfgs = append(cfgs, p.Ldap)
+	}
+	ps := make([]providers.Provider, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		ps = append(ps, &provider{config: *cfg})
+	}
+	return ps
+}
+
+func init() {
+	go func() {
+		conf.Watch(func() {
+			providers.Update(providerType, getProviders())
+		})
+	}()
+}
@@ -0,0 +1,99 @@
+// Package ldap implements auth via LDAP, including Active Directory.
+package ldap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// All LDAP endpoints are under this path prefix.
+const authPrefix = auth.AuthURLPrefix + "/ldap"
+
+// Middleware is middleware for LDAP authentication, adding the endpoint under the auth path
+// prefix ("/.auth") that signs in users with their LDAP username and password.
+//
+// Unlike the SSO auth providers, there is no redirect to an external login page: the sign-in
+// page posts the credentials to the login endpoint, which checks them against the LDAP
+// server. Upon successful sign-in, the handler creates a new session and session cookie.
+//
+// 🚨 SECURITY
+func Middleware(db database.DB) *auth.Middleware {
+	return &auth.Middleware{
+		API: func(next http.Handler) http.Handler {
+			return next
+		},
+		App: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == authPrefix+"/login" {
+					loginHandler(db)(w, r)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		},
+	}
+}
+
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func loginHandler(db database.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("Unsupported method %s", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		p := getProvider(r.URL.Query().Get("pc"))
+		if p == nil {
+			log15.Error("No LDAP auth provider found with ID.", "id", r.URL.Query().Get("pc"))
+			http.Error(w, "Misconfigured LDAP auth provider.", http.StatusInternalServerError)
+			return
+		}
+
+		var creds credentials
+		if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+			http.Error(w, "Could not decode request body", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		e, safeErrMsg, err := p.authenticate(ctx, creds.Username, creds.Password)
+		if err != nil {
+			log15.Warn("LDAP auth failed.", "username", creds.Username, "error", err)
+			http.Error(w, safeErrMsg, http.StatusUnauthorized)
+			return
+		}
+
+		actr, safeErrMsg, err := getOrCreateUser(ctx, db, p, e)
+		if err != nil {
+			log15.Error("LDAP auth failed: error looking up or creating user.", "error", err, "userErr", safeErrMsg)
+			http.Error(w, safeErrMsg, http.StatusInternalServerError)
+			return
+		}
+
+		user, err := db.Users().GetByID(ctx, actr.UID)
+		if err != nil {
+			log15.Error("LDAP auth failed: error retrieving user from database.", "error", err)
+			http.Error(w, "Failed to retrieve user.", http.StatusInternalServerError)
+			return
+		}
+
+		if err := session.SetActor(w, r, actr, 0, user.CreatedAt); err != nil {
+			log15.Error("LDAP auth failed: could not initiate session.", "error", err)
+			http.Error(w, "Authentication failed. Try signing in again (and clearing cookies for the current site). The error was: could not initiate session.", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
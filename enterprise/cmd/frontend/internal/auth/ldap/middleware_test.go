@@ -0,0 +1,77 @@
+package ldap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestMiddleware(t *testing.T) {
+	cleanup := session.ResetMockSessionStore(t)
+	defer cleanup()
+
+	s := &testServer{entries: newTestDirectory()}
+	s.start(t)
+	mockGetProviderValue = newTestProvider(s)
+	defer func() { mockGetProviderValue = nil }()
+
+	auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (userID int32, safeErrMsg string, err error) {
+		if op.ExternalAccount.ServiceType == "ldap" && op.ExternalAccount.ServiceID == s.url() && op.ExternalAccount.AccountID == testAliceUUID {
+			return 123, "", nil
+		}
+		return 0, "safeErr", errors.Errorf("account %v not found in mock", op.ExternalAccount)
+	}
+	defer func() { auth.MockGetAndSaveUser = nil }()
+
+	users := database.NewStrictMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, CreatedAt: time.Now()}, nil
+	})
+	db := database.NewStrictMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	handler := Middleware(db).App(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	doRequest := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("other paths", func(t *testing.T) {
+		rec := doRequest("GET", "/", "")
+		assert.Equal(t, http.StatusTeapot, rec.Code)
+	})
+
+	t.Run("GET", func(t *testing.T) {
+		rec := doRequest("GET", authPrefix+"/login", "")
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		rec := doRequest("POST", authPrefix+"/login", `{"username":"alice","password":"wrong"}`)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Body.String(), authenticationFailed)
+		assert.Empty(t, rec.Result().Cookies())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		rec := doRequest("POST", authPrefix+"/login", `{"username":"alice","password":"alice-secret"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEmpty(t, rec.Result().Cookies(), "session cookie")
+	})
+}
@@ -0,0 +1,100 @@
+package ldap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"path"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const providerType = "ldap"
+
+type provider struct {
+	config schema.LDAPAuthProvider
+}
+
+// ConfigID implements providers.Provider.
+func (p *provider) ConfigID() providers.ConfigID {
+	return providers.ConfigID{
+		Type: providerType,
+		ID:   providerConfigID(&p.config),
+	}
+}
+
+// Config implements providers.Provider.
+func (p *provider) Config() schema.AuthProviders {
+	return schema.AuthProviders{Ldap: &p.config}
+}
+
+// Refresh implements providers.Provider.
+func (p *provider) Refresh(context.Context) error { return nil }
+
+// CachedInfo implements providers.Provider.
+func (p *provider) CachedInfo() *providers.Info {
+	info := providers.Info{
+		ServiceID:   p.config.Url,
+		DisplayName: p.config.DisplayName,
+		AuthenticationURL: (&url.URL{
+			Path:     path.Join(authPrefix, "login"),
+			RawQuery: (url.Values{"pc": []string{providerConfigID(&p.config)}}).Encode(),
+		}).String(),
+	}
+	if info.DisplayName == "" {
+		info.DisplayName = "LDAP"
+	}
+	return &info
+}
+
+// attributes returns the names of the attributes that are mapped to the Sourcegraph user,
+// falling back to the defaults for the attributes that are not configured.
+func (p *provider) attributes() schema.LDAPAttributes {
+	attrs := schema.LDAPAttributes{Username: "uid", Email: "mail", DisplayName: "cn"}
+	if a := p.config.Attributes; a != nil {
+		if a.Username != "" {
+			attrs.Username = a.Username
+		}
+		if a.Email != "" {
+			attrs.Email = a.Email
+		}
+		if a.DisplayName != "" {
+			attrs.DisplayName = a.DisplayName
+		}
+	}
+	return attrs
+}
+
+// idAttributes returns the attributes that identify the LDAP account of a user, in order of
+// preference. Unlike the DN, they don't change when an entry is renamed or moved.
+func (p *provider) idAttributes() []string {
+	if a := p.config.Attributes; a != nil && a.Id != "" {
+		return []string{a.Id}
+	}
+	return []string{"objectGUID", "entryUUID"}
+}
+
+func (p *provider) userSearchFilter() string {
+	if p.config.UserSearchFilter != "" {
+		return p.config.UserSearchFilter
+	}
+	return "(uid={username})"
+}
+
+// providerConfigID produces a semi-stable identifier for an LDAP auth provider config object.
+// It is used to distinguish between multiple auth providers of the same type when signing
+// in. Its value is never persisted, and it must be deterministic.
+func providerConfigID(pc *schema.LDAPAuthProvider) string {
+	if pc.ConfigID != "" {
+		return pc.ConfigID
+	}
+	data, err := json.Marshal(pc)
+	if err != nil {
+		panic(err)
+	}
+	b := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(b[:16])
+}
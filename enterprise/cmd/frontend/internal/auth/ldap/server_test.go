@@ -0,0 +1,319 @@
+package ldap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// startTLSOID is the name of the StartTLS extended operation (RFC 4511 section 4.14).
+const startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// testEntry is an entry in the directory of a testServer.
+type testEntry struct {
+	dn         string
+	password   string
+	attributes map[string][]string
+}
+
+// testServer is an in-process LDAP server that supports the operations that are used to
+// authenticate users. Its filter evaluation is simplistic: all comparisons are
+// case-insensitive, and extensible matches ignore the matching rule.
+type testServer struct {
+	entries []testEntry
+	// tlsConfig is used for StartTLS, and for all connections if ldaps is true.
+	tlsConfig *tls.Config
+	ldaps     bool
+	// allowAnonymousSearch allows searches without binding first.
+	allowAnonymousSearch bool
+
+	listener net.Listener
+	// certificate is the PEM-encoded certificate of the server.
+	certificate string
+}
+
+// start starts the server, which is stopped at the end of the test.
+func (s *testServer) start(t *testing.T) {
+	t.Helper()
+	s.certificate, s.tlsConfig = newTestCertificate(t)
+
+	var err error
+	s.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ldaps {
+		s.listener = tls.NewListener(s.listener, s.tlsConfig)
+	}
+	t.Cleanup(func() { s.listener.Close() })
+
+	go func() {
+		for {
+			c, err := s.listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(c)
+		}
+	}()
+}
+
+// url returns the URL of the server.
+func (s *testServer) url() string {
+	scheme := "ldap"
+	if s.ldaps {
+		scheme = "ldaps"
+	}
+	return scheme + "://" + s.listener.Addr().String()
+}
+
+func (s *testServer) serve(c net.Conn) {
+	defer func() { c.Close() }()
+	var boundDN string
+	for {
+		msg, err := ber.ReadPacket(c)
+		if err != nil {
+			return
+		}
+		id := msg.Children[0].Value.(int64)
+		op := msg.Children[1]
+
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := op.Children[1].Value.(string)
+			password := op.Children[2].Data.String()
+			boundDN = ""
+			code := int64(ldap.LDAPResultInvalidCredentials)
+			if e := s.entry(dn); e != nil && password != "" && e.password == password {
+				boundDN, code = dn, ldap.LDAPResultSuccess
+			}
+			s.reply(c, id, newTestResult(ldap.ApplicationBindResponse, code))
+
+		case ldap.ApplicationSearchRequest:
+			if boundDN == "" && !s.allowAnonymousSearch {
+				s.reply(c, id, newTestResult(ldap.ApplicationSearchResultDone, 50)) // insufficientAccessRights
+				continue
+			}
+			s.search(c, id, op)
+
+		case ldap.ApplicationExtendedRequest:
+			if op.Children[0].Data.String() != startTLSOID {
+				s.reply(c, id, newTestResult(ldap.ApplicationExtendedResponse, 2)) // protocolError
+				continue
+			}
+			s.reply(c, id, newTestResult(ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess))
+			tc := tls.Server(c, s.tlsConfig)
+			if err := tc.Handshake(); err != nil {
+				return
+			}
+			c = tc
+
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func (s *testServer) search(c net.Conn, id int64, op *ber.Packet) {
+	base := strings.ToLower(op.Children[0].Value.(string))
+	scope := op.Children[1].Value.(int64)
+	sizeLimit := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+	var attrs []string
+	for _, a := range op.Children[7].Children {
+		attrs = append(attrs, strings.ToLower(a.Value.(string)))
+	}
+
+	var n int64
+	for _, e := range s.entries {
+		dn := strings.ToLower(e.dn)
+		inScope := dn == base
+		if scope == ldap.ScopeWholeSubtree {
+			inScope = inScope || strings.HasSuffix(dn, ","+base)
+		}
+		if !inScope || !matchTestFilter(e, filter) {
+			continue
+		}
+		if sizeLimit > 0 && n == sizeLimit {
+			s.reply(c, id, newTestResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSizeLimitExceeded))
+			return
+		}
+		n++
+
+		resp := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+		resp.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "DN"))
+		list := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+		for name, values := range e.attributes {
+			if !requestedTestAttribute(attrs, name) {
+				continue
+			}
+			attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+			attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+			set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+			for _, v := range values {
+				set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+			}
+			attr.AppendChild(set)
+			list.AppendChild(attr)
+		}
+		resp.AppendChild(list)
+		s.reply(c, id, resp)
+	}
+	s.reply(c, id, newTestResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+}
+
+func (s *testServer) entry(dn string) *testEntry {
+	for i := range s.entries {
+		if strings.EqualFold(s.entries[i].dn, dn) {
+			return &s.entries[i]
+		}
+	}
+	return nil
+}
+
+func (s *testServer) reply(c net.Conn, id int64, op *ber.Packet) {
+	msg := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Message")
+	msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+	msg.AppendChild(op)
+	_, _ = c.Write(msg.Bytes())
+}
+
+func newTestResult(tag ber.Tag, code int64) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return p
+}
+
+func requestedTestAttribute(attrs []string, name string) bool {
+	if len(attrs) == 0 {
+		return true
+	}
+	for _, a := range attrs {
+		if a == strings.ToLower(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTestFilter evaluates the BER-encoded filter against the entry.
+func matchTestFilter(e testEntry, f *ber.Packet) bool {
+	values := func(attr string) []string {
+		for name, vs := range e.attributes {
+			if strings.EqualFold(name, attr) {
+				return vs
+			}
+		}
+		return nil
+	}
+	anyValue := func(attr string, match func(v string) bool) bool {
+		for _, v := range values(attr) {
+			if match(strings.ToLower(v)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch f.Tag {
+	case ldap.FilterAnd:
+		for _, c := range f.Children {
+			if !matchTestFilter(e, c) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, c := range f.Children {
+			if matchTestFilter(e, c) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !matchTestFilter(e, f.Children[0])
+	case ldap.FilterEqualityMatch, ldap.FilterApproxMatch:
+		want := strings.ToLower(f.Children[1].Value.(string))
+		return anyValue(f.Children[0].Value.(string), func(v string) bool { return v == want })
+	case ldap.FilterSubstrings:
+		return anyValue(f.Children[0].Value.(string), func(v string) bool {
+			for _, part := range f.Children[1].Children {
+				s := strings.ToLower(part.Data.String())
+				switch part.Tag {
+				case 0:
+					if !strings.HasPrefix(v, s) {
+						return false
+					}
+					v = v[len(s):]
+				case 1:
+					i := strings.Index(v, s)
+					if i < 0 {
+						return false
+					}
+					v = v[i+len(s):]
+				case 2:
+					if !strings.HasSuffix(v, s) {
+						return false
+					}
+				}
+			}
+			return true
+		})
+	case ldap.FilterPresent:
+		return len(values(f.Data.String())) > 0
+	case ldap.FilterExtensibleMatch:
+		var attr, want string
+		for _, c := range f.Children {
+			switch c.Tag {
+			case 2:
+				attr = c.Data.String()
+			case 3:
+				want = strings.ToLower(c.Data.String())
+			}
+		}
+		return anyValue(attr, func(v string) bool { return v == want })
+	}
+	return false
+}
+
+// newTestCertificate returns a self-signed certificate for 127.0.0.1, and a TLS config for
+// a server that uses it.
+func newTestCertificate(t *testing.T) (string, *tls.Config) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ldap test server"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}
+}
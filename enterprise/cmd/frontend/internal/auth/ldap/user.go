@@ -0,0 +1,144 @@
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// authenticationFailed is the error message shown to users whose username and password
+// were rejected. It doesn't say why, so that it doesn't reveal which usernames exist.
+const authenticationFailed = "Authentication failed. Check your username and password."
+
+// authenticate verifies the username and password of a user against the LDAP directory.
+// It returns the entry of the user if successful; otherwise it returns a friendly error
+// message (safeErrMsg) that is safe to display to users, and a non-nil err with lower-level
+// error details.
+//
+// 🚨 SECURITY: The user is only authenticated if the bind with the DN of their entry and the
+// given password succeeds, and if their entry matches the group filter.
+func (p *provider) authenticate(ctx context.Context, username, password string) (_ *entry, safeErrMsg string, err error) {
+	if username == "" || password == "" {
+		return nil, authenticationFailed, errors.New("empty username or password")
+	}
+
+	c, err := dial(ctx, &p.config)
+	if err != nil {
+		return nil, "Unable to connect to the LDAP server.", err
+	}
+	defer c.Close()
+
+	// Search for the user with the service account, or anonymously.
+	if p.config.BindDN != "" {
+		if err := c.Bind(p.config.BindDN, p.config.BindPassword); err != nil {
+			return nil, "Unable to search for the user on the LDAP server.", errors.Wrap(err, "bind with service account")
+		}
+	}
+	attrs := p.attributes()
+	// 🚨 SECURITY: The username must be escaped so that it can't change the filter.
+	filter := strings.ReplaceAll(p.userSearchFilter(), "{username}", ldap.EscapeFilter(username))
+	idAttrs := p.idAttributes()
+	entries, err := search(c, p.config.UserSearchBaseDN, ldap.ScopeWholeSubtree, filter, append([]string{attrs.Username, attrs.Email, attrs.DisplayName}, idAttrs...), 2)
+	if err != nil {
+		return nil, "Unable to search for the user on the LDAP server.", errors.Wrap(err, "search for user")
+	}
+	switch len(entries) {
+	case 0:
+		return nil, authenticationFailed, errors.Errorf("no entry matches %s", filter)
+	case 1:
+	default:
+		return nil, authenticationFailed, errors.Errorf("multiple entries match %s", filter)
+	}
+	e := newEntry(entries[0], idAttrs)
+
+	// Check the password of the user.
+	if err := c.Bind(e.DN, password); err != nil {
+		if isInvalidCredentials(err) {
+			return nil, authenticationFailed, errors.Wrapf(err, "bind as %s", e.DN)
+		}
+		return nil, "Unable to verify the password on the LDAP server.", errors.Wrapf(err, "bind as %s", e.DN)
+	}
+
+	// Check that the user is allowed to sign in. Go back to the service account for this,
+	// because users may not be allowed to read the attributes that the filter uses.
+	if p.config.GroupFilter != "" {
+		if p.config.BindDN != "" {
+			if err := c.Bind(p.config.BindDN, p.config.BindPassword); err != nil {
+				return nil, "Unable to search for the user on the LDAP server.", errors.Wrap(err, "bind with service account")
+			}
+		}
+		allowed, err := search(c, e.DN, ldap.ScopeBaseObject, p.config.GroupFilter, []string{"1.1"}, 1)
+		if err != nil {
+			return nil, "Unable to check the group membership of the user on the LDAP server.", errors.Wrap(err, "search with group filter")
+		}
+		if len(allowed) == 0 {
+			return nil, "You are not allowed to sign in. Ask your site admin to add you to the group of users that are allowed to use Sourcegraph.", errors.Errorf("%s does not match the group filter", e.DN)
+		}
+	}
+
+	return e, "", nil
+}
+
+// getOrCreateUser gets or creates a user account for the LDAP entry of an authenticated
+// user. It returns the authenticated actor if successful; otherwise it returns a friendly
+// error message (safeErrMsg) that is safe to display to users, and a non-nil err with
+// lower-level error details.
+func getOrCreateUser(ctx context.Context, db database.DB, p *provider, e *entry) (_ *actor.Actor, safeErrMsg string, err error) {
+	attrs := p.attributes()
+	email := e.get(attrs.Email)
+	if email == "" {
+		return nil, "Only users with an email address may authenticate to Sourcegraph.", errors.Errorf("no %s attribute in entry %s", attrs.Email, e.DN)
+	}
+
+	if e.ID == "" {
+		return nil, "Your LDAP entry has no unique identifier. Ask your site admin to check the LDAP auth provider configuration.", errors.Errorf("none of the attributes %v in entry %s", p.idAttributes(), e.DN)
+	}
+
+	login := e.get(attrs.Username)
+	if login == "" {
+		login = email
+	}
+	login, err = auth.NormalizeUsername(login)
+	if err != nil {
+		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
+	}
+
+	serialized, err := json.Marshal(e)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pi := p.CachedInfo()
+	userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, db, auth.GetAndSaveUserOp{
+		UserProps: database.NewUser{
+			Username: login,
+			Email:    email,
+			// The directory is managed by the site admins, so its email addresses are
+			// trusted like the ones of other SSO providers.
+			EmailIsVerified: true,
+			DisplayName:     e.get(attrs.DisplayName),
+		},
+		ExternalAccount: extsvc.AccountSpec{
+			ServiceType: providerType,
+			ServiceID:   pi.ServiceID,
+			AccountID:   e.ID,
+		},
+		ExternalAccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(serialized),
+		},
+		CreateIfNotExist: p.config.AllowSignup == nil || *p.config.AllowSignup,
+	})
+	if err != nil {
+		return nil, safeErrMsg, err
+	}
+	return actor.FromUser(userID), "", nil
+}
@@ -0,0 +1,258 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	testServiceDN       = "cn=sourcegraph,ou=services,dc=example,dc=com"
+	testServicePassword = "service-secret"
+	testAliceDN         = "uid=alice,ou=people,dc=example,dc=com"
+	testAliceUUID       = "4c9d1a3e-3a41-103d-8e1a-3d3c2a7f5b20"
+	testGroupDN         = "cn=sourcegraph-users,ou=groups,dc=example,dc=com"
+)
+
+func newTestDirectory() []testEntry {
+	return []testEntry{
+		{
+			dn:       testServiceDN,
+			password: testServicePassword,
+		},
+		{
+			dn:       testAliceDN,
+			password: "alice-secret",
+			attributes: map[string][]string{
+				"objectClass": {"inetOrgPerson"},
+				"entryUUID":   {testAliceUUID},
+				"uid":         {"alice"},
+				"mail":        {"alice@example.com"},
+				"cn":          {"Alice Liddell"},
+				"memberOf":    {testGroupDN},
+			},
+		},
+		{
+			dn:       "uid=bob,ou=people,dc=example,dc=com",
+			password: "bob-secret",
+			attributes: map[string][]string{
+				"objectClass": {"inetOrgPerson"},
+				"entryUUID":   {"5f0a1c9e-3a41-103d-8e1b-3d3c2a7f5b20"},
+				"uid":         {"bob"},
+				"mail":        {"bob@example.com"},
+				"cn":          {"Bob"},
+			},
+		},
+	}
+}
+
+func newTestProvider(s *testServer) *provider {
+	return &provider{config: schema.LDAPAuthProvider{
+		Type:             providerType,
+		Url:              s.url(),
+		Certificate:      s.certificate,
+		BindDN:           testServiceDN,
+		BindPassword:     testServicePassword,
+		UserSearchBaseDN: "ou=people,dc=example,dc=com",
+		UserSearchFilter: "(&(objectClass=inetOrgPerson)(uid={username}))",
+	}}
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	s := &testServer{entries: newTestDirectory()}
+	s.start(t)
+
+	t.Run("success", func(t *testing.T) {
+		e, _, err := newTestProvider(s).authenticate(ctx, "alice", "alice-secret")
+		require.NoError(t, err)
+		assert.Equal(t, testAliceDN, e.DN)
+		assert.Equal(t, testAliceUUID, e.ID)
+		assert.Equal(t, "alice@example.com", e.get("mail"))
+		assert.Equal(t, "Alice Liddell", e.get("CN"))
+	})
+
+	for name, tc := range map[string]struct {
+		username, password string
+	}{
+		"wrong password":   {"alice", "bob-secret"},
+		"empty password":   {"alice", ""},
+		"unknown user":     {"carol", "alice-secret"},
+		"filter injection": {"*", "alice-secret"},
+		"closing paren":    {"alice)(uid=*", "alice-secret"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, safeErrMsg, err := newTestProvider(s).authenticate(ctx, tc.username, tc.password)
+			require.Error(t, err)
+			assert.Equal(t, authenticationFailed, safeErrMsg)
+		})
+	}
+
+	t.Run("wrong service account password", func(t *testing.T) {
+		p := newTestProvider(s)
+		p.config.BindPassword = "wrong"
+		_, _, err := p.authenticate(ctx, "alice", "alice-secret")
+		require.Error(t, err)
+		assert.True(t, isInvalidCredentials(err))
+	})
+
+	t.Run("group filter", func(t *testing.T) {
+		p := newTestProvider(s)
+		p.config.GroupFilter = "(memberOf:1.2.840.113556.1.4.1941:=" + testGroupDN + ")"
+
+		_, _, err := p.authenticate(ctx, "alice", "alice-secret")
+		require.NoError(t, err)
+
+		_, safeErrMsg, err := p.authenticate(ctx, "bob", "bob-secret")
+		require.Error(t, err)
+		assert.Contains(t, safeErrMsg, "not allowed")
+
+		// Users that are not in the group must still use the right password to find out.
+		_, safeErrMsg, err = p.authenticate(ctx, "bob", "wrong")
+		require.Error(t, err)
+		assert.Equal(t, authenticationFailed, safeErrMsg)
+	})
+
+	t.Run("anonymous search", func(t *testing.T) {
+		p := newTestProvider(s)
+		p.config.BindDN, p.config.BindPassword = "", ""
+		_, _, err := p.authenticate(ctx, "alice", "alice-secret")
+		require.Error(t, err, "server doesn't allow anonymous search")
+
+		anonymous := &testServer{entries: newTestDirectory(), allowAnonymousSearch: true}
+		anonymous.start(t)
+		p = newTestProvider(anonymous)
+		p.config.BindDN, p.config.BindPassword = "", ""
+		e, _, err := p.authenticate(ctx, "alice", "alice-secret")
+		require.NoError(t, err)
+		assert.Equal(t, testAliceDN, e.DN)
+	})
+}
+
+func TestAuthenticate_TLS(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("StartTLS", func(t *testing.T) {
+		s := &testServer{entries: newTestDirectory()}
+		s.start(t)
+		p := newTestProvider(s)
+		p.config.StartTLS = true
+		_, _, err := p.authenticate(ctx, "alice", "alice-secret")
+		require.NoError(t, err)
+	})
+
+	t.Run("ldaps", func(t *testing.T) {
+		s := &testServer{entries: newTestDirectory(), ldaps: true}
+		s.start(t)
+		_, _, err := newTestProvider(s).authenticate(ctx, "alice", "alice-secret")
+		require.NoError(t, err)
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		s := &testServer{entries: newTestDirectory(), ldaps: true}
+		s.start(t)
+		p := newTestProvider(s)
+		p.config.Certificate = ""
+		_, _, err := p.authenticate(ctx, "alice", "alice-secret")
+		require.Error(t, err)
+	})
+}
+
+func TestGetOrCreateUser(t *testing.T) {
+	ctx := context.Background()
+	p := &provider{config: schema.LDAPAuthProvider{
+		Url:        "ldaps://ldap.example.com",
+		Attributes: &schema.LDAPAttributes{Username: "sAMAccountName", DisplayName: "displayName"},
+	}}
+	e := &entry{
+		DN: "CN=Alice Liddell,OU=People,DC=example,DC=com",
+		ID: "3f2504e0-4f89-11d3-9a0c-0305e82c3301",
+		Attributes: map[string][]string{
+			"samaccountname": {"alice.liddell"},
+			"mail":           {"alice@example.com"},
+			"displayname":    {"Alice"},
+		},
+	}
+
+	var got auth.GetAndSaveUserOp
+	auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (userID int32, safeErrMsg string, err error) {
+		got = op
+		return 42, "", nil
+	}
+	t.Cleanup(func() { auth.MockGetAndSaveUser = nil })
+
+	actr, _, err := getOrCreateUser(ctx, nil, p, e)
+	require.NoError(t, err)
+	assert.Equal(t, int32(42), actr.UID)
+	assert.Equal(t, "alice.liddell", got.UserProps.Username)
+	assert.Equal(t, "alice@example.com", got.UserProps.Email)
+	assert.True(t, got.UserProps.EmailIsVerified)
+	assert.Equal(t, "Alice", got.UserProps.DisplayName)
+	assert.Equal(t, "ldap", got.ExternalAccount.ServiceType)
+	assert.Equal(t, "ldaps://ldap.example.com", got.ExternalAccount.ServiceID)
+	assert.Equal(t, e.ID, got.ExternalAccount.AccountID)
+	assert.True(t, got.CreateIfNotExist)
+
+	t.Run("no email", func(t *testing.T) {
+		auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (int32, string, error) {
+			return 0, "", errors.New("must not be called")
+		}
+		_, safeErrMsg, err := getOrCreateUser(ctx, nil, p, &entry{DN: e.DN, ID: e.ID, Attributes: map[string][]string{}})
+		require.Error(t, err)
+		assert.Contains(t, safeErrMsg, "email address")
+
+		_, safeErrMsg, err = getOrCreateUser(ctx, nil, p, &entry{DN: e.DN, Attributes: e.Attributes})
+		require.Error(t, err)
+		assert.Contains(t, safeErrMsg, "unique identifier")
+	})
+}
+
+func TestNewEntry(t *testing.T) {
+	objectGUID := string([]byte{0xe0, 0x04, 0x25, 0x3f, 0x89, 0x4f, 0xd3, 0x11, 0x9a, 0x0c, 0x03, 0x05, 0xe8, 0x2c, 0x33, 0x01})
+	for name, tc := range map[string]struct {
+		attributes   map[string][]string
+		idAttributes []string
+		want         string
+	}{
+		"objectGUID": {
+			attributes:   map[string][]string{"objectGUID": {objectGUID}, "entryUUID": {testAliceUUID}},
+			idAttributes: []string{"objectGUID", "entryUUID"},
+			want:         "3f2504e0-4f89-11d3-9a0c-0305e82c3301",
+		},
+		"entryUUID": {
+			attributes:   map[string][]string{"entryUUID": {testAliceUUID}},
+			idAttributes: []string{"objectGUID", "entryUUID"},
+			want:         testAliceUUID,
+		},
+		"case-insensitive": {
+			attributes:   map[string][]string{"ENTRYUUID": {testAliceUUID}},
+			idAttributes: []string{"objectGUID", "entryUUID"},
+			want:         testAliceUUID,
+		},
+		"binary": {
+			attributes:   map[string][]string{"msDS-ConsistencyGuid": {"\xff\x00"}},
+			idAttributes: []string{"msDS-ConsistencyGuid"},
+			want:         "ff00",
+		},
+		"missing": {
+			attributes:   map[string][]string{"uid": {"alice"}},
+			idAttributes: []string{"objectGUID", "entryUUID"},
+			want:         "",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := newEntry(ldap.NewEntry(testAliceDN, tc.attributes), tc.idAttributes)
+			assert.Equal(t, tc.want, e.ID)
+			for _, a := range tc.idAttributes {
+				assert.Empty(t, e.get(a), "ID attributes are not copied")
+			}
+		})
+	}
+}
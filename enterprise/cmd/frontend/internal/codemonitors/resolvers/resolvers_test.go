@@ -19,8 +19,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -85,6 +87,36 @@ func TestCreateCodeMonitor(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("invalid teams webhook", func(t *testing.T) {
+		namespace := relay.MarshalID("User", user.ID)
+		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
+			Monitor: &graphqlbackend.CreateMonitorArgs{Namespace: namespace},
+			Trigger: &graphqlbackend.CreateTriggerArgs{Query: "repo:."},
+			Actions: []*graphqlbackend.CreateActionArgs{{
+				TeamsWebhook: &graphqlbackend.CreateActionTeamsWebhookArgs{
+					URL: "https://internal:3443",
+				},
+			}},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid webhook template", func(t *testing.T) {
+		namespace := relay.MarshalID("User", user.ID)
+		template := `{"summary": {{ .Monitor.Description }}}`
+		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
+			Monitor: &graphqlbackend.CreateMonitorArgs{Namespace: namespace},
+			Trigger: &graphqlbackend.CreateTriggerArgs{Query: "repo:."},
+			Actions: []*graphqlbackend.CreateActionArgs{{
+				Webhook: &graphqlbackend.CreateActionWebhookArgs{
+					URL:      "https://generic.webhook.com",
+					Template: &template,
+				},
+			}},
+		})
+		require.Error(t, err)
+	})
+
 	t.Run("invalid query", func(t *testing.T) {
 		namespace := relay.MarshalID("User", user.ID)
 		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
@@ -1323,3 +1355,59 @@ func TestValidateSlackURL(t *testing.T) {
 		require.Error(t, validateSlackURL(url))
 	}
 }
+
+func TestValidateTeamsURL(t *testing.T) {
+	valid := []string{
+		"https://sourcegraph.webhook.office.com/webhookb2/8d8d8@8dd88d/IncomingWebhook/838383/8d8d8",
+		"https://outlook.office.com/webhook/8d8d8@8dd88d/IncomingWebhook/838383/8d8d8",
+	}
+
+	for _, url := range valid {
+		require.NoError(t, validateTeamsURL(url))
+	}
+
+	invalid := []string{
+		"http://sourcegraph.webhook.office.com/webhookb2",
+		"https://sourcegraph.webhook.office.com:3443/webhookb2",
+		"https://webhook.office.com.internal/webhookb2",
+		"https://internal:8989",
+	}
+
+	for _, url := range invalid {
+		require.Error(t, validateTeamsURL(url))
+	}
+}
+
+func TestIssueRepoID(t *testing.T) {
+	users := database.NewMockUserStore()
+	repos := database.NewMockRepoStore()
+	repos.GetByNameFunc.SetDefaultHook(func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+		serviceType := extsvc.TypeGitHub
+		if name == "bitbucket.org/foo/bar" {
+			serviceType = extsvc.TypeBitbucketCloud
+		}
+		return &types.Repo{ID: 1, Name: name, ExternalRepo: api.ExternalRepoSpec{ServiceType: serviceType}}, nil
+	})
+	db := edb.NewMockEnterpriseDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	r := &Resolver{logger: logtest.Scoped(t), db: db}
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	t.Run("non-admin", func(t *testing.T) {
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+		_, err := r.issueRepoID(ctx, "github.com/foo/bar")
+		require.Error(t, err)
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+		repoID, err := r.issueRepoID(ctx, "github.com/foo/bar")
+		require.NoError(t, err)
+		require.Equal(t, api.RepoID(1), repoID)
+
+		_, err = r.issueRepoID(ctx, "bitbucket.org/foo/bar")
+		require.Error(t, err)
+	})
+}
@@ -102,7 +102,19 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	matchesBuf := streamhttp.NewJSONArrayBuf(32*1024, func(data []byte) error {
 		return eventWriter.EventBytes("results", data)
 	})
+
+	// Aggregate commands return an aggregation for every search result. Instead of
+	// sending these, we send the aggregation of all results seen so far whenever it
+	// changes.
+	var aggregator *compute.Aggregator
+	if command, ok := computeQuery.Command.(*compute.Aggregate); ok {
+		aggregator = compute.NewAggregator(command)
+	}
+
 	matchesFlush := func() {
+		if aggregator != nil && aggregator.Dirty() {
+			_ = matchesBuf.Append(aggregator.Result())
+		}
 		if err := matchesBuf.Flush(); err != nil {
 			// EOF
 			return
@@ -124,6 +136,10 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		progress.Stats.Update(&event.Stats)
 
 		for _, result := range event.Results {
+			if aggregation, ok := result.(*compute.Aggregation); ok && aggregator != nil {
+				aggregator.Add(aggregation)
+				continue
+			}
 			_ = matchesBuf.Append(result)
 		}
 
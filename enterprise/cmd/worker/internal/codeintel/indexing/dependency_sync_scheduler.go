@@ -28,6 +28,8 @@ var schemeToExternalService = map[string]string{
 	dependencies.NpmPackagesScheme:    extsvc.KindNpmPackages,
 	dependencies.RustPackagesScheme:   extsvc.KindRustPackages,
 	dependencies.PythonPackagesScheme: extsvc.KindPythonPackages,
+	dependencies.RubyPackagesScheme:   extsvc.KindRubyPackages,
+	dependencies.NuGetPackagesScheme:  extsvc.KindNuGetPackages,
 }
 
 // NewDependencySyncScheduler returns a new worker instance that processes
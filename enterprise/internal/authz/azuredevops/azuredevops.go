@@ -0,0 +1,185 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Provider implements authz.Provider for Azure DevOps. Azure DevOps users are
+// identified by the verified email addresses of Sourcegraph users, and can
+// access all repositories of the projects they are a member of.
+type Provider struct {
+	urn      string
+	client   client
+	codeHost *extsvc.CodeHost
+	// orgs are the organizations whose projects are checked for members.
+	orgs []string
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Azure DevOps authz provider for the given
+// connection. Permissions can only be enforced for Azure DevOps Services, as
+// the member entitlement API doesn't exist on Azure DevOps Server.
+func NewProvider(conn *types.AzureDevOpsConnection) (*Provider, error) {
+	cli, err := azuredevops.NewClient(conn.URN, conn.AzureDevOpsConnection, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !cli.IsAzureDevOpsServices() {
+		return nil, errors.Errorf("Azure DevOps permissions can only be enforced for Azure DevOps Services, not for %s", conn.Url)
+	}
+
+	return newProvider(conn, cli.URL, cli), nil
+}
+
+func newProvider(conn *types.AzureDevOpsConnection, baseURL *url.URL, cli client) *Provider {
+	seen := make(map[string]bool)
+	var orgs []string
+	addOrg := func(org string) {
+		if !seen[org] {
+			seen[org] = true
+			orgs = append(orgs, org)
+		}
+	}
+	for _, org := range conn.Orgs {
+		addOrg(org)
+	}
+	for _, p := range conn.Projects {
+		org, _, _ := strings.Cut(p, "/")
+		addOrg(org)
+	}
+
+	return &Provider{
+		urn:      conn.URN,
+		client:   cli,
+		codeHost: extsvc.NewCodeHost(baseURL, extsvc.TypeAzureDevOps),
+		orgs:     orgs,
+	}
+}
+
+// FetchAccount returns the Azure DevOps account of the user, which is the
+// first member of a configured organization whose email address matches one
+// of the verified emails of the user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account, verifiedEmails []string) (*extsvc.Account, error) {
+	for _, email := range verifiedEmails {
+		for _, org := range p.orgs {
+			ue, err := p.client.FindUserEntitlementByEmail(ctx, org, email)
+			if err != nil {
+				return nil, err
+			}
+			if ue == nil {
+				continue
+			}
+
+			data, err := json.Marshal(azuredevops.AccountData{
+				ID:          ue.ID,
+				Email:       email,
+				DisplayName: ue.User.DisplayName,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "marshaling account data")
+			}
+
+			return &extsvc.Account{
+				UserID: user.ID,
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: p.codeHost.ServiceType,
+					ServiceID:   p.codeHost.ServiceID,
+					AccountID:   ue.ID,
+				},
+				AccountData: extsvc.AccountData{
+					Data: extsvc.NewUnencryptedData(data),
+				},
+				CreatedAt: user.CreatedAt,
+				UpdatedAt: user.UpdatedAt,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FetchUserPerms returns the IDs of the private repositories of all projects
+// the account is a member of.
+//
+// Callers shouldn't discard partial results on error, since they are valid:
+// each project contributes all or none of its repositories.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, _ authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	if account == nil {
+		return nil, errors.New("no account provided")
+	} else if !extsvc.IsHostOfAccount(p.codeHost, account) {
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			account.AccountSpec.ServiceID, p.codeHost.ServiceID)
+	}
+
+	perms := &authz.ExternalUserPermissions{}
+	for _, org := range p.orgs {
+		ue, err := p.client.GetUserEntitlement(ctx, org, account.AccountID)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				// The user is not a member of this organization.
+				continue
+			}
+			return perms, errors.Wrapf(err, "getting user entitlement in org %q", org)
+		}
+
+		for _, pe := range ue.ProjectEntitlements {
+			repos, err := p.client.ListRepositoriesByProjectOrOrg(ctx, org, pe.ProjectRef.ID)
+			if err != nil {
+				return perms, errors.Wrapf(err, "listing repositories of project %q", pe.ProjectRef.Name)
+			}
+			for _, r := range repos {
+				// Repositories of public projects are accessible to everyone.
+				if r.Project.Visibility == "public" {
+					continue
+				}
+				perms.Exacts = append(perms.Exacts, extsvc.RepoID(r.ID))
+			}
+		}
+	}
+
+	return perms, nil
+}
+
+func (p *Provider) FetchRepoPerms(context.Context, *extsvc.Repository, authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	return nil, &authz.ErrUnimplemented{Feature: "azuredevops.FetchRepoPerms"}
+}
+
+func (p *Provider) FetchUserPermsByToken(context.Context, string, authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	return nil, &authz.ErrUnimplemented{Feature: "azuredevops.FetchUserPermsByToken"}
+}
+
+func (p *Provider) ServiceType() string {
+	return p.codeHost.ServiceType
+}
+
+func (p *Provider) ServiceID() string {
+	return p.codeHost.ServiceID
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ValidateConnection validates that the configured credentials can be used to
+// access the Azure DevOps API.
+func (p *Provider) ValidateConnection(ctx context.Context) (warnings []string) {
+	if _, err := p.client.GetAuthorizedProfile(ctx); err != nil {
+		return []string{fmt.Sprintf("Unable to get the profile of the configured Azure DevOps user: %v", err)}
+	}
+	if len(p.orgs) == 0 {
+		return []string{"No Azure DevOps organizations or projects are configured, so no permissions will be synced."}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package azuredevops
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewAuthzProviders(t *testing.T) {
+	conn := func(url string, authorization *schema.AzureDevOpsAuthorization) *types.AzureDevOpsConnection {
+		return &types.AzureDevOpsConnection{
+			URN: "extsvc:azuredevops:1",
+			AzureDevOpsConnection: &schema.AzureDevOpsConnection{
+				Url:           url,
+				Username:      "admin",
+				Token:         "secret",
+				Authorization: authorization,
+			},
+		}
+	}
+
+	t.Run("no authorization", func(t *testing.T) {
+		ps, problems, _ := NewAuthzProviders([]*types.AzureDevOpsConnection{conn("https://dev.azure.com", nil)})
+		assert.Empty(t, ps)
+		assert.Empty(t, problems)
+	})
+
+	t.Run("Azure DevOps Services", func(t *testing.T) {
+		ps, problems, _ := NewAuthzProviders([]*types.AzureDevOpsConnection{conn("https://dev.azure.com", &schema.AzureDevOpsAuthorization{})})
+		assert.Empty(t, problems)
+		require.Len(t, ps, 1)
+		assert.Equal(t, extsvc.TypeAzureDevOps, ps[0].ServiceType())
+		assert.Equal(t, "https://dev.azure.com/", ps[0].ServiceID())
+	})
+
+	t.Run("Azure DevOps Server", func(t *testing.T) {
+		ps, problems, _ := NewAuthzProviders([]*types.AzureDevOpsConnection{conn("https://azure.corp.com/tfs", &schema.AzureDevOpsAuthorization{})})
+		assert.Empty(t, ps)
+		assert.Len(t, problems, 1)
+	})
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(&mockClient{
+		mockFindUserEntitlementByEmail: func(ctx context.Context, org, email string) (*azuredevops.UserEntitlement, error) {
+			if org == "other" && email == "alice@example.com" {
+				return &azuredevops.UserEntitlement{ID: "alice-id", User: azuredevops.GraphUser{DisplayName: "Alice"}}, nil
+			}
+			return nil, nil
+		},
+	})
+	user := &types.User{ID: 42, Username: "alice"}
+
+	acct, err := p.FetchAccount(context.Background(), user, nil, []string{"alice@corp.com", "alice@example.com"})
+	require.Nil(t, err)
+	require.NotNil(t, acct)
+	assert.Equal(t, int32(42), acct.UserID)
+	assert.Equal(t, extsvc.AccountSpec{
+		ServiceType: extsvc.TypeAzureDevOps,
+		ServiceID:   "https://dev.azure.com/",
+		AccountID:   "alice-id",
+	}, acct.AccountSpec)
+
+	data, err := azuredevops.GetExternalAccountData(context.Background(), &acct.AccountData)
+	require.Nil(t, err)
+	assert.Equal(t, &azuredevops.AccountData{ID: "alice-id", Email: "alice@example.com", DisplayName: "Alice"}, data)
+
+	acct, err = p.FetchAccount(context.Background(), user, nil, []string{"bob@example.com"})
+	assert.Nil(t, err)
+	assert.Nil(t, acct)
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	account := &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: extsvc.TypeAzureDevOps,
+			ServiceID:   "https://dev.azure.com/",
+			AccountID:   "alice-id",
+		},
+	}
+
+	t.Run("wrong code host", func(t *testing.T) {
+		p := newTestProvider(&mockClient{})
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{ServiceType: extsvc.TypeGitHub, ServiceID: "https://github.com/"},
+		}, authz.FetchPermsOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		p := newTestProvider(&mockClient{
+			mockGetUserEntitlement: func(ctx context.Context, org, userID string) (*azuredevops.UserEntitlement, error) {
+				if org == "other" {
+					return nil, &notFoundError{}
+				}
+				return &azuredevops.UserEntitlement{
+					ID: userID,
+					ProjectEntitlements: []azuredevops.ProjectEntitlement{
+						{ProjectRef: azuredevops.ProjectRef{ID: "private-id", Name: "private"}},
+						{ProjectRef: azuredevops.ProjectRef{ID: "public-id", Name: "public"}},
+					},
+				}, nil
+			},
+			mockListRepositoriesByProjectOrOrg: func(ctx context.Context, org, project string) ([]*azuredevops.Repository, error) {
+				switch project {
+				case "private-id":
+					return []*azuredevops.Repository{
+						{ID: "1", Project: azuredevops.Project{Visibility: "private"}},
+						{ID: "2", Project: azuredevops.Project{Visibility: "private"}},
+					}, nil
+				case "public-id":
+					return []*azuredevops.Repository{{ID: "3", Project: azuredevops.Project{Visibility: "public"}}}, nil
+				}
+				return nil, errors.Errorf("unexpected project %q", project)
+			},
+		})
+
+		perms, err := p.FetchUserPerms(context.Background(), account, authz.FetchPermsOptions{})
+		require.Nil(t, err)
+		assert.Equal(t, []extsvc.RepoID{"1", "2"}, perms.Exacts)
+	})
+
+	t.Run("partial results", func(t *testing.T) {
+		p := newTestProvider(&mockClient{
+			mockGetUserEntitlement: func(ctx context.Context, org, userID string) (*azuredevops.UserEntitlement, error) {
+				if org == "other" {
+					return nil, errors.New("boom")
+				}
+				return &azuredevops.UserEntitlement{
+					ID:                  userID,
+					ProjectEntitlements: []azuredevops.ProjectEntitlement{{ProjectRef: azuredevops.ProjectRef{ID: "private-id"}}},
+				}, nil
+			},
+			mockListRepositoriesByProjectOrOrg: func(ctx context.Context, org, project string) ([]*azuredevops.Repository, error) {
+				return []*azuredevops.Repository{{ID: "1", Project: azuredevops.Project{Visibility: "private"}}}, nil
+			},
+		})
+
+		perms, err := p.FetchUserPerms(context.Background(), account, authz.FetchPermsOptions{})
+		assert.Error(t, err)
+		assert.Equal(t, []extsvc.RepoID{"1"}, perms.Exacts)
+	})
+}
+
+func TestProvider_ValidateConnection(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		p := newTestProvider(&mockClient{})
+		assert.Empty(t, p.ValidateConnection(context.Background()))
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		p := newTestProvider(&mockClient{
+			mockGetAuthorizedProfile: func(ctx context.Context) (*azuredevops.Profile, error) {
+				return nil, errors.New("unauthorized")
+			},
+		})
+		assert.Len(t, p.ValidateConnection(context.Background()), 1)
+	})
+}
+
+// notFoundError mimics the error returned by the Azure DevOps client for 404
+// responses.
+type notFoundError struct{}
+
+func (notFoundError) Error() string  { return "not found" }
+func (notFoundError) NotFound() bool { return true }
+
+func newTestProvider(cli client) *Provider {
+	baseURL, _ := url.Parse("https://dev.azure.com")
+	return newProvider(&types.AzureDevOpsConnection{
+		URN: "extsvc:azuredevops:1",
+		AzureDevOpsConnection: &schema.AzureDevOpsConnection{
+			Url:      "https://dev.azure.com",
+			Orgs:     []string{"sgtest"},
+			Projects: []string{"other/project", "sgtest/private"},
+		},
+	}, baseURL, cli)
+}
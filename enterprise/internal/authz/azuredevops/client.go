@@ -0,0 +1,51 @@
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+)
+
+type client interface {
+	GetAuthorizedProfile(ctx context.Context) (*azuredevops.Profile, error)
+	FindUserEntitlementByEmail(ctx context.Context, org, email string) (*azuredevops.UserEntitlement, error)
+	GetUserEntitlement(ctx context.Context, org, userID string) (*azuredevops.UserEntitlement, error)
+	ListRepositoriesByProjectOrOrg(ctx context.Context, org, project string) ([]*azuredevops.Repository, error)
+}
+
+var _ client = (*azuredevops.Client)(nil)
+
+type mockClient struct {
+	mockGetAuthorizedProfile           func(ctx context.Context) (*azuredevops.Profile, error)
+	mockFindUserEntitlementByEmail     func(ctx context.Context, org, email string) (*azuredevops.UserEntitlement, error)
+	mockGetUserEntitlement             func(ctx context.Context, org, userID string) (*azuredevops.UserEntitlement, error)
+	mockListRepositoriesByProjectOrOrg func(ctx context.Context, org, project string) ([]*azuredevops.Repository, error)
+}
+
+func (m *mockClient) GetAuthorizedProfile(ctx context.Context) (*azuredevops.Profile, error) {
+	if m.mockGetAuthorizedProfile != nil {
+		return m.mockGetAuthorizedProfile(ctx)
+	}
+	return &azuredevops.Profile{}, nil
+}
+
+func (m *mockClient) FindUserEntitlementByEmail(ctx context.Context, org, email string) (*azuredevops.UserEntitlement, error) {
+	if m.mockFindUserEntitlementByEmail != nil {
+		return m.mockFindUserEntitlementByEmail(ctx, org, email)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) GetUserEntitlement(ctx context.Context, org, userID string) (*azuredevops.UserEntitlement, error) {
+	if m.mockGetUserEntitlement != nil {
+		return m.mockGetUserEntitlement(ctx, org, userID)
+	}
+	return &azuredevops.UserEntitlement{ID: userID}, nil
+}
+
+func (m *mockClient) ListRepositoriesByProjectOrOrg(ctx context.Context, org, project string) ([]*azuredevops.Repository, error) {
+	if m.mockListRepositoriesByProjectOrOrg != nil {
+		return m.mockListRepositoriesByProjectOrOrg(ctx, org, project)
+	}
+	return nil, nil
+}
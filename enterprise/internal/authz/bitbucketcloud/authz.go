@@ -0,0 +1,77 @@
+package bitbucketcloud
+
+import (
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewAuthzProviders returns the set of Bitbucket Cloud authz providers derived from the connections.
+//
+// It also returns any simple validation problems with the config, separating these into "serious problems"
+// and "warnings". "Serious problems" are those that should make Sourcegraph set authz.allowAccessByDefault
+// to false. "Warnings" are all other validation problems.
+//
+// This constructor does not and should not directly check connectivity to external services - if
+// desired, callers should use `(*Provider).ValidateConnection` directly to get warnings related
+// to connection issues.
+func NewAuthzProviders(
+	db database.DB,
+	conns []*types.BitbucketCloudConnection,
+	authProviders []schema.AuthProviders,
+) (ps []authz.Provider, problems []string, warnings []string) {
+	for _, c := range conns {
+		p, err := newAuthzProvider(db, c, authProviders)
+		if err != nil {
+			problems = append(problems, err.Error())
+		} else if p != nil {
+			ps = append(ps, p)
+		}
+	}
+
+	return ps, problems, warnings
+}
+
+func newAuthzProvider(db database.DB, c *types.BitbucketCloudConnection, ps []schema.AuthProviders) (authz.Provider, error) {
+	if c.Authorization == nil {
+		return nil, nil
+	}
+
+	bbURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Errorf("Could not parse URL for Bitbucket Cloud instance %q: %s", c.Url, err)
+	}
+
+	// Users are matched by the external accounts created when they sign in
+	// with Bitbucket Cloud, so there must be a corresponding authn provider.
+	for _, authnProvider := range ps {
+		if authnProvider.Bitbucketcloud == nil {
+			continue
+		}
+		authnURL := authnProvider.Bitbucketcloud.Url
+		if authnURL == "" {
+			authnURL = "https://bitbucket.org"
+		}
+		authProviderURL, err := url.Parse(authnURL)
+		if err != nil {
+			// Ignore the error here, because the authn provider is responsible for its own validation
+			continue
+		}
+		if authProviderURL.Hostname() == bbURL.Hostname() {
+			return NewProvider(db, c, authnProvider.Bitbucketcloud)
+		}
+	}
+
+	return nil, errors.Errorf("Did not find authentication provider matching %q. Check the [**site configuration**](/site-admin/configuration) to verify an entry in [`auth.providers`](https://docs.sourcegraph.com/admin/auth) exists for %s.", c.Url, c.Url)
+}
+
+// ValidateAuthz validates the authorization fields of the given Bitbucket Cloud external
+// service config.
+func ValidateAuthz(cfg *schema.BitbucketCloudConnection, ps []schema.AuthProviders) error {
+	_, err := newAuthzProvider(nil, &types.BitbucketCloudConnection{BitbucketCloudConnection: cfg}, ps)
+	return err
+}
@@ -0,0 +1,225 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Provider implements authz.Provider for Bitbucket Cloud repository
+// permissions. Users are identified by the external accounts created when they
+// sign in with the Bitbucket Cloud auth provider, whose OAuth tokens are used
+// to list the repositories they can access. Repository permissions are read
+// from the workspace permissions using the credentials of the connection.
+type Provider struct {
+	urn      string
+	codeHost *extsvc.CodeHost
+	client   bitbucketcloud.Client
+	db       database.DB
+
+	// oauth2Config is used to refresh expired OAuth tokens of users.
+	oauth2Config oauth2.Config
+
+	// pageSize is the number of permissions requested per page.
+	pageSize int
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Bitbucket Cloud authz provider for the given
+// connection. The auth provider is the one users of the code host sign in
+// with.
+func NewProvider(db database.DB, conn *types.BitbucketCloudConnection, authProvider *schema.BitbucketCloudAuthProvider) (*Provider, error) {
+	return newProvider(db, conn, authProvider, nil)
+}
+
+func newProvider(db database.DB, conn *types.BitbucketCloudConnection, authProvider *schema.BitbucketCloudAuthProvider, cli httpcli.Doer) (*Provider, error) {
+	baseURL, err := url.Parse(conn.Url)
+	if err != nil {
+		return nil, errors.Errorf("Could not parse URL for Bitbucket Cloud instance %q: %s", conn.Url, err)
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	client, err := bitbucketcloud.NewClient(conn.URN, conn.BitbucketCloudConnection, cli)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		urn:      conn.URN,
+		codeHost: extsvc.NewCodeHost(baseURL, extsvc.TypeBitbucketCloud),
+		client:   client,
+		db:       db,
+		oauth2Config: oauth2.Config{
+			ClientID:     authProvider.ClientKey,
+			ClientSecret: authProvider.ClientSecret,
+			Endpoint:     bitbucketcloud.OAuthEndpoint(baseURL),
+		},
+		pageSize: 100,
+	}, nil
+}
+
+func (p *Provider) ValidateConnection(ctx context.Context) (warnings []string) {
+	if err := p.client.Ping(ctx); err != nil {
+		return []string{fmt.Sprintf("Unable to connect to Bitbucket Cloud with the configured app password: %v", err)}
+	}
+	return nil
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+func (p *Provider) ServiceID() string {
+	return p.codeHost.ServiceID
+}
+
+func (p *Provider) ServiceType() string {
+	return p.codeHost.ServiceType
+}
+
+// FetchAccount always returns nil, because external accounts are created when
+// users sign in with the Bitbucket Cloud auth provider.
+func (p *Provider) FetchAccount(context.Context, *types.User, []*extsvc.Account, []string) (mine *extsvc.Account, err error) {
+	return nil, nil
+}
+
+// FetchUserPerms returns the UUIDs of the repositories (on code host) that the
+// given account has been granted access to, directly or through a group. The
+// UUID has the same value as it would be used as api.ExternalRepoSpec.ID.
+//
+// The OAuth token of the account is refreshed and saved if it has expired.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-permissions-repositories-get
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	if account == nil {
+		return nil, errors.New("no account provided")
+	} else if !extsvc.IsHostOfAccount(p.codeHost, account) {
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			account.AccountSpec.ServiceID, p.codeHost.ServiceID)
+	}
+
+	user, tok, err := bitbucketcloud.GetExternalAccountData(ctx, &account.AccountData)
+	if err != nil {
+		return nil, errors.Wrap(err, "get external account data")
+	} else if tok == nil {
+		return nil, errors.New("no token found in the external account data")
+	}
+
+	tok, err = p.refreshToken(ctx, account, user, tok)
+	if err != nil {
+		return nil, err
+	}
+	return p.fetchUserPerms(ctx, tok.AccessToken)
+}
+
+// FetchUserPermsByToken is the same as FetchUserPerms, but it only requires a
+// token.
+func (p *Provider) FetchUserPermsByToken(ctx context.Context, token string, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	return p.fetchUserPerms(ctx, token)
+}
+
+func (p *Provider) fetchUserPerms(ctx context.Context, token string) (*authz.ExternalUserPermissions, error) {
+	client := p.client.WithAuthenticator(&auth.OAuthBearerToken{Token: token})
+
+	perms := &authz.ExternalUserPermissions{}
+	next := &bitbucketcloud.PageToken{Pagelen: p.pageSize}
+	for {
+		var repoPerms []*bitbucketcloud.RepoPermission
+		var err error
+		repoPerms, next, err = client.CurrentUserRepoPermissions(ctx, next)
+		if err != nil {
+			return perms, errors.Wrap(err, "list repository permissions")
+		}
+		for _, rp := range repoPerms {
+			if rp.Repo != nil {
+				perms.Exacts = append(perms.Exacts, extsvc.RepoID(rp.Repo.UUID))
+			}
+		}
+		if !next.HasMore() {
+			return perms, nil
+		}
+	}
+}
+
+// refreshToken returns the given token if it is still valid. Otherwise, it
+// returns a new token, which is saved to the account.
+func (p *Provider) refreshToken(ctx context.Context, account *extsvc.Account, user *bitbucketcloud.User, tok *oauth2.Token) (*oauth2.Token, error) {
+	if tok.Valid() || tok.RefreshToken == "" {
+		return tok, nil
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpcli.ExternalClient)
+	refreshed, err := p.oauth2Config.TokenSource(ctx, tok).Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "refresh token")
+	}
+
+	if err := bitbucketcloud.SetExternalAccountData(&account.AccountData, user, refreshed); err != nil {
+		return nil, errors.Wrap(err, "set external account data")
+	}
+	if _, err := p.db.UserExternalAccounts().LookupUserAndSave(ctx, account.AccountSpec, account.AccountData); err != nil {
+		return nil, errors.Wrap(err, "save refreshed token")
+	}
+	return refreshed, nil
+}
+
+// FetchRepoPerms returns the UUIDs of the users (on code host) who have read
+// access to the given repository on the code host. The UUID has the same value
+// as it would be used as extsvc.Account.AccountID. The user of the app
+// password must be an admin of the workspace of the repository.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-repo-slug-get
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	if repo == nil {
+		return nil, errors.New("no repository provided")
+	} else if !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec) {
+		return nil, errors.Errorf("not a code host of the repository: want %q but have %q",
+			repo.ServiceID, p.codeHost.ServiceID)
+	}
+
+	// The URI has the form "bitbucket.org/<workspace>/<slug>".
+	parts := strings.Split(repo.URI, "/")
+	if len(parts) != 3 {
+		return nil, errors.Errorf("unexpected repository URI %q", repo.URI)
+	}
+	workspace, slug := parts[1], parts[2]
+
+	var userIDs []extsvc.AccountID
+	next := &bitbucketcloud.PageToken{Pagelen: p.pageSize}
+	for {
+		var repoPerms []*bitbucketcloud.RepoPermission
+		var err error
+		repoPerms, next, err = p.client.RepoUserPermissions(ctx, next, workspace, slug)
+		if err != nil {
+			return userIDs, errors.Wrap(err, "list repository permissions")
+		}
+		for _, rp := range repoPerms {
+			if rp.User != nil {
+				userIDs = append(userIDs, extsvc.AccountID(rp.User.UUID))
+			}
+		}
+		if !next.HasMore() {
+			return userIDs, nil
+		}
+	}
+}
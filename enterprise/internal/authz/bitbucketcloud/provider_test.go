@@ -0,0 +1,215 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewAuthzProviders(t *testing.T) {
+	conn := func(authorization *schema.BitbucketCloudAuthorization) *types.BitbucketCloudConnection {
+		return &types.BitbucketCloudConnection{
+			URN: "extsvc:bitbucketcloud:1",
+			BitbucketCloudConnection: &schema.BitbucketCloudConnection{
+				Url:           "https://bitbucket.org",
+				Username:      "admin",
+				AppPassword:   "secret",
+				Authorization: authorization,
+			},
+		}
+	}
+	authProvider := schema.AuthProviders{Bitbucketcloud: &schema.BitbucketCloudAuthProvider{
+		Type:         "bitbucketcloud",
+		ClientKey:    "key",
+		ClientSecret: "secret",
+	}}
+
+	t.Run("no authorization", func(t *testing.T) {
+		ps, problems, _ := NewAuthzProviders(nil, []*types.BitbucketCloudConnection{conn(nil)}, []schema.AuthProviders{authProvider})
+		assert.Empty(t, ps)
+		assert.Empty(t, problems)
+	})
+
+	t.Run("no matching auth provider", func(t *testing.T) {
+		ps, problems, _ := NewAuthzProviders(nil, []*types.BitbucketCloudConnection{conn(&schema.BitbucketCloudAuthorization{})}, []schema.AuthProviders{
+			{Bitbucketcloud: &schema.BitbucketCloudAuthProvider{Url: "https://bitbucket.example.com"}},
+			{Builtin: &schema.BuiltinAuthProvider{Type: "builtin"}},
+		})
+		assert.Empty(t, ps)
+		assert.Len(t, problems, 1)
+	})
+
+	t.Run("matching auth provider", func(t *testing.T) {
+		ps, problems, _ := NewAuthzProviders(nil, []*types.BitbucketCloudConnection{conn(&schema.BitbucketCloudAuthorization{})}, []schema.AuthProviders{authProvider})
+		assert.Empty(t, problems)
+		require.Len(t, ps, 1)
+		assert.Equal(t, extsvc.TypeBitbucketCloud, ps[0].ServiceType())
+		assert.Equal(t, "https://bitbucket.org/", ps[0].ServiceID())
+		assert.Equal(t, "extsvc:bitbucketcloud:1", ps[0].URN())
+	})
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/site/oauth2/access_token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.Form.Get("grant_type"))
+		assert.Equal(t, "refresh", r.Form.Get("refresh_token"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "refreshed", "token_type": "bearer", "refresh_token": "refresh2", "expires_in": 7200}`)
+	})
+	mux.HandleFunc("/2.0/user/permissions/repositories", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer refreshed" && r.Header.Get("Authorization") != "Bearer valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values": [{"permission": "admin", "repository": {"uuid": "{repo-3}"}}]}`)
+			return
+		}
+		assert.Equal(t, "100", r.URL.Query().Get("pagelen"))
+		fmt.Fprintf(w, `{
+			"values": [
+				{"permission": "read", "repository": {"uuid": "{repo-1}", "full_name": "ws/repo-1"}},
+				{"permission": "write", "repository": {"uuid": "{repo-2}", "full_name": "ws/repo-2"}}
+			],
+			"next": "http://%s/2.0/user/permissions/repositories?page=2"
+		}`, r.Host)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	db := database.NewMockDB()
+	externalAccounts := database.NewMockUserExternalAccountsStore()
+	db.UserExternalAccountsFunc.SetDefaultReturn(externalAccounts)
+
+	p := newTestProvider(t, db, srv.URL)
+	account := func(tok *oauth2.Token) *extsvc.Account {
+		a := &extsvc.Account{
+			ID: 1,
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   p.ServiceID(),
+				AccountID:   "{alice}",
+			},
+		}
+		require.NoError(t, bitbucketcloud.SetExternalAccountData(&a.AccountData, &bitbucketcloud.User{Account: bitbucketcloud.Account{UUID: "{alice}"}}, tok))
+		return a
+	}
+	want := []extsvc.RepoID{"{repo-1}", "{repo-2}", "{repo-3}"}
+
+	t.Run("wrong code host", func(t *testing.T) {
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{ServiceType: extsvc.TypeGitHub, ServiceID: "https://github.com/"},
+		}, authz.FetchPermsOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		perms, err := p.FetchUserPerms(context.Background(), account(&oauth2.Token{AccessToken: "valid"}), authz.FetchPermsOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, want, perms.Exacts)
+		assert.Empty(t, externalAccounts.LookupUserAndSaveFunc.History())
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		acct := account(&oauth2.Token{
+			AccessToken:  "expired",
+			RefreshToken: "refresh",
+			Expiry:       time.Now().Add(-time.Hour),
+		})
+		perms, err := p.FetchUserPerms(context.Background(), acct, authz.FetchPermsOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, want, perms.Exacts)
+
+		// The refreshed token is saved to the account.
+		history := externalAccounts.LookupUserAndSaveFunc.History()
+		require.Len(t, history, 1)
+		assert.Equal(t, acct.AccountSpec, history[0].Arg1)
+		_, tok, err := bitbucketcloud.GetExternalAccountData(context.Background(), &history[0].Arg2)
+		require.NoError(t, err)
+		assert.Equal(t, "refreshed", tok.AccessToken)
+		assert.Equal(t, "refresh2", tok.RefreshToken)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := p.FetchUserPermsByToken(context.Background(), "invalid", authz.FetchPermsOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2.0/workspaces/ws/permissions/repositories/repo" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		username, password, _ := r.BasicAuth()
+		assert.Equal(t, "admin", username)
+		assert.Equal(t, "secret", password)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"values": []map[string]any{
+				{"permission": "admin", "user": map[string]any{"uuid": "{alice}"}},
+				{"permission": "read", "user": map[string]any{"uuid": "{bob}"}},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	p := newTestProvider(t, database.NewMockDB(), srv.URL)
+	repo := func(uri string) *extsvc.Repository {
+		return &extsvc.Repository{
+			URI: uri,
+			ExternalRepoSpec: api.ExternalRepoSpec{
+				ID:          "{repo}",
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   p.ServiceID(),
+			},
+		}
+	}
+
+	userIDs, err := p.FetchRepoPerms(context.Background(), repo("bitbucket.org/ws/repo"), authz.FetchPermsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []extsvc.AccountID{"{alice}", "{bob}"}, userIDs)
+
+	_, err = p.FetchRepoPerms(context.Background(), repo("bitbucket.org/ws/other"), authz.FetchPermsOptions{})
+	assert.Error(t, err)
+
+	_, err = p.FetchRepoPerms(context.Background(), repo("ws/repo"), authz.FetchPermsOptions{})
+	assert.Error(t, err)
+}
+
+func newTestProvider(t *testing.T, db database.DB, url string) *Provider {
+	t.Helper()
+	p, err := newProvider(db, &types.BitbucketCloudConnection{
+		URN: "extsvc:bitbucketcloud:1",
+		BitbucketCloudConnection: &schema.BitbucketCloudConnection{
+			Url:         url,
+			ApiURL:      url,
+			Username:    "admin",
+			AppPassword: "secret",
+		},
+	}, &schema.BitbucketCloudAuthProvider{
+		Type:         "bitbucketcloud",
+		ClientKey:    "key",
+		ClientSecret: "secret",
+	}, http.DefaultClient)
+	require.NoError(t, err)
+	return p
+}
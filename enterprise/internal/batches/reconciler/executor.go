@@ -192,6 +192,9 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if pdcss, ok := css.(sources.PushDecoratingChangesetSource); ok {
+		pdcss.DecoratePushOpts(e.targetRepo, e.spec, &opts)
+	}
 
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
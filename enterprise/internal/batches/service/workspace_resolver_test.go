@@ -65,7 +65,7 @@ func TestService_ResolveWorkspacesForBatchSpec(t *testing.T) {
 	u := bt.CreateTestUser(t, db, false)
 
 	rs, _ := bt.CreateTestRepos(t, ctx, db, 7)
-	unsupported, _ := bt.CreateAWSCodeCommitTestRepos(t, ctx, db, 1)
+	unsupported, _ := bt.CreateGitoliteTestRepos(t, ctx, db, 1)
 	// Allow access to all repos but rs[4].
 	bt.MockRepoPermissions(t, db, u.ID, rs[0].ID, rs[1].ID, rs[2].ID, rs[3].ID, rs[5].ID, rs[6].ID, unsupported[0].ID)
 
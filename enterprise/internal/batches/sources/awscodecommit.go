@@ -0,0 +1,293 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"golang.org/x/net/http2"
+
+	accbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// AWSCodeCommitSource is a ChangesetSource for AWS CodeCommit.
+//
+// The AWS CodeCommit API is always accessed with the access key configured in
+// the external service, since Git credentials for CodeCommit can't be used to
+// sign API requests. The authenticator of the source is only used to push
+// commits: by default, these are the Git credentials of the external service.
+type AWSCodeCommitSource struct {
+	client *awscodecommit.Client
+	au     auth.Authenticator
+}
+
+func NewAWSCodeCommitSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AWSCodeCommitSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AWSCodeCommitConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer(func(c *http.Client) error {
+		tr := awshttp.NewBuildableClient().GetTransport()
+		if err := http2.ConfigureTransport(tr); err != nil {
+			return err
+		}
+		c.Transport = tr
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(c.Region),
+		config.WithCredentialsProvider(
+			awscredentials.StaticCredentialsProvider{
+				Value: aws.Credentials{
+					AccessKeyID:     c.AccessKeyID,
+					SecretAccessKey: c.SecretAccessKey,
+					Source:          "sourcegraph-site-configuration",
+				},
+			},
+		),
+		config.WithHTTPClient(cli),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading AWS config")
+	}
+
+	return &AWSCodeCommitSource{
+		client: awscodecommit.NewClient(awsConfig),
+		au: &auth.BasicAuth{
+			Username: c.GitCredentials.Username,
+			Password: c.GitCredentials.Password,
+		},
+	}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s AWSCodeCommitSource) GitserverPushConfig(ctx context.Context, store database.ExternalServiceStore, repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(ctx, store, repo, s.au)
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host. The authenticator must contain Git credentials for AWS CodeCommit.
+func (s AWSCodeCommitSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("AWSCodeCommitSource", a)
+	}
+
+	return &AWSCodeCommitSource{client: s.client, au: a}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+//
+// Git credentials for AWS CodeCommit can only be used by git itself, so there
+// is no API to validate them against; only their presence is checked.
+func (s AWSCodeCommitSource) ValidateAuthenticator(ctx context.Context) error {
+	var username, password string
+	switch a := s.au.(type) {
+	case *auth.BasicAuth:
+		username, password = a.Username, a.Password
+	case *auth.BasicAuthWithSSH:
+		username, password = a.Username, a.Password
+	}
+	if username == "" || password == "" {
+		return errors.New("AWS CodeCommit Git credentials require a username and password")
+	}
+	return nil
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s AWSCodeCommitSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	pr, err := s.client.GetPullRequest(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, pr, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s AWSCodeCommitSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	repo := cs.TargetRepo.Metadata.(*awscodecommit.Repository)
+
+	// AWS CodeCommit happily creates multiple pull requests for the same
+	// branches, so we have to check for an existing one ourselves.
+	existing, err := s.findOpenPullRequest(ctx, repo.Name, cs.HeadRef, cs.BaseRef)
+	if err != nil {
+		return false, errors.Wrap(err, "finding existing pull request")
+	}
+	if existing != nil {
+		return true, s.setChangesetMetadata(ctx, existing, cs)
+	}
+
+	pr, err := s.client.CreatePullRequest(ctx, &awscodecommit.CreatePullRequestInput{
+		RepositoryName:  repo.Name,
+		Title:           cs.Title,
+		Description:     cs.Body,
+		SourceReference: gitdomain.AbbreviateRef(cs.HeadRef),
+		DestReference:   gitdomain.AbbreviateRef(cs.BaseRef),
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "creating pull request")
+	}
+
+	return false, s.setChangesetMetadata(ctx, pr, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "declined" on
+// Bitbucket Server).
+func (s AWSCodeCommitSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	pr := cs.Metadata.(*accbatches.AnnotatedPullRequest)
+
+	updated, err := s.client.ClosePullRequest(ctx, pr.ID)
+	if err != nil {
+		return errors.Wrap(err, "closing pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, updated, cs)
+}
+
+// UpdateChangeset can update Changesets.
+func (s AWSCodeCommitSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	pr := cs.Metadata.(*accbatches.AnnotatedPullRequest)
+
+	updated := pr.PullRequest
+	var err error
+	if pr.Title != cs.Title {
+		updated, err = s.client.UpdatePullRequestTitle(ctx, pr.ID, cs.Title)
+		if err != nil {
+			return errors.Wrap(err, "updating pull request title")
+		}
+	}
+	if pr.Description != cs.Body {
+		updated, err = s.client.UpdatePullRequestDescription(ctx, pr.ID, cs.Body)
+		if err != nil {
+			return errors.Wrap(err, "updating pull request description")
+		}
+	}
+
+	return s.setChangesetMetadata(ctx, updated, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s AWSCodeCommitSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	// Closed pull requests can't be reopened in AWS CodeCommit, so, like on
+	// Bitbucket Cloud, we recreate the pull request instead. If the pull
+	// request is still open, CreateChangeset finds and returns it.
+	_, err := s.CreateChangeset(ctx, cs)
+	return err
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s AWSCodeCommitSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	pr := cs.Metadata.(*accbatches.AnnotatedPullRequest)
+
+	return s.client.CreatePullRequestComment(ctx, pr.PullRequest, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, the pull request is squash merged, otherwise a merge
+// commit is created. If the changeset cannot be merged, because it is in an
+// unmergeable state, ChangesetNotMergeableError is returned.
+func (s AWSCodeCommitSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	pr := cs.Metadata.(*accbatches.AnnotatedPullRequest)
+
+	updated, err := s.client.MergePullRequest(ctx, pr.PullRequest, squash)
+	if err != nil {
+		if awscodecommit.IsNotMergeable(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "merging pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, updated, cs)
+}
+
+// findOpenPullRequest returns the open pull request from headRef into baseRef
+// in the given repository, or nil if there is none.
+func (s AWSCodeCommitSource) findOpenPullRequest(ctx context.Context, repoName, headRef, baseRef string) (*awscodecommit.PullRequest, error) {
+	headRef, baseRef = gitdomain.EnsureRefPrefix(headRef), gitdomain.EnsureRefPrefix(baseRef)
+
+	var nextToken string
+	for {
+		ids, next, err := s.client.ListOpenPullRequests(ctx, repoName, nextToken)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			pr, err := s.client.GetPullRequest(ctx, id)
+			if err != nil {
+				if errcode.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			if gitdomain.EnsureRefPrefix(pr.SourceReference) == headRef && gitdomain.EnsureRefPrefix(pr.DestReference) == baseRef {
+				return pr, nil
+			}
+		}
+
+		if next == "" {
+			return nil, nil
+		}
+		nextToken = next
+	}
+}
+
+func (s AWSCodeCommitSource) setChangesetMetadata(ctx context.Context, pr *awscodecommit.PullRequest, cs *Changeset) error {
+	approvals, err := s.client.GetPullRequestApprovals(ctx, pr)
+	if err != nil {
+		return errors.Wrap(err, "getting pull request approvals")
+	}
+
+	if err := cs.SetMetadata(&accbatches.AnnotatedPullRequest{
+		PullRequest: pr,
+		Approvals:   approvals,
+		Region:      s.client.Region(),
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package awscodecommit
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+)
+
+// AnnotatedPullRequest adds metadata we need that lives outside the main
+// PullRequest type returned by the AWS CodeCommit API alongside the pull
+// request. This type is used as the primary metadata type for AWS CodeCommit
+// changesets.
+type AnnotatedPullRequest struct {
+	*awscodecommit.PullRequest
+	Approvals *awscodecommit.PullRequestApprovals
+	// Region is the AWS region of the repository, which is required to build
+	// links to the pull request in the AWS console.
+	Region string
+}
+
+// URL returns the link to the pull request in the AWS console.
+func (pr *AnnotatedPullRequest) URL() string {
+	u := url.URL{
+		Scheme:   "https",
+		Host:     pr.Region + ".console.aws.amazon.com",
+		Path:     "/codesuite/codecommit/repositories/" + pr.RepositoryName + "/pull-requests/" + pr.ID + "/details",
+		RawQuery: url.Values{"region": {pr.Region}}.Encode(),
+	}
+	return u.String()
+}
+
+// AuthorName returns the name of the IAM user or role that created the pull
+// request, which is the last segment of its ARN.
+func (pr *AnnotatedPullRequest) AuthorName() string {
+	if i := strings.LastIndex(pr.AuthorARN, "/"); i >= 0 {
+		return pr.AuthorARN[i+1:]
+	}
+	return pr.AuthorARN
+}
@@ -0,0 +1,315 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	accbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAWSCodeCommitSource_LoadChangeset(t *testing.T) {
+	testCases := []struct {
+		name string
+		cs   *Changeset
+		err  string
+	}{
+		{
+			name: "found",
+			cs:   testAWSCodeCommitChangeset(&btypes.Changeset{ExternalID: "1"}),
+		},
+		{
+			name: "not-found",
+			cs:   testAWSCodeCommitChangeset(&btypes.Changeset{ExternalID: "100000"}),
+			err:  "Changeset with external ID 100000 not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_LoadChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newTestAWSCodeCommitSource(t, tc.name)
+			defer save(t)
+
+			if tc.err == "" {
+				tc.err = "<nil>"
+			}
+
+			err := src.LoadChangeset(context.Background(), tc.cs)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+
+			if err != nil {
+				return
+			}
+
+			testutil.AssertGolden(
+				t,
+				"testdata/golden/"+tc.name,
+				update(tc.name),
+				tc.cs.Changeset.Metadata.(*accbatches.AnnotatedPullRequest),
+			)
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_CreateChangeset(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cs     *Changeset
+		exists bool
+	}{
+		{
+			name: "success",
+			cs: testAWSCodeCommitChangeset(&btypes.Changeset{
+				ExternalBranch: "refs/heads/test-pr",
+			}),
+		},
+		{
+			name: "already-exists",
+			cs: testAWSCodeCommitChangeset(&btypes.Changeset{
+				ExternalBranch: "refs/heads/test-pr",
+			}),
+			exists: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_CreateChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newTestAWSCodeCommitSource(t, tc.name)
+			defer save(t)
+
+			tc.cs.Title = "This is a test PR"
+			tc.cs.Body = "This is the description of the test PR"
+			tc.cs.HeadRef = "refs/heads/test-pr"
+			tc.cs.BaseRef = "refs/heads/main"
+
+			exists, err := src.CreateChangeset(context.Background(), tc.cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.exists, exists)
+
+			pr := tc.cs.Changeset.Metadata.(*accbatches.AnnotatedPullRequest)
+			assert.Equal(t, "1", tc.cs.ExternalID)
+			assert.Equal(t, "refs/heads/test-pr", pr.SourceReference)
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), pr)
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_CloseChangeset(t *testing.T) {
+	name := "AWSCodeCommitSource_CloseChangeset_success"
+	src, save := newTestAWSCodeCommitSource(t, name)
+	defer save(t)
+
+	cs := testAWSCodeCommitChangeset(&btypes.Changeset{Metadata: testAWSCodeCommitPullRequest()})
+	if err := src.CloseChangeset(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	pr := cs.Changeset.Metadata.(*accbatches.AnnotatedPullRequest)
+	assert.Equal(t, awscodecommit.PullRequestStatusClosed, pr.Status)
+	assert.False(t, pr.IsMerged)
+}
+
+func TestAWSCodeCommitSource_UpdateChangeset(t *testing.T) {
+	name := "AWSCodeCommitSource_UpdateChangeset_success"
+	src, save := newTestAWSCodeCommitSource(t, name)
+	defer save(t)
+
+	cs := testAWSCodeCommitChangeset(&btypes.Changeset{Metadata: testAWSCodeCommitPullRequest()})
+	cs.Title = "This is a new title"
+	cs.Body = "This is a new body"
+
+	if err := src.UpdateChangeset(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	pr := cs.Changeset.Metadata.(*accbatches.AnnotatedPullRequest)
+	assert.Equal(t, "This is a new title", pr.Title)
+	assert.Equal(t, "This is a new body", pr.Description)
+}
+
+func TestAWSCodeCommitSource_CreateComment(t *testing.T) {
+	name := "AWSCodeCommitSource_CreateComment_success"
+	src, save := newTestAWSCodeCommitSource(t, name)
+	defer save(t)
+
+	cs := testAWSCodeCommitChangeset(&btypes.Changeset{Metadata: testAWSCodeCommitPullRequest()})
+	if err := src.CreateComment(context.Background(), cs, "test-comment"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAWSCodeCommitSource_MergeChangeset(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  string
+	}{
+		{
+			name: "success",
+		},
+		{
+			name: "conflict",
+			err:  "changeset cannot be merged:\noperation error CodeCommit: MergePullRequestBySquash, https response error StatusCode: 400, RequestID: 8a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d, ManualMergeRequiredException: The pull request cannot be merged automatically into the destination branch. You must manually merge the branches and resolve any conflicts.",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_MergeChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newTestAWSCodeCommitSource(t, tc.name)
+			defer save(t)
+
+			if tc.err == "" {
+				tc.err = "<nil>"
+			}
+
+			cs := testAWSCodeCommitChangeset(&btypes.Changeset{Metadata: testAWSCodeCommitPullRequest()})
+			err := src.MergeChangeset(context.Background(), cs, true)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+
+			if err != nil {
+				return
+			}
+
+			pr := cs.Changeset.Metadata.(*accbatches.AnnotatedPullRequest)
+			assert.Equal(t, awscodecommit.PullRequestStatusClosed, pr.Status)
+			assert.True(t, pr.IsMerged)
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_WithAuthenticator(t *testing.T) {
+	src := newAWSCodeCommitSourceWithFactory(t, nil)
+
+	t.Run("supported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"BasicAuth":        &auth.BasicAuth{Username: "user", Password: "pass"},
+			"BasicAuthWithSSH": &auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "pass"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				newSrc, err := src.WithAuthenticator(tc)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Same(t, tc, newSrc.(*AWSCodeCommitSource).au)
+				assert.Nil(t, newSrc.ValidateAuthenticator(context.Background()))
+			})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"nil":         nil,
+			"OAuthBearer": &auth.OAuthBearerToken{Token: "token"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := src.WithAuthenticator(tc)
+				assert.Error(t, err)
+				assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+			})
+		}
+	})
+
+	t.Run("missing password", func(t *testing.T) {
+		newSrc, err := src.WithAuthenticator(&auth.BasicAuth{Username: "user"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Error(t, newSrc.ValidateAuthenticator(context.Background()))
+	})
+}
+
+// newTestAWSCodeCommitSource returns an AWSCodeCommitSource that replays the
+// HTTP interactions recorded in the cassette with the given name. To record
+// new interactions, set the AWS_CODE_COMMIT_* environment variables to the
+// credentials of a test account and run the tests with -update.
+func newTestAWSCodeCommitSource(t *testing.T, name string) (*AWSCodeCommitSource, func(testing.TB)) {
+	t.Helper()
+
+	cf, save := newClientFactory(t, name)
+	return newAWSCodeCommitSourceWithFactory(t, cf), save
+}
+
+func newAWSCodeCommitSourceWithFactory(t *testing.T, cf *httpcli.Factory) *AWSCodeCommitSource {
+	t.Helper()
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindAWSCodeCommit,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.AWSCodeCommitConnection{
+			AccessKeyID:     getEnvOrDefault("AWS_CODE_COMMIT_ACCESS_KEY_ID", "secret-access-key-id"),
+			SecretAccessKey: getEnvOrDefault("AWS_CODE_COMMIT_SECRET_ACCESS_KEY", "secret-secret-access-key"),
+			Region:          "us-west-1",
+			GitCredentials: schema.AWSCodeCommitGitCredentials{
+				Username: getEnvOrDefault("AWS_CODE_COMMIT_GIT_USERNAME", "git-username"),
+				Password: getEnvOrDefault("AWS_CODE_COMMIT_GIT_PASSWORD", "git-password"),
+			},
+		})),
+	}
+
+	src, err := NewAWSCodeCommitSource(context.Background(), svc, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
+
+func testAWSCodeCommitChangeset(cs *btypes.Changeset) *Changeset {
+	repo := &types.Repo{
+		Metadata: &awscodecommit.Repository{
+			ARN:       "arn:aws:codecommit:us-west-1:999999999999:batch-changes-test",
+			AccountID: "999999999999",
+			ID:        "f001337a-3450-46fd-b7d2-650c0EXAMPLE",
+			Name:      "batch-changes-test",
+		},
+	}
+	return &Changeset{RemoteRepo: repo, TargetRepo: repo, Changeset: cs}
+}
+
+func testAWSCodeCommitPullRequest() *accbatches.AnnotatedPullRequest {
+	return &accbatches.AnnotatedPullRequest{
+		PullRequest: &awscodecommit.PullRequest{
+			ID:              "1",
+			RevisionID:      "3ad5d33b4d2b5b5f81da4e7f6d7c4f8aa9c1d2e7",
+			Title:           "This is a test PR",
+			Description:     "This is the description of the test PR",
+			Status:          awscodecommit.PullRequestStatusOpen,
+			RepositoryName:  "batch-changes-test",
+			SourceReference: "refs/heads/test-pr",
+			SourceCommit:    "9f1b7c1e4c5fd0a6e0e0b2c4b0f6e0c6e1e2f3a4",
+			DestReference:   "refs/heads/main",
+			DestCommit:      "0f6c3e1b2a4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f",
+		},
+		Region: "us-west-1",
+	}
+}
+
+func getEnvOrDefault(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,340 @@
+package sources
+
+import (
+	"context"
+	"strconv"
+
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// AzureDevOpsSource is a ChangesetSource for Azure DevOps pull requests.
+type AzureDevOpsSource struct {
+	client *azuredevops.Client
+}
+
+var _ DraftChangesetSource = AzureDevOpsSource{}
+
+func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := azuredevops.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Azure DevOps client")
+	}
+
+	return &AzureDevOpsSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s AzureDevOpsSource) GitserverPushConfig(ctx context.Context, store database.ExternalServiceStore, repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(ctx, store, repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s AzureDevOpsSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("AzureDevOpsSource", a)
+	}
+
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureDevOpsSource{client: client}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+//
+// Azure DevOps Server has no API that isn't scoped to a collection to
+// validate credentials against, so they are only validated on Azure DevOps
+// Services.
+func (s AzureDevOpsSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthorizedProfile(ctx)
+	if errors.Is(err, azuredevops.ErrNotSupportedOnServer) {
+		return nil
+	}
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s AzureDevOpsSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	args, err := azureDevOpsRepoArgs(cs)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(cs.ExternalID)
+	if err != nil {
+		return errors.Wrap(err, "parsing pull request ID")
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, args, id)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, args, pr, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s AzureDevOpsSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	return s.createChangeset(ctx, cs, false)
+}
+
+// CreateDraftChangeset creates the Changeset on the source as a draft pull
+// request. If it already exists, *Changeset will be populated and the return
+// value will be true.
+func (s AzureDevOpsSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	exists, err := s.createChangeset(ctx, cs, true)
+	if err != nil {
+		return exists, err
+	}
+
+	// An existing pull request may not be a draft, so we have to update it.
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+	if exists && !pr.IsDraft {
+		draft := true
+		return exists, s.updatePullRequest(ctx, cs, azuredevops.UpdatePullRequestInput{IsDraft: &draft})
+	}
+
+	return exists, nil
+}
+
+func (s AzureDevOpsSource) createChangeset(ctx context.Context, cs *Changeset, draft bool) (bool, error) {
+	args, err := azureDevOpsRepoArgs(cs)
+	if err != nil {
+		return false, err
+	}
+
+	exists := false
+	pr, err := s.client.CreatePullRequest(ctx, args, azuredevops.CreatePullRequestInput{
+		SourceRefName: gitdomain.EnsureRefPrefix(cs.HeadRef),
+		TargetRefName: gitdomain.EnsureRefPrefix(cs.BaseRef),
+		Title:         cs.Title,
+		Description:   cs.Body,
+		IsDraft:       draft,
+	})
+	if err != nil {
+		if !azuredevops.IsConflict(err) {
+			return false, errors.Wrap(err, "creating pull request")
+		}
+
+		// There already is an active pull request for the branches.
+		pr, err = s.findActivePullRequest(ctx, args, cs.HeadRef, cs.BaseRef)
+		if err != nil {
+			return false, errors.Wrap(err, "finding existing pull request")
+		}
+		exists = true
+	}
+
+	return exists, s.setChangesetMetadata(ctx, args, pr, cs)
+}
+
+// UndraftChangeset will update the Changeset on the source to be not in draft
+// mode anymore.
+func (s AzureDevOpsSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	draft := false
+	return s.updatePullRequest(ctx, cs, azuredevops.UpdatePullRequestInput{IsDraft: &draft})
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "abandoned" on
+// Azure DevOps).
+func (s AzureDevOpsSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	args, err := azureDevOpsRepoArgs(cs)
+	if err != nil {
+		return err
+	}
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	updated, err := s.client.AbandonPullRequest(ctx, args, pr.ID)
+	if err != nil {
+		return errors.Wrap(err, "abandoning pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, args, updated, cs)
+}
+
+// UpdateChangeset can update Changesets.
+func (s AzureDevOpsSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	baseRef := gitdomain.EnsureRefPrefix(cs.BaseRef)
+	return s.updatePullRequest(ctx, cs, azuredevops.UpdatePullRequestInput{
+		Title:         &cs.Title,
+		Description:   &cs.Body,
+		TargetRefName: &baseRef,
+	})
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s AzureDevOpsSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+	if pr.Status != azuredevops.PullRequestStatusAbandoned {
+		return nil
+	}
+
+	args, err := azureDevOpsRepoArgs(cs)
+	if err != nil {
+		return err
+	}
+
+	updated, err := s.client.ReactivatePullRequest(ctx, args, pr.ID)
+	if err != nil {
+		return errors.Wrap(err, "reactivating pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, args, updated, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s AzureDevOpsSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	args, err := azureDevOpsRepoArgs(cs)
+	if err != nil {
+		return err
+	}
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	return s.client.CreatePullRequestCommentThread(ctx, args, pr.ID, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, the pull request is squash merged, otherwise a merge
+// commit is created. If the changeset cannot be merged, because it is in an
+// unmergeable state, ChangesetNotMergeableError is returned.
+func (s AzureDevOpsSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	args, err := azureDevOpsRepoArgs(cs)
+	if err != nil {
+		return err
+	}
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	if pr.MergeStatus == azuredevops.PullRequestMergeStatusConflicts {
+		return ChangesetNotMergeableError{ErrorMsg: "pull request has merge conflicts"}
+	}
+
+	strategy := azuredevops.MergeStrategyNoFastForward
+	if squash {
+		strategy = azuredevops.MergeStrategySquash
+	}
+
+	updated, err := s.client.CompletePullRequest(ctx, args, pr.PullRequest, strategy)
+	if err != nil {
+		// Azure DevOps responds with a conflict if the pull request can't be
+		// completed, e.g. because branch policies aren't satisfied.
+		if azuredevops.IsConflict(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "completing pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, args, updated, cs)
+}
+
+func (s AzureDevOpsSource) updatePullRequest(ctx context.Context, cs *Changeset, in azuredevops.UpdatePullRequestInput) error {
+	args, err := azureDevOpsRepoArgs(cs)
+	if err != nil {
+		return err
+	}
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	updated, err := s.client.UpdatePullRequest(ctx, args, pr.ID, in)
+	if err != nil {
+		return errors.Wrap(err, "updating pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, args, updated, cs)
+}
+
+// findActivePullRequest returns the active pull request from headRef into
+// baseRef in the given repository.
+func (s AzureDevOpsSource) findActivePullRequest(ctx context.Context, args azuredevops.OrgProjectRepoArgs, headRef, baseRef string) (*azuredevops.PullRequest, error) {
+	prs, err := s.client.ListPullRequests(ctx, args, azuredevops.PullRequestCriteria{
+		SourceRefName: gitdomain.EnsureRefPrefix(headRef),
+		TargetRefName: gitdomain.EnsureRefPrefix(baseRef),
+		Status:        azuredevops.PullRequestStatusActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(prs) == 0 {
+		return nil, errors.New("no active pull request found")
+	}
+	return prs[0], nil
+}
+
+func (s AzureDevOpsSource) setChangesetMetadata(ctx context.Context, args azuredevops.OrgProjectRepoArgs, pr *azuredevops.PullRequest, cs *Changeset) error {
+	statuses, err := s.client.GetPullRequestStatuses(ctx, args, pr.ID)
+	if err != nil {
+		return errors.Wrap(err, "getting pull request statuses")
+	}
+
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	if err := cs.SetMetadata(&adobatches.AnnotatedPullRequest{
+		PullRequest:      pr,
+		Statuses:         statuses,
+		RepositoryWebURL: repo.WebURL,
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// azureDevOpsRepoArgs returns the arguments identifying the target repository
+// of the changeset in the Azure DevOps API.
+func azureDevOpsRepoArgs(cs *Changeset) (azuredevops.OrgProjectRepoArgs, error) {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	org, err := repo.Org()
+	if err != nil {
+		return azuredevops.OrgProjectRepoArgs{}, err
+	}
+
+	return azuredevops.OrgProjectRepoArgs{
+		Org:          org,
+		Project:      repo.Project.ID,
+		RepoNameOrID: repo.ID,
+	}, nil
+}
@@ -0,0 +1,26 @@
+package azuredevops
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+)
+
+// AnnotatedPullRequest adds metadata we need that lives outside the main
+// PullRequest type returned by the Azure DevOps API alongside the pull
+// request. This type is used as the primary metadata type for Azure DevOps
+// changesets.
+type AnnotatedPullRequest struct {
+	*azuredevops.PullRequest
+	Statuses []*azuredevops.PullRequestBuildStatus
+	// RepositoryWebURL is the link to the repository in the Azure DevOps web
+	// UI, which is required to build links to the pull request, since the API
+	// only returns API URLs.
+	RepositoryWebURL string
+}
+
+// URL returns the link to the pull request in the Azure DevOps web UI.
+func (pr *AnnotatedPullRequest) URL() string {
+	return strings.TrimSuffix(pr.RepositoryWebURL, "/") + "/pullrequest/" + strconv.Itoa(pr.ID)
+}
@@ -0,0 +1,356 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const testAzureDevOpsPullRequestPath = "/sgtest/project-id/_apis/git/repositories/repo-id/pullrequests"
+
+func TestNewAzureDevOpsSource(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for name, input := range map[string]string{
+			"invalid JSON":   "invalid JSON",
+			"invalid schema": `{"token": ["not a string"]}`,
+			"bad URL":        `{"url": "http://[::1]:namedport"}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				ctx := context.Background()
+				s, err := NewAzureDevOpsSource(ctx, &types.ExternalService{
+					Config: extsvc.NewUnencryptedConfig(input),
+				}, nil)
+				assert.Nil(t, s)
+				assert.NotNil(t, err)
+			})
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ctx := context.Background()
+		s, err := NewAzureDevOpsSource(ctx, &types.ExternalService{Config: extsvc.NewEmptyConfig()}, nil)
+		assert.NotNil(t, s)
+		assert.Nil(t, err)
+	})
+}
+
+func TestAzureDevOpsSource_WithAuthenticator(t *testing.T) {
+	s, _ := newTestAzureDevOpsSource(t, http.NotFoundHandler())
+
+	t.Run("unsupported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.OAuthBearerToken{},
+			&auth.OAuthBearerTokenWithSSH{},
+			&auth.OAuthClient{},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, newSource)
+				assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+			})
+		}
+	})
+
+	t.Run("supported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.BasicAuth{Username: "user", Password: "pass"},
+			&auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "pass"}},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				require.Nil(t, err)
+				assert.Equal(t, &auth.BasicAuth{Username: "user", Password: "pass"}, newSource.(*AzureDevOpsSource).client.Authenticator())
+			})
+		}
+	})
+}
+
+func TestAzureDevOpsSource_LoadChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not found", func(t *testing.T) {
+		s, _ := newTestAzureDevOpsSource(t, http.NotFoundHandler())
+		cs := &Changeset{TargetRepo: testAzureDevOpsRepo(), Changeset: &btypes.Changeset{ExternalID: "42"}}
+
+		err := s.LoadChangeset(ctx, cs)
+		assert.ErrorAs(t, err, &ChangesetNotFoundError{})
+	})
+
+	t.Run("found", func(t *testing.T) {
+		s, requests := newTestAzureDevOpsSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case testAzureDevOpsPullRequestPath + "/42":
+				writeAzureDevOpsJSON(t, w, testAzureDevOpsPullRequest(azuredevops.PullRequestStatusActive))
+			case testAzureDevOpsPullRequestPath + "/42/statuses":
+				writeAzureDevOpsJSON(t, w, map[string]any{"value": []*azuredevops.PullRequestBuildStatus{
+					{ID: 1, State: azuredevops.PullRequestStatusStateSucceeded, Context: azuredevops.StatusContext{Name: "build"}},
+				}})
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		cs := &Changeset{TargetRepo: testAzureDevOpsRepo(), Changeset: &btypes.Changeset{ExternalID: "42"}}
+
+		require.Nil(t, s.LoadChangeset(ctx, cs))
+		assert.Len(t, *requests, 2)
+		assert.Equal(t, "42", cs.ExternalID)
+		assert.Equal(t, extsvc.TypeAzureDevOps, cs.ExternalServiceType)
+		assert.Equal(t, "refs/heads/batch/foo", cs.ExternalBranch)
+		assert.Len(t, cs.Metadata.(*adobatches.AnnotatedPullRequest).Statuses, 1)
+
+		title, err := cs.Changeset.Title()
+		require.Nil(t, err)
+		assert.Equal(t, "Fix all the things", title)
+		url, err := cs.Changeset.URL()
+		require.Nil(t, err)
+		assert.Equal(t, "https://dev.azure.com/sgtest/project/_git/repo/pullrequest/42", url)
+	})
+}
+
+func TestAzureDevOpsSource_CreateChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		var input azuredevops.CreatePullRequestInput
+		s, _ := newTestAzureDevOpsSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == "POST" && r.URL.Path == testAzureDevOpsPullRequestPath:
+				require.Nil(t, json.NewDecoder(r.Body).Decode(&input))
+				writeAzureDevOpsJSON(t, w, testAzureDevOpsPullRequest(azuredevops.PullRequestStatusActive))
+			default:
+				writeAzureDevOpsJSON(t, w, map[string]any{"value": []any{}})
+			}
+		}))
+		cs := testAzureDevOpsChangeset()
+
+		exists, err := s.CreateDraftChangeset(ctx, cs)
+		require.Nil(t, err)
+		assert.False(t, exists)
+		assert.Equal(t, azuredevops.CreatePullRequestInput{
+			SourceRefName: "refs/heads/batch/foo",
+			TargetRefName: "refs/heads/main",
+			Title:         "Fix all the things",
+			Description:   "Because they were broken.",
+			IsDraft:       true,
+		}, input)
+		assert.Equal(t, "42", cs.ExternalID)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		s, requests := newTestAzureDevOpsSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == "POST":
+				w.WriteHeader(http.StatusConflict)
+				writeAzureDevOpsJSON(t, w, map[string]string{
+					"typeKey": "GitPullRequestExistsException",
+					"message": "TF401179: An active pull request for the source and target branch already exists.",
+				})
+			case r.URL.Path == testAzureDevOpsPullRequestPath:
+				writeAzureDevOpsJSON(t, w, map[string]any{"value": []*azuredevops.PullRequest{testAzureDevOpsPullRequest(azuredevops.PullRequestStatusActive)}})
+			default:
+				writeAzureDevOpsJSON(t, w, map[string]any{"value": []any{}})
+			}
+		}))
+		cs := testAzureDevOpsChangeset()
+
+		exists, err := s.CreateChangeset(ctx, cs)
+		require.Nil(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, "42", cs.ExternalID)
+
+		query := (*requests)[1].URL.Query()
+		assert.Equal(t, "refs/heads/batch/foo", query.Get("searchCriteria.sourceRefName"))
+		assert.Equal(t, "refs/heads/main", query.Get("searchCriteria.targetRefName"))
+		assert.Equal(t, "active", query.Get("searchCriteria.status"))
+	})
+}
+
+func TestAzureDevOpsSource_CloseAndReopenChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	s, requests := newTestAzureDevOpsSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			var input azuredevops.UpdatePullRequestInput
+			require.Nil(t, json.NewDecoder(r.Body).Decode(&input))
+			writeAzureDevOpsJSON(t, w, testAzureDevOpsPullRequest(*input.Status))
+			return
+		}
+		writeAzureDevOpsJSON(t, w, map[string]any{"value": []any{}})
+	}))
+	cs := testAzureDevOpsChangeset()
+	require.Nil(t, cs.SetMetadata(&adobatches.AnnotatedPullRequest{PullRequest: testAzureDevOpsPullRequest(azuredevops.PullRequestStatusActive)}))
+
+	// Reopening an active pull request is a noop.
+	require.Nil(t, s.ReopenChangeset(ctx, cs))
+	assert.Len(t, *requests, 0)
+
+	require.Nil(t, s.CloseChangeset(ctx, cs))
+	assert.Equal(t, azuredevops.PullRequestStatusAbandoned, cs.Metadata.(*adobatches.AnnotatedPullRequest).Status)
+
+	require.Nil(t, s.ReopenChangeset(ctx, cs))
+	assert.Equal(t, azuredevops.PullRequestStatusActive, cs.Metadata.(*adobatches.AnnotatedPullRequest).Status)
+}
+
+func TestAzureDevOpsSource_UpdateChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	var input azuredevops.UpdatePullRequestInput
+	s, _ := newTestAzureDevOpsSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" && r.URL.Path == testAzureDevOpsPullRequestPath+"/42" {
+			require.Nil(t, json.NewDecoder(r.Body).Decode(&input))
+			writeAzureDevOpsJSON(t, w, testAzureDevOpsPullRequest(azuredevops.PullRequestStatusActive))
+			return
+		}
+		writeAzureDevOpsJSON(t, w, map[string]any{"value": []any{}})
+	}))
+	cs := testAzureDevOpsChangeset()
+	cs.Title = "New title"
+	cs.Body = "New body"
+	require.Nil(t, cs.SetMetadata(&adobatches.AnnotatedPullRequest{PullRequest: testAzureDevOpsPullRequest(azuredevops.PullRequestStatusActive)}))
+
+	require.Nil(t, s.UpdateChangeset(ctx, cs))
+	require.NotNil(t, input.Title)
+	assert.Equal(t, "New title", *input.Title)
+	require.NotNil(t, input.Description)
+	assert.Equal(t, "New body", *input.Description)
+	require.NotNil(t, input.TargetRefName)
+	assert.Equal(t, "refs/heads/main", *input.TargetRefName)
+	assert.Nil(t, input.Status)
+}
+
+func TestAzureDevOpsSource_MergeChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("conflicts", func(t *testing.T) {
+		s, requests := newTestAzureDevOpsSource(t, http.NotFoundHandler())
+		cs := testAzureDevOpsChangeset()
+		pr := testAzureDevOpsPullRequest(azuredevops.PullRequestStatusActive)
+		pr.MergeStatus = azuredevops.PullRequestMergeStatusConflicts
+		require.Nil(t, cs.SetMetadata(&adobatches.AnnotatedPullRequest{PullRequest: pr}))
+
+		err := s.MergeChangeset(ctx, cs, false)
+		assert.ErrorAs(t, err, &ChangesetNotMergeableError{})
+		assert.Len(t, *requests, 0)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		s, _ := newTestAzureDevOpsSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+		cs := testAzureDevOpsChangeset()
+		require.Nil(t, cs.SetMetadata(&adobatches.AnnotatedPullRequest{PullRequest: testAzureDevOpsPullRequest(azuredevops.PullRequestStatusActive)}))
+
+		err := s.MergeChangeset(ctx, cs, false)
+		assert.ErrorAs(t, err, &ChangesetNotMergeableError{})
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var input azuredevops.UpdatePullRequestInput
+		s, _ := newTestAzureDevOpsSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "PATCH" {
+				require.Nil(t, json.NewDecoder(r.Body).Decode(&input))
+				writeAzureDevOpsJSON(t, w, testAzureDevOpsPullRequest(azuredevops.PullRequestStatusCompleted))
+				return
+			}
+			writeAzureDevOpsJSON(t, w, map[string]any{"value": []any{}})
+		}))
+		cs := testAzureDevOpsChangeset()
+		require.Nil(t, cs.SetMetadata(&adobatches.AnnotatedPullRequest{PullRequest: testAzureDevOpsPullRequest(azuredevops.PullRequestStatusActive)}))
+
+		require.Nil(t, s.MergeChangeset(ctx, cs, true))
+		assert.Equal(t, azuredevops.PullRequestStatusCompleted, cs.Metadata.(*adobatches.AnnotatedPullRequest).Status)
+		require.NotNil(t, input.CompletionOptions)
+		assert.Equal(t, azuredevops.MergeStrategySquash, input.CompletionOptions.MergeStrategy)
+		assert.Equal(t, &azuredevops.CommitRef{CommitID: "deadbeef"}, input.LastMergeSourceCommit)
+	})
+}
+
+// newTestAzureDevOpsSource returns an AzureDevOpsSource talking to a test
+// server using the given handler, and a pointer to the requests the server
+// received.
+func newTestAzureDevOpsSource(t *testing.T, handler http.Handler) (*AzureDevOpsSource, *[]*http.Request) {
+	t.Helper()
+
+	var requests []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	svc := &types.ExternalService{
+		Kind:   extsvc.KindAzureDevOps,
+		Config: extsvc.NewUnencryptedConfig(fmt.Sprintf(`{"url": %q, "username": "admin", "token": "secret"}`, srv.URL)),
+	}
+	s, err := NewAzureDevOpsSource(context.Background(), svc, httpcli.NewFactory(nil))
+	require.Nil(t, err)
+
+	return s, &requests
+}
+
+func writeAzureDevOpsJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	require.Nil(t, json.NewEncoder(w).Encode(v))
+}
+
+func testAzureDevOpsRepo() *types.Repo {
+	return &types.Repo{
+		Name: "dev.azure.com/sgtest/project/repo",
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "repo-id",
+			ServiceType: extsvc.TypeAzureDevOps,
+			ServiceID:   "https://dev.azure.com/",
+		},
+		Metadata: &azuredevops.Repository{
+			ID:      "repo-id",
+			Name:    "repo",
+			URL:     "https://dev.azure.com/sgtest/project-id/_apis/git/repositories/repo-id",
+			Project: azuredevops.Project{ID: "project-id", Name: "project"},
+			WebURL:  "https://dev.azure.com/sgtest/project/_git/repo",
+		},
+	}
+}
+
+func testAzureDevOpsChangeset() *Changeset {
+	return &Changeset{
+		Title:      "Fix all the things",
+		Body:       "Because they were broken.",
+		HeadRef:    "refs/heads/batch/foo",
+		BaseRef:    "refs/heads/main",
+		TargetRepo: testAzureDevOpsRepo(),
+		Changeset:  &btypes.Changeset{},
+	}
+}
+
+func testAzureDevOpsPullRequest(status azuredevops.PullRequestStatus) *azuredevops.PullRequest {
+	return &azuredevops.PullRequest{
+		ID:                    42,
+		Status:                status,
+		CreatedBy:             azuredevops.Identity{DisplayName: "Alice", UniqueName: "alice@example.com"},
+		CreationDate:          time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC),
+		Title:                 "Fix all the things",
+		Description:           "Because they were broken.",
+		SourceRefName:         "refs/heads/batch/foo",
+		TargetRefName:         "refs/heads/main",
+		LastMergeSourceCommit: &azuredevops.CommitRef{CommitID: "deadbeef"},
+		LastMergeTargetCommit: &azuredevops.CommitRef{CommitID: "cafebabe"},
+	}
+}
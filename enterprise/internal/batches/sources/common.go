@@ -37,6 +37,17 @@ type ArchivableChangesetSource interface {
 	IsArchivedPushError(output string) bool
 }
 
+// A PushDecoratingChangesetSource needs the commit of a changeset to be
+// modified before it is pushed to the code host, e.g. because the code host
+// requires a trailer in the commit message or a special ref to push to.
+type PushDecoratingChangesetSource interface {
+	ChangesetSource
+
+	// DecoratePushOpts modifies the given request to create and push the
+	// commit for the given changeset spec in the target repo.
+	DecoratePushOpts(targetRepo *types.Repo, spec *btypes.ChangesetSpec, opts *protocol.CreateCommitFromPatchRequest)
+}
+
 // A DraftChangesetSource can create draft changesets and undraft them.
 type DraftChangesetSource interface {
 	ChangesetSource
@@ -0,0 +1,256 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GerritSource is a ChangesetSource for Gerrit. Gerrit doesn't have pull
+// requests: instead, commits are pushed to the magic refs/for/<branch> ref,
+// which creates a new change or a new patch set of an existing change,
+// identified by the Change-Id trailer of the commit message.
+type GerritSource struct {
+	client *gerrit.Client
+}
+
+var _ PushDecoratingChangesetSource = GerritSource{}
+
+func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(ctx context.Context, store database.ExternalServiceStore, repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(ctx, store, repo, s.client.Authenticator())
+}
+
+// DecoratePushOpts adds the Change-Id trailer identifying the changeset to
+// the commit message, and pushes to the magic refs/for/<branch> ref instead
+// of the head ref of the changeset. The head ref is used as the topic of the
+// change, so that we can map changes back to their branch.
+func (s GerritSource) DecoratePushOpts(targetRepo *types.Repo, spec *btypes.ChangesetSpec, opts *protocol.CreateCommitFromPatchRequest) {
+	changeID := gerritChangeID(targetRepo, spec.Spec.HeadRef)
+	if !strings.Contains(opts.CommitInfo.Message, "\nChange-Id: ") {
+		opts.CommitInfo.Message = strings.TrimRight(opts.CommitInfo.Message, "\n") + "\n\nChange-Id: " + changeID
+	}
+
+	pushRef := "refs/for/" + gitdomain.AbbreviateRef(spec.Spec.BaseRef) + "%topic=" + gitdomain.AbbreviateRef(spec.Spec.HeadRef)
+	opts.PushRef = &pushRef
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedUser(ctx)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, gerritProjectName(cs.TargetRepo), cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(change, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	// The change has already been created by pushing the commit, so all we
+	// need to do here is to look it up by its Change-Id.
+	change, err := s.client.GetChange(ctx, gerritProjectName(cs.TargetRepo), gerritChangeID(cs.TargetRepo, cs.HeadRef))
+	if err != nil {
+		return false, errors.Wrap(err, "getting change")
+	}
+
+	if err := s.setChangesetMetadata(change, cs); err != nil {
+		return false, err
+	}
+
+	// A change that has just been created by the push only has the patch set
+	// that was pushed, and its commit message already matches the changeset
+	// spec. If there are more patch sets, the change existed before the push
+	// and may need to be updated.
+	return change.Revisions[change.CurrentRevision].Number > 1, nil
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "abandoned" on
+// Gerrit).
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	if err := s.client.AbandonChange(ctx, change.Project, change.Number, ""); err != nil {
+		return errors.Wrap(err, "abandoning change")
+	}
+
+	return s.reloadChange(ctx, change, cs)
+}
+
+// UpdateChangeset can update Changesets.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	message := cs.Title
+	if cs.Body != "" {
+		message += "\n\n" + cs.Body
+	}
+	message += "\n\nChange-Id: " + change.ChangeID
+
+	if err := s.client.SetCommitMessage(ctx, change.Project, change.Number, message); err != nil {
+		return errors.Wrap(err, "setting commit message")
+	}
+
+	return s.reloadChange(ctx, change, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if change.Status != gerrit.ChangeStatusAbandoned {
+		return nil
+	}
+
+	if err := s.client.RestoreChange(ctx, change.Project, change.Number); err != nil {
+		return errors.Wrap(err, "restoring change")
+	}
+
+	return s.reloadChange(ctx, change, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	return s.client.CreateChangeComment(ctx, change.Project, change.Number, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// Gerrit changes consist of a single commit, so the squash argument is
+// ignored. If the changeset cannot be merged, because it is in an unmergeable
+// state, ChangesetNotMergeableError is returned.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	if err := s.client.SubmitChange(ctx, change.Project, change.Number); err != nil {
+		if gerrit.IsConflict(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "submitting change")
+	}
+
+	return s.reloadChange(ctx, change, cs)
+}
+
+// reloadChange loads the current state of the given change after it has been
+// modified, since the Gerrit API doesn't return the labels and revisions of a
+// change in response to modifications.
+func (s GerritSource) reloadChange(ctx context.Context, change *gerritbatches.AnnotatedChange, cs *Changeset) error {
+	updated, err := s.client.GetChange(ctx, change.Project, strconv.Itoa(change.Number))
+	if err != nil {
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(updated, cs)
+}
+
+func (s GerritSource) setChangesetMetadata(change *gerrit.Change, cs *Changeset) error {
+	if err := cs.SetMetadata(&gerritbatches.AnnotatedChange{
+		Change:      change,
+		CodeHostURL: s.client.URL.String(),
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// gerritProjectName returns the name of the Gerrit project of the given repo.
+// The name is derived from the project ID, because repos synced by older
+// versions don't have the name in their metadata.
+func gerritProjectName(repo *types.Repo) string {
+	name, err := url.PathUnescape(repo.ExternalRepo.ID)
+	if err != nil {
+		return repo.ExternalRepo.ID
+	}
+	return name
+}
+
+// gerritChangeID returns the Change-Id of the change for the given head ref in
+// the given repo. It has to be stable across pushes, so that pushing a new
+// commit creates a new patch set of the same change instead of a new change.
+func gerritChangeID(repo *types.Repo, headRef string) string {
+	sum := sha1.Sum([]byte(repo.ExternalRepo.ID + ":" + gitdomain.EnsureRefPrefix(headRef)))
+	return "I" + hex.EncodeToString(sum[:])
+}
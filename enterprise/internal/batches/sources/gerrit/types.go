@@ -0,0 +1,45 @@
+package gerrit
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+// AnnotatedChange adds metadata we need that lives outside the main Change
+// type returned by the Gerrit API alongside the change. This type is used as
+// the primary metadata type for Gerrit changesets.
+type AnnotatedChange struct {
+	*gerrit.Change
+	// CodeHostURL is the base URL of the Gerrit instance, which is required to
+	// build links to the change.
+	CodeHostURL string
+}
+
+// URL returns the link to the change in the Gerrit web UI.
+func (c *AnnotatedChange) URL() string {
+	return strings.TrimSuffix(c.CodeHostURL, "/") + "/c/" + c.Project + "/+/" + strconv.Itoa(c.Number)
+}
+
+// Description returns the commit message of the current revision of the
+// change without its subject line and Change-Id trailer, which is what other
+// code hosts call the body of a changeset.
+func (c *AnnotatedChange) Description() string {
+	commit := c.CurrentCommit()
+	if commit == nil {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimSpace(commit.Message), "\n")
+	// Skip the subject, which is the first paragraph of the message.
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) != "" {
+		lines = lines[1:]
+	}
+	// Drop the trailing Change-Id trailer, which Gerrit requires in every
+	// commit message.
+	if n := len(lines); n > 0 && strings.HasPrefix(lines[n-1], "Change-Id: ") {
+		lines = lines[:n-1]
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,340 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestNewGerritSource(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for name, input := range map[string]string{
+			"invalid JSON":   "invalid JSON",
+			"invalid schema": `{"password": ["not a string"]}`,
+			"bad URL":        `{"url": "http://[::1]:namedport"}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				ctx := context.Background()
+				s, err := NewGerritSource(ctx, &types.ExternalService{
+					Config: extsvc.NewUnencryptedConfig(input),
+				}, nil)
+				assert.Nil(t, s)
+				assert.NotNil(t, err)
+			})
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ctx := context.Background()
+		s, err := NewGerritSource(ctx, &types.ExternalService{Config: extsvc.NewEmptyConfig()}, nil)
+		assert.NotNil(t, s)
+		assert.Nil(t, err)
+	})
+}
+
+func TestGerritSource_WithAuthenticator(t *testing.T) {
+	s, _ := newTestGerritSource(t, http.NotFoundHandler())
+
+	t.Run("unsupported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.OAuthBearerToken{},
+			&auth.OAuthBearerTokenWithSSH{},
+			&auth.OAuthClient{},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, newSource)
+				assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+			})
+		}
+	})
+
+	t.Run("supported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.BasicAuth{Username: "user", Password: "pass"},
+			&auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "pass"}},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				require.Nil(t, err)
+				assert.Equal(t, &auth.BasicAuth{Username: "user", Password: "pass"}, newSource.(*GerritSource).client.Authenticator())
+			})
+		}
+	})
+}
+
+func TestGerritSource_DecoratePushOpts(t *testing.T) {
+	s, _ := newTestGerritSource(t, http.NotFoundHandler())
+	repo := testGerritRepo()
+	spec := &btypes.ChangesetSpec{Spec: &batcheslib.ChangesetSpec{
+		BaseRef: "refs/heads/main",
+		HeadRef: "refs/heads/batch/foo",
+	}}
+
+	opts := protocol.CreateCommitFromPatchRequest{
+		TargetRef:  "refs/heads/batch/foo",
+		CommitInfo: protocol.PatchCommitInfo{Message: "Fix all the things\n"},
+	}
+	s.DecoratePushOpts(repo, spec, &opts)
+
+	changeID := gerritChangeID(repo, "refs/heads/batch/foo")
+	assert.Equal(t, "Fix all the things\n\nChange-Id: "+changeID, opts.CommitInfo.Message)
+	require.NotNil(t, opts.PushRef)
+	assert.Equal(t, "refs/for/main%topic=batch/foo", *opts.PushRef)
+
+	// Decorating again must not add a second trailer, and the Change-Id must
+	// be stable so that pushes update the same change.
+	s.DecoratePushOpts(repo, spec, &opts)
+	assert.Equal(t, "Fix all the things\n\nChange-Id: "+changeID, opts.CommitInfo.Message)
+}
+
+func TestGerritSource_LoadChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not found", func(t *testing.T) {
+		s, _ := newTestGerritSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeGerritJSON(t, w, []*gerrit.Change{})
+		}))
+		cs := &Changeset{TargetRepo: testGerritRepo(), Changeset: &btypes.Changeset{ExternalID: "42"}}
+
+		err := s.LoadChangeset(ctx, cs)
+		assert.ErrorAs(t, err, &ChangesetNotFoundError{})
+	})
+
+	t.Run("found", func(t *testing.T) {
+		s, requests := newTestGerritSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeGerritJSON(t, w, []*gerrit.Change{testGerritChange(gerrit.ChangeStatusNew)})
+		}))
+		cs := &Changeset{TargetRepo: testGerritRepo(), Changeset: &btypes.Changeset{ExternalID: "42"}}
+
+		require.Nil(t, s.LoadChangeset(ctx, cs))
+		assert.Equal(t, "project:sourcegraph/batches change:42", (*requests)[0].URL.Query().Get("q"))
+		assert.Equal(t, "42", cs.ExternalID)
+		assert.Equal(t, extsvc.TypeGerrit, cs.ExternalServiceType)
+		assert.Equal(t, "refs/heads/batch/foo", cs.ExternalBranch)
+
+		title, err := cs.Changeset.Title()
+		require.Nil(t, err)
+		assert.Equal(t, "Fix all the things", title)
+		body, err := cs.Changeset.Body()
+		require.Nil(t, err)
+		assert.Equal(t, "Because they were broken.", body)
+		url, err := cs.Changeset.URL()
+		require.Nil(t, err)
+		assert.Equal(t, strings.TrimSuffix(s.client.URL.String(), "/")+"/c/sourcegraph/batches/+/42", url)
+	})
+}
+
+func TestGerritSource_CreateChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	for name, tc := range map[string]struct {
+		patchSet   int
+		wantExists bool
+	}{
+		"new change":      {patchSet: 1, wantExists: false},
+		"existing change": {patchSet: 2, wantExists: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			change := testGerritChange(gerrit.ChangeStatusNew)
+			rev := change.Revisions[change.CurrentRevision]
+			rev.Number = tc.patchSet
+			change.Revisions[change.CurrentRevision] = rev
+
+			s, requests := newTestGerritSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				writeGerritJSON(t, w, []*gerrit.Change{change})
+			}))
+			repo := testGerritRepo()
+			cs := &Changeset{
+				HeadRef:    "refs/heads/batch/foo",
+				TargetRepo: repo,
+				Changeset:  &btypes.Changeset{},
+			}
+
+			exists, err := s.CreateChangeset(ctx, cs)
+			require.Nil(t, err)
+			assert.Equal(t, tc.wantExists, exists)
+			assert.Equal(t, "project:sourcegraph/batches change:"+gerritChangeID(repo, "refs/heads/batch/foo"), (*requests)[0].URL.Query().Get("q"))
+			assert.Equal(t, "42", cs.ExternalID)
+		})
+	}
+}
+
+func TestGerritSource_CloseAndReopenChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	status := gerrit.ChangeStatusNew
+	s, requests := newTestGerritSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/a/changes/sourcegraph%2Fbatches~42/abandon":
+			status = gerrit.ChangeStatusAbandoned
+			writeGerritJSON(t, w, testGerritChange(status))
+		case "/a/changes/sourcegraph%2Fbatches~42/restore":
+			status = gerrit.ChangeStatusNew
+			writeGerritJSON(t, w, testGerritChange(status))
+		case "/a/changes/":
+			writeGerritJSON(t, w, []*gerrit.Change{testGerritChange(status)})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	cs := &Changeset{TargetRepo: testGerritRepo(), Changeset: &btypes.Changeset{}}
+	require.Nil(t, cs.SetMetadata(&gerritbatches.AnnotatedChange{Change: testGerritChange(status)}))
+
+	require.Nil(t, s.CloseChangeset(ctx, cs))
+	assert.Equal(t, gerrit.ChangeStatusAbandoned, cs.Metadata.(*gerritbatches.AnnotatedChange).Status)
+	assert.Equal(t, "POST", (*requests)[0].Method)
+
+	require.Nil(t, s.ReopenChangeset(ctx, cs))
+	assert.Equal(t, gerrit.ChangeStatusNew, cs.Metadata.(*gerritbatches.AnnotatedChange).Status)
+
+	// Reopening an open change is a noop.
+	n := len(*requests)
+	require.Nil(t, s.ReopenChangeset(ctx, cs))
+	assert.Len(t, *requests, n)
+}
+
+func TestGerritSource_MergeChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not mergeable", func(t *testing.T) {
+		s, _ := newTestGerritSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = io.WriteString(w, "change is new")
+		}))
+		cs := &Changeset{TargetRepo: testGerritRepo(), Changeset: &btypes.Changeset{}}
+		require.Nil(t, cs.SetMetadata(&gerritbatches.AnnotatedChange{Change: testGerritChange(gerrit.ChangeStatusNew)}))
+
+		err := s.MergeChangeset(ctx, cs, false)
+		assert.ErrorAs(t, err, &ChangesetNotMergeableError{})
+	})
+
+	t.Run("success", func(t *testing.T) {
+		s, _ := newTestGerritSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.EscapedPath() == "/a/changes/sourcegraph%2Fbatches~42/submit" {
+				writeGerritJSON(t, w, testGerritChange(gerrit.ChangeStatusMerged))
+				return
+			}
+			writeGerritJSON(t, w, []*gerrit.Change{testGerritChange(gerrit.ChangeStatusMerged)})
+		}))
+		cs := &Changeset{TargetRepo: testGerritRepo(), Changeset: &btypes.Changeset{}}
+		require.Nil(t, cs.SetMetadata(&gerritbatches.AnnotatedChange{Change: testGerritChange(gerrit.ChangeStatusNew)}))
+
+		require.Nil(t, s.MergeChangeset(ctx, cs, true))
+		assert.Equal(t, gerrit.ChangeStatusMerged, cs.Metadata.(*gerritbatches.AnnotatedChange).Status)
+	})
+}
+
+func TestGerritSource_UpdateChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	var message string
+	s, _ := newTestGerritSource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.EscapedPath() == "/a/changes/sourcegraph%2Fbatches~42/message" {
+			var body struct{ Message string }
+			require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+			message = body.Message
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeGerritJSON(t, w, []*gerrit.Change{testGerritChange(gerrit.ChangeStatusNew)})
+	}))
+	cs := &Changeset{
+		Title:      "New title",
+		Body:       "New body",
+		TargetRepo: testGerritRepo(),
+		Changeset:  &btypes.Changeset{},
+	}
+	require.Nil(t, cs.SetMetadata(&gerritbatches.AnnotatedChange{Change: testGerritChange(gerrit.ChangeStatusNew)}))
+
+	require.Nil(t, s.UpdateChangeset(ctx, cs))
+	assert.Equal(t, "New title\n\nNew body\n\nChange-Id: I0123456789abcdef", message)
+}
+
+// newTestGerritSource returns a GerritSource talking to a test server using the
+// given handler, and a pointer to the requests the server received.
+func newTestGerritSource(t *testing.T, handler http.Handler) (*GerritSource, *[]*http.Request) {
+	t.Helper()
+
+	var requests []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	svc := &types.ExternalService{
+		Kind:   extsvc.KindGerrit,
+		Config: extsvc.NewUnencryptedConfig(fmt.Sprintf(`{"url": %q, "username": "admin", "password": "secret"}`, srv.URL+"/")),
+	}
+	s, err := NewGerritSource(context.Background(), svc, httpcli.NewFactory(nil))
+	require.Nil(t, err)
+
+	return s, &requests
+}
+
+func writeGerritJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+
+	// Gerrit prefixes all JSON responses to prevent XSSI.
+	_, _ = io.WriteString(w, ")]}'\n")
+	require.Nil(t, json.NewEncoder(w).Encode(v))
+}
+
+func testGerritRepo() *types.Repo {
+	return &types.Repo{
+		Name: "gerrit.example.com/sourcegraph/batches",
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "sourcegraph%2Fbatches",
+			ServiceType: extsvc.TypeGerrit,
+			ServiceID:   "https://gerrit.example.com/",
+		},
+		// Repos synced by older versions don't have the project name in their
+		// metadata.
+		Metadata: &gerrit.Project{ID: "sourcegraph%2Fbatches"},
+	}
+}
+
+func testGerritChange(status gerrit.ChangeStatus) *gerrit.Change {
+	return &gerrit.Change{
+		ID:              "sourcegraph%2Fbatches~main~I0123456789abcdef",
+		Project:         "sourcegraph/batches",
+		Branch:          "main",
+		Topic:           "batch/foo",
+		ChangeID:        "I0123456789abcdef",
+		Subject:         "Fix all the things",
+		Status:          status,
+		Number:          42,
+		CurrentRevision: "deadbeef",
+		Revisions: map[string]gerrit.Revision{
+			"deadbeef": {
+				Number: 1,
+				Ref:    "refs/changes/42/42/1",
+				Commit: gerrit.Commit{
+					Parents: []gerrit.CommitParent{{Commit: "cafebabe"}},
+					Subject: "Fix all the things",
+					Message: "Fix all the things\n\nBecause they were broken.\n\nChange-Id: I0123456789abcdef\n",
+				},
+			},
+		},
+	}
+}
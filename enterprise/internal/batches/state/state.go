@@ -11,15 +11,21 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	accbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -113,6 +119,12 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 
 	case *bbcs.AnnotatedPullRequest:
 		return computeBitbucketCloudBuildState(c.UpdatedAt, m, events)
+
+	case *gerritbatches.AnnotatedChange:
+		return computeGerritCheckState(m)
+
+	case *adobatches.AnnotatedPullRequest:
+		return computeAzureDevOpsCheckState(m)
 	}
 
 	return btypes.ChangesetCheckStateUnknown
@@ -252,6 +264,58 @@ func parseBitbucketCloudBuildState(s bitbucketcloud.PullRequestStatusState) btyp
 	}
 }
 
+// computeGerritCheckState maps the votes on the Verified label, which is
+// where CI systems report their results on Gerrit, to a check state.
+func computeGerritCheckState(c *gerritbatches.AnnotatedChange) btypes.ChangesetCheckState {
+	label, ok := c.Labels[gerrit.LabelVerified]
+	if !ok {
+		return btypes.ChangesetCheckStateUnknown
+	}
+
+	switch {
+	case label.Rejected != nil || label.Disliked != nil:
+		return btypes.ChangesetCheckStateFailed
+	case label.Approved != nil || label.Recommended != nil:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStatePending
+	}
+}
+
+// computeAzureDevOpsCheckState combines the latest status of each context
+// posted to the pull request.
+func computeAzureDevOpsCheckState(pr *adobatches.AnnotatedPullRequest) btypes.ChangesetCheckState {
+	latest := make(map[azuredevops.StatusContext]*azuredevops.PullRequestBuildStatus)
+	for _, status := range pr.Statuses {
+		if l, ok := latest[status.Context]; !ok || l.ID < status.ID {
+			latest[status.Context] = status
+		}
+	}
+
+	states := make([]btypes.ChangesetCheckState, 0, len(latest))
+	for _, status := range latest {
+		if status.State == azuredevops.PullRequestStatusStateNotApplicable {
+			continue
+		}
+		states = append(states, parseAzureDevOpsStatusState(status.State))
+	}
+
+	return combineCheckStates(states)
+}
+
+func parseAzureDevOpsStatusState(s azuredevops.PullRequestStatusState) btypes.ChangesetCheckState {
+	switch s {
+	case azuredevops.PullRequestStatusStateFailed, azuredevops.PullRequestStatusStateError:
+		return btypes.ChangesetCheckStateFailed
+	case azuredevops.PullRequestStatusStatePending, azuredevops.PullRequestStatusStateNotSet:
+		return btypes.ChangesetCheckStatePending
+	case azuredevops.PullRequestStatusStateSucceeded:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStateUnknown
+	}
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -516,6 +580,49 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
 		}
+	case *gerritbatches.AnnotatedChange:
+		switch m.Status {
+		case gerrit.ChangeStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case gerrit.ChangeStatusMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case gerrit.ChangeStatusNew:
+			if m.WorkInProgress {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gerrit change status: %s", m.Status)
+		}
+	case *accbatches.AnnotatedPullRequest:
+		switch m.Status {
+		case awscodecommit.PullRequestStatusClosed:
+			if m.IsMerged {
+				s = btypes.ChangesetExternalStateMerged
+			} else {
+				s = btypes.ChangesetExternalStateClosed
+			}
+		case awscodecommit.PullRequestStatusOpen:
+			s = btypes.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown AWS CodeCommit pull request status: %s", m.Status)
+		}
+	case *adobatches.AnnotatedPullRequest:
+		switch m.Status {
+		case azuredevops.PullRequestStatusActive:
+			if m.IsDraft {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		case azuredevops.PullRequestStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case azuredevops.PullRequestStatusCompleted:
+			s = btypes.ChangesetExternalStateMerged
+		default:
+			return "", errors.Errorf("unknown Azure DevOps pull request status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -585,6 +692,43 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 		}
 
+	case *gerritbatches.AnnotatedChange:
+		// Gerrit reviews are votes on the Code-Review label: any negative vote
+		// means that changes were requested, and the maximum positive vote
+		// approves the change. Everything else is still pending.
+		label, ok := m.Labels[gerrit.LabelCodeReview]
+		switch {
+		case !ok:
+			states[btypes.ChangesetReviewStatePending] = true
+		case label.Rejected != nil || label.Disliked != nil:
+			states[btypes.ChangesetReviewStateChangesRequested] = true
+		case label.Approved != nil:
+			states[btypes.ChangesetReviewStateApproved] = true
+		default:
+			states[btypes.ChangesetReviewStatePending] = true
+		}
+
+	case *accbatches.AnnotatedPullRequest:
+		// AWS CodeCommit has no way to request changes: reviewers can only
+		// approve a pull request or revoke their approval. If approval rules
+		// apply to the pull request, it's approved once all of them are
+		// satisfied (or overridden), otherwise a single approval is enough.
+		states[computeAWSCodeCommitReviewState(m)] = true
+
+	case *adobatches.AnnotatedPullRequest:
+		// Azure DevOps reviewers vote on a scale from rejected to approved;
+		// waiting for the author is a request for changes, too.
+		for _, r := range m.Reviewers {
+			switch {
+			case r.Vote >= azuredevops.VoteApprovedWithSuggestions:
+				states[btypes.ChangesetReviewStateApproved] = true
+			case r.Vote <= azuredevops.VoteWaitingForAuthor:
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			default:
+				states[btypes.ChangesetReviewStatePending] = true
+			}
+		}
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -592,6 +736,28 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 	return selectReviewState(states), nil
 }
 
+// computeAWSCodeCommitReviewState computes the review state of an AWS
+// CodeCommit pull request from its approvals and approval rule evaluation.
+func computeAWSCodeCommitReviewState(pr *accbatches.AnnotatedPullRequest) btypes.ChangesetReviewState {
+	if pr.Approvals == nil {
+		return btypes.ChangesetReviewStatePending
+	}
+
+	if pr.HasApprovalRules {
+		if pr.Approvals.Approved || pr.Approvals.Overridden {
+			return btypes.ChangesetReviewStateApproved
+		}
+		return btypes.ChangesetReviewStatePending
+	}
+
+	for _, a := range pr.Approvals.Approvals {
+		if a.State == awscodecommit.ApprovalStateApprove {
+			return btypes.ChangesetReviewStateApproved
+		}
+	}
+	return btypes.ChangesetReviewStatePending
+}
+
 // selectReviewState computes the single review state for a given set of
 // ChangesetReviewStates. Since a pull request, for example, can have multiple
 // reviews with different states, we need a function to determine what the
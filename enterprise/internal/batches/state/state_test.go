@@ -7,9 +7,15 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	accbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -407,6 +413,85 @@ func TestComputeGitLabCheckState(t *testing.T) {
 	})
 }
 
+func TestComputeGerritCheckState(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		labels map[string]gerrit.LabelInfo
+		want   btypes.ChangesetCheckState
+	}{
+		"no verified label": {
+			labels: map[string]gerrit.LabelInfo{gerrit.LabelCodeReview: {}},
+			want:   btypes.ChangesetCheckStateUnknown,
+		},
+		"no votes": {
+			labels: map[string]gerrit.LabelInfo{gerrit.LabelVerified: {}},
+			want:   btypes.ChangesetCheckStatePending,
+		},
+		"verified": {
+			labels: map[string]gerrit.LabelInfo{gerrit.LabelVerified: {Approved: &gerrit.Account{}}},
+			want:   btypes.ChangesetCheckStatePassed,
+		},
+		"rejected": {
+			labels: map[string]gerrit.LabelInfo{gerrit.LabelVerified: {Approved: &gerrit.Account{}, Rejected: &gerrit.Account{}}},
+			want:   btypes.ChangesetCheckStateFailed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := gerritChangeset(time.Now(), gerrit.ChangeStatusNew, tc.labels)
+			have := computeGerritCheckState(c.Metadata.(*gerritbatches.AnnotatedChange))
+			if have != tc.want {
+				t.Errorf("unexpected check state: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestComputeAzureDevOpsCheckState(t *testing.T) {
+	t.Parallel()
+
+	build := azuredevops.StatusContext{Name: "build"}
+	lint := azuredevops.StatusContext{Name: "lint"}
+
+	for name, tc := range map[string]struct {
+		statuses []*azuredevops.PullRequestBuildStatus
+		want     btypes.ChangesetCheckState
+	}{
+		"no statuses": {
+			want: btypes.ChangesetCheckStateUnknown,
+		},
+		"pending": {
+			statuses: []*azuredevops.PullRequestBuildStatus{
+				{ID: 1, Context: build, State: azuredevops.PullRequestStatusStateSucceeded},
+				{ID: 2, Context: lint, State: azuredevops.PullRequestStatusStatePending},
+			},
+			want: btypes.ChangesetCheckStatePending,
+		},
+		"failed": {
+			statuses: []*azuredevops.PullRequestBuildStatus{
+				{ID: 1, Context: build, State: azuredevops.PullRequestStatusStateError},
+				{ID: 2, Context: lint, State: azuredevops.PullRequestStatusStateSucceeded},
+			},
+			want: btypes.ChangesetCheckStateFailed,
+		},
+		"latest status per context wins": {
+			statuses: []*azuredevops.PullRequestBuildStatus{
+				{ID: 3, Context: build, State: azuredevops.PullRequestStatusStateSucceeded},
+				{ID: 1, Context: build, State: azuredevops.PullRequestStatusStateFailed},
+				{ID: 2, Context: lint, State: azuredevops.PullRequestStatusStateNotApplicable},
+			},
+			want: btypes.ChangesetCheckStatePassed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have := computeAzureDevOpsCheckState(&adobatches.AnnotatedPullRequest{Statuses: tc.statuses})
+			if have != tc.want {
+				t.Errorf("unexpected check state: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestComputeReviewState(t *testing.T) {
 	t.Parallel()
 
@@ -536,6 +621,93 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gerrit - no votes",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - recommended",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				gerrit.LabelCodeReview: {Recommended: &gerrit.Account{}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - approved",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				gerrit.LabelCodeReview: {Approved: &gerrit.Account{}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "gerrit - approved and disliked",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				gerrit.LabelCodeReview: {Approved: &gerrit.Account{}, Disliked: &gerrit.Account{}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name:      "aws codecommit - no approvals",
+			changeset: awsCodeCommitChangeset(daysAgo(0), awscodecommit.PullRequestStatusOpen, false, &awscodecommit.PullRequestApprovals{}),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "aws codecommit - approved without rules",
+			changeset: awsCodeCommitChangeset(daysAgo(0), awscodecommit.PullRequestStatusOpen, false, &awscodecommit.PullRequestApprovals{
+				Approvals: []awscodecommit.Approval{{State: awscodecommit.ApprovalStateApprove}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "aws codecommit - approved, but rules not satisfied",
+			changeset: func() *btypes.Changeset {
+				c := awsCodeCommitChangeset(daysAgo(0), awscodecommit.PullRequestStatusOpen, false, &awscodecommit.PullRequestApprovals{
+					RulesNotSatisfied: []string{"two-approvals"},
+					Approvals:         []awscodecommit.Approval{{State: awscodecommit.ApprovalStateApprove}},
+				})
+				c.Metadata.(*accbatches.AnnotatedPullRequest).HasApprovalRules = true
+				return c
+			}(),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "aws codecommit - rules overridden",
+			changeset: func() *btypes.Changeset {
+				c := awsCodeCommitChangeset(daysAgo(0), awscodecommit.PullRequestStatusOpen, false, &awscodecommit.PullRequestApprovals{
+					Overridden: true,
+				})
+				c.Metadata.(*accbatches.AnnotatedPullRequest).HasApprovalRules = true
+				return c
+			}(),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name:      "azure devops - no reviewers",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name:      "azure devops - approved with suggestions",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive, false, azuredevops.VoteNoVote, azuredevops.VoteApprovedWithSuggestions),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name:      "azure devops - approved and waiting for author",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive, false, azuredevops.VoteApproved, azuredevops.VoteWaitingForAuthor),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStateChangesRequested,
+		},
 	}
 
 	for i, tc := range tests {
@@ -744,6 +916,76 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateReadOnly,
 		},
+		{
+			name:      "gerrit - no events, new",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusNew, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "gerrit - no events, abandoned",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusAbandoned, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "gerrit - no events, merged",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusMerged, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
+		{
+			name: "gerrit - no events, work in progress",
+			changeset: func() *btypes.Changeset {
+				c := gerritChangeset(daysAgo(10), gerrit.ChangeStatusNew, nil)
+				c.Metadata.(*gerritbatches.AnnotatedChange).WorkInProgress = true
+				return c
+			}(),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "aws codecommit - no events, open",
+			changeset: awsCodeCommitChangeset(daysAgo(10), awscodecommit.PullRequestStatusOpen, false, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "aws codecommit - no events, closed",
+			changeset: awsCodeCommitChangeset(daysAgo(10), awscodecommit.PullRequestStatusClosed, false, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "aws codecommit - no events, merged",
+			changeset: awsCodeCommitChangeset(daysAgo(10), awscodecommit.PullRequestStatusClosed, true, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
+		{
+			name:      "azure devops - no events, active",
+			changeset: azureDevOpsChangeset(daysAgo(10), azuredevops.PullRequestStatusActive, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "azure devops - no events, draft",
+			changeset: azureDevOpsChangeset(daysAgo(10), azuredevops.PullRequestStatusActive, true),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "azure devops - no events, abandoned",
+			changeset: azureDevOpsChangeset(daysAgo(10), azuredevops.PullRequestStatusAbandoned, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "azure devops - no events, completed",
+			changeset: azureDevOpsChangeset(daysAgo(10), azuredevops.PullRequestStatusCompleted, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
 	}
 
 	for i, tc := range tests {
@@ -900,6 +1142,51 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func gerritChangeset(updatedAt time.Time, status gerrit.ChangeStatus, labels map[string]gerrit.LabelInfo) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGerrit,
+		UpdatedAt:           updatedAt,
+		Metadata: &gerritbatches.AnnotatedChange{
+			Change: &gerrit.Change{
+				Status: status,
+				Labels: labels,
+			},
+		},
+	}
+}
+
+func awsCodeCommitChangeset(updatedAt time.Time, status awscodecommit.PullRequestStatus, merged bool, approvals *awscodecommit.PullRequestApprovals) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeAWSCodeCommit,
+		UpdatedAt:           updatedAt,
+		Metadata: &accbatches.AnnotatedPullRequest{
+			PullRequest: &awscodecommit.PullRequest{
+				Status:   status,
+				IsMerged: merged,
+			},
+			Approvals: approvals,
+		},
+	}
+}
+
+func azureDevOpsChangeset(updatedAt time.Time, status azuredevops.PullRequestStatus, draft bool, votes ...int) *btypes.Changeset {
+	var reviewers []azuredevops.Reviewer
+	for _, v := range votes {
+		reviewers = append(reviewers, azuredevops.Reviewer{Vote: v})
+	}
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeAzureDevOps,
+		UpdatedAt:           updatedAt,
+		Metadata: &adobatches.AnnotatedPullRequest{
+			PullRequest: &azuredevops.PullRequest{
+				Status:    status,
+				IsDraft:   draft,
+				Reviewers: reviewers,
+			},
+		},
+	}
+}
+
 func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
@@ -15,7 +15,10 @@ import (
 	"github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/search"
+	accbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -1181,6 +1184,12 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		t.Metadata = new(gitlab.MergeRequest)
 	case extsvc.TypeBitbucketCloud:
 		t.Metadata = new(bbcs.AnnotatedPullRequest)
+	case extsvc.TypeGerrit:
+		t.Metadata = new(gerritbatches.AnnotatedChange)
+	case extsvc.TypeAWSCodeCommit:
+		t.Metadata = new(accbatches.AnnotatedPullRequest)
+	case extsvc.TypeAzureDevOps:
+		t.Metadata = new(adobatches.AnnotatedPullRequest)
 	default:
 		return errors.New("unknown external service type")
 	}
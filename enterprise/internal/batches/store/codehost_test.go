@@ -34,7 +34,7 @@ func testStoreCodeHost(t *testing.T, ctx context.Context, s *Store, clock bt.Clo
 
 	gitlabRepo := bt.TestRepo(t, es, extsvc.KindGitLab)
 	bitbucketRepo := bt.TestRepo(t, es, extsvc.KindBitbucketServer)
-	awsRepo := bt.TestRepo(t, es, extsvc.KindAWSCodeCommit)
+	gitoliteRepo := bt.TestRepo(t, es, extsvc.KindGitolite)
 
 	// Enable webhooks on GitHub only.
 	rawConfig, err := ghExtSvc.Configuration(ctx)
@@ -52,7 +52,7 @@ func testStoreCodeHost(t *testing.T, ctx context.Context, s *Store, clock bt.Clo
 	ghExtSvc.Config.Set(string(marshalledConfig))
 	es.Upsert(ctx, ghExtSvc)
 
-	if err := rs.Create(ctx, repo, otherRepo, gitlabRepo, bitbucketRepo, awsRepo); err != nil {
+	if err := rs.Create(ctx, repo, otherRepo, gitlabRepo, bitbucketRepo, gitoliteRepo); err != nil {
 		t.Fatal(err)
 	}
 	deletedRepo := otherRepo.With(typestest.Opt.RepoDeletedAt(clock.Now()))
@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -294,3 +295,40 @@ func CreateAWSCodeCommitTestRepos(t *testing.T, ctx context.Context, db database
 
 	return rs, ext
 }
+
+func CreateGitoliteTestRepos(t *testing.T, ctx context.Context, db database.DB, count int) ([]*types.Repo, *types.ExternalService) {
+	t.Helper()
+
+	repoStore := db.Repos()
+	esStore := db.ExternalServices()
+
+	ext := &types.ExternalService{
+		Kind:        extsvc.KindGitolite,
+		DisplayName: "Gitolite",
+		Config: extsvc.NewUnencryptedConfig(MarshalJSON(t, &schema.GitoliteConnection{
+			Host:   "git@gitolite.horse-town.com",
+			Prefix: "gitolite.horse-town.com/",
+		})),
+	}
+	if err := esStore.Upsert(ctx, ext); err != nil {
+		t.Fatal(err)
+	}
+
+	var rs []*types.Repo
+	for i := 0; i < count; i++ {
+		r := TestRepoWithService(t, esStore, fmt.Sprintf("repo-%d-%d", ext.ID, i+1), ext)
+		r.Metadata = &gitolite.Repo{
+			Name: string(r.Name),
+			URL:  fmt.Sprintf("git@gitolite.horse-town.com:%s", r.Name),
+		}
+
+		rs = append(rs, r)
+	}
+
+	err := repoStore.Create(ctx, rs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rs, ext
+}
@@ -8,7 +8,10 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/go-diff/diff"
 
+	accbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
@@ -426,6 +429,36 @@ func (c *Changeset) SetMetadata(meta any) error {
 		} else {
 			c.ExternalForkNamespace = ""
 		}
+	case *gerritbatches.AnnotatedChange:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.Number)
+		c.ExternalServiceType = extsvc.TypeGerrit
+		// Gerrit changes aren't created from branches, but we push them with
+		// the head ref of the changeset as their topic.
+		if pr.Topic != "" {
+			c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Topic)
+		}
+		c.ExternalUpdatedAt = pr.Updated.Time
+		c.ExternalForkNamespace = ""
+	case *accbatches.AnnotatedPullRequest:
+		c.Metadata = pr
+		c.ExternalID = pr.ID
+		c.ExternalServiceType = extsvc.TypeAWSCodeCommit
+		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.SourceReference)
+		c.ExternalUpdatedAt = pr.LastActivityDate
+		c.ExternalForkNamespace = ""
+	case *adobatches.AnnotatedPullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.ID)
+		c.ExternalServiceType = extsvc.TypeAzureDevOps
+		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.SourceRefName)
+		// Azure DevOps pull requests have no update timestamp.
+		if !pr.ClosedDate.IsZero() {
+			c.ExternalUpdatedAt = pr.ClosedDate
+		} else {
+			c.ExternalUpdatedAt = pr.CreationDate
+		}
+		c.ExternalForkNamespace = ""
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -453,6 +486,12 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Title, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Subject, nil
+	case *accbatches.AnnotatedPullRequest:
+		return m.Title, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.Title, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -472,6 +511,12 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.Username, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Author.Username, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Username, nil
+	case *accbatches.AnnotatedPullRequest:
+		return m.AuthorName(), nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.CreatedBy.UniqueName, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -499,6 +544,18 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		// Bitbucket Cloud does not provide the e-mail of the author under any
 		// circumstances.
 		return "", nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Email, nil
+	case *accbatches.AnnotatedPullRequest:
+		// AWS CodeCommit only provides the ARN of the author.
+		return "", nil
+	case *adobatches.AnnotatedPullRequest:
+		// The unique name of Azure DevOps Services users is their email
+		// address, but it is a domain account name on Azure DevOps Server.
+		if strings.Contains(m.CreatedBy.UniqueName, "@") {
+			return m.CreatedBy.UniqueName, nil
+		}
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -517,6 +574,12 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt.Time
 	case *bbcs.AnnotatedPullRequest:
 		return m.CreatedOn
+	case *gerritbatches.AnnotatedChange:
+		return m.Created.Time
+	case *accbatches.AnnotatedPullRequest:
+		return m.CreationDate
+	case *adobatches.AnnotatedPullRequest:
+		return m.CreationDate
 	default:
 		return time.Time{}
 	}
@@ -533,6 +596,12 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Rendered.Description.Raw, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Description(), nil
+	case *accbatches.AnnotatedPullRequest:
+		return m.Description, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.Description, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -578,6 +647,12 @@ func (c *Changeset) URL() (s string, err error) {
 		// pull request ID, but since the link _should_ be there, we'll error
 		// instead.
 		return "", errors.New("Bitbucket Cloud pull request does not have a html link")
+	case *gerritbatches.AnnotatedChange:
+		return m.URL(), nil
+	case *accbatches.AnnotatedPullRequest:
+		return m.URL(), nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.URL(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -750,6 +825,20 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 				Metadata:    status,
 			})
 		}
+
+	case *gerritbatches.AnnotatedChange:
+		// Gerrit has no webhooks we consume, and review and CI votes are
+		// stored as labels on the change itself, so the state of a Gerrit
+		// changeset is always computed from the last synced change and there
+		// are no events to extract.
+
+	case *accbatches.AnnotatedPullRequest:
+		// The same applies to AWS CodeCommit: approvals are synced alongside
+		// the pull request, and there are no CI statuses on pull requests.
+
+	case *adobatches.AnnotatedPullRequest:
+		// Azure DevOps service hooks aren't supported yet, so votes and
+		// statuses are only synced alongside the pull request.
 	}
 	return events, nil
 }
@@ -767,6 +856,15 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.DiffRefs.HeadSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Source.Commit.Hash, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.CurrentRevision, nil
+	case *accbatches.AnnotatedPullRequest:
+		return m.SourceCommit, nil
+	case *adobatches.AnnotatedPullRequest:
+		if m.LastMergeSourceCommit != nil {
+			return m.LastMergeSourceCommit.CommitID, nil
+		}
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -784,6 +882,15 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.SourceBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Source.Branch.Name, nil
+	case *gerritbatches.AnnotatedChange:
+		if rev, ok := m.Revisions[m.CurrentRevision]; ok {
+			return rev.Ref, nil
+		}
+		return "", nil
+	case *accbatches.AnnotatedPullRequest:
+		return gitdomain.EnsureRefPrefix(m.SourceReference), nil
+	case *adobatches.AnnotatedPullRequest:
+		return gitdomain.EnsureRefPrefix(m.SourceRefName), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -802,6 +909,18 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.DiffRefs.BaseSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Destination.Commit.Hash, nil
+	case *gerritbatches.AnnotatedChange:
+		if commit := m.CurrentCommit(); commit != nil && len(commit.Parents) > 0 {
+			return commit.Parents[0].Commit, nil
+		}
+		return "", nil
+	case *accbatches.AnnotatedPullRequest:
+		return m.DestCommit, nil
+	case *adobatches.AnnotatedPullRequest:
+		if m.LastMergeTargetCommit != nil {
+			return m.LastMergeTargetCommit.CommitID, nil
+		}
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -819,6 +938,12 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.TargetBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Destination.Branch.Name, nil
+	case *gerritbatches.AnnotatedChange:
+		return "refs/heads/" + m.Branch, nil
+	case *accbatches.AnnotatedPullRequest:
+		return gitdomain.EnsureRefPrefix(m.DestReference), nil
+	case *adobatches.AnnotatedPullRequest:
+		return gitdomain.EnsureRefPrefix(m.TargetRefName), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
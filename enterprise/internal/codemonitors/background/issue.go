@@ -0,0 +1,248 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// issueClient opens and comments on issues of a single repository on its
+// code host.
+type issueClient interface {
+	// IsIssueOpen returns whether the issue with the given number is open.
+	IsIssueOpen(ctx context.Context, number int64) (bool, error)
+	// CreateIssue opens a new issue and returns its number.
+	CreateIssue(ctx context.Context, title, body string) (int64, error)
+	CommentOnIssue(ctx context.Context, number int64, body string) error
+}
+
+// openOrCommentOnIssue adds a comment with the given body to the issue with
+// the given number if it is still open. Otherwise, it opens a new issue. It
+// returns the number of the issue that was commented on or opened.
+//
+// This keeps the events of a monitor in one issue, instead of opening a new
+// issue every time the monitor is triggered.
+func openOrCommentOnIssue(ctx context.Context, client issueClient, number *int64, title, body string) (int64, error) {
+	if number != nil {
+		open, err := client.IsIssueOpen(ctx, *number)
+		if err != nil {
+			return 0, errors.Wrap(err, "IsIssueOpen")
+		}
+		if open {
+			return *number, errors.Wrap(client.CommentOnIssue(ctx, *number, body), "CommentOnIssue")
+		}
+	}
+
+	n, err := client.CreateIssue(ctx, title, body)
+	return n, errors.Wrap(err, "CreateIssue")
+}
+
+func issueTitle(args actionArgs) string {
+	return fmt.Sprintf("Sourcegraph code monitor: %s", args.MonitorDescription)
+}
+
+// issueBody renders the markdown body of an issue or issue comment for a code
+// monitor event. It ends with a marker that identifies the code monitor.
+func issueBody(args actionArgs) string {
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	searchURL := getSearchURL(args.ExternalURL, args.Query, args.UTMSource)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s's Sourcegraph code monitor, **%s**, detected **%d** new matches.\n\n",
+		args.MonitorOwnerName,
+		args.MonitorDescription,
+		totalCount,
+	)
+
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			resultType, lang, content := "Message", "", ""
+			if result.DiffPreview != nil {
+				resultType, lang, content = "Diff", "diff", result.DiffPreview.Content
+			} else {
+				content = result.MessagePreview.Content
+			}
+			fmt.Fprintf(&b, "%s match: [%s@%s](%s)\n\n",
+				resultType,
+				result.Repo.Name,
+				result.Commit.ID.Short(),
+				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
+			)
+			b.WriteString(markdownCodeBlock(lang, truncateString(content, 10)))
+			b.WriteString("\n")
+		}
+		if truncatedCount > 0 {
+			fmt.Fprintf(&b, "...and [%d more matches](%s).\n\n", truncatedCount, searchURL)
+		}
+	} else {
+		fmt.Fprintf(&b, "[View results](%s)\n\n", searchURL)
+	}
+
+	fmt.Fprintf(&b, "If you are %s, you can [edit your code monitor](%s).\n\n",
+		args.MonitorOwnerName,
+		getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+	)
+	fmt.Fprintf(&b, "<!-- sourcegraph-code-monitor: %d -->\n", args.MonitorID)
+	return b.String()
+}
+
+// markdownCodeBlock fences s with more backticks than it contains in a row,
+// so that s cannot end the code block.
+func markdownCodeBlock(lang, s string) string {
+	fence, run := "```", 0
+	for _, c := range s {
+		if c != '`' {
+			run = 0
+			continue
+		}
+		if run++; run >= len(fence) {
+			fence += "`"
+		}
+	}
+	return fmt.Sprintf("%s%s\n%s\n%s\n", fence, lang, strings.TrimSuffix(s, "\n"), fence)
+}
+
+// newIssueClient returns an issueClient for the given repository. It uses the
+// token of one of the GitHub or GitLab external services that the repository
+// is synced by.
+func newIssueClient(ctx context.Context, db database.DB, repo *types.Repo) (issueClient, error) {
+	var errs error
+	for urn := range repo.Sources {
+		_, id := extsvc.DecodeURN(urn)
+		svc, err := db.ExternalServices().GetByID(ctx, id)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		client, err := newIssueClientForExternalService(ctx, svc, repo)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		return client, nil
+	}
+	if errs == nil {
+		errs = errors.Newf("repository %s has no external service", repo.Name)
+	}
+	return nil, errs
+}
+
+func newIssueClientForExternalService(ctx context.Context, svc *types.ExternalService, repo *types.Repo) (issueClient, error) {
+	parsed, err := extsvc.ParseEncryptableConfig(ctx, svc.Kind, svc.Config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	switch c := parsed.(type) {
+	case *schema.GitHubConnection:
+		metadata, ok := repo.Metadata.(*github.Repository)
+		if !ok {
+			return nil, errors.Newf("unexpected metadata %T for GitHub repository %s", repo.Metadata, repo.Name)
+		}
+		owner, name, err := github.SplitRepositoryNameWithOwner(metadata.NameWithOwner)
+		if err != nil {
+			return nil, err
+		}
+		if c.Token == "" {
+			return nil, errors.Newf("external service id=%d has no token", svc.ID)
+		}
+		baseURL, err := url.Parse(c.Url)
+		if err != nil {
+			return nil, err
+		}
+		apiURL, _ := github.APIRoot(extsvc.NormalizeBaseURL(baseURL))
+		return &githubIssueClient{
+			client: github.NewV3Client(log.Scoped("codeMonitorIssue", "GitHub client for code monitor issue actions"), svc.URN(), apiURL, &auth.OAuthBearerToken{Token: c.Token}, httpcli.ExternalDoer),
+			owner:  owner,
+			name:   name,
+		}, nil
+
+	case *schema.GitLabConnection:
+		project, ok := repo.Metadata.(*gitlab.Project)
+		if !ok {
+			return nil, errors.Newf("unexpected metadata %T for GitLab repository %s", repo.Metadata, repo.Name)
+		}
+		if c.Token == "" {
+			return nil, errors.Newf("external service id=%d has no token", svc.ID)
+		}
+		baseURL, err := url.Parse(c.Url)
+		if err != nil {
+			return nil, err
+		}
+		var a auth.Authenticator = &gitlab.SudoableToken{Token: c.Token}
+		if c.TokenType == "oauth" {
+			a = &auth.OAuthBearerToken{Token: c.Token}
+		}
+		provider := gitlab.NewClientProvider(svc.URN(), extsvc.NormalizeBaseURL(baseURL), httpcli.ExternalDoer, nil)
+		return &gitlabIssueClient{
+			client:  provider.GetAuthenticatorClient(a),
+			project: project,
+		}, nil
+
+	default:
+		return nil, errors.Newf("issues can only be opened on GitHub and GitLab, not %s", svc.Kind)
+	}
+}
+
+type githubIssueClient struct {
+	client      *github.V3Client
+	owner, name string
+}
+
+func (c *githubIssueClient) IsIssueOpen(ctx context.Context, number int64) (bool, error) {
+	issue, err := c.client.GetIssue(ctx, c.owner, c.name, number)
+	if err != nil {
+		return false, err
+	}
+	return issue.State == "open", nil
+}
+
+func (c *githubIssueClient) CreateIssue(ctx context.Context, title, body string) (int64, error) {
+	issue, err := c.client.CreateIssue(ctx, c.owner, c.name, &github.CreateIssueInput{Title: title, Body: body})
+	if err != nil {
+		return 0, err
+	}
+	return issue.Number, nil
+}
+
+func (c *githubIssueClient) CommentOnIssue(ctx context.Context, number int64, body string) error {
+	return c.client.CreateIssueComment(ctx, c.owner, c.name, number, body)
+}
+
+type gitlabIssueClient struct {
+	client  *gitlab.Client
+	project *gitlab.Project
+}
+
+func (c *gitlabIssueClient) IsIssueOpen(ctx context.Context, number int64) (bool, error) {
+	issue, err := c.client.GetIssue(ctx, c.project, gitlab.ID(number))
+	if err != nil {
+		return false, err
+	}
+	return issue.State == gitlab.IssueStateOpened, nil
+}
+
+func (c *gitlabIssueClient) CreateIssue(ctx context.Context, title, body string) (int64, error) {
+	issue, err := c.client.CreateIssue(ctx, c.project, gitlab.CreateIssueOpts{Title: title, Description: body})
+	if err != nil {
+		return 0, err
+	}
+	return int64(issue.IID), nil
+}
+
+func (c *gitlabIssueClient) CommentOnIssue(ctx context.Context, number int64, body string) error {
+	return c.client.CreateIssueNote(ctx, c.project, &gitlab.Issue{IID: gitlab.ID(number)}, body)
+}
@@ -0,0 +1,111 @@
+package background
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestIssueBody(t *testing.T) {
+	t.Parallel()
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	action := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorID:          42,
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		UTMSource:          "code-monitor-issue",
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		IncludeResults:     false,
+	}
+
+	t.Run("golden without results", func(t *testing.T) {
+		autogold.Equal(t, autogold.Raw(issueBody(action)))
+	})
+
+	t.Run("golden with results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		autogold.Equal(t, autogold.Raw(issueBody(actionCopy)))
+	})
+
+	t.Run("golden with truncated results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		// quadruple the number of results
+		actionCopy.Results = append(actionCopy.Results, actionCopy.Results...)
+		actionCopy.Results = append(actionCopy.Results, actionCopy.Results...)
+		autogold.Equal(t, autogold.Raw(issueBody(actionCopy)))
+	})
+}
+
+func TestMarkdownCodeBlock(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "```go\nfoo()\n```\n", markdownCodeBlock("go", "foo()\n"))
+	require.Equal(t, "````\na ``` b\n````\n", markdownCodeBlock("", "a ``` b"))
+}
+
+type fakeIssueClient struct {
+	open     bool
+	created  []string
+	comments map[int64][]string
+}
+
+func (c *fakeIssueClient) IsIssueOpen(_ context.Context, _ int64) (bool, error) {
+	return c.open, nil
+}
+
+func (c *fakeIssueClient) CreateIssue(_ context.Context, title, _ string) (int64, error) {
+	c.created = append(c.created, title)
+	return int64(100 + len(c.created)), nil
+}
+
+func (c *fakeIssueClient) CommentOnIssue(_ context.Context, number int64, body string) error {
+	if c.comments == nil {
+		c.comments = make(map[int64][]string)
+	}
+	c.comments[number] = append(c.comments[number], body)
+	return nil
+}
+
+func TestOpenOrCommentOnIssue(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("no previous issue", func(t *testing.T) {
+		client := &fakeIssueClient{open: true}
+		n, err := openOrCommentOnIssue(ctx, client, nil, "title", "body")
+		require.NoError(t, err)
+		require.Equal(t, int64(101), n)
+		require.Equal(t, []string{"title"}, client.created)
+		require.Empty(t, client.comments)
+	})
+
+	t.Run("previous issue is open", func(t *testing.T) {
+		client := &fakeIssueClient{open: true}
+		previous := int64(7)
+		n, err := openOrCommentOnIssue(ctx, client, &previous, "title", "body")
+		require.NoError(t, err)
+		require.Equal(t, previous, n)
+		require.Empty(t, client.created)
+		require.Equal(t, map[int64][]string{7: {"body"}}, client.comments)
+	})
+
+	t.Run("previous issue is closed", func(t *testing.T) {
+		client := &fakeIssueClient{open: false}
+		previous := int64(7)
+		n, err := openOrCommentOnIssue(ctx, client, &previous, "title", "body")
+		require.NoError(t, err)
+		require.Equal(t, int64(101), n)
+		require.Equal(t, []string{"title"}, client.created)
+		require.Empty(t, client.comments)
+	})
+}
@@ -0,0 +1,164 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func sendTeamsNotification(ctx context.Context, url string, args actionArgs) error {
+	return postTeamsWebhook(ctx, httpcli.ExternalDoer, url, teamsPayload(args))
+}
+
+// teamsMessage is the body of a request to a Microsoft Teams incoming webhook.
+// See https://docs.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/connectors-using#send-adaptive-cards-using-an-incoming-webhook
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string            `json:"contentType"`
+	Content     teamsAdaptiveCard `json:"content"`
+}
+
+type teamsAdaptiveCard struct {
+	Schema  string            `json:"$schema"`
+	Type    string            `json:"type"`
+	Version string            `json:"version"`
+	Body    []any             `json:"body"`
+	Actions []teamsOpenURL    `json:"actions,omitempty"`
+	MSTeams map[string]string `json:"msteams,omitempty"`
+}
+
+type teamsTextBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+	Wrap bool   `json:"wrap"`
+}
+
+// teamsRichTextBlock is used for code, because its text is not interpreted as
+// markdown, unlike the text of a teamsTextBlock.
+type teamsRichTextBlock struct {
+	Type    string         `json:"type"`
+	Inlines []teamsTextRun `json:"inlines"`
+}
+
+type teamsTextRun struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	FontType string `json:"fontType"`
+	Size     string `json:"size"`
+}
+
+type teamsOpenURL struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func newTeamsMessage(body []any, actions []teamsOpenURL) *teamsMessage {
+	return &teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: teamsAdaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+				Actions: actions,
+				// Use the full width of the chat, so that code is not wrapped
+				// more than necessary.
+				MSTeams: map[string]string{"width": "Full"},
+			},
+		}},
+	}
+}
+
+func newTeamsText(s string) teamsTextBlock {
+	return teamsTextBlock{Type: "TextBlock", Text: s, Wrap: true}
+}
+
+func newTeamsCode(s string) teamsRichTextBlock {
+	return teamsRichTextBlock{
+		Type:    "RichTextBlock",
+		Inlines: []teamsTextRun{{Type: "TextRun", Text: s, FontType: "Monospace", Size: "Small"}},
+	}
+}
+
+func teamsPayload(args actionArgs) *teamsMessage {
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+
+	body := []any{
+		newTeamsText(fmt.Sprintf(
+			"%s's Sourcegraph Code monitor, **%s**, detected **%d** new matches.",
+			args.MonitorOwnerName,
+			args.MonitorDescription,
+			totalCount,
+		)),
+	}
+
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			resultType := "Message"
+			if result.DiffPreview != nil {
+				resultType = "Diff"
+			}
+			body = append(body, newTeamsText(fmt.Sprintf(
+				"%s match: [%s@%s](%s)",
+				resultType,
+				result.Repo.Name,
+				result.Commit.ID.Short(),
+				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
+			)))
+			var contentRaw string
+			if result.DiffPreview != nil {
+				contentRaw = truncateString(result.DiffPreview.Content, 10)
+			} else {
+				contentRaw = truncateString(result.MessagePreview.Content, 10)
+			}
+			body = append(body, newTeamsCode(contentRaw))
+		}
+		if truncatedCount > 0 {
+			body = append(body, newTeamsText(fmt.Sprintf(
+				"...and [%d more matches](%s).",
+				truncatedCount,
+				getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+			)))
+		}
+	}
+
+	body = append(body, newTeamsText(fmt.Sprintf(
+		"If you are %s, you can [edit your code monitor](%s).",
+		args.MonitorOwnerName,
+		getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+	)))
+
+	actions := []teamsOpenURL{{
+		Type:  "Action.OpenUrl",
+		Title: "View results",
+		URL:   getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+	}}
+
+	return newTeamsMessage(body, actions)
+}
+
+func postTeamsWebhook(ctx context.Context, doer httpcli.Doer, url string, msg *teamsMessage) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+	return postWebhookJSON(ctx, doer, url, raw)
+}
+
+func SendTestTeamsWebhook(ctx context.Context, doer httpcli.Doer, description, url string) error {
+	testMessage := newTeamsMessage([]any{
+		newTeamsText(fmt.Sprintf("Test message for Code Monitor '%s'", description)),
+	}, nil)
+
+	return postTeamsWebhook(ctx, doer, url, testMessage)
+}
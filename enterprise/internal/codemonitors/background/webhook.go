@@ -13,7 +13,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func sendWebhookNotification(ctx context.Context, url string, args actionArgs) error {
+func sendWebhookNotification(ctx context.Context, url string, template *string, args actionArgs) error {
+	if template != nil {
+		return postTemplatedWebhook(ctx, httpcli.ExternalDoer, url, *template, args)
+	}
 	return postWebhook(ctx, httpcli.ExternalDoer, url, generateWebhookPayload(args))
 }
 
@@ -22,7 +25,20 @@ func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload web
 	if err != nil {
 		return errors.Wrap(err, "marshal failed")
 	}
+	return postWebhookJSON(ctx, doer, url, raw)
+}
 
+func postTemplatedWebhook(ctx context.Context, doer httpcli.Doer, url, template string, args actionArgs) error {
+	raw, err := renderWebhookTemplate(template, args)
+	if err != nil {
+		return err
+	}
+	return postWebhookJSON(ctx, doer, url, raw)
+}
+
+// postWebhookJSON posts the JSON body raw to url and returns a StatusCodeError
+// if the response is not 200 OK.
+func postWebhookJSON(ctx context.Context, doer httpcli.Doer, url string, raw []byte) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
 	if err != nil {
 		return errors.Wrap(err, "failed new request")
@@ -47,13 +63,16 @@ func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload web
 	return nil
 }
 
-func SendTestWebhook(ctx context.Context, doer httpcli.Doer, description string, u string) error {
+func SendTestWebhook(ctx context.Context, doer httpcli.Doer, description string, u string, template *string) error {
 	args := actionArgs{
 		ExternalURL:        &url.URL{},
 		MonitorDescription: description,
 		Query:              "test query",
 	}
-	return postWebhook(ctx, httpcli.ExternalDoer, u, generateWebhookPayload(args))
+	if template != nil {
+		return postTemplatedWebhook(ctx, doer, u, *template, args)
+	}
+	return postWebhook(ctx, doer, u, generateWebhookPayload(args))
 }
 
 type webhookPayload struct {
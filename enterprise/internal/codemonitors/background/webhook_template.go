@@ -0,0 +1,94 @@
+package background
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"text/template"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// webhookTemplateData is the data a user-provided webhook template is
+// executed with.
+type webhookTemplateData struct {
+	Monitor webhookTemplateMonitor
+	Query   string
+	// SearchURL links to the results of the query on Sourcegraph.
+	SearchURL string
+	// ResultCount is the number of new matches, and is set even if the
+	// webhook does not include results.
+	ResultCount int
+	Results     []webhookResult
+}
+
+type webhookTemplateMonitor struct {
+	ID          int64
+	Description string
+	OwnerName   string
+	URL         string
+}
+
+var webhookTemplateFuncs = template.FuncMap{
+	// json encodes a value as JSON, so that strings from search results can
+	// be embedded into the body safely.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func newWebhookTemplateData(args actionArgs) webhookTemplateData {
+	var resultCount int
+	for _, r := range args.Results {
+		resultCount += r.ResultCount()
+	}
+
+	d := webhookTemplateData{
+		Monitor: webhookTemplateMonitor{
+			ID:          args.MonitorID,
+			Description: args.MonitorDescription,
+			OwnerName:   args.MonitorOwnerName,
+			URL:         getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+		},
+		Query:       args.Query,
+		SearchURL:   getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+		ResultCount: resultCount,
+	}
+	if args.IncludeResults {
+		d.Results = generateResults(args.Results)
+	}
+	return d
+}
+
+// renderWebhookTemplate executes the Go template text with the data of the
+// code monitor event and returns the rendered request body, which must be
+// valid JSON.
+func renderWebhookTemplate(text string, args actionArgs) ([]byte, error) {
+	t, err := template.New("webhook").Funcs(webhookTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse webhook template")
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, newWebhookTemplateData(args)); err != nil {
+		return nil, errors.Wrap(err, "execute webhook template")
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, errors.New("webhook template did not render valid JSON")
+	}
+	return buf.Bytes(), nil
+}
+
+// ValidateWebhookTemplate returns an error if text is not a webhook template
+// that renders valid JSON.
+func ValidateWebhookTemplate(text string) error {
+	args := actionArgs{
+		ExternalURL:        &url.URL{},
+		MonitorDescription: "test description",
+		Query:              "test query",
+		IncludeResults:     true,
+	}
+	_, err := renderWebhookTemplate(text, args)
+	return err
+}
@@ -79,6 +79,68 @@ func TestTriggerTestWebhookAction(t *testing.T) {
 	defer s.Close()
 
 	client := s.Client()
-	err := SendTestWebhook(context.Background(), client, "My test monitor", s.URL)
+	err := SendTestWebhook(context.Background(), client, "My test monitor", s.URL, nil)
 	require.NoError(t, err)
 }
+
+func TestTemplatedWebhook(t *testing.T) {
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	action := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		MonitorID:          42,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		IncludeResults:     true,
+	}
+
+	t.Run("golden", func(t *testing.T) {
+		template := `{
+	"routing_key": "abc",
+	"event_action": "trigger",
+	"payload": {
+		"summary": {{ json .Monitor.Description }},
+		"source": {{ json .Monitor.URL }},
+		"custom_details": {
+			"query": {{ json .Query }},
+			"count": {{ .ResultCount }},
+			"commits": [{{ range $i, $r := .Results }}{{ if $i }}, {{ end }}{{ json $r.Commit }}{{ end }}]
+		}
+	}
+}`
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			autogold.Equal(t, autogold.Raw(b))
+			w.WriteHeader(200)
+		}))
+		defer s.Close()
+
+		client := s.Client()
+		err := postTemplatedWebhook(context.Background(), client, s.URL, template, action)
+		require.NoError(t, err)
+	})
+
+	t.Run("results are only included if enabled", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = false
+
+		b, err := renderWebhookTemplate(`{"count": {{ .ResultCount }}, "results": {{ json .Results }}}`, actionCopy)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"count": 3, "results": null}`, string(b))
+	})
+
+	t.Run("invalid templates", func(t *testing.T) {
+		for _, template := range []string{
+			`{"summary": {{ .Monitor.Description }`,
+			`{"summary": {{ .Monitor.Unknown }}}`,
+			`{"summary": {{ .Monitor.Description }}}`,
+		} {
+			require.Error(t, ValidateWebhookTemplate(template), template)
+		}
+		require.NoError(t, ValidateWebhookTemplate(`{"summary": {{ json .Monitor.Description }}}`))
+	})
+}
@@ -229,8 +229,12 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, record wor
 		return r.handleWebhook(ctx, j)
 	case j.SlackWebhook != nil:
 		return r.handleSlackWebhook(ctx, j)
+	case j.TeamsWebhook != nil:
+		return r.handleTeamsWebhook(ctx, j)
+	case j.IssueAction != nil:
+		return r.handleIssueAction(ctx, j)
 	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
+		return errors.New("job must be one of type email, webhook, slack webhook, teams webhook, or issue")
 	}
 }
 
@@ -325,7 +329,7 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) erro
 		IncludeResults:     w.IncludeResults,
 	}
 
-	return sendWebhookNotification(ctx, w.URL, args)
+	return sendWebhookNotification(ctx, w.URL, w.Template, args)
 }
 
 func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob) error {
@@ -364,6 +368,98 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 	return sendSlackNotification(ctx, w.URL, args)
 }
 
+func (r *actionRunner) handleTeamsWebhook(ctx context.Context, j *edb.ActionJob) error {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetTeamsWebhookAction(ctx, *j.TeamsWebhook)
+	if err != nil {
+		return errors.Wrap(err, "GetTeamsWebhookAction")
+	}
+
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-teams-webhook",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		IncludeResults:     w.IncludeResults,
+	}
+
+	return sendTeamsNotification(ctx, w.URL, args)
+}
+
+func (r *actionRunner) handleIssueAction(ctx context.Context, j *edb.ActionJob) (err error) {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	a, err := s.GetIssueAction(ctx, *j.IssueAction)
+	if err != nil {
+		return errors.Wrap(err, "GetIssueAction")
+	}
+
+	// The repository and the token of its code host are read on behalf of
+	// Sourcegraph, not the owner of the monitor.
+	db := database.NewDBWith(log.Scoped("handleIssueAction", ""), s)
+	repo, err := db.Repos().Get(actor.WithInternalActor(ctx), a.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "get repo")
+	}
+
+	client, err := newIssueClient(ctx, db, repo)
+	if err != nil {
+		return errors.Wrap(err, "newIssueClient")
+	}
+
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          a.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-issue",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		IncludeResults:     a.IncludeResults,
+	}
+
+	number, err := openOrCommentOnIssue(ctx, client, a.IssueNumber, issueTitle(args), issueBody(args))
+	if err != nil {
+		return err
+	}
+	if a.IssueNumber == nil || *a.IssueNumber != number {
+		return s.SetIssueActionIssueNumber(ctx, a.ID, number)
+	}
+	return nil
+}
+
 type StatusCodeError struct {
 	Code   int
 	Status string
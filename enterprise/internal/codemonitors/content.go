@@ -0,0 +1,370 @@
+package codemonitors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var ErrContentMonitorLimitHit = errors.New("code monitor query matched more results than the result limit, add a count: filter to raise the limit")
+
+// isContentSearch returns whether the plan searches file contents or symbols
+// rather than commits.
+func isContentSearch(planJob job.Job) bool {
+	return !job.HasDescendent[*commit.SearchJob](planJob)
+}
+
+type repoRevision struct {
+	RepoID   api.RepoID
+	Revision string
+}
+
+type contentSearchResults struct {
+	snapshots map[repoRevision]*edb.ContentSnapshot
+	repos     map[api.RepoID]types.MinimalRepo
+
+	// incomplete contains the repositories that were not searched completely,
+	// so the matches we have for them cannot be compared with the snapshot.
+	incomplete map[api.RepoID]struct{}
+}
+
+func runContentSearch(ctx context.Context, clients job.RuntimeClients, planJob job.Job) (*contentSearchResults, error) {
+	agg := streaming.NewAggregatingStream()
+	if _, err := planJob.Run(ctx, clients, agg); err != nil {
+		return nil, err
+	}
+	if agg.Stats.IsLimitHit {
+		// We don't know which matches are missing, so we cannot tell apart
+		// matches that disappeared from matches we didn't get.
+		return nil, ErrContentMonitorLimitHit
+	}
+
+	res := &contentSearchResults{
+		snapshots:  make(map[repoRevision]*edb.ContentSnapshot),
+		repos:      make(map[api.RepoID]types.MinimalRepo),
+		incomplete: make(map[api.RepoID]struct{}),
+	}
+	agg.Stats.Status.Iterate(func(id api.RepoID, status search.RepoStatus) {
+		if status&(search.RepoStatusCloning|search.RepoStatusMissing|search.RepoStatusLimitHit|search.RepoStatusTimedout) != 0 {
+			res.incomplete[id] = struct{}{}
+		}
+	})
+
+	for _, match := range agg.Results {
+		// Repository and commit matches cannot be compared between runs.
+		fm, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		if fm.LimitHit {
+			res.incomplete[fm.Repo.ID] = struct{}{}
+		}
+
+		key := repoRevision{RepoID: fm.Repo.ID, Revision: inputRevision(fm)}
+		snapshot, ok := res.snapshots[key]
+		if !ok {
+			snapshot = &edb.ContentSnapshot{
+				RepoID:   key.RepoID,
+				Revision: key.Revision,
+				CommitID: fm.CommitID,
+			}
+			res.snapshots[key] = snapshot
+			res.repos[fm.Repo.ID] = fm.Repo
+		}
+		for _, lm := range fm.ChunkMatches.AsLineMatches() {
+			snapshot.Matches = append(snapshot.Matches, edb.ContentMatch{
+				Path:       fm.Path,
+				LineNumber: int(lm.LineNumber),
+				Preview:    strings.TrimSuffix(lm.Preview, "\r"),
+			})
+		}
+		for _, sm := range fm.Symbols {
+			snapshot.Matches = append(snapshot.Matches, edb.ContentMatch{
+				Path:       fm.Path,
+				LineNumber: sm.Symbol.Line - 1,
+				Preview:    sm.Symbol.Name,
+				SymbolKind: strings.ToLower(sm.Symbol.Kind),
+			})
+		}
+	}
+
+	for _, snapshot := range res.snapshots {
+		sortContentMatches(snapshot.Matches)
+	}
+	return res, nil
+}
+
+// searchContent runs a content code monitor, which is triggered by a regular
+// content or symbol search rather than a commit or diff search. Every run
+// records the matched lines and symbols per repository and revision, and
+// reports the matches that appeared or disappeared since the previous run.
+//
+// Changes are reported as a commit match on the commit the revision resolved
+// to, with a diff preview that adds the new matches and removes the matches
+// that disappeared, so that all actions work for content code monitors
+// unchanged.
+func searchContent(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) ([]*result.CommitMatch, error) {
+	res, err := runContentSearch(ctx, clients, planJob)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+	previous, err := cm.ListContentSnapshots(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+	previousByKey := make(map[repoRevision]*edb.ContentSnapshot, len(previous))
+	for _, snapshot := range previous {
+		previousByKey[repoRevision{RepoID: snapshot.RepoID, Revision: snapshot.Revision}] = snapshot
+	}
+
+	keys := make([]repoRevision, 0, len(res.snapshots))
+	for key := range res.snapshots {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].RepoID != keys[j].RepoID {
+			return keys[i].RepoID < keys[j].RepoID
+		}
+		return keys[i].Revision < keys[j].Revision
+	})
+
+	var matches []*result.CommitMatch
+	for _, key := range keys {
+		if _, ok := res.incomplete[key.RepoID]; ok {
+			continue
+		}
+
+		snapshot := res.snapshots[key]
+		var previousMatches []edb.ContentMatch
+		if p, ok := previousByKey[key]; ok {
+			previousMatches = p.Matches
+		}
+		added, removed := diffContentMatches(previousMatches, snapshot.Matches)
+		if err := cm.UpsertContentSnapshot(ctx, monitorID, snapshot); err != nil {
+			return nil, err
+		}
+		if len(added) > 0 || len(removed) > 0 {
+			matches = append(matches, contentCommitMatch(res.repos[key.RepoID], snapshot.CommitID, added, removed))
+		}
+	}
+
+	// Repositories and revisions without any matches are not part of the
+	// search results, so all their previous matches disappeared.
+	var gone []*edb.ContentSnapshot
+	for _, snapshot := range previous {
+		key := repoRevision{RepoID: snapshot.RepoID, Revision: snapshot.Revision}
+		if _, ok := res.snapshots[key]; ok {
+			continue
+		}
+		if _, ok := res.incomplete[key.RepoID]; ok || len(snapshot.Matches) == 0 {
+			continue
+		}
+		gone = append(gone, snapshot)
+	}
+	if len(gone) == 0 {
+		return matches, nil
+	}
+
+	ids := make([]api.RepoID, 0, len(gone))
+	for _, snapshot := range gone {
+		ids = append(ids, snapshot.RepoID)
+	}
+	repos, err := db.Repos().GetReposSetByIDs(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	gs := gitserver.NewClient(db)
+	for _, snapshot := range gone {
+		repo, ok := repos[snapshot.RepoID]
+		if !ok {
+			// The repository was deleted or is no longer visible to the owner
+			// of the code monitor.
+			continue
+		}
+
+		spec := snapshot.Revision
+		if spec == "" {
+			spec = "HEAD"
+		}
+		commitID, err := gs.ResolveRevision(ctx, repo.Name, spec, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+		if err != nil {
+			if errcode.IsNotFound(err) || gitdomain.IsCloneInProgress(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := cm.UpsertContentSnapshot(ctx, monitorID, &edb.ContentSnapshot{
+			RepoID:   snapshot.RepoID,
+			Revision: snapshot.Revision,
+			CommitID: commitID,
+		}); err != nil {
+			return nil, err
+		}
+		matches = append(matches, contentCommitMatch(types.MinimalRepo{ID: repo.ID, Name: repo.Name}, commitID, nil, snapshot.Matches))
+	}
+
+	return matches, nil
+}
+
+// snapshotContent records the current matches of a content code monitor
+// without reporting them, so that only changes after the monitor was created
+// or its query was updated are reported.
+func snapshotContent(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) error {
+	res, err := runContentSearch(ctx, clients, planJob)
+	if err != nil {
+		return err
+	}
+
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+	if err := cm.DeleteContentSnapshots(ctx, monitorID); err != nil {
+		return err
+	}
+	for _, snapshot := range res.snapshots {
+		if err := cm.UpsertContentSnapshot(ctx, monitorID, snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inputRevision returns the revision requested for the file match, or the
+// empty string for the default branch.
+func inputRevision(fm *result.FileMatch) string {
+	if fm.InputRev == nil || *fm.InputRev == "HEAD" {
+		return ""
+	}
+	return *fm.InputRev
+}
+
+func sortContentMatches(matches []edb.ContentMatch) {
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.LineNumber != b.LineNumber {
+			return a.LineNumber < b.LineNumber
+		}
+		if a.SymbolKind != b.SymbolKind {
+			return a.SymbolKind < b.SymbolKind
+		}
+		return a.Preview < b.Preview
+	})
+}
+
+// diffContentMatches returns the matches that were added and removed between
+// the two sets of matches. Matches are compared by path, content and symbol
+// kind, but not by line number, so that matches moved by unrelated changes to
+// a file are not reported. A line that is matched more often than before is
+// reported as added.
+func diffContentMatches(previous, current []edb.ContentMatch) (added, removed []edb.ContentMatch) {
+	type matchKey struct {
+		path, preview, symbolKind string
+	}
+	count := func(matches []edb.ContentMatch) map[matchKey]int {
+		counts := make(map[matchKey]int, len(matches))
+		for _, m := range matches {
+			counts[matchKey{m.Path, m.Preview, m.SymbolKind}]++
+		}
+		return counts
+	}
+	subtract := func(matches []edb.ContentMatch, counts map[matchKey]int) (out []edb.ContentMatch) {
+		for _, m := range matches {
+			k := matchKey{m.Path, m.Preview, m.SymbolKind}
+			if counts[k] > 0 {
+				counts[k]--
+				continue
+			}
+			out = append(out, m)
+		}
+		return out
+	}
+
+	return subtract(current, count(previous)), subtract(previous, count(current))
+}
+
+// contentCommitMatch returns a commit match on the given commit whose diff
+// preview adds the added matches and removes the removed matches. Every match
+// is highlighted, so that actions count every change as a result.
+func contentCommitMatch(repo types.MinimalRepo, commitID api.CommitID, added, removed []edb.ContentMatch) *result.CommitMatch {
+	type change struct {
+		edb.ContentMatch
+		added bool
+	}
+	changes := make([]change, 0, len(added)+len(removed))
+	for _, m := range removed {
+		changes = append(changes, change{ContentMatch: m})
+	}
+	for _, m := range added {
+		changes = append(changes, change{ContentMatch: m, added: true})
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Path != changes[j].Path {
+			return changes[i].Path < changes[j].Path
+		}
+		return changes[i].LineNumber < changes[j].LineNumber
+	})
+
+	var (
+		content strings.Builder
+		ranges  result.Ranges
+		line    int
+	)
+	writeLine := func(s string) {
+		content.WriteString(s)
+		content.WriteByte('\n')
+		line++
+	}
+	for i, c := range changes {
+		if i == 0 || changes[i-1].Path != c.Path {
+			writeLine(c.Path + " " + c.Path)
+		}
+
+		hunk, prefix := fmt.Sprintf("@@ -%d,1 +0,0 @@", c.LineNumber+1), "-"
+		if c.added {
+			hunk, prefix = fmt.Sprintf("@@ -0,0 +%d,1 @@", c.LineNumber+1), "+"
+		}
+		if c.SymbolKind != "" {
+			hunk += " " + c.SymbolKind
+		}
+		writeLine(hunk)
+
+		if c.Preview != "" {
+			start := content.Len() + len(prefix)
+			ranges = append(ranges, result.Range{
+				Start: result.Location{Offset: start, Line: line, Column: 1},
+				End:   result.Location{Offset: start + len(c.Preview), Line: line, Column: 1 + utf8.RuneCountInString(c.Preview)},
+			})
+		}
+		writeLine(prefix + c.Preview)
+	}
+
+	return &result.CommitMatch{
+		Commit: gitdomain.Commit{ID: commitID},
+		Repo:   repo,
+		DiffPreview: &result.MatchedString{
+			Content:       content.String(),
+			MatchedRanges: ranges,
+		},
+	}
+}
@@ -0,0 +1,128 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestIsContentSearch(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, input string) bool {
+		plan, err := query.Pipeline(query.InitRegexp(input))
+		require.NoError(t, err)
+		inputs := &search.Inputs{
+			UserSettings:        &schema.Settings{},
+			PatternType:         query.SearchTypeLiteral,
+			Protocol:            search.Streaming,
+			Features:            &search.Features{},
+			OnSourcegraphDotCom: true,
+		}
+		j, err := jobutil.NewPlanJob(inputs, plan)
+		require.NoError(t, err)
+		return isContentSearch(j)
+	}
+
+	for _, q := range []string{
+		"deprecated",
+		"type:file deprecated repo:a",
+		"type:symbol Deprecated repo:a",
+		"type:file a or b",
+	} {
+		require.True(t, test(t, q), q)
+	}
+
+	for _, q := range []string{
+		"type:diff a",
+		"type:commit a or b repo:c",
+		"type:file a or type:diff b",
+	} {
+		require.False(t, test(t, q), q)
+	}
+}
+
+func TestDiffContentMatches(t *testing.T) {
+	t.Parallel()
+
+	previous := []edb.ContentMatch{
+		{Path: "a.go", LineNumber: 1, Preview: "deprecated()"},
+		{Path: "a.go", LineNumber: 5, Preview: "deprecated()"},
+		{Path: "a.go", LineNumber: 9, Preview: "Deprecated", SymbolKind: "function"},
+		{Path: "b.go", LineNumber: 2, Preview: "x := deprecated()"},
+	}
+	current := []edb.ContentMatch{
+		// Moved by an unrelated change.
+		{Path: "a.go", LineNumber: 3, Preview: "deprecated()"},
+		{Path: "a.go", LineNumber: 7, Preview: "deprecated()"},
+		// Matched once more than before.
+		{Path: "a.go", LineNumber: 8, Preview: "deprecated()"},
+		// Same content, but now a symbol.
+		{Path: "a.go", LineNumber: 11, Preview: "Deprecated", SymbolKind: "variable"},
+		{Path: "c.go", LineNumber: 0, Preview: "deprecated()"},
+	}
+
+	added, removed := diffContentMatches(previous, current)
+	require.Equal(t, []edb.ContentMatch{
+		{Path: "a.go", LineNumber: 8, Preview: "deprecated()"},
+		{Path: "a.go", LineNumber: 11, Preview: "Deprecated", SymbolKind: "variable"},
+		{Path: "c.go", LineNumber: 0, Preview: "deprecated()"},
+	}, added)
+	require.Equal(t, []edb.ContentMatch{
+		{Path: "a.go", LineNumber: 9, Preview: "Deprecated", SymbolKind: "function"},
+		{Path: "b.go", LineNumber: 2, Preview: "x := deprecated()"},
+	}, removed)
+
+	added, removed = diffContentMatches(current, current)
+	require.Empty(t, added)
+	require.Empty(t, removed)
+}
+
+func TestContentCommitMatch(t *testing.T) {
+	t.Parallel()
+
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/test/test"}
+	added := []edb.ContentMatch{
+		{Path: "a.go", LineNumber: 2, Preview: "foo := deprecated()"},
+		{Path: "b.go", LineNumber: 0, Preview: "Deprecated", SymbolKind: "function"},
+	}
+	removed := []edb.ContentMatch{
+		{Path: "a.go", LineNumber: 0, Preview: "ünïcode(deprecated())"},
+	}
+
+	match := contentCommitMatch(repo, "abc", added, removed)
+	require.Equal(t, repo, match.Repo)
+	require.Equal(t, "abc", string(match.Commit.ID))
+
+	content := "a.go a.go\n" +
+		"@@ -1,1 +0,0 @@\n" +
+		"-ünïcode(deprecated())\n" +
+		"@@ -0,0 +3,1 @@\n" +
+		"+foo := deprecated()\n" +
+		"b.go b.go\n" +
+		"@@ -0,0 +1,1 @@ function\n" +
+		"+Deprecated\n"
+	require.Equal(t, content, match.DiffPreview.Content)
+
+	// The diff preview is a valid diff.
+	diff, err := result.ParseDiffString(match.DiffPreview.Content)
+	require.NoError(t, err)
+	require.Len(t, diff, 2)
+
+	// Every change is highlighted.
+	var highlighted []string
+	for _, r := range match.DiffPreview.MatchedRanges {
+		highlighted = append(highlighted, content[r.Start.Offset:r.End.Offset])
+	}
+	require.Equal(t, []string{"ünïcode(deprecated())", "foo := deprecated()", "Deprecated"}, highlighted)
+	require.Equal(t, result.Location{Offset: 124, Line: 7, Column: 1}, match.DiffPreview.MatchedRanges[2].Start)
+	require.Equal(t, 3, match.ResultCount())
+}
@@ -121,6 +121,10 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	if isContentSearch(planJob) {
+		return searchContent(ctx, db, clients, planJob, monitorID)
+	}
+
 	if featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
 		hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, doSearch commit.DoSearchFunc) error {
 			return hookWithID(ctx, db, gs, monitorID, repoID, args, doSearch)
@@ -191,6 +195,15 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return err
 	}
 
+	// Content monitors always compare against a snapshot, but commit monitors
+	// only do if they are repo-aware.
+	if isContentSearch(planJob) {
+		return snapshotContent(ctx, db, clients, planJob, monitorID)
+	}
+	if !featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
+		return nil
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, _ commit.DoSearchFunc) error {
 		return snapshotHook(ctx, db, gs, args, monitorID, repoID)
 	}
@@ -0,0 +1,193 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/comby"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// AggregateSort is the order of the groups of an aggregation.
+type AggregateSort string
+
+const (
+	// SortByCount orders groups by descending count, and groups with equal counts by
+	// value.
+	SortByCount AggregateSort = "count"
+	// SortByValue orders groups by value.
+	SortByValue AggregateSort = "value"
+)
+
+// defaultAggregateLimit is the number of groups an aggregation returns if no limit is
+// specified with `top=N`.
+const defaultAggregateLimit = 100
+
+// Aggregate counts the matches of its search pattern in search results. A `count`
+// aggregate counts all matches, and a `group-by` aggregate counts the matches for each
+// value its group-by template expands to, such as a capture group value ($1), the
+// repository ($repo) or the commit author ($author).
+type Aggregate struct {
+	SearchPattern MatchPattern
+	GroupBy       string
+	Limit         int
+	SortBy        AggregateSort
+	Kind          string
+}
+
+func (c *Aggregate) ToSearchPattern() string {
+	return c.SearchPattern.String()
+}
+
+func (c *Aggregate) String() string {
+	if c.GroupBy == "" {
+		return fmt.Sprintf("Aggregate count: (%s)", c.SearchPattern.String())
+	}
+	return fmt.Sprintf("Aggregate group by: (%s) -> (%s) top: %d sort: %s", c.SearchPattern.String(), c.GroupBy, c.Limit, c.SortBy)
+}
+
+func (c *Aggregate) isCount() bool {
+	return strings.HasPrefix(c.Kind, "count")
+}
+
+func (c *Aggregate) aggregationKind() string {
+	if c.isCount() {
+		return "count"
+	}
+	return "group-by"
+}
+
+// groupValues returns the value of the group-by template for every match of the search
+// pattern in content. For a count aggregate, every value is empty.
+func (c *Aggregate) groupValues(ctx context.Context, content, groupBy string) ([]string, error) {
+	switch match := c.SearchPattern.(type) {
+	case *Regexp:
+		submatches := match.Value.FindAllStringSubmatchIndex(content, -1)
+		values := make([]string, 0, len(submatches))
+		for _, submatch := range submatches {
+			values = append(values, string(match.Value.ExpandString(nil, groupBy, content, submatch)))
+		}
+		return values, nil
+
+	case *Comby:
+		// Comby separates outputs by newlines, so every match needs a nonempty output to
+		// be counted. Newlines within a value would split it into multiple values.
+		rewrite := groupBy
+		if c.isCount() {
+			rewrite = "_"
+		}
+		out, err := comby.Outputs(ctx, comby.Args{
+			Input:           comby.FileContent(content),
+			MatchTemplate:   match.Value,
+			RewriteTemplate: rewrite,
+			Matcher:         ".generic",
+			ResultKind:      comby.NewlineSeparatedOutput,
+			NumWorkers:      0,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var values []string
+		for _, value := range strings.Split(out, "\n") {
+			if value == "" {
+				continue
+			}
+			if c.isCount() {
+				value = ""
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+	return nil, nil
+}
+
+// Run returns the aggregation of the matches in a single search result. Aggregations of
+// multiple results are combined with an Aggregator.
+func (c *Aggregate) Run(ctx context.Context, _ database.DB, r result.Match) (Result, error) {
+	counts := map[string]int{}
+	for _, content := range resultChunks(r, c.Kind, false) {
+		groupBy := ""
+		if !c.isCount() {
+			var err error
+			groupBy, err = substituteMetaVariables(c.GroupBy, NewMetaEnvironment(r, content))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		values, err := c.groupValues(ctx, content, groupBy)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			counts[value]++
+		}
+	}
+
+	return &Aggregation{
+		Kind:   c.aggregationKind(),
+		Groups: sortGroups(toGroups(counts), SortByValue),
+	}, nil
+}
+
+// Aggregator combines the aggregations of individual search results into the aggregation
+// of all results seen so far. It is not safe for concurrent use.
+type Aggregator struct {
+	command *Aggregate
+	counts  map[string]int
+	dirty   bool
+}
+
+func NewAggregator(command *Aggregate) *Aggregator {
+	return &Aggregator{command: command, counts: map[string]int{}}
+}
+
+// Add adds the groups of the given aggregation to the running totals.
+func (a *Aggregator) Add(aggregation *Aggregation) {
+	for _, group := range aggregation.Groups {
+		a.counts[group.Value] += group.Count
+		a.dirty = true
+	}
+}
+
+// Dirty returns whether the running totals changed since the last call to Result.
+func (a *Aggregator) Dirty() bool {
+	return a.dirty
+}
+
+// Result returns the sorted and limited aggregation of the running totals.
+func (a *Aggregator) Result() *Aggregation {
+	a.dirty = false
+
+	groups := sortGroups(toGroups(a.counts), a.command.SortBy)
+	if a.command.Limit > 0 && len(groups) > a.command.Limit {
+		groups = groups[:a.command.Limit]
+	}
+	return &Aggregation{
+		Kind:        a.command.aggregationKind(),
+		Groups:      groups,
+		TotalGroups: len(a.counts),
+	}
+}
+
+func toGroups(counts map[string]int) []AggregationGroup {
+	groups := make([]AggregationGroup, 0, len(counts))
+	for value, count := range counts {
+		groups = append(groups, AggregationGroup{Value: value, Count: count})
+	}
+	return groups
+}
+
+func sortGroups(groups []AggregationGroup, sortBy AggregateSort) []AggregationGroup {
+	sort.Slice(groups, func(i, j int) bool {
+		if sortBy == SortByCount && groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups
+}
@@ -0,0 +1,83 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestAggregate(t *testing.T) {
+	test := func(q string, matches ...result.Match) string {
+		computeQuery, err := Parse(q)
+		if err != nil {
+			return err.Error()
+		}
+		command, ok := computeQuery.Command.(*Aggregate)
+		if !ok {
+			return "Error, unrecognized command"
+		}
+
+		aggregator := NewAggregator(command)
+		for _, m := range matches {
+			res, err := command.Run(context.Background(), database.NewMockDB(), m)
+			if err != nil {
+				return err.Error()
+			}
+			aggregator.Add(res.(*Aggregation))
+		}
+		result, _ := json.Marshal(aggregator.Result())
+		return string(result)
+	}
+
+	autogold.Want(
+		"count matches",
+		`{"kind":"count","groups":[{"value":"","count":4}],"totalGroups":1}`).
+		Equal(t, test(`content:count(\d)`, fileMatch("a 1 b 2 c 3"), fileMatch("d 4")))
+
+	autogold.Want(
+		"group by capture group",
+		`{"kind":"group-by","groups":[{"value":"go","count":3},{"value":"ml","count":2},{"value":"c","count":1}],"totalGroups":3}`).
+		Equal(t, test(`content:group-by(\.(\w+)\b -> $1)`, fileMatch("a.go b.ml c.go"), fileMatch("d.ml e.c f.go")))
+
+	autogold.Want(
+		"group by repository",
+		`{"kind":"group-by","groups":[{"value":"my/awesome/repo","count":3}],"totalGroups":1}`).
+		Equal(t, test(`content:group-by(\d -> $repo)`, fileMatch("a 1 b 2 c 3")))
+
+	autogold.Want(
+		"group by author",
+		`{"kind":"group-by","groups":[{"value":"bob","count":2}],"totalGroups":1}`).
+		Equal(t, test(`type:commit content:group-by(fix -> $author)`, commitMatch("fix this"), commitMatch("fix that")))
+
+	autogold.Want(
+		"top and sort by value",
+		`{"kind":"group-by","groups":[{"value":"a","count":1},{"value":"b","count":3}],"totalGroups":3}`).
+		Equal(t, test(`content:group-by((\w) -> $1; top=2; sort=value)`, fileMatch("b b c b a")))
+
+	autogold.Want(
+		"no matches",
+		`{"kind":"group-by","groups":[]}`).
+		Equal(t, test(`content:group-by(\d -> $1)`, fileMatch("no digits")))
+}
+
+func TestAggregatorDirty(t *testing.T) {
+	aggregator := NewAggregator(&Aggregate{Kind: "count"})
+	if aggregator.Dirty() {
+		t.Fatal("expected new aggregator not to be dirty")
+	}
+
+	aggregator.Add(&Aggregation{Kind: "count", Groups: []AggregationGroup{{Count: 2}}})
+	if !aggregator.Dirty() {
+		t.Fatal("expected aggregator to be dirty after adding groups")
+	}
+
+	aggregator.Result()
+	if aggregator.Dirty() {
+		t.Fatal("expected aggregator not to be dirty after computing the result")
+	}
+}
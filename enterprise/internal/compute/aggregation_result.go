@@ -0,0 +1,19 @@
+package compute
+
+// Aggregation is the number of matches of an aggregate command, grouped by value. A
+// count aggregation has a single group with an empty value.
+//
+// The streaming compute API sends the aggregation of all results seen so far every time
+// it changes, so every aggregation replaces the previous one.
+type Aggregation struct {
+	Kind   string             `json:"kind"`
+	Groups []AggregationGroup `json:"groups"`
+
+	// TotalGroups is the number of groups before the limit of the command was applied.
+	TotalGroups int `json:"totalGroups,omitempty"`
+}
+
+type AggregationGroup struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
@@ -65,6 +65,8 @@ func resultChunks(r result.Match, kind string, onlyPath bool) []string {
 	switch m := r.(type) {
 	case *result.RepoMatch:
 		return []string{string(m.Name)}
+	case *result.OwnerMatch:
+		return []string{m.Handle}
 	case *result.FileMatch:
 		if onlyPath {
 			return []string{m.Path}
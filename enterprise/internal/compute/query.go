@@ -2,6 +2,8 @@ package compute
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/grafana/regexp"
 
@@ -97,13 +99,19 @@ func toRegexpPattern(value string) (MatchPattern, error) {
 
 var ComputePredicateRegistry = query.PredicateRegistry{
 	query.FieldContent: {
-		"replace":            func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.structural": func() query.Predicate { return query.EmptyPredicate{} },
-		"output":             func() query.Predicate { return query.EmptyPredicate{} },
-		"output.regexp":      func() query.Predicate { return query.EmptyPredicate{} },
-		"output.structural":  func() query.Predicate { return query.EmptyPredicate{} },
-		"output.extra":       func() query.Predicate { return query.EmptyPredicate{} },
+		"replace":             func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.regexp":      func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.structural":  func() query.Predicate { return query.EmptyPredicate{} },
+		"output":              func() query.Predicate { return query.EmptyPredicate{} },
+		"output.regexp":       func() query.Predicate { return query.EmptyPredicate{} },
+		"output.structural":   func() query.Predicate { return query.EmptyPredicate{} },
+		"output.extra":        func() query.Predicate { return query.EmptyPredicate{} },
+		"count":               func() query.Predicate { return query.EmptyPredicate{} },
+		"count.regexp":        func() query.Predicate { return query.EmptyPredicate{} },
+		"count.structural":    func() query.Predicate { return query.EmptyPredicate{} },
+		"group-by":            func() query.Predicate { return query.EmptyPredicate{} },
+		"group-by.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
+		"group-by.structural": func() query.Predicate { return query.EmptyPredicate{} },
 	},
 }
 
@@ -137,7 +145,7 @@ func parseReplace(q *query.Basic) (Command, bool, error) {
 	}
 
 	name, args, ok := parseContentPredicate(pattern)
-	if !ok {
+	if !ok || !strings.HasPrefix(name, "replace") {
 		return nil, false, nil
 	}
 	left, right, err := parseArrowSyntax(args)
@@ -171,7 +179,7 @@ func parseOutput(q *query.Basic) (Command, bool, error) {
 	}
 
 	name, args, ok := parseContentPredicate(pattern)
-	if !ok {
+	if !ok || !strings.HasPrefix(name, "output") {
 		return nil, false, nil
 	}
 	left, right, err := parseArrowSyntax(args)
@@ -217,6 +225,87 @@ func parseOutput(q *query.Basic) (Command, bool, error) {
 	}, true, nil
 }
 
+var aggregateOption = lazyregexp.New(`;\s*(top|sort)\s*=\s*([^;\s]*)\s*$`)
+
+// parseAggregateOptions strips the trailing `; top=N` and `; sort=count|value` options
+// from the arguments of an aggregate command.
+func parseAggregateOptions(args string) (string, int, AggregateSort, error) {
+	limit, sortBy := defaultAggregateLimit, SortByCount
+	for {
+		submatches := aggregateOption.FindStringSubmatchIndex(args)
+		if submatches == nil {
+			return args, limit, sortBy, nil
+		}
+		name, value := args[submatches[2]:submatches[3]], args[submatches[4]:submatches[5]]
+		switch name {
+		case "top":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return "", 0, "", errors.Errorf("invalid top=%s, expected a positive number", value)
+			}
+			limit = n
+		case "sort":
+			switch AggregateSort(value) {
+			case SortByCount, SortByValue:
+				sortBy = AggregateSort(value)
+			default:
+				return "", 0, "", errors.Errorf("invalid sort=%s, expected sort=count or sort=value", value)
+			}
+		}
+		args = args[:submatches[0]]
+	}
+}
+
+func parseAggregate(q *query.Basic) (Command, bool, error) {
+	pattern, err := extractPattern(q)
+	if err != nil {
+		return nil, false, err
+	}
+
+	name, args, ok := parseContentPredicate(pattern)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var left, right string
+	limit, sortBy := 0, SortByCount
+	switch name {
+	case "count", "count.regexp", "count.structural":
+		left = args
+	case "group-by", "group-by.regexp", "group-by.structural":
+		args, limit, sortBy, err = parseAggregateOptions(args)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "group-by command")
+		}
+		left, right, err = parseArrowSyntax(args)
+		if err != nil {
+			return nil, false, err
+		}
+	default:
+		// unrecognized name
+		return nil, false, nil
+	}
+
+	var matchPattern MatchPattern
+	if strings.HasSuffix(name, ".structural") {
+		// structural search doesn't do any match pattern validation
+		matchPattern = &Comby{Value: left}
+	} else {
+		matchPattern, err = toRegexpPattern(left)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "%s command", name)
+		}
+	}
+
+	return &Aggregate{
+		SearchPattern: matchPattern,
+		GroupBy:       right,
+		Limit:         limit,
+		SortBy:        sortBy,
+		Kind:          name,
+	}, true, nil
+}
+
 func parseMatchOnly(q *query.Basic) (Command, bool, error) {
 	pattern, err := extractPattern(q)
 	if err != nil {
@@ -260,6 +349,7 @@ func first(parsers ...commandParser) commandParser {
 var parseCommand = first(
 	parseReplace,
 	parseOutput,
+	parseAggregate,
 	parseMatchOnly,
 )
 
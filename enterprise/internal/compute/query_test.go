@@ -50,6 +50,26 @@ func TestParse(t *testing.T) {
 	autogold.Want("replace no left hand side",
 		"Command: `Replace in place: () -> (b)`").
 		Equal(t, test("content:replace(->b)"))
+
+	autogold.Want("count",
+		"Command: `Aggregate count: (TODO)`").
+		Equal(t, test("content:count(TODO)"))
+
+	autogold.Want("group-by",
+		"Command: `Aggregate group by: (TODO\\((\\w+)\\)) -> ($1) top: 100 sort: count`").
+		Equal(t, test(`content:group-by(TODO\((\w+)\) -> $1)`))
+
+	autogold.Want("group-by with options",
+		"Command: `Aggregate group by: (fix) -> ($author) top: 5 sort: value`, Parameters: `type:commit`").
+		Equal(t, test("type:commit content:group-by(fix -> $author; top=5; sort=value)"))
+
+	autogold.Want("group-by with invalid top",
+		"group-by command: invalid top=none, expected a positive number").
+		Equal(t, test("content:group-by(fix -> $repo; top=none)"))
+
+	autogold.Want("group-by with invalid sort",
+		"group-by command: invalid sort=random, expected sort=count or sort=value").
+		Equal(t, test("content:group-by(fix -> $repo; sort=random)"))
 }
 
 func TestToSearchQuery(t *testing.T) {
@@ -287,6 +287,11 @@ func NewMetaEnvironment(r result.Match, content string) *MetaEnvironment {
 			Email:   m.Commit.Author.Email,
 			Content: content,
 		}
+	case *result.OwnerMatch:
+		return &MetaEnvironment{
+			Repo:    string(m.Repo.Name),
+			Content: m.Handle,
+		}
 	case *result.CommitDiffMatch:
 		path := m.Path()
 		lang, _ := enry.GetLanguageByExtension(path)
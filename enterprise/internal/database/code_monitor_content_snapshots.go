@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// ContentSnapshot is the set of lines and symbols a content code monitor
+// matched in a repository at a revision.
+type ContentSnapshot struct {
+	RepoID api.RepoID
+	// Revision is the revision as requested by the query, or the empty string
+	// for the default branch.
+	Revision string
+	CommitID api.CommitID
+	Matches  []ContentMatch
+}
+
+// ContentMatch is a line or symbol matched by a content code monitor.
+type ContentMatch struct {
+	Path string `json:"path"`
+	// LineNumber is the 0-based line number of the match. It is not used to
+	// compare matches between runs, so that unrelated changes to a file do not
+	// report the matches below them again.
+	LineNumber int `json:"lineNumber"`
+	// Preview is the matched line, or the name of the matched symbol.
+	Preview string `json:"preview"`
+	// SymbolKind is the kind of the matched symbol, and empty for line matches.
+	SymbolKind string `json:"symbolKind,omitempty"`
+}
+
+func (s *codeMonitorStore) ListContentSnapshots(ctx context.Context, monitorID int64) ([]*ContentSnapshot, error) {
+	rawQuery := `
+	SELECT repo_id, revision, commit_oid, matches
+	FROM cm_content_snapshots
+	WHERE monitor_id = %s
+	ORDER BY repo_id, revision
+	`
+
+	q := sqlf.Sprintf(rawQuery, monitorID)
+	return scanContentSnapshots(s.Query(ctx, q))
+}
+
+func (s *codeMonitorStore) UpsertContentSnapshot(ctx context.Context, monitorID int64, snapshot *ContentSnapshot) error {
+	rawQuery := `
+	INSERT INTO cm_content_snapshots (monitor_id, repo_id, revision, commit_oid, matches)
+	VALUES (%s, %s, %s, %s, %s)
+	ON CONFLICT (monitor_id, repo_id, revision) DO UPDATE
+	SET commit_oid = EXCLUDED.commit_oid,
+		matches = EXCLUDED.matches
+	`
+
+	// Appease non-null constraint on column
+	matches := snapshot.Matches
+	if matches == nil {
+		matches = []ContentMatch{}
+	}
+	matchesJSON, err := json.Marshal(matches)
+	if err != nil {
+		return err
+	}
+
+	q := sqlf.Sprintf(rawQuery, monitorID, int64(snapshot.RepoID), snapshot.Revision, string(snapshot.CommitID), matchesJSON)
+	return s.Exec(ctx, q)
+}
+
+func (s *codeMonitorStore) DeleteContentSnapshots(ctx context.Context, monitorID int64) error {
+	rawQuery := `
+	DELETE FROM cm_content_snapshots
+	WHERE monitor_id = %s
+	`
+
+	q := sqlf.Sprintf(rawQuery, monitorID)
+	return s.Exec(ctx, q)
+}
+
+var scanContentSnapshots = basestore.NewSliceScanner(scanContentSnapshot)
+
+func scanContentSnapshot(scanner dbutil.Scanner) (*ContentSnapshot, error) {
+	var (
+		s           ContentSnapshot
+		matchesJSON []byte
+	)
+	if err := scanner.Scan(&s.RepoID, &s.Revision, &s.CommitID, &matchesJSON); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(matchesJSON, &s.Matches); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
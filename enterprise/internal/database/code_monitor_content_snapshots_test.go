@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreContentSnapshots(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	t.Run("upsert list delete", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		// List without snapshots
+		snapshots, err := cm.ListContentSnapshots(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Empty(t, snapshots)
+
+		// Insert
+		defaultBranch := &ContentSnapshot{
+			RepoID:   fixtures.Repo.ID,
+			CommitID: "commit1",
+			Matches: []ContentMatch{
+				{Path: "a.go", LineNumber: 1, Preview: "deprecated()"},
+				{Path: "a.go", LineNumber: 5, Preview: "Deprecated", SymbolKind: "function"},
+			},
+		}
+		branch := &ContentSnapshot{
+			RepoID:   fixtures.Repo.ID,
+			Revision: "feature",
+			CommitID: "commit2",
+		}
+		require.NoError(t, cm.UpsertContentSnapshot(ctx, fixtures.Monitor.ID, defaultBranch))
+		require.NoError(t, cm.UpsertContentSnapshot(ctx, fixtures.Monitor.ID, branch))
+
+		// List
+		snapshots, err = cm.ListContentSnapshots(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		branch.Matches = []ContentMatch{}
+		require.Equal(t, []*ContentSnapshot{defaultBranch, branch}, snapshots)
+
+		// Update
+		defaultBranch.CommitID = "commit3"
+		defaultBranch.Matches = defaultBranch.Matches[:1]
+		require.NoError(t, cm.UpsertContentSnapshot(ctx, fixtures.Monitor.ID, defaultBranch))
+
+		snapshots, err = cm.ListContentSnapshots(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, []*ContentSnapshot{defaultBranch, branch}, snapshots)
+
+		// Snapshots of other monitors are not listed
+		snapshots, err = cm.ListContentSnapshots(ctx, fixtures.Monitor.ID+1)
+		require.NoError(t, err)
+		require.Empty(t, snapshots)
+
+		// Delete
+		require.NoError(t, cm.DeleteContentSnapshots(ctx, fixtures.Monitor.ID))
+		snapshots, err = cm.ListContentSnapshots(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Empty(t, snapshots)
+	})
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCodeMonitorStoreIssueActions(t *testing.T) {
+	logger := logtest.Scoped(t)
+
+	setup := func(t *testing.T) (context.Context, EnterpriseDB, codeMonitorTestFixtures) {
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		ctx := actor.WithActor(context.Background(), actor.FromUser(fixtures.User.ID))
+		return ctx, db, fixtures
+	}
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		t.Parallel()
+		ctx, db, fixtures := setup(t)
+		s := db.CodeMonitors()
+
+		action, err := s.CreateIssueAction(ctx, fixtures.Monitor.ID, true, false, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Nil(t, action.IssueNumber)
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+		require.Equal(t, action, got)
+	})
+
+	t.Run("SetIssueNumber", func(t *testing.T) {
+		t.Parallel()
+		ctx, db, fixtures := setup(t)
+		s := db.CodeMonitors()
+
+		action, err := s.CreateIssueAction(ctx, fixtures.Monitor.ID, true, false, fixtures.Repo.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, s.SetIssueActionIssueNumber(ctx, action.ID, 42))
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.IssueNumber)
+		require.Equal(t, int64(42), *got.IssueNumber)
+
+		// Updating the action without changing the repository keeps the issue.
+		updated, err := s.UpdateIssueAction(ctx, action.ID, false, true, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.False(t, updated.Enabled)
+		require.True(t, updated.IncludeResults)
+		require.Equal(t, got.IssueNumber, updated.IssueNumber)
+
+		// Moving the action to another repository forgets the issue.
+		require.NoError(t, db.Repos().Create(ctx, &types.Repo{Name: "other"}))
+		other, err := db.Repos().GetByName(ctx, "other")
+		require.NoError(t, err)
+		updated, err = s.UpdateIssueAction(ctx, action.ID, true, true, other.ID)
+		require.NoError(t, err)
+		require.Equal(t, other.ID, updated.RepoID)
+		require.Nil(t, updated.IssueNumber)
+	})
+
+	t.Run("ErrorOnUpdateNonexistent", func(t *testing.T) {
+		t.Parallel()
+		ctx, db, fixtures := setup(t)
+
+		_, err := db.CodeMonitors().UpdateIssueAction(ctx, 383838, false, false, fixtures.Repo.ID)
+		require.Error(t, err)
+	})
+
+	t.Run("CreateDeleteListCount", func(t *testing.T) {
+		t.Parallel()
+		ctx, db, fixtures := setup(t)
+		s := db.CodeMonitors()
+
+		action1, err := s.CreateIssueAction(ctx, fixtures.Monitor.ID, true, false, fixtures.Repo.ID)
+		require.NoError(t, err)
+		action2, err := s.CreateIssueAction(ctx, fixtures.Monitor.ID, true, true, fixtures.Repo.ID)
+		require.NoError(t, err)
+
+		count, err := s.CountIssueActions(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		first := 1
+		actions, err := s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.Monitor.ID, First: &first})
+		require.NoError(t, err)
+		require.Equal(t, []*IssueAction{action1}, actions)
+
+		require.NoError(t, s.DeleteIssueActions(ctx, fixtures.Monitor.ID, action1.ID))
+
+		actions, err = s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.Monitor.ID})
+		require.NoError(t, err)
+		require.Equal(t, []*IssueAction{action2}, actions)
+	})
+}
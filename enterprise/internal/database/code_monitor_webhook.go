@@ -17,6 +17,9 @@ type WebhookAction struct {
 	Enabled        bool
 	URL            string
 	IncludeResults bool
+	// Template is an optional Go template rendering the JSON body of the
+	// webhook request. When nil, the default payload is sent.
+	Template *string
 
 	CreatedBy int32
 	CreatedAt time.Time
@@ -29,6 +32,7 @@ UPDATE cm_webhooks
 SET enabled = %s,
     include_results = %s,
 	url = %s,
+	template = %s,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -41,13 +45,14 @@ WHERE
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string) (*WebhookAction, error) {
+func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string, template *string) (*WebhookAction, error) {
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
 		updateWebhookActionQuery,
 		enabled,
 		includeResults,
 		url,
+		template,
 		a.UID,
 		s.Now(),
 		id,
@@ -61,12 +66,12 @@ func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, en
 
 const createWebhookActionQuery = `
 INSERT INTO cm_webhooks
-(monitor, enabled, include_results, url, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, url, template, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*WebhookAction, error) {
+func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string, template *string) (*WebhookAction, error) {
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
@@ -75,6 +80,7 @@ func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID in
 		enabled,
 		includeResults,
 		url,
+		template,
 		a.UID,
 		now,
 		a.UID,
@@ -169,6 +175,7 @@ var webhookActionColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_webhooks.enabled"),
 	sqlf.Sprintf("cm_webhooks.url"),
 	sqlf.Sprintf("cm_webhooks.include_results"),
+	sqlf.Sprintf("cm_webhooks.template"),
 	sqlf.Sprintf("cm_webhooks.created_by"),
 	sqlf.Sprintf("cm_webhooks.created_at"),
 	sqlf.Sprintf("cm_webhooks.changed_by"),
@@ -197,6 +204,7 @@ func scanWebhookAction(scanner dbutil.Scanner) (*WebhookAction, error) {
 		&w.Enabled,
 		&w.URL,
 		&w.IncludeResults,
+		&w.Template,
 		&w.CreatedBy,
 		&w.CreatedAt,
 		&w.ChangedBy,
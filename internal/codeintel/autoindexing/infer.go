@@ -19,6 +19,8 @@ func InferRepositoryAndRevision(pkg precise.Package) (repoName api.RepoName, git
 		inferNpmRepositoryAndRevision,
 		inferRustRepositoryAndRevision,
 		inferPythonRepositoryAndRevision,
+		inferRubyRepositoryAndRevision,
+		inferNuGetRepositoryAndRevision,
 	} {
 		if repoName, gitTagOrCommit, ok := fn(pkg); ok {
 			return repoName, gitTagOrCommit, true
@@ -102,3 +104,33 @@ func inferPythonRepositoryAndRevision(pkg precise.Package) (api.RepoName, string
 
 	return pythonPkg.RepoName(), pkg.Version, true
 }
+
+func inferRubyRepositoryAndRevision(pkg precise.Package) (api.RepoName, string, bool) {
+	if pkg.Scheme != dependencies.RubyPackagesScheme {
+		return "", "", false
+	}
+
+	logger := log.Scoped("inferRubyRepositoryAndRevision", "")
+	rubyPkg, err := reposource.ParseRubyPackageFromName(reposource.PackageName(pkg.Name))
+	if err != nil {
+		logger.Error("invalid ruby package name in database", log.Error(err), log.String("pkg", pkg.Name))
+		return "", "", false
+	}
+
+	return rubyPkg.RepoName(), "v" + pkg.Version, true
+}
+
+func inferNuGetRepositoryAndRevision(pkg precise.Package) (api.RepoName, string, bool) {
+	if pkg.Scheme != dependencies.NuGetPackagesScheme {
+		return "", "", false
+	}
+
+	logger := log.Scoped("inferNuGetRepositoryAndRevision", "")
+	nugetPkg, err := reposource.ParseNuGetPackageFromName(reposource.PackageName(pkg.Name))
+	if err != nil {
+		logger.Error("invalid nuget package name in database", log.Error(err), log.String("pkg", pkg.Name))
+		return "", "", false
+	}
+
+	return nugetPkg.RepoName(), "v" + pkg.Version, true
+}
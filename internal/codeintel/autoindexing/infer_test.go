@@ -58,6 +58,24 @@ func TestInferRepositoryAndRevision(t *testing.T) {
 				repoName: "npm/myscope/mypackage",
 				revision: "v1.0.0",
 			},
+			{
+				pkg: precise.Package{
+					Scheme:  "scip-ruby",
+					Name:    "rails",
+					Version: "7.0.4",
+				},
+				repoName: "rubygems/rails",
+				revision: "v7.0.4",
+			},
+			{
+				pkg: precise.Package{
+					Scheme:  "scip-dotnet",
+					Name:    "Newtonsoft.Json",
+					Version: "13.0.1",
+				},
+				repoName: "nuget/Newtonsoft.Json",
+				revision: "v13.0.1",
+			},
 		}
 
 		for _, testCase := range testCases {
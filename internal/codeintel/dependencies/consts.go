@@ -5,7 +5,9 @@ import "github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/share
 const (
 	JVMPackagesScheme    = shared.JVMPackagesScheme
 	NpmPackagesScheme    = shared.NpmPackagesScheme
+	NuGetPackagesScheme  = shared.NuGetPackagesScheme
 	GoPackagesScheme     = shared.GoPackagesScheme
 	PythonPackagesScheme = shared.PythonPackagesScheme
+	RubyPackagesScheme   = shared.RubyPackagesScheme
 	RustPackagesScheme   = shared.RustPackagesScheme
 )
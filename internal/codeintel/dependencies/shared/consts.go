@@ -4,6 +4,8 @@ const (
 	GoPackagesScheme     = "go"
 	JVMPackagesScheme    = "semanticdb"
 	NpmPackagesScheme    = "npm"
+	NuGetPackagesScheme  = "scip-dotnet"
 	PythonPackagesScheme = "python"
+	RubyPackagesScheme   = "scip-ruby"
 	RustPackagesScheme   = "rust-analyzer"
 )
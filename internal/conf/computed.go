@@ -425,6 +425,18 @@ func GitMaxCodehostRequestsPerSecond() int {
 	return *val
 }
 
+const defaultGitLFSMaxFileSize = 2 * 1024 * 1024
+
+// GitLFSMaxFileSize returns the maximum size in bytes of the Git LFS objects
+// that gitserver fetches. If not set, it returns the default value.
+func GitLFSMaxFileSize() int64 {
+	val := Get().GitLFSMaxFileSize
+	if val < 1 {
+		return defaultGitLFSMaxFileSize
+	}
+	return int64(val)
+}
+
 func GitMaxConcurrentClones() int {
 	v := Get().GitMaxConcurrentClones
 	if v <= 0 {
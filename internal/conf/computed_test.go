@@ -136,6 +136,31 @@ func TestGitLongCommandTimeout(t *testing.T) {
 	}
 }
 
+func TestGitLFSMaxFileSize(t *testing.T) {
+	tests := []struct {
+		name string
+		sc   *Unified
+		want int64
+	}{{
+		name: "Git LFS max file size has a default value if null",
+		sc:   &Unified{},
+		want: defaultGitLFSMaxFileSize,
+	}, {
+		name: "Git LFS max file size can be customized",
+		sc:   &Unified{SiteConfiguration: schema.SiteConfiguration{GitLFSMaxFileSize: 1024}},
+		want: 1024,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			Mock(test.sc)
+			if got, want := GitLFSMaxFileSize(), test.want; got != want {
+				t.Fatalf("GitLFSMaxFileSize() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func TestGitMaxCodehostRequestsPerSecond(t *testing.T) {
 	tests := []struct {
 		name string
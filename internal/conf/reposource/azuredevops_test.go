@@ -0,0 +1,48 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAzureDevOps_cloneURLToRepoName(t *testing.T) {
+	tests := []struct {
+		conn schema.AzureDevOpsConnection
+		urls []urlToRepoName
+	}{
+		{
+			conn: schema.AzureDevOpsConnection{
+				Url: "https://dev.azure.com",
+			},
+			urls: []urlToRepoName{
+				{"https://dev.azure.com/sgtest/project/_git/repo", "dev.azure.com/sgtest/project/repo"},
+				{"https://sgtest@dev.azure.com/sgtest/project/_git/repo", "dev.azure.com/sgtest/project/repo"},
+				{"https://dev.azure.com/sgtest/project/_git/repo.git", "dev.azure.com/sgtest/project/repo"},
+
+				{"https://asdf.com/sgtest/project/_git/repo", ""},
+			},
+		},
+		{
+			conn: schema.AzureDevOpsConnection{
+				Url:                   "https://dev.azure.com",
+				RepositoryPathPattern: "ado/{nameWithOwner}",
+			},
+			urls: []urlToRepoName{
+				{"https://dev.azure.com/sgtest/project/_git/repo", "ado/sgtest/project/repo"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		for _, u := range test.urls {
+			repoName, err := AzureDevOps{&test.conn}.CloneURLToRepoName(u.cloneURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.repoName != string(repoName) {
+				t.Errorf("expected %q but got %q for clone URL %q (connection: %+v)", u.repoName, repoName, u.cloneURL, test.conn)
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGitea_cloneURLToRepoName(t *testing.T) {
+	tests := []struct {
+		conn schema.GiteaConnection
+		urls []urlToRepoName
+	}{
+		{
+			conn: schema.GiteaConnection{
+				Url: "https://gitea.example.com",
+			},
+			urls: []urlToRepoName{
+				{"git@gitea.example.com:alice/dotfiles.git", "gitea.example.com/alice/dotfiles"},
+				{"https://gitea.example.com/alice/dotfiles", "gitea.example.com/alice/dotfiles"},
+				{"https://alice@gitea.example.com/alice/dotfiles.git", "gitea.example.com/alice/dotfiles"},
+
+				{"https://codeberg.org/alice/dotfiles.git", ""},
+			},
+		},
+		{
+			conn: schema.GiteaConnection{
+				Url:                   "https://gitea.example.com/gitea/",
+				RepositoryPathPattern: "gitea/{nameWithOwner}",
+			},
+			urls: []urlToRepoName{
+				{"https://gitea.example.com/gitea/alice/dotfiles.git", "gitea/alice/dotfiles"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		for _, u := range test.urls {
+			repoName, err := Gitea{&test.conn}.CloneURLToRepoName(u.cloneURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.repoName != string(repoName) {
+				t.Errorf("expected %q but got %q for clone URL %q (connection: %+v)", u.repoName, repoName, u.cloneURL, test.conn)
+			}
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// MercurialRepoName returns the Sourcegraph name of the Mercurial repository at
+// the given path of a Mercurial server.
+func MercurialRepoName(repositoryPathPattern, host, path string) api.RepoName {
+	if repositoryPathPattern == "" {
+		repositoryPathPattern = "{host}/{path}"
+	}
+
+	return api.RepoName(strings.NewReplacer(
+		"{host}", host,
+		"{path}", strings.Trim(path, "/"),
+	).Replace(repositoryPathPattern))
+}
@@ -0,0 +1,103 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	// nugetPackageIDRegex matches the package IDs accepted by NuGet.
+	nugetPackageIDRegex = lazyregexp.New(`^\w+([.-]\w+)*$`)
+	// nugetVersionRegex matches NuGet (SemVer 2.0) versions, including
+	// prerelease labels and build metadata.
+	nugetVersionRegex = lazyregexp.New(`^[0-9][a-zA-Z0-9.+-]*$`)
+)
+
+type NuGetVersionedPackage struct {
+	Name    PackageName
+	Version string
+}
+
+func NewNuGetVersionedPackage(name PackageName, version string) *NuGetVersionedPackage {
+	return &NuGetVersionedPackage{
+		Name:    name,
+		Version: version,
+	}
+}
+
+// ParseNuGetVersionedPackage parses a string in a '<id>(@version>)?' format into an
+// NuGetVersionedPackage.
+func ParseNuGetVersionedPackage(dependency string) (*NuGetVersionedPackage, error) {
+	var dep NuGetVersionedPackage
+	if i := strings.LastIndex(dependency, "@"); i == -1 {
+		dep.Name = PackageName(strings.TrimSpace(dependency))
+	} else {
+		dep.Name = PackageName(strings.TrimSpace(dependency[:i]))
+		dep.Version = strings.TrimSpace(dependency[i+1:])
+	}
+
+	if !nugetPackageIDRegex.MatchString(string(dep.Name)) {
+		return nil, errors.Errorf("invalid NuGet package ID %q", dep.Name)
+	}
+	if dep.Version != "" && !nugetVersionRegex.MatchString(dep.Version) {
+		return nil, errors.Errorf("invalid version %q of NuGet package %q", dep.Version, dep.Name)
+	}
+	return &dep, nil
+}
+
+func ParseNuGetPackageFromName(name PackageName) (*NuGetVersionedPackage, error) {
+	return ParseNuGetVersionedPackage(string(name))
+}
+
+// ParseNuGetPackageFromRepoName is a convenience function to parse a repo name in a
+// 'nuget/<id>(@<version>)?' format into a NuGetVersionedPackage.
+func ParseNuGetPackageFromRepoName(name api.RepoName) (*NuGetVersionedPackage, error) {
+	dependency := strings.TrimPrefix(string(name), "nuget/")
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid NuGet dependency repo name, missing nuget/ prefix '%s'", name)
+	}
+	return ParseNuGetVersionedPackage(dependency)
+}
+
+func (p *NuGetVersionedPackage) Scheme() string {
+	return "scip-dotnet"
+}
+
+func (p *NuGetVersionedPackage) PackageSyntax() PackageName {
+	return p.Name
+}
+
+func (p *NuGetVersionedPackage) VersionedPackageSyntax() string {
+	if p.Version == "" {
+		return string(p.Name)
+	}
+	return string(p.Name) + "@" + p.Version
+}
+
+func (p *NuGetVersionedPackage) PackageVersion() string {
+	return p.Version
+}
+
+func (p *NuGetVersionedPackage) Description() string { return "" }
+
+func (p *NuGetVersionedPackage) RepoName() api.RepoName {
+	return api.RepoName("nuget/" + p.Name)
+}
+
+func (p *NuGetVersionedPackage) GitTagFromVersion() string {
+	version := strings.TrimPrefix(p.Version, "v")
+	return "v" + version
+}
+
+func (p *NuGetVersionedPackage) Less(other VersionedPackage) bool {
+	o := other.(*NuGetVersionedPackage)
+
+	if p.Name == o.Name {
+		return versionGreaterThan(p.Version, o.Version)
+	}
+
+	return p.Name > o.Name
+}
@@ -0,0 +1,48 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseNuGetVersionedPackage(t *testing.T) {
+	for _, tc := range []struct {
+		dep     string
+		name    PackageName
+		version string
+		ok      bool
+	}{
+		{dep: "Newtonsoft.Json@13.0.1", name: "Newtonsoft.Json", version: "13.0.1", ok: true},
+		{dep: "Serilog", name: "Serilog", ok: true},
+		{dep: "Microsoft.Extensions.Logging@7.0.0-rc.2.22472.3", name: "Microsoft.Extensions.Logging", version: "7.0.0-rc.2.22472.3", ok: true},
+		{dep: "../Newtonsoft.Json@13.0.1"},
+		{dep: "Newtonsoft.Json@../13.0.1"},
+		{dep: ""},
+	} {
+		t.Run(tc.dep, func(t *testing.T) {
+			dep, err := ParseNuGetVersionedPackage(tc.dep)
+			if !tc.ok {
+				assert.Error(t, err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, tc.name, dep.Name)
+			assert.Equal(t, tc.version, dep.Version)
+			assert.Equal(t, tc.dep, dep.VersionedPackageSyntax())
+		})
+	}
+}
+
+func TestParseNuGetPackageFromRepoName(t *testing.T) {
+	dep, err := ParseNuGetPackageFromRepoName("nuget/Newtonsoft.Json")
+	require.Nil(t, err)
+	assert.Equal(t, PackageName("Newtonsoft.Json"), dep.Name)
+	assert.Equal(t, api.RepoName("nuget/Newtonsoft.Json"), dep.RepoName())
+
+	_, err = ParseNuGetPackageFromRepoName("npm/Newtonsoft.Json")
+	assert.Error(t, err)
+}
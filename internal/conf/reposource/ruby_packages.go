@@ -0,0 +1,103 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	// rubyGemNameRegex matches the gem names accepted by rubygems.org.
+	rubyGemNameRegex = lazyregexp.New(`^[a-zA-Z0-9._-]+$`)
+	// rubyGemVersionRegex matches gem versions, which may include a platform
+	// suffix such as "1.13.8-x86_64-linux".
+	rubyGemVersionRegex = lazyregexp.New(`^[0-9][a-zA-Z0-9._-]*$`)
+)
+
+type RubyVersionedPackage struct {
+	Name    PackageName
+	Version string
+}
+
+func NewRubyVersionedPackage(name PackageName, version string) *RubyVersionedPackage {
+	return &RubyVersionedPackage{
+		Name:    name,
+		Version: version,
+	}
+}
+
+// ParseRubyVersionedPackage parses a string in a '<name>(@version>)?' format into an
+// RubyVersionedPackage.
+func ParseRubyVersionedPackage(dependency string) (*RubyVersionedPackage, error) {
+	var dep RubyVersionedPackage
+	if i := strings.LastIndex(dependency, "@"); i == -1 {
+		dep.Name = PackageName(strings.TrimSpace(dependency))
+	} else {
+		dep.Name = PackageName(strings.TrimSpace(dependency[:i]))
+		dep.Version = strings.TrimSpace(dependency[i+1:])
+	}
+
+	if !rubyGemNameRegex.MatchString(string(dep.Name)) {
+		return nil, errors.Errorf("invalid Ruby package name %q", dep.Name)
+	}
+	if dep.Version != "" && !rubyGemVersionRegex.MatchString(dep.Version) {
+		return nil, errors.Errorf("invalid version %q of Ruby package %q", dep.Version, dep.Name)
+	}
+	return &dep, nil
+}
+
+func ParseRubyPackageFromName(name PackageName) (*RubyVersionedPackage, error) {
+	return ParseRubyVersionedPackage(string(name))
+}
+
+// ParseRubyPackageFromRepoName is a convenience function to parse a repo name in a
+// 'rubygems/<name>(@<version>)?' format into a RubyVersionedPackage.
+func ParseRubyPackageFromRepoName(name api.RepoName) (*RubyVersionedPackage, error) {
+	dependency := strings.TrimPrefix(string(name), "rubygems/")
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid Ruby dependency repo name, missing rubygems/ prefix '%s'", name)
+	}
+	return ParseRubyVersionedPackage(dependency)
+}
+
+func (p *RubyVersionedPackage) Scheme() string {
+	return "scip-ruby"
+}
+
+func (p *RubyVersionedPackage) PackageSyntax() PackageName {
+	return p.Name
+}
+
+func (p *RubyVersionedPackage) VersionedPackageSyntax() string {
+	if p.Version == "" {
+		return string(p.Name)
+	}
+	return string(p.Name) + "@" + p.Version
+}
+
+func (p *RubyVersionedPackage) PackageVersion() string {
+	return p.Version
+}
+
+func (p *RubyVersionedPackage) Description() string { return "" }
+
+func (p *RubyVersionedPackage) RepoName() api.RepoName {
+	return api.RepoName("rubygems/" + p.Name)
+}
+
+func (p *RubyVersionedPackage) GitTagFromVersion() string {
+	version := strings.TrimPrefix(p.Version, "v")
+	return "v" + version
+}
+
+func (p *RubyVersionedPackage) Less(other VersionedPackage) bool {
+	o := other.(*RubyVersionedPackage)
+
+	if p.Name == o.Name {
+		return versionGreaterThan(p.Version, o.Version)
+	}
+
+	return p.Name > o.Name
+}
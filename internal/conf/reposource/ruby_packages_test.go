@@ -0,0 +1,66 @@
+package reposource
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseRubyVersionedPackage(t *testing.T) {
+	for _, tc := range []struct {
+		dep     string
+		name    PackageName
+		version string
+		ok      bool
+	}{
+		{dep: "rails@7.0.4", name: "rails", version: "7.0.4", ok: true},
+		{dep: "rails", name: "rails", ok: true},
+		{dep: "aws-sdk-s3@1.117.1", name: "aws-sdk-s3", version: "1.117.1", ok: true},
+		{dep: "nokogiri@1.13.8-x86_64-linux", name: "nokogiri", version: "1.13.8-x86_64-linux", ok: true},
+		{dep: "../etc/passwd@1.0.0"},
+		{dep: "rails@../1.0.0"},
+		{dep: ""},
+	} {
+		t.Run(tc.dep, func(t *testing.T) {
+			dep, err := ParseRubyVersionedPackage(tc.dep)
+			if !tc.ok {
+				assert.Error(t, err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, tc.name, dep.Name)
+			assert.Equal(t, tc.version, dep.Version)
+			assert.Equal(t, tc.dep, dep.VersionedPackageSyntax())
+		})
+	}
+}
+
+func TestParseRubyPackageFromRepoName(t *testing.T) {
+	dep, err := ParseRubyPackageFromRepoName("rubygems/rails")
+	require.Nil(t, err)
+	assert.Equal(t, PackageName("rails"), dep.Name)
+	assert.Equal(t, api.RepoName("rubygems/rails"), dep.RepoName())
+
+	_, err = ParseRubyPackageFromRepoName("crates/rails")
+	assert.Error(t, err)
+}
+
+func TestRubyVersionedPackage_Less(t *testing.T) {
+	deps := []VersionedPackage{
+		NewRubyVersionedPackage("rails", "6.1.7"),
+		NewRubyVersionedPackage("rack", "3.0.0"),
+		NewRubyVersionedPackage("rails", "7.0.4"),
+		NewRubyVersionedPackage("rails", "6.10.0"),
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Less(deps[j]) })
+
+	var got []string
+	for _, d := range deps {
+		got = append(got, d.VersionedPackageSyntax())
+	}
+	assert.Equal(t, []string{"rails@7.0.4", "rails@6.10.0", "rails@6.1.7", "rack@3.0.0"}, got)
+}
@@ -0,0 +1,20 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// SubversionRepoName returns the Sourcegraph name of the Subversion repository
+// at the given path of a Subversion server.
+func SubversionRepoName(repositoryPathPattern, host, path string) api.RepoName {
+	if repositoryPathPattern == "" {
+		repositoryPathPattern = "{host}/{path}"
+	}
+
+	return api.RepoName(strings.NewReplacer(
+		"{host}", host,
+		"{path}", strings.Trim(path, "/"),
+	).Replace(repositoryPathPattern))
+}
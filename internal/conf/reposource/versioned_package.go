@@ -22,7 +22,9 @@ type VersionedPackage interface {
 var (
 	_ VersionedPackage = (*MavenVersionedPackage)(nil)
 	_ VersionedPackage = (*NpmVersionedPackage)(nil)
+	_ VersionedPackage = (*NuGetVersionedPackage)(nil)
 	_ VersionedPackage = (*GoVersionedPackage)(nil)
 	_ VersionedPackage = (*PythonVersionedPackage)(nil)
+	_ VersionedPackage = (*RubyVersionedPackage)(nil)
 	_ VersionedPackage = (*RustVersionedPackage)(nil)
 )
@@ -177,6 +177,70 @@ func TestRedactSecrets_AuthProvidersSectionNotAdded(t *testing.T) {
 	assert.Equal(t, want, redacted.Site)
 }
 
+func TestRedactSecrets_LDAPBindPassword(t *testing.T) {
+	const cfg = `{
+  "auth.providers": [
+    {
+      "bindDN": "cn=sourcegraph,dc=example,dc=com",
+      "bindPassword": "%s",
+      "type": "ldap",
+      "url": "ldaps://ldap.example.com",
+      "userSearchBaseDN": "dc=example,dc=com"
+    }
+  ]
+}`
+	site := fmt.Sprintf(cfg, "ldapBindPassword")
+
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cfg, redactedSecret), redacted.Site)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.Equal(t, site, unredacted)
+}
+
+func TestRedactSecrets_BitbucketCloudClientSecret(t *testing.T) {
+	const cfg = `{
+  "auth.providers": [
+    {
+      "clientKey": "sourcegraph-client-bitbucketcloud",
+      "clientSecret": "%s",
+      "type": "bitbucketcloud"
+    }
+  ]
+}`
+	site := fmt.Sprintf(cfg, "authBitbucketCloudClientSecret")
+
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cfg, redactedSecret), redacted.Site)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.Equal(t, site, unredacted)
+}
+
+func TestRedactSecrets_SCIMAuthToken(t *testing.T) {
+	const cfg = `{
+  "auth.providers": [
+    {
+      "type": "builtin"
+    }
+  ],
+  "scim.authToken": "%s"
+}`
+	site := fmt.Sprintf(cfg, "scimAuthTokenscimAuthToken")
+
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cfg, redactedSecret), redacted.Site)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.Equal(t, site, unredacted)
+}
+
 func TestUnredactSecrets(t *testing.T) {
 	previousSite := getTestSiteWithSecrets(
 		executorsAccessToken,
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -358,6 +359,18 @@ type ExternalAccountsListOptions struct {
 	ExcludeExpired bool
 	OnlyExpired    bool
 
+	// IncludeDeleted includes accounts that were soft-deleted, e.g. along with their user.
+	IncludeDeleted bool
+
+	// LatestPerUser only includes the most recent account of every user that matches the
+	// other options, except for AccountData. The accounts are ordered by user ID instead of ID.
+	LatestPerUser bool
+
+	// AccountData only includes accounts whose account data is a JSON object with the given
+	// string values at the given top-level keys. Values are compared case-insensitively.
+	// Encrypted account data never matches.
+	AccountData map[string]string
+
 	*LimitOffset
 }
 
@@ -425,7 +438,11 @@ func (s *userExternalAccountsStore) List(ctx context.Context, opt ExternalAccoun
 	}()
 
 	conds := s.listSQL(opt)
-	return s.ListBySQL(ctx, sqlf.Sprintf("WHERE %s ORDER BY id ASC %s", sqlf.Join(conds, "AND"), opt.LimitOffset.SQL()))
+	order := sqlf.Sprintf("id ASC")
+	if opt.LatestPerUser {
+		order = sqlf.Sprintf("user_id ASC")
+	}
+	return s.ListBySQL(ctx, sqlf.Sprintf("WHERE %s ORDER BY %s %s", sqlf.Join(conds, "AND"), order, opt.LimitOffset.SQL()))
 }
 
 func (s *userExternalAccountsStore) Count(ctx context.Context, opt ExternalAccountsListOptions) (int, error) {
@@ -483,7 +500,10 @@ func (s *userExternalAccountsStore) ListBySQL(ctx context.Context, querySuffix *
 }
 
 func (s *userExternalAccountsStore) listSQL(opt ExternalAccountsListOptions) (conds []*sqlf.Query) {
-	conds = []*sqlf.Query{sqlf.Sprintf("deleted_at IS NULL")}
+	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if !opt.IncludeDeleted {
+		conds = append(conds, sqlf.Sprintf("deleted_at IS NULL"))
+	}
 
 	if opt.UserID != 0 {
 		conds = append(conds, sqlf.Sprintf("user_id=%d", opt.UserID))
@@ -509,6 +529,23 @@ func (s *userExternalAccountsStore) listSQL(opt ExternalAccountsListOptions) (co
 	if opt.AccountIDLike != "" {
 		conds = append(conds, sqlf.Sprintf("account_id LIKE %s", opt.AccountIDLike))
 	}
+	if opt.LatestPerUser {
+		latest := sqlf.Sprintf("SELECT MAX(id) FROM user_external_accounts WHERE %s GROUP BY user_id", sqlf.Join(conds, "AND"))
+		conds = append(conds, sqlf.Sprintf("id IN (%s)", latest))
+	}
+
+	keys := make([]string, 0, len(opt.AccountData))
+	for key := range opt.AccountData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		// Encrypted account data is not JSON, so it must not be cast.
+		conds = append(conds, sqlf.Sprintf(
+			"CASE WHEN encryption_key_id IN ('', 'previously-migrated') THEN lower(account_data::jsonb->>%s) = lower(%s) ELSE FALSE END",
+			key, opt.AccountData[key],
+		))
+	}
 
 	return conds
 }
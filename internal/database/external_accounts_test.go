@@ -338,6 +338,77 @@ func TestExternalAccounts_List(t *testing.T) {
 	}
 }
 
+func TestExternalAccounts_ListLatestPerUser(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	data := func(userName string) extsvc.AccountData {
+		return extsvc.AccountData{Data: extsvc.NewUnencryptedData(json.RawMessage(fmt.Sprintf(`{"userName": %q}`, userName)))}
+	}
+	spec := func(accountID string) extsvc.AccountSpec {
+		return extsvc.AccountSpec{ServiceType: "xa", ServiceID: "xb", AccountID: accountID}
+	}
+
+	alice, err := db.UserExternalAccounts().CreateUserAndSave(ctx, NewUser{Username: "alice"}, spec("1"), data("Alice"))
+	require.NoError(t, err)
+	bob, err := db.UserExternalAccounts().CreateUserAndSave(ctx, NewUser{Username: "bob"}, spec("2"), data("bob"))
+	require.NoError(t, err)
+	// The most recent account of alice replaces the first one.
+	require.NoError(t, db.UserExternalAccounts().AssociateUserAndSave(ctx, alice, spec("3"), data("alice2")))
+
+	tc := []struct {
+		name         string
+		args         ExternalAccountsListOptions
+		wantAccounts []string
+	}{
+		{
+			name:         "latest per user",
+			args:         ExternalAccountsListOptions{ServiceType: "xa", LatestPerUser: true},
+			wantAccounts: []string{"3", "2"},
+		},
+		{
+			name:         "account data of the latest account",
+			args:         ExternalAccountsListOptions{ServiceType: "xa", LatestPerUser: true, AccountData: map[string]string{"userName": "ALICE2"}},
+			wantAccounts: []string{"3"},
+		},
+		{
+			name:         "account data of an older account",
+			args:         ExternalAccountsListOptions{ServiceType: "xa", LatestPerUser: true, AccountData: map[string]string{"userName": "alice"}},
+			wantAccounts: []string{},
+		},
+		{
+			name:         "limit and offset",
+			args:         ExternalAccountsListOptions{ServiceType: "xa", LatestPerUser: true, LimitOffset: &LimitOffset{Limit: 1, Offset: 1}},
+			wantAccounts: []string{"2"},
+		},
+	}
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			accounts, err := db.UserExternalAccounts().List(ctx, c.args)
+			require.NoError(t, err)
+			got := []string{}
+			for _, a := range accounts {
+				got = append(got, a.AccountID)
+			}
+			require.Equal(t, c.wantAccounts, got)
+		})
+	}
+
+	count, err := db.UserExternalAccounts().Count(ctx, ExternalAccountsListOptions{ServiceType: "xa", LatestPerUser: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	accounts, err := db.UserExternalAccounts().List(ctx, ExternalAccountsListOptions{ServiceType: "xa", LatestPerUser: true, AccountData: map[string]string{"userName": "bob"}})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, bob, accounts[0].UserID)
+}
+
 func TestExternalAccounts_Encryption(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
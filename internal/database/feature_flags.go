@@ -3,8 +3,10 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -25,6 +27,7 @@ type FeatureFlagStore interface {
 	DeleteFeatureFlag(context.Context, string) error
 	CreateRollout(ctx context.Context, name string, rollout int32) (*ff.FeatureFlag, error)
 	CreateBool(ctx context.Context, name string, value bool) (*ff.FeatureFlag, error)
+	CreateRules(ctx context.Context, name string, rules *ff.FeatureFlagRules) (*ff.FeatureFlag, error)
 	GetFeatureFlag(ctx context.Context, flagName string) (*ff.FeatureFlag, error)
 	GetFeatureFlags(context.Context) ([]*ff.FeatureFlag, error)
 	CreateOverride(context.Context, *ff.Override) (*ff.Override, error)
@@ -63,36 +66,28 @@ func (f *featureFlagStore) CreateFeatureFlag(ctx context.Context, flag *ff.Featu
 			flag_name,
 			flag_type,
 			bool_value,
-			rollout
+			rollout,
+			rules
 		) VALUES (
 			%s,
 			%s,
 			%s,
+			%s,
 			%s
 		) RETURNING
 			flag_name,
 			flag_type,
 			bool_value,
 			rollout,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at
 		;
 	`
-	var (
-		flagType string
-		boolVal  *bool
-		rollout  *int32
-	)
-	switch {
-	case flag.Bool != nil:
-		flagType = "bool"
-		boolVal = &flag.Bool.Value
-	case flag.Rollout != nil:
-		flagType = "rollout"
-		rollout = &flag.Rollout.Rollout
-	default:
-		return nil, errors.New("feature flag must have exactly one type")
+	flagType, boolVal, rollout, rules, err := featureFlagColumns(flag)
+	if err != nil {
+		return nil, err
 	}
 
 	row := f.QueryRow(ctx, sqlf.Sprintf(
@@ -100,7 +95,8 @@ func (f *featureFlagStore) CreateFeatureFlag(ctx context.Context, flag *ff.Featu
 		flag.Name,
 		flagType,
 		boolVal,
-		rollout))
+		rollout,
+		rules))
 	return scanFeatureFlag(row)
 }
 
@@ -110,32 +106,23 @@ func (f *featureFlagStore) UpdateFeatureFlag(ctx context.Context, flag *ff.Featu
 		SET
 			flag_type = %s,
 			bool_value = %s,
-			rollout = %s
+			rollout = %s,
+			rules = %s
 		WHERE flag_name = %s
 		RETURNING
 			flag_name,
 			flag_type,
 			bool_value,
 			rollout,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at
 		;
 	`
-	var (
-		flagType string
-		boolVal  *bool
-		rollout  *int32
-	)
-	switch {
-	case flag.Bool != nil:
-		flagType = "bool"
-		boolVal = &flag.Bool.Value
-	case flag.Rollout != nil:
-		flagType = "rollout"
-		rollout = &flag.Rollout.Rollout
-	default:
-		return nil, errors.New("feature flag must have exactly one type")
+	flagType, boolVal, rollout, rules, err := featureFlagColumns(flag)
+	if err != nil {
+		return nil, err
 	}
 
 	row := f.QueryRow(ctx, sqlf.Sprintf(
@@ -143,6 +130,7 @@ func (f *featureFlagStore) UpdateFeatureFlag(ctx context.Context, flag *ff.Featu
 		flagType,
 		boolVal,
 		rollout,
+		rules,
 		flag.Name,
 	))
 	return scanFeatureFlag(row)
@@ -179,6 +167,38 @@ func (f *featureFlagStore) CreateBool(ctx context.Context, name string, value bo
 	})
 }
 
+func (f *featureFlagStore) CreateRules(ctx context.Context, name string, rules *ff.FeatureFlagRules) (*ff.FeatureFlag, error) {
+	return f.CreateFeatureFlag(ctx, &ff.FeatureFlag{
+		Name:  name,
+		Rules: rules,
+	})
+}
+
+// featureFlagColumns returns the values of the type-specific columns of the
+// given feature flag.
+func featureFlagColumns(flag *ff.FeatureFlag) (flagType string, boolVal *bool, rollout *int32, rules []byte, err error) {
+	switch {
+	case flag.Bool != nil:
+		flagType = "bool"
+		boolVal = &flag.Bool.Value
+	case flag.Rollout != nil:
+		flagType = "rollout"
+		rollout = &flag.Rollout.Rollout
+	case flag.Rules != nil:
+		if err := flag.Rules.Validate(); err != nil {
+			return "", nil, nil, nil, err
+		}
+		flagType = "rules"
+		rules, err = json.Marshal(flag.Rules)
+		if err != nil {
+			return "", nil, nil, nil, err
+		}
+	default:
+		return "", nil, nil, nil, errors.New("feature flag must have exactly one type")
+	}
+	return flagType, boolVal, rollout, rules, nil
+}
+
 var ErrInvalidColumnState = errors.New("encountered column that is unexpectedly null based on column type")
 
 // rowScanner is an interface that can scan from either a sql.Row or sql.Rows
@@ -192,12 +212,14 @@ func scanFeatureFlag(scanner rowScanner) (*ff.FeatureFlag, error) {
 		flagType string
 		boolVal  *bool
 		rollout  *int32
+		rules    []byte
 	)
 	err := scanner.Scan(
 		&res.Name,
 		&flagType,
 		&boolVal,
 		&rollout,
+		&rules,
 		&res.CreatedAt,
 		&res.UpdatedAt,
 		&res.DeletedAt,
@@ -221,6 +243,14 @@ func scanFeatureFlag(scanner rowScanner) (*ff.FeatureFlag, error) {
 		res.Rollout = &ff.FeatureFlagRollout{
 			Rollout: *rollout,
 		}
+	case "rules":
+		if rules == nil {
+			return nil, ErrInvalidColumnState
+		}
+		res.Rules = &ff.FeatureFlagRules{}
+		if err := json.Unmarshal(rules, res.Rules); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, ErrInvalidColumnState
 	}
@@ -235,6 +265,7 @@ func (f *featureFlagStore) GetFeatureFlag(ctx context.Context, flagName string)
 			flag_type,
 			bool_value,
 			rollout,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at
@@ -254,6 +285,7 @@ func (f *featureFlagStore) GetFeatureFlags(ctx context.Context) ([]*ff.FeatureFl
 			flag_type,
 			bool_value,
 			rollout,
+			rules,
 			created_at,
 			updated_at,
 			deleted_at
@@ -480,25 +512,27 @@ func scanFeatureFlagOverride(scanner rowScanner) (*ff.Override, error) {
 // be the primary entrypoint for getting the user flags since it handles retrieving all the flags,
 // the org overrides, and the user overrides, and merges them in priority order.
 func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[string]bool, error) {
-	g, ctx := errgroup.WithContext(ctx)
+	// The context of the group is canceled once Wait returns, so we keep ctx
+	// around for the queries that follow.
+	g, gctx := errgroup.WithContext(ctx)
 
 	var flags []*ff.FeatureFlag
 	g.Go(func() error {
-		res, err := f.GetFeatureFlags(ctx)
+		res, err := f.GetFeatureFlags(gctx)
 		flags = res
 		return err
 	})
 
 	var orgOverrides []*ff.Override
 	g.Go(func() error {
-		res, err := f.GetOrgOverridesForUser(ctx, userID)
+		res, err := f.GetOrgOverridesForUser(gctx, userID)
 		orgOverrides = res
 		return err
 	})
 
 	var userOverrides []*ff.Override
 	g.Go(func() error {
-		res, err := f.GetUserOverrides(ctx, userID)
+		res, err := f.GetUserOverrides(gctx, userID)
 		userOverrides = res
 		return err
 	})
@@ -507,10 +541,23 @@ func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[
 		return nil, err
 	}
 
+	// The attributes of the user are only needed to evaluate targeting rules,
+	// so we avoid loading them if no flag has any.
+	user := &ff.UserAttributes{UserID: userID}
+	for _, flag := range flags {
+		if flag.Rules != nil {
+			var err error
+			if user, err = f.getUserAttributes(ctx, userID); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
 	res := make(map[string]bool, len(flags))
 
 	for _, ff := range flags {
-		res[ff.Name] = ff.EvaluateForUser(userID)
+		res[ff.Name] = ff.EvaluateForUser(user)
 	}
 
 	// Org overrides are higher priority than default
@@ -526,6 +573,52 @@ func (f *featureFlagStore) GetUserFlags(ctx context.Context, userID int32) (map[
 	return res, nil
 }
 
+// getUserAttributes returns the attributes of the given user that targeting
+// rules are evaluated against.
+func (f *featureFlagStore) getUserAttributes(ctx context.Context, userID int32) (*ff.UserAttributes, error) {
+	const getUserAttributesFmtStr = `
+		SELECT
+			users.site_admin,
+			ARRAY(
+				SELECT org_members.org_id
+				FROM org_members
+				JOIN orgs ON orgs.id = org_members.org_id
+				WHERE org_members.user_id = users.id
+					AND orgs.deleted_at IS NULL
+			),
+			ARRAY(
+				SELECT user_emails.email
+				FROM user_emails
+				WHERE user_emails.user_id = users.id
+					AND user_emails.verified_at IS NOT NULL
+			)
+		FROM users
+		WHERE users.id = %s
+			AND users.deleted_at IS NULL;
+	`
+
+	user := &ff.UserAttributes{UserID: userID}
+	var emails []string
+	err := f.QueryRow(ctx, sqlf.Sprintf(getUserAttributesFmtStr, userID)).Scan(
+		&user.SiteAdmin,
+		pq.Array(&user.OrgIDs),
+		pq.Array(&emails),
+	)
+	if err == sql.ErrNoRows {
+		// Deleted users only match rules that don't depend on attributes.
+		return user, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	for _, email := range emails {
+		if domain := ff.EmailDomain(email); domain != "" {
+			user.EmailDomains = append(user.EmailDomains, domain)
+		}
+	}
+	return user, nil
+}
+
 // GetAnonymousUserFlags returns the calculated values for feature flags for the given anonymousUID
 func (f *featureFlagStore) GetAnonymousUserFlags(ctx context.Context, anonymousUID string) (map[string]bool, error) {
 	flags, err := f.GetFeatureFlags(ctx)
@@ -557,7 +650,8 @@ func (f *featureFlagStore) GetGlobalFeatureFlags(ctx context.Context) (map[strin
 	return res, nil
 }
 
-// GetOrgFeatureFlag returns the calculated flag value for the given organization, taking potential override into account
+// GetOrgFeatureFlag returns the calculated flag value for the given organization, taking potential override into account.
+// Targeting rules are evaluated against the organization itself, so only org_members rules can match it.
 func (f *featureFlagStore) GetOrgFeatureFlag(ctx context.Context, orgID int32, flagName string) (bool, error) {
 	g, ctx := errgroup.WithContext(ctx)
 
@@ -584,7 +678,7 @@ func (f *featureFlagStore) GetOrgFeatureFlag(ctx context.Context, orgID int32, f
 	if override != nil {
 		return override.Value, nil
 	} else if globalFlag != nil {
-		return globalFlag.Bool.Value, nil
+		return globalFlag.EvaluateForOrg(orgID), nil
 	}
 
 	return false, nil
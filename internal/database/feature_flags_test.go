@@ -86,6 +86,25 @@ func testNewFeatureFlagRoundtrip(t *testing.T) {
 			flag:      &ff.FeatureFlag{Name: "err_too_low_rollout", Rollout: &ff.FeatureFlagRollout{Rollout: -1}},
 			assertErr: errorContains(`violates check constraint "feature_flags_rollout_check"`),
 		},
+		{
+			flag: &ff.FeatureFlag{Name: "rules", Rules: &ff.FeatureFlagRules{
+				Rules: []*ff.TargetingRule{
+					{Type: ff.TargetingRuleOrgMembers, OrgIDs: []int32{1, 2}, Value: true},
+					{Type: ff.TargetingRuleEmailDomains, EmailDomains: []string{"example.com"}, Value: true},
+					{Type: ff.TargetingRuleSiteAdmins, Value: false},
+				},
+				Default: true,
+			}},
+		},
+		{
+			flag: &ff.FeatureFlag{Name: "empty_rules", Rules: &ff.FeatureFlagRules{
+				Rules: []*ff.TargetingRule{},
+			}},
+		},
+		{
+			flag:      &ff.FeatureFlag{Name: "err_invalid_rules", Rules: &ff.FeatureFlagRules{Rules: []*ff.TargetingRule{{Type: ff.TargetingRuleUsers}}}},
+			assertErr: errorContains(`users rule must specify at least one of the users it applies to`),
+		},
 		{
 			flag:      &ff.FeatureFlag{Name: "err_no_types"},
 			assertErr: errorContains(`feature flag must have exactly one type`),
@@ -106,6 +125,7 @@ func testNewFeatureFlagRoundtrip(t *testing.T) {
 			require.Equal(t, tc.flag.Name, res.Name)
 			require.Equal(t, tc.flag.Bool, res.Bool)
 			require.Equal(t, tc.flag.Rollout, res.Rollout)
+			require.Equal(t, tc.flag.Rules, res.Rules)
 		})
 	}
 }
@@ -505,6 +525,49 @@ func testUserFlags(t *testing.T) {
 		require.Equal(t, expected, got)
 	})
 
+	t.Run("rules", func(t *testing.T) {
+		t.Cleanup(cleanup(t, db))
+		o1 := mkOrg("o1")
+		o2 := mkOrg("o2")
+		member := mkUser("member", o1.ID)
+		admin := mkUser("admin", o2.ID)
+		require.NoError(t, users.SetIsSiteAdmin(ctx, admin.ID, true))
+		employee, err := users.Create(ctx, NewUser{Username: "employee", Email: "employee@Example.com", EmailIsVerified: true, Password: "p"})
+		require.NoError(t, err)
+		unverified, err := users.Create(ctx, NewUser{Username: "unverified", Email: "unverified@example.com", EmailVerificationCode: "c", Password: "p"})
+		require.NoError(t, err)
+		other := mkUser("other", o2.ID)
+		require.NoError(t, users.SetIsSiteAdmin(ctx, other.ID, false))
+
+		_, err = flagStore.CreateRules(ctx, "f1", &ff.FeatureFlagRules{
+			Rules: []*ff.TargetingRule{
+				{Type: ff.TargetingRuleUsers, UserIDs: []int32{other.ID}, Value: false},
+				{Type: ff.TargetingRuleOrgMembers, OrgIDs: []int32{o1.ID}, Value: true},
+				{Type: ff.TargetingRuleSiteAdmins, Value: true},
+				{Type: ff.TargetingRuleEmailDomains, EmailDomains: []string{"example.com"}, Value: true},
+			},
+			Default: false,
+		})
+		require.NoError(t, err)
+		mkFFBool("f2", true)
+		mkOrgOverride(o1.ID, "f1", false)
+
+		for _, tc := range []struct {
+			user *types.User
+			want bool
+		}{
+			{user: member, want: false}, // org override beats rules
+			{user: admin, want: true},
+			{user: employee, want: true},
+			{user: unverified, want: false},
+			{user: other, want: false},
+		} {
+			got, err := flagStore.GetUserFlags(ctx, tc.user.ID)
+			require.NoError(t, err)
+			require.Equal(t, map[string]bool{"f1": tc.want, "f2": true}, got, tc.user.Username)
+		}
+	})
+
 	t.Run("delete flag with override", func(t *testing.T) {
 		t.Cleanup(cleanup(t, db))
 		o1 := mkOrg("o1")
@@ -572,6 +635,25 @@ func testAnonymousUserFlags(t *testing.T) {
 		require.Equal(t, expected, got)
 	})
 
+	t.Run("rules", func(t *testing.T) {
+		t.Cleanup(cleanup(t, db))
+		_, err := flagStore.CreateRules(ctx, "f1", &ff.FeatureFlagRules{
+			Rules:   []*ff.TargetingRule{{Type: ff.TargetingRuleAnonymous, Value: true}},
+			Default: false,
+		})
+		require.NoError(t, err)
+		_, err = flagStore.CreateRules(ctx, "f2", &ff.FeatureFlagRules{
+			Rules:   []*ff.TargetingRule{{Type: ff.TargetingRuleSiteAdmins, Value: true}},
+			Default: false,
+		})
+		require.NoError(t, err)
+
+		got, err := flagStore.GetAnonymousUserFlags(ctx, "testuser")
+		require.NoError(t, err)
+		expected := map[string]bool{"f1": true, "f2": false}
+		require.Equal(t, expected, got)
+	})
+
 	// No override tests for AnonymousUserFlags because no override
 	// can be defined for an anonymous user.
 }
@@ -701,4 +783,25 @@ func testOrgFeatureFlag(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, false, got)
 	})
+
+	t.Run("rules", func(t *testing.T) {
+		t.Cleanup(cleanup(t, db))
+		org1 := mkOrg("o1")
+		org2 := mkOrg("o2")
+		_, err := flagStore.CreateRules(ctx, "f1", &ff.FeatureFlagRules{
+			Rules: []*ff.TargetingRule{
+				{Type: ff.TargetingRuleSiteAdmins, Value: false},
+				{Type: ff.TargetingRuleOrgMembers, OrgIDs: []int32{org1.ID}, Value: true},
+			},
+		})
+		require.NoError(t, err)
+
+		got, err := flagStore.GetOrgFeatureFlag(ctx, org1.ID, "f1")
+		require.NoError(t, err)
+		require.Equal(t, true, got)
+
+		got, err = flagStore.GetOrgFeatureFlag(ctx, org2.ID, "f1")
+		require.NoError(t, err)
+		require.Equal(t, false, got)
+	})
 }
@@ -15310,6 +15310,9 @@ type MockFeatureFlagStore struct {
 	// CreateRolloutFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateRollout.
 	CreateRolloutFunc *FeatureFlagStoreCreateRolloutFunc
+	// CreateRulesFunc is an instance of a mock function object controlling
+	// the behavior of the method CreateRules.
+	CreateRulesFunc *FeatureFlagStoreCreateRulesFunc
 	// DeleteFeatureFlagFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteFeatureFlag.
 	DeleteFeatureFlagFunc *FeatureFlagStoreDeleteFeatureFlagFunc
@@ -15388,6 +15391,11 @@ func NewMockFeatureFlagStore() *MockFeatureFlagStore {
 				return
 			},
 		},
+		CreateRulesFunc: &FeatureFlagStoreCreateRulesFunc{
+			defaultHook: func(context.Context, string, *featureflag.FeatureFlagRules) (r0 *featureflag.FeatureFlag, r1 error) {
+				return
+			},
+		},
 		DeleteFeatureFlagFunc: &FeatureFlagStoreDeleteFeatureFlagFunc{
 			defaultHook: func(context.Context, string) (r0 error) {
 				return
@@ -15500,6 +15508,11 @@ func NewStrictMockFeatureFlagStore() *MockFeatureFlagStore {
 				panic("unexpected invocation of MockFeatureFlagStore.CreateRollout")
 			},
 		},
+		CreateRulesFunc: &FeatureFlagStoreCreateRulesFunc{
+			defaultHook: func(context.Context, string, *featureflag.FeatureFlagRules) (*featureflag.FeatureFlag, error) {
+				panic("unexpected invocation of MockFeatureFlagStore.CreateRules")
+			},
+		},
 		DeleteFeatureFlagFunc: &FeatureFlagStoreDeleteFeatureFlagFunc{
 			defaultHook: func(context.Context, string) error {
 				panic("unexpected invocation of MockFeatureFlagStore.DeleteFeatureFlag")
@@ -15605,6 +15618,9 @@ func NewMockFeatureFlagStoreFrom(i FeatureFlagStore) *MockFeatureFlagStore {
 		CreateRolloutFunc: &FeatureFlagStoreCreateRolloutFunc{
 			defaultHook: i.CreateRollout,
 		},
+		CreateRulesFunc: &FeatureFlagStoreCreateRulesFunc{
+			defaultHook: i.CreateRules,
+		},
 		DeleteFeatureFlagFunc: &FeatureFlagStoreDeleteFeatureFlagFunc{
 			defaultHook: i.DeleteFeatureFlag,
 		},
@@ -16104,6 +16120,118 @@ func (c FeatureFlagStoreCreateRolloutFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// FeatureFlagStoreCreateRulesFunc describes the behavior when the
+// CreateRules method of the parent MockFeatureFlagStore instance is
+// invoked.
+type FeatureFlagStoreCreateRulesFunc struct {
+	defaultHook func(context.Context, string, *featureflag.FeatureFlagRules) (*featureflag.FeatureFlag, error)
+	hooks       []func(context.Context, string, *featureflag.FeatureFlagRules) (*featureflag.FeatureFlag, error)
+	history     []FeatureFlagStoreCreateRulesFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateRules delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockFeatureFlagStore) CreateRules(v0 context.Context, v1 string, v2 *featureflag.FeatureFlagRules) (*featureflag.FeatureFlag, error) {
+	r0, r1 := m.CreateRulesFunc.nextHook()(v0, v1, v2)
+	m.CreateRulesFunc.appendCall(FeatureFlagStoreCreateRulesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateRules method
+// of the parent MockFeatureFlagStore instance is invoked and the hook queue
+// is empty.
+func (f *FeatureFlagStoreCreateRulesFunc) SetDefaultHook(hook func(context.Context, string, *featureflag.FeatureFlagRules) (*featureflag.FeatureFlag, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateRules method of the parent MockFeatureFlagStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *FeatureFlagStoreCreateRulesFunc) PushHook(hook func(context.Context, string, *featureflag.FeatureFlagRules) (*featureflag.FeatureFlag, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *FeatureFlagStoreCreateRulesFunc) SetDefaultReturn(r0 *featureflag.FeatureFlag, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, *featureflag.FeatureFlagRules) (*featureflag.FeatureFlag, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *FeatureFlagStoreCreateRulesFunc) PushReturn(r0 *featureflag.FeatureFlag, r1 error) {
+	f.PushHook(func(context.Context, string, *featureflag.FeatureFlagRules) (*featureflag.FeatureFlag, error) {
+		return r0, r1
+	})
+}
+
+func (f *FeatureFlagStoreCreateRulesFunc) nextHook() func(context.Context, string, *featureflag.FeatureFlagRules) (*featureflag.FeatureFlag, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *FeatureFlagStoreCreateRulesFunc) appendCall(r0 FeatureFlagStoreCreateRulesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of FeatureFlagStoreCreateRulesFuncCall objects
+// describing the invocations of this function.
+func (f *FeatureFlagStoreCreateRulesFunc) History() []FeatureFlagStoreCreateRulesFuncCall {
+	f.mutex.Lock()
+	history := make([]FeatureFlagStoreCreateRulesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// FeatureFlagStoreCreateRulesFuncCall is an object that describes an
+// invocation of method CreateRules on an instance of MockFeatureFlagStore.
+type FeatureFlagStoreCreateRulesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *featureflag.FeatureFlagRules
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *featureflag.FeatureFlag
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c FeatureFlagStoreCreateRulesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c FeatureFlagStoreCreateRulesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // FeatureFlagStoreDeleteFeatureFlagFunc describes the behavior when the
 // DeleteFeatureFlag method of the parent MockFeatureFlagStore instance is
 // invoked.
@@ -42874,6 +43002,9 @@ type MockUserStore struct {
 	// a mock function object controlling the behavior of the method
 	// RandomizePasswordAndClearPasswordResetRateLimit.
 	RandomizePasswordAndClearPasswordResetRateLimitFunc *UserStoreRandomizePasswordAndClearPasswordResetRateLimitFunc
+	// RecoverUsersListFunc is an instance of a mock function object
+	// controlling the behavior of the method RecoverUsersList.
+	RecoverUsersListFunc *UserStoreRecoverUsersListFunc
 	// RenewPasswordResetCodeFunc is an instance of a mock function object
 	// controlling the behavior of the method RenewPasswordResetCode.
 	RenewPasswordResetCodeFunc *UserStoreRenewPasswordResetCodeFunc
@@ -43041,6 +43172,11 @@ func NewMockUserStore() *MockUserStore {
 				return
 			},
 		},
+		RecoverUsersListFunc: &UserStoreRecoverUsersListFunc{
+			defaultHook: func(context.Context, []int32) (r0 error) {
+				return
+			},
+		},
 		RenewPasswordResetCodeFunc: &UserStoreRenewPasswordResetCodeFunc{
 			defaultHook: func(context.Context, int32) (r0 string, r1 error) {
 				return
@@ -43228,6 +43364,11 @@ func NewStrictMockUserStore() *MockUserStore {
 				panic("unexpected invocation of MockUserStore.RandomizePasswordAndClearPasswordResetRateLimit")
 			},
 		},
+		RecoverUsersListFunc: &UserStoreRecoverUsersListFunc{
+			defaultHook: func(context.Context, []int32) error {
+				panic("unexpected invocation of MockUserStore.RecoverUsersList")
+			},
+		},
 		RenewPasswordResetCodeFunc: &UserStoreRenewPasswordResetCodeFunc{
 			defaultHook: func(context.Context, int32) (string, error) {
 				panic("unexpected invocation of MockUserStore.RenewPasswordResetCode")
@@ -43363,6 +43504,9 @@ func NewMockUserStoreFrom(i UserStore) *MockUserStore {
 		RandomizePasswordAndClearPasswordResetRateLimitFunc: &UserStoreRandomizePasswordAndClearPasswordResetRateLimitFunc{
 			defaultHook: i.RandomizePasswordAndClearPasswordResetRateLimit,
 		},
+		RecoverUsersListFunc: &UserStoreRecoverUsersListFunc{
+			defaultHook: i.RecoverUsersList,
+		},
 		RenewPasswordResetCodeFunc: &UserStoreRenewPasswordResetCodeFunc{
 			defaultHook: i.RenewPasswordResetCode,
 		},
@@ -46189,6 +46333,111 @@ func (c UserStoreRandomizePasswordAndClearPasswordResetRateLimitFuncCall) Result
 	return []interface{}{c.Result0}
 }
 
+// UserStoreRecoverUsersListFunc describes the behavior when the
+// RecoverUsersList method of the parent MockUserStore instance is invoked.
+type UserStoreRecoverUsersListFunc struct {
+	defaultHook func(context.Context, []int32) error
+	hooks       []func(context.Context, []int32) error
+	history     []UserStoreRecoverUsersListFuncCall
+	mutex       sync.Mutex
+}
+
+// RecoverUsersList delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUserStore) RecoverUsersList(v0 context.Context, v1 []int32) error {
+	r0 := m.RecoverUsersListFunc.nextHook()(v0, v1)
+	m.RecoverUsersListFunc.appendCall(UserStoreRecoverUsersListFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RecoverUsersList
+// method of the parent MockUserStore instance is invoked and the hook queue
+// is empty.
+func (f *UserStoreRecoverUsersListFunc) SetDefaultHook(hook func(context.Context, []int32) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RecoverUsersList method of the parent MockUserStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UserStoreRecoverUsersListFunc) PushHook(hook func(context.Context, []int32) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UserStoreRecoverUsersListFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []int32) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UserStoreRecoverUsersListFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []int32) error {
+		return r0
+	})
+}
+
+func (f *UserStoreRecoverUsersListFunc) nextHook() func(context.Context, []int32) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UserStoreRecoverUsersListFunc) appendCall(r0 UserStoreRecoverUsersListFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UserStoreRecoverUsersListFuncCall objects
+// describing the invocations of this function.
+func (f *UserStoreRecoverUsersListFunc) History() []UserStoreRecoverUsersListFuncCall {
+	f.mutex.Lock()
+	history := make([]UserStoreRecoverUsersListFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UserStoreRecoverUsersListFuncCall is an object that describes an
+// invocation of method RecoverUsersList on an instance of MockUserStore.
+type UserStoreRecoverUsersListFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UserStoreRecoverUsersListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UserStoreRecoverUsersListFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // UserStoreRenewPasswordResetCodeFunc describes the behavior when the
 // RenewPasswordResetCode method of the parent MockUserStore instance is
 // invoked.
@@ -28,6 +28,12 @@ func (e *OrgNotFoundError) NotFound() bool {
 
 var errOrgNameAlreadyExists = errors.New("organization name is already taken (by a user or another organization)")
 
+// IsOrgNameAlreadyExists reports whether err is an error indicating that the organization name is
+// already taken.
+func IsOrgNameAlreadyExists(err error) bool {
+	return errors.Is(err, errOrgNameAlreadyExists)
+}
+
 type OrgStore interface {
 	AddOrgsOpenBetaStats(ctx context.Context, userID int32, data string) (string, error)
 	Count(context.Context, OrgsListOptions) (int, error)
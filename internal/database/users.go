@@ -78,6 +78,7 @@ type UserStore interface {
 	List(context.Context, *UsersListOptions) (_ []*types.User, err error)
 	ListDates(context.Context) ([]types.UserDates, error)
 	RandomizePasswordAndClearPasswordResetRateLimit(context.Context, int32) error
+	RecoverUsersList(context.Context, []int32) error
 	RenewPasswordResetCode(context.Context, int32) (string, error)
 	SetIsSiteAdmin(ctx context.Context, id int32, isSiteAdmin bool) error
 	SetPassword(ctx context.Context, id int32, resetCode, newPassword string) (bool, error)
@@ -561,6 +562,67 @@ func (u *userStore) DeleteList(ctx context.Context, ids []int32) (err error) {
 	return nil
 }
 
+// RecoverUsersList undoes a soft-delete of the given users. The usernames are claimed again, and
+// the access tokens and external accounts that were deleted along with the users are restored.
+// Email addresses are not restored because soft-deleting a user removes them.
+func (u *userStore) RecoverUsersList(ctx context.Context, ids []int32) (err error) {
+	tx, err := u.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	userIDs := make([]*sqlf.Query, len(ids))
+	for i := range ids {
+		userIDs[i] = sqlf.Sprintf("%d", ids[i])
+	}
+
+	idsCond := sqlf.Join(userIDs, ",")
+
+	// Soft-deleting a user marks all of its resources as deleted in the same transaction, so they
+	// share the deleted_at timestamp of the user. Only those resources are restored.
+	if err := tx.Exec(ctx, sqlf.Sprintf(`
+UPDATE user_external_accounts a SET deleted_at=NULL, updated_at=now()
+FROM users u WHERE a.user_id=u.id AND u.id IN (%s) AND a.deleted_at=u.deleted_at`, idsCond)); err != nil {
+		return err
+	}
+	if err := tx.Exec(ctx, sqlf.Sprintf(`
+UPDATE access_tokens t SET deleted_at=NULL
+FROM users u WHERE t.subject_user_id=u.id AND u.id IN (%s) AND t.deleted_at=u.deleted_at`, idsCond)); err != nil {
+		return err
+	}
+
+	// The usernames may have been claimed by another user or org in the meantime.
+	usernameTaken := func(err error) bool {
+		var e *pgconn.PgError
+		return errors.As(err, &e) && (e.ConstraintName == "users_username" || e.ConstraintName == "names_pkey")
+	}
+
+	res, err := tx.ExecResult(ctx, sqlf.Sprintf("UPDATE users SET deleted_at=NULL, updated_at=now() WHERE id IN (%s) AND deleted_at IS NOT NULL", idsCond))
+	if err != nil {
+		if usernameTaken(err) {
+			return errCannotCreateUser{errorCodeUsernameExists}
+		}
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows != int64(len(ids)) {
+		return userNotFoundErr{args: []any{fmt.Sprintf("Some users were not found. Expected to recover %d users, but recovered only %d", len(ids), rows)}}
+	}
+
+	if err := tx.Exec(ctx, sqlf.Sprintf("INSERT INTO names(name, user_id) SELECT username, id FROM users WHERE id IN (%s)", idsCond)); err != nil {
+		if usernameTaken(err) {
+			return errCannotCreateUser{errorCodeUsernameExists}
+		}
+		return err
+	}
+
+	return nil
+}
+
 // HardDelete removes the user and all resources associated with this user.
 func (u *userStore) HardDelete(ctx context.Context, id int32) (err error) {
 	return u.HardDeleteList(ctx, []int32{id})
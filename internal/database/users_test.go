@@ -659,6 +659,55 @@ func TestUsers_Delete(t *testing.T) {
 	}
 }
 
+func TestUsers_RecoverUsersList(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1, Internal: true})
+
+	spec := extsvc.AccountSpec{ServiceType: "xa", ServiceID: "xb", ClientID: "xc", AccountID: "xd"}
+	userID, err := db.UserExternalAccounts().CreateUserAndSave(ctx, NewUser{Username: "u"}, spec, extsvc.AccountData{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Users().Delete(ctx, userID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Users().RecoverUsersList(ctx, []int32{userID}); err != nil {
+		t.Fatal(err)
+	}
+	if user, err := db.Users().GetByUsername(ctx, "u"); err != nil {
+		t.Fatal(err)
+	} else if user.ID != userID {
+		t.Errorf("got user %d, want %d", user.ID, userID)
+	}
+	if accounts, err := db.UserExternalAccounts().List(ctx, ExternalAccountsListOptions{UserID: userID}); err != nil {
+		t.Fatal(err)
+	} else if len(accounts) != 1 {
+		t.Errorf("got %d external accounts, want 1", len(accounts))
+	}
+
+	// Recovering a user that is not deleted fails.
+	if err := db.Users().RecoverUsersList(ctx, []int32{userID}); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+
+	// Recovering a user whose username was claimed in the meantime fails.
+	if err := db.Users().Delete(ctx, userID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Users().Create(ctx, NewUser{Username: "u"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Users().RecoverUsersList(ctx, []int32{userID}); !IsUsernameExists(err) {
+		t.Errorf("got error %v, want username exists", err)
+	}
+}
+
 func TestUsers_HasTag(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
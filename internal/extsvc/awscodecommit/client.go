@@ -32,6 +32,11 @@ func NewClient(config aws.Config) *Client {
 	}
 }
 
+// Region returns the AWS region the client is configured for.
+func (c *Client) Region() string {
+	return c.aws.Region
+}
+
 // cacheKeyPrefix returns the cache key prefix to use. It incorporates the credentials to
 // avoid leaking cached data that was fetched with one set of credentials to a (possibly
 // different) user with a different set of credentials.
@@ -0,0 +1,312 @@
+package awscodecommit
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/codecommit"
+	codecommittypes "github.com/aws/aws-sdk-go-v2/service/codecommit/types"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// PullRequestStatus is the status of an AWS CodeCommit pull request.
+type PullRequestStatus string
+
+const (
+	PullRequestStatusOpen   PullRequestStatus = "OPEN"
+	PullRequestStatusClosed PullRequestStatus = "CLOSED"
+)
+
+// ApprovalState is the state of an approval of a pull request by a single user.
+type ApprovalState string
+
+const (
+	ApprovalStateApprove ApprovalState = "APPROVE"
+	ApprovalStateRevoke  ApprovalState = "REVOKE"
+)
+
+// PullRequest is an AWS CodeCommit pull request. Pull requests created by
+// Sourcegraph always have a single target, so the fields of the target are
+// flattened into the pull request.
+type PullRequest struct {
+	ID               string            // the system-generated ID of the pull request
+	RevisionID       string            // the ID of the current revision of the pull request
+	Title            string            // the title of the pull request
+	Description      string            // the description of the pull request
+	Status           PullRequestStatus // the status of the pull request
+	AuthorARN        string            // the ARN of the user who created the pull request
+	CreationDate     time.Time         // the date the pull request was created
+	LastActivityDate time.Time         // the date of the last activity on the pull request
+	RepositoryName   string            // the name of the repository of the pull request target
+	SourceReference  string            // the branch that contains the changes
+	SourceCommit     string            // the tip of the source branch
+	DestReference    string            // the branch the changes are merged into
+	DestCommit       string            // the tip of the destination branch
+	MergeBase        string            // the merge base of the source and destination branches
+	IsMerged         bool              // whether the pull request has been merged
+	HasApprovalRules bool              // whether approval rules apply to the pull request
+}
+
+// PullRequestApprovals are the approvals and the evaluation of the approval
+// rules of a single revision of a pull request.
+type PullRequestApprovals struct {
+	// Approved is true if all approval rules of the pull request are
+	// satisfied.
+	Approved bool
+	// Overridden is true if the approval rules have been overridden.
+	Overridden bool
+	// RulesNotSatisfied contains the names of the approval rules that aren't
+	// satisfied yet.
+	RulesNotSatisfied []string
+	// Approvals contains the approval state per user.
+	Approvals []Approval
+}
+
+// Approval is the approval state of a pull request by a single user.
+type Approval struct {
+	UserARN string
+	State   ApprovalState
+}
+
+// CreatePullRequestInput contains the arguments of CreatePullRequest.
+type CreatePullRequestInput struct {
+	RepositoryName  string
+	Title           string
+	Description     string
+	SourceReference string
+	DestReference   string
+}
+
+// CreatePullRequest creates a new pull request.
+func (c *Client) CreatePullRequest(ctx context.Context, in *CreatePullRequestInput) (_ *PullRequest, err error) {
+	defer wrapError(&err)
+
+	result, err := codecommit.NewFromConfig(c.aws).CreatePullRequest(ctx, &codecommit.CreatePullRequestInput{
+		Title:       aws.String(in.Title),
+		Description: aws.String(in.Description),
+		Targets: []codecommittypes.Target{{
+			RepositoryName:       aws.String(in.RepositoryName),
+			SourceReference:      aws.String(in.SourceReference),
+			DestinationReference: aws.String(in.DestReference),
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// GetPullRequest gets the pull request with the given ID.
+func (c *Client) GetPullRequest(ctx context.Context, id string) (_ *PullRequest, err error) {
+	defer wrapError(&err)
+
+	result, err := codecommit.NewFromConfig(c.aws).GetPullRequest(ctx, &codecommit.GetPullRequestInput{
+		PullRequestId: aws.String(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// ListOpenPullRequests lists the IDs of the open pull requests in the given
+// repository.
+func (c *Client) ListOpenPullRequests(ctx context.Context, repositoryName, nextToken string) (ids []string, nextNextToken string, err error) {
+	defer wrapError(&err)
+
+	in := codecommit.ListPullRequestsInput{
+		RepositoryName:    aws.String(repositoryName),
+		PullRequestStatus: codecommittypes.PullRequestStatusEnumOpen,
+	}
+	if nextToken != "" {
+		in.NextToken = &nextToken
+	}
+	result, err := codecommit.NewFromConfig(c.aws).ListPullRequests(ctx, &in)
+	if err != nil {
+		return nil, "", err
+	}
+	if result.NextToken != nil {
+		nextNextToken = *result.NextToken
+	}
+	return result.PullRequestIds, nextNextToken, nil
+}
+
+// UpdatePullRequestTitle updates the title of the given pull request.
+func (c *Client) UpdatePullRequestTitle(ctx context.Context, id, title string) (_ *PullRequest, err error) {
+	defer wrapError(&err)
+
+	result, err := codecommit.NewFromConfig(c.aws).UpdatePullRequestTitle(ctx, &codecommit.UpdatePullRequestTitleInput{
+		PullRequestId: aws.String(id),
+		Title:         aws.String(title),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// UpdatePullRequestDescription updates the description of the given pull
+// request.
+func (c *Client) UpdatePullRequestDescription(ctx context.Context, id, description string) (_ *PullRequest, err error) {
+	defer wrapError(&err)
+
+	result, err := codecommit.NewFromConfig(c.aws).UpdatePullRequestDescription(ctx, &codecommit.UpdatePullRequestDescriptionInput{
+		PullRequestId: aws.String(id),
+		Description:   aws.String(description),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// ClosePullRequest closes the given pull request. Closed pull requests cannot
+// be reopened.
+func (c *Client) ClosePullRequest(ctx context.Context, id string) (_ *PullRequest, err error) {
+	defer wrapError(&err)
+
+	result, err := codecommit.NewFromConfig(c.aws).UpdatePullRequestStatus(ctx, &codecommit.UpdatePullRequestStatusInput{
+		PullRequestId:     aws.String(id),
+		PullRequestStatus: codecommittypes.PullRequestStatusEnumClosed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// MergePullRequest merges the given pull request, either by squashing its
+// commits or by creating a merge commit. The merge fails if the tip of the
+// source branch has changed since the pull request was loaded.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash bool) (_ *PullRequest, err error) {
+	defer wrapError(&err)
+
+	svc := codecommit.NewFromConfig(c.aws)
+	var merged *codecommittypes.PullRequest
+	if squash {
+		result, err := svc.MergePullRequestBySquash(ctx, &codecommit.MergePullRequestBySquashInput{
+			PullRequestId:  aws.String(pr.ID),
+			RepositoryName: aws.String(pr.RepositoryName),
+			SourceCommitId: aws.String(pr.SourceCommit),
+		})
+		if err != nil {
+			return nil, err
+		}
+		merged = result.PullRequest
+	} else {
+		result, err := svc.MergePullRequestByThreeWay(ctx, &codecommit.MergePullRequestByThreeWayInput{
+			PullRequestId:  aws.String(pr.ID),
+			RepositoryName: aws.String(pr.RepositoryName),
+			SourceCommitId: aws.String(pr.SourceCommit),
+		})
+		if err != nil {
+			return nil, err
+		}
+		merged = result.PullRequest
+	}
+	return fromPullRequest(merged), nil
+}
+
+// CreatePullRequestComment posts a general comment on the given pull request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, content string) (err error) {
+	defer wrapError(&err)
+
+	_, err = codecommit.NewFromConfig(c.aws).PostCommentForPullRequest(ctx, &codecommit.PostCommentForPullRequestInput{
+		PullRequestId:  aws.String(pr.ID),
+		RepositoryName: aws.String(pr.RepositoryName),
+		BeforeCommitId: aws.String(pr.DestCommit),
+		AfterCommitId:  aws.String(pr.SourceCommit),
+		Content:        aws.String(content),
+	})
+	return err
+}
+
+// GetPullRequestApprovals returns the approvals of the current revision of the
+// given pull request, and the evaluation of its approval rules.
+func (c *Client) GetPullRequestApprovals(ctx context.Context, pr *PullRequest) (_ *PullRequestApprovals, err error) {
+	defer wrapError(&err)
+
+	svc := codecommit.NewFromConfig(c.aws)
+	states, err := svc.GetPullRequestApprovalStates(ctx, &codecommit.GetPullRequestApprovalStatesInput{
+		PullRequestId: aws.String(pr.ID),
+		RevisionId:    aws.String(pr.RevisionID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	eval, err := svc.EvaluatePullRequestApprovalRules(ctx, &codecommit.EvaluatePullRequestApprovalRulesInput{
+		PullRequestId: aws.String(pr.ID),
+		RevisionId:    aws.String(pr.RevisionID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	approvals := &PullRequestApprovals{Approvals: make([]Approval, 0, len(states.Approvals))}
+	for _, a := range states.Approvals {
+		approvals.Approvals = append(approvals.Approvals, Approval{
+			UserARN: aws.ToString(a.UserArn),
+			State:   ApprovalState(a.ApprovalState),
+		})
+	}
+	if e := eval.Evaluation; e != nil {
+		approvals.Approved = e.Approved
+		approvals.Overridden = e.Overridden
+		approvals.RulesNotSatisfied = e.ApprovalRulesNotSatisfied
+	}
+	return approvals, nil
+}
+
+// IsPullRequestNotFound reports whether err is an AWS CodeCommit API error
+// indicating that the pull request doesn't exist.
+func IsPullRequestNotFound(err error) bool {
+	return errors.HasType(err, &codecommittypes.PullRequestDoesNotExistException{})
+}
+
+// IsNotMergeable reports whether err is an AWS CodeCommit API error indicating
+// that the pull request cannot be merged, because it has conflicts, its
+// approval rules aren't satisfied, or its source branch has changed.
+func IsNotMergeable(err error) bool {
+	return errors.HasType(err, &codecommittypes.ManualMergeRequiredException{}) ||
+		errors.HasType(err, &codecommittypes.PullRequestApprovalRulesNotSatisfiedException{}) ||
+		errors.HasType(err, &codecommittypes.TipOfSourceReferenceIsDifferentException{}) ||
+		errors.HasType(err, &codecommittypes.PullRequestAlreadyClosedException{})
+}
+
+// wrapError wraps a non-nil error in a wrappedError, so that it reports
+// not-found and unauthorized errors to errcode.
+func wrapError(err *error) {
+	if *err != nil {
+		*err = &wrappedError{err: *err}
+	}
+}
+
+func fromPullRequest(p *codecommittypes.PullRequest) *PullRequest {
+	pr := PullRequest{
+		ID:               aws.ToString(p.PullRequestId),
+		RevisionID:       aws.ToString(p.RevisionId),
+		Title:            aws.ToString(p.Title),
+		Description:      aws.ToString(p.Description),
+		Status:           PullRequestStatus(p.PullRequestStatus),
+		AuthorARN:        aws.ToString(p.AuthorArn),
+		CreationDate:     aws.ToTime(p.CreationDate),
+		LastActivityDate: aws.ToTime(p.LastActivityDate),
+		HasApprovalRules: len(p.ApprovalRules) > 0,
+	}
+	if len(p.PullRequestTargets) > 0 {
+		t := p.PullRequestTargets[0]
+		pr.RepositoryName = aws.ToString(t.RepositoryName)
+		pr.SourceReference = aws.ToString(t.SourceReference)
+		pr.SourceCommit = aws.ToString(t.SourceCommit)
+		pr.DestReference = aws.ToString(t.DestinationReference)
+		pr.DestCommit = aws.ToString(t.DestinationCommit)
+		pr.MergeBase = aws.ToString(t.MergeBase)
+		if t.MergeMetadata != nil {
+			pr.IsMerged = t.MergeMetadata.IsMerged
+		}
+	}
+	return &pr
+}
@@ -0,0 +1,26 @@
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// AccountData stores information of an Azure DevOps account.
+type AccountData struct {
+	ID          string `json:"id"`
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+}
+
+// GetExternalAccountData extracts account data for the external account.
+func GetExternalAccountData(ctx context.Context, data *extsvc.AccountData) (*AccountData, error) {
+	if data.Data == nil {
+		return nil, nil
+	}
+
+	var d AccountData
+	err := encryption.DecryptJSON(ctx, data.Data, &d)
+	return &d, err
+}
@@ -0,0 +1,278 @@
+//nolint:bodyclose // Body is closed in Client.do, but the response is still returned to provide access to the headers
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// apiVersion is the version of the Azure DevOps REST API the client uses. It
+// is supported by Azure DevOps Services and Azure DevOps Server 2022.
+const apiVersion = "7.0"
+
+// servicesHost is the host of Azure DevOps Services. Some APIs, such as the
+// identity and member entitlement APIs, are only available on Azure DevOps
+// Services, where they are served from subdomains of this host.
+const servicesHost = "dev.azure.com"
+
+// ErrNotSupportedOnServer is returned by methods calling APIs that only exist
+// on Azure DevOps Services when the client is configured for Azure DevOps
+// Server.
+var ErrNotSupportedOnServer = errors.New("API is only supported on Azure DevOps Services")
+
+// Client access Azure DevOps via the REST API.
+type Client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the base URL of Azure DevOps, e.g. https://dev.azure.com/. The
+	// API paths of organizations (or collections, on Azure DevOps Server) are
+	// relative to it.
+	URL *url.URL
+
+	// auther is used to authenticate requests against the Azure DevOps API.
+	// It defaults to the username and personal access token in the config.
+	auther *auth.BasicAuth
+
+	// RateLimit is the self-imposed rate limiter. Azure DevOps throttles
+	// clients based on their resource consumption rather than with a fixed
+	// quota, so there is no rate limit to read from the responses.
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns an authenticated Azure DevOps API client with the provided
+// configuration. If a nil httpClient is provided, httpcli.ExternalDoer will be
+// used.
+func NewClient(urn string, config *schema.AzureDevOpsConnection, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+	// Paths are resolved relative to the base URL, so it must end with a
+	// slash to not drop its last path segment on Azure DevOps Server.
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		httpClient: httpClient,
+		URL:        u,
+		auther:     &auth.BasicAuth{Username: config.Username, Password: config.Token},
+		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTP client, and rate limiter as the current Client, except authenticated
+// with the given authenticator. Azure DevOps personal access tokens are used
+// with basic authentication, so only basic authenticators are supported.
+func (c *Client) WithAuthenticator(a auth.Authenticator) (*Client, error) {
+	var basic *auth.BasicAuth
+	switch a := a.(type) {
+	case *auth.BasicAuth:
+		basic = a
+	case *auth.BasicAuthWithSSH:
+		basic = &a.BasicAuth
+	default:
+		return nil, errors.Errorf("authenticator type unsupported for Azure DevOps clients: %T", a)
+	}
+
+	return &Client{
+		httpClient: c.httpClient,
+		URL:        c.URL,
+		auther:     basic,
+		rateLimit:  c.rateLimit,
+	}, nil
+}
+
+// Authenticator returns the authenticator used by the client.
+func (c *Client) Authenticator() auth.Authenticator {
+	return c.auther
+}
+
+// IsAzureDevOpsServices returns true if the client talks to Azure DevOps
+// Services rather than to an Azure DevOps Server instance.
+func (c *Client) IsAzureDevOpsServices() bool {
+	return c.URL.Hostname() == servicesHost
+}
+
+// serviceURL returns the base URL of the Azure DevOps Services API served
+// from the given subdomain, such as "vssps" for identities.
+func (c *Client) serviceURL(subdomain string) (*url.URL, error) {
+	if !c.IsAzureDevOpsServices() {
+		return nil, ErrNotSupportedOnServer
+	}
+	return &url.URL{Scheme: c.URL.Scheme, Host: subdomain + "." + servicesHost, Path: "/"}, nil
+}
+
+// Profile is the profile of the authenticated user.
+type Profile struct {
+	ID           string `json:"id"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+// GetAuthorizedProfile returns the profile of the authenticated user. It is
+// used to validate credentials, and is only supported on Azure DevOps
+// Services.
+func (c *Client) GetAuthorizedProfile(ctx context.Context) (*Profile, error) {
+	u, err := c.serviceURL("vssps")
+	if err != nil {
+		return nil, err
+	}
+	u.Path = "/_apis/profile/profiles/me"
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Profile
+	if _, err := c.do(ctx, req, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// listResponse is the envelope of all Azure DevOps API responses returning
+// a list of values.
+type listResponse[T any] struct {
+	Count int `json:"count"`
+	Value []T `json:"value"`
+}
+
+// do sends the request to the API and decodes the JSON response into result.
+// Relative request URLs are resolved against the base URL of the client, and
+// the API version is added to the query.
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(req.URL)
+
+	q := req.URL.Query()
+	if q.Get("api-version") == "" {
+		q.Set("api-version", apiVersion)
+		req.URL.RawQuery = q.Encode()
+	}
+
+	if err := c.auther.Authenticate(req); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if req.Body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Azure DevOps redirects unauthenticated requests to a sign-in page
+	// instead of responding with 401 Unauthorized, which the HTTP client
+	// follows to a 200 OK HTML page.
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 || isSignInPage(resp) {
+		return nil, newHTTPError(req.URL, resp, bs)
+	}
+
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return resp, nil
+	}
+
+	return resp, json.Unmarshal(bs, result)
+}
+
+// newJSONRequest returns a request with the JSON encoding of body.
+func newJSONRequest(method, urlStr string, body any) (*http.Request, error) {
+	bs, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return http.NewRequest(method, urlStr, bytes.NewReader(bs))
+}
+
+func isSignInPage(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusOK && strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html")
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+
+	// TypeKey and Message are set if the response contains an Azure DevOps
+	// error, e.g. "GitPullRequestNotFoundException".
+	TypeKey string
+	Message string
+}
+
+func newHTTPError(u *url.URL, resp *http.Response, body []byte) *httpError {
+	e := &httpError{URL: u, StatusCode: resp.StatusCode, Body: body}
+	if isSignInPage(resp) {
+		e.StatusCode = http.StatusUnauthorized
+		return e
+	}
+
+	var apiErr struct {
+		TypeKey string `json:"typeKey"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &apiErr); err == nil {
+		e.TypeKey = apiErr.TypeKey
+		e.Message = apiErr.Message
+	}
+	return e
+}
+
+func (e *httpError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("Azure DevOps API HTTP error: code=%d url=%q type=%q message=%q", e.StatusCode, e.URL, e.TypeKey, e.Message)
+	}
+	return fmt.Sprintf("Azure DevOps API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsNotFound reports whether err is an Azure DevOps API error with HTTP status
+// 404.
+func IsNotFound(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.NotFound()
+}
+
+// IsConflict reports whether err is an Azure DevOps API error with HTTP status
+// 409, e.g. because an active pull request for the same branches exists.
+func IsConflict(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.StatusCode == http.StatusConflict
+}
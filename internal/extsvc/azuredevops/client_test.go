@@ -0,0 +1,189 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestClient_ListProjects(t *testing.T) {
+	cli, requests := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("continuationToken") == "" {
+			w.Header().Set("X-Ms-Continuationtoken", "next")
+			writeJSON(t, w, listResponse[*Project]{Count: 1, Value: []*Project{{ID: "1", Name: "first"}}})
+			return
+		}
+		writeJSON(t, w, listResponse[*Project]{Count: 1, Value: []*Project{{ID: "2", Name: "second"}}})
+	}))
+	ctx := context.Background()
+
+	projects, next, err := cli.ListProjects(ctx, ListProjectsArgs{Org: "sgtest", Top: 1})
+	require.Nil(t, err)
+	assert.Equal(t, []*Project{{ID: "1", Name: "first"}}, projects)
+	assert.Equal(t, "next", next)
+
+	projects, next, err = cli.ListProjects(ctx, ListProjectsArgs{Org: "sgtest", Top: 1, ContinuationToken: next})
+	require.Nil(t, err)
+	assert.Equal(t, []*Project{{ID: "2", Name: "second"}}, projects)
+	assert.Equal(t, "", next)
+
+	req := (*requests)[1]
+	assert.Equal(t, "/sgtest/_apis/projects", req.URL.Path)
+	assert.Equal(t, "1", req.URL.Query().Get("$top"))
+	assert.Equal(t, apiVersion, req.URL.Query().Get("api-version"))
+	user, pass, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "admin", user)
+	assert.Equal(t, "secret", pass)
+}
+
+func TestClient_ListRepositoriesByProjectOrOrg(t *testing.T) {
+	cli, requests := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, listResponse[*Repository]{Count: 1, Value: []*Repository{{ID: "repo", Name: "repo"}}})
+	}))
+	ctx := context.Background()
+
+	_, err := cli.ListRepositoriesByProjectOrOrg(ctx, "sgtest", "")
+	require.Nil(t, err)
+	_, err = cli.ListRepositoriesByProjectOrOrg(ctx, "sgtest", "my project")
+	require.Nil(t, err)
+
+	assert.Equal(t, "/sgtest/_apis/git/repositories", (*requests)[0].URL.Path)
+	assert.Equal(t, "/sgtest/my%20project/_apis/git/repositories", (*requests)[1].URL.EscapedPath())
+}
+
+func TestClient_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not found", func(t *testing.T) {
+		cli, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(t, w, map[string]string{
+				"typeKey": "GitPullRequestNotFoundException",
+				"message": "TF401180: The requested pull request was not found.",
+			})
+		}))
+
+		_, err := cli.GetPullRequest(ctx, OrgProjectRepoArgs{Org: "sgtest", Project: "sgtest", RepoNameOrID: "src-cli"}, 42)
+		assert.True(t, IsNotFound(err))
+		assert.True(t, errcode.IsNotFound(err))
+		assert.Contains(t, err.Error(), "GitPullRequestNotFoundException")
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		cli, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+
+		_, err := cli.CreatePullRequest(ctx, OrgProjectRepoArgs{Org: "sgtest", Project: "sgtest", RepoNameOrID: "src-cli"}, CreatePullRequestInput{})
+		assert.True(t, IsConflict(err))
+	})
+
+	t.Run("sign-in page", func(t *testing.T) {
+		cli, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = io.WriteString(w, "<html>Sign in</html>")
+		}))
+
+		_, err := cli.ListRepositoriesByProjectOrOrg(ctx, "sgtest", "")
+		assert.True(t, errcode.IsUnauthorized(err))
+	})
+}
+
+func TestClient_FindUserEntitlementByEmail(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Azure DevOps Server", func(t *testing.T) {
+		cli, _ := newTestClient(t, http.NotFoundHandler())
+		_, err := cli.FindUserEntitlementByEmail(ctx, "sgtest", "alice@example.com")
+		assert.ErrorIs(t, err, ErrNotSupportedOnServer)
+	})
+
+	t.Run("Azure DevOps Services", func(t *testing.T) {
+		var req *http.Request
+		doer := httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			req = r
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: io.NopCloser(strings.NewReader(`{"members": [
+					{"id": "1", "user": {"principalName": "bob@example.com"}},
+					{"id": "2", "user": {"principalName": "alice@example.com"}, "projectEntitlements": [{"projectRef": {"id": "p1", "name": "sgtest"}}]}
+				]}`)),
+			}, nil
+		})
+		cli, err := NewClient("urn", &schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "admin", Token: "secret"}, doer)
+		require.Nil(t, err)
+
+		ue, err := cli.FindUserEntitlementByEmail(ctx, "sgtest", "alice@example.com")
+		require.Nil(t, err)
+		assert.Equal(t, "2", ue.ID)
+		assert.Equal(t, "vsaex.dev.azure.com", req.URL.Host)
+		assert.Equal(t, "/sgtest/_apis/userentitlements", req.URL.Path)
+		assert.Equal(t, "name eq 'alice@example.com'", req.URL.Query().Get("$filter"))
+	})
+}
+
+func TestRepository_Org(t *testing.T) {
+	for name, tc := range map[string]struct {
+		url  string
+		want string
+	}{
+		"Azure DevOps Services": {
+			url:  "https://dev.azure.com/sgtest/0fa3b3bf-4d4c-4c4e-b8a4-1e8c8a1f0b0e/_apis/git/repositories/c0ee43b6-4a9b-49ad-9c6c-1f7bd83e0ef0",
+			want: "sgtest",
+		},
+		"Azure DevOps Server": {
+			url:  "https://azure.corp.com/tfs/DefaultCollection/0fa3b3bf-4d4c-4c4e-b8a4-1e8c8a1f0b0e/_apis/git/repositories/c0ee43b6-4a9b-49ad-9c6c-1f7bd83e0ef0",
+			want: "DefaultCollection",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := Repository{Name: "repo", URL: tc.url, Project: Project{Name: "project"}}
+			org, err := r.Org()
+			require.Nil(t, err)
+			assert.Equal(t, tc.want, org)
+
+			nwo, err := r.NameWithOwner()
+			require.Nil(t, err)
+			assert.Equal(t, tc.want+"/project/repo", nwo)
+		})
+	}
+
+	_, err := (&Repository{URL: "https://dev.azure.com/sgtest"}).Org()
+	assert.Error(t, err)
+}
+
+// newTestClient returns a Client talking to a test server using the given
+// handler, and a pointer to the requests the server received.
+func newTestClient(t *testing.T, handler http.Handler) (*Client, *[]*http.Request) {
+	t.Helper()
+
+	var requests []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient("urn", &schema.AzureDevOpsConnection{Url: srv.URL, Username: "admin", Token: "secret"}, http.DefaultClient)
+	require.Nil(t, err)
+
+	return cli, &requests
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+	require.Nil(t, json.NewEncoder(w).Encode(v))
+}
@@ -0,0 +1,91 @@
+package azuredevops
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// UserEntitlement describes the access of a user to an organization, including
+// the projects they are a member of.
+type UserEntitlement struct {
+	ID                  string               `json:"id"`
+	User                GraphUser            `json:"user"`
+	ProjectEntitlements []ProjectEntitlement `json:"projectEntitlements"`
+}
+
+// GraphUser is a user of an organization.
+type GraphUser struct {
+	PrincipalName string `json:"principalName"`
+	MailAddress   string `json:"mailAddress"`
+	DisplayName   string `json:"displayName"`
+	Descriptor    string `json:"descriptor"`
+}
+
+// ProjectEntitlement is the membership of a user in a project.
+type ProjectEntitlement struct {
+	ProjectRef ProjectRef `json:"projectRef"`
+}
+
+// ProjectRef references a project.
+type ProjectRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// FindUserEntitlementByEmail returns the entitlement of the user of the given
+// organization whose principal name or email address is email, or nil if there
+// is none. It is only supported on Azure DevOps Services.
+func (c *Client) FindUserEntitlementByEmail(ctx context.Context, org, email string) (*UserEntitlement, error) {
+	u, err := c.serviceURL("vsaex")
+	if err != nil {
+		return nil, err
+	}
+	u.Path = "/" + org + "/_apis/userentitlements"
+	u.RawQuery = url.Values{
+		"$filter":     {"name eq '" + strings.ReplaceAll(email, "'", "''") + "'"},
+		"api-version": {apiVersion + "-preview.3"},
+	}.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Members []*UserEntitlement `json:"members"`
+	}
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	for _, m := range resp.Members {
+		if m.User.PrincipalName == email || m.User.MailAddress == email {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetUserEntitlement returns the entitlement of the user with the given ID in
+// the given organization. It is only supported on Azure DevOps Services.
+func (c *Client) GetUserEntitlement(ctx context.Context, org, userID string) (*UserEntitlement, error) {
+	u, err := c.serviceURL("vsaex")
+	if err != nil {
+		return nil, err
+	}
+	u.Path = "/" + org + "/_apis/userentitlements/" + userID
+	u.RawQuery = url.Values{"api-version": {apiVersion + "-preview.3"}}.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ue UserEntitlement
+	if _, err := c.do(ctx, req, &ue); err != nil {
+		return nil, err
+	}
+	return &ue, nil
+}
@@ -0,0 +1,289 @@
+package azuredevops
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PullRequestStatus is the status of an Azure DevOps pull request.
+type PullRequestStatus string
+
+const (
+	PullRequestStatusActive    PullRequestStatus = "active"
+	PullRequestStatusAbandoned PullRequestStatus = "abandoned"
+	PullRequestStatusCompleted PullRequestStatus = "completed"
+)
+
+// PullRequestMergeStatus is the status of the test merge of a pull request
+// that Azure DevOps performs whenever one of its branches changes.
+type PullRequestMergeStatus string
+
+const (
+	PullRequestMergeStatusNotSet    PullRequestMergeStatus = "notSet"
+	PullRequestMergeStatusQueued    PullRequestMergeStatus = "queued"
+	PullRequestMergeStatusConflicts PullRequestMergeStatus = "conflicts"
+	PullRequestMergeStatusSucceeded PullRequestMergeStatus = "succeeded"
+	PullRequestMergeStatusRejected  PullRequestMergeStatus = "rejectedByPolicy"
+	PullRequestMergeStatusFailure   PullRequestMergeStatus = "failure"
+)
+
+// Votes that reviewers can cast on a pull request.
+const (
+	VoteApproved                = 10
+	VoteApprovedWithSuggestions = 5
+	VoteNoVote                  = 0
+	VoteWaitingForAuthor        = -5
+	VoteRejected                = -10
+)
+
+// PullRequest is an Azure DevOps pull request. See
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pull-requests/get-pull-request.
+type PullRequest struct {
+	ID                    int                    `json:"pullRequestId"`
+	Status                PullRequestStatus      `json:"status"`
+	CreatedBy             Identity               `json:"createdBy"`
+	CreationDate          time.Time              `json:"creationDate"`
+	ClosedDate            time.Time              `json:"closedDate,omitempty"`
+	Title                 string                 `json:"title"`
+	Description           string                 `json:"description"`
+	SourceRefName         string                 `json:"sourceRefName"`
+	TargetRefName         string                 `json:"targetRefName"`
+	MergeStatus           PullRequestMergeStatus `json:"mergeStatus,omitempty"`
+	IsDraft               bool                   `json:"isDraft"`
+	LastMergeSourceCommit *CommitRef             `json:"lastMergeSourceCommit,omitempty"`
+	LastMergeTargetCommit *CommitRef             `json:"lastMergeTargetCommit,omitempty"`
+	Reviewers             []Reviewer             `json:"reviewers"`
+	Repository            Repository             `json:"repository"`
+	URL                   string                 `json:"url"`
+}
+
+// Identity is a user or group referenced by the API.
+type Identity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+// Reviewer is an identity asked to review a pull request, and their vote.
+type Reviewer struct {
+	Identity
+	Vote       int  `json:"vote"`
+	IsRequired bool `json:"isRequired,omitempty"`
+}
+
+// CommitRef references a commit.
+type CommitRef struct {
+	CommitID string `json:"commitId"`
+}
+
+// PullRequestStatusState is the state of a status posted to a pull request,
+// e.g. by a build pipeline.
+type PullRequestStatusState string
+
+const (
+	PullRequestStatusStateNotSet        PullRequestStatusState = "notSet"
+	PullRequestStatusStatePending       PullRequestStatusState = "pending"
+	PullRequestStatusStateSucceeded     PullRequestStatusState = "succeeded"
+	PullRequestStatusStateFailed        PullRequestStatusState = "failed"
+	PullRequestStatusStateError         PullRequestStatusState = "error"
+	PullRequestStatusStateNotApplicable PullRequestStatusState = "notApplicable"
+)
+
+// PullRequestBuildStatus is a status posted to a pull request. Multiple
+// statuses with the same context may exist, the latest being the one with the
+// highest ID.
+type PullRequestBuildStatus struct {
+	ID           int                    `json:"id"`
+	State        PullRequestStatusState `json:"state"`
+	Description  string                 `json:"description"`
+	Context      StatusContext          `json:"context"`
+	CreationDate time.Time              `json:"creationDate"`
+	UpdatedDate  time.Time              `json:"updatedDate"`
+}
+
+// StatusContext identifies the service that posted a status.
+type StatusContext struct {
+	Name  string `json:"name"`
+	Genre string `json:"genre,omitempty"`
+}
+
+// CreatePullRequestInput contains the arguments of CreatePullRequest.
+type CreatePullRequestInput struct {
+	SourceRefName string `json:"sourceRefName"`
+	TargetRefName string `json:"targetRefName"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	IsDraft       bool   `json:"isDraft,omitempty"`
+}
+
+// UpdatePullRequestInput contains the fields of a pull request to update. Nil
+// fields are left unchanged.
+type UpdatePullRequestInput struct {
+	Title                 *string            `json:"title,omitempty"`
+	Description           *string            `json:"description,omitempty"`
+	TargetRefName         *string            `json:"targetRefName,omitempty"`
+	Status                *PullRequestStatus `json:"status,omitempty"`
+	IsDraft               *bool              `json:"isDraft,omitempty"`
+	LastMergeSourceCommit *CommitRef         `json:"lastMergeSourceCommit,omitempty"`
+	CompletionOptions     *CompletionOptions `json:"completionOptions,omitempty"`
+}
+
+// CompletionOptions define how a pull request is merged when it is completed.
+type CompletionOptions struct {
+	MergeStrategy      MergeStrategy `json:"mergeStrategy"`
+	DeleteSourceBranch bool          `json:"deleteSourceBranch,omitempty"`
+}
+
+// MergeStrategy is the strategy used to merge a pull request.
+type MergeStrategy string
+
+const (
+	MergeStrategyNoFastForward MergeStrategy = "noFastForward"
+	MergeStrategySquash        MergeStrategy = "squash"
+)
+
+// PullRequestCriteria filters the pull requests returned by ListPullRequests.
+// Empty fields are ignored.
+type PullRequestCriteria struct {
+	SourceRefName string
+	TargetRefName string
+	Status        PullRequestStatus
+}
+
+// CreatePullRequest creates a pull request in the given repository. If an
+// active pull request for the same source and target branch already exists,
+// an error for which IsConflict returns true is returned.
+func (c *Client) CreatePullRequest(ctx context.Context, args OrgProjectRepoArgs, in CreatePullRequestInput) (*PullRequest, error) {
+	req, err := newJSONRequest("POST", args.path("/pullrequests"), in)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// GetPullRequest returns the pull request with the given ID.
+func (c *Client) GetPullRequest(ctx context.Context, args OrgProjectRepoArgs, id int) (*PullRequest, error) {
+	req, err := http.NewRequest("GET", args.path("/pullrequests/"+strconv.Itoa(id)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// ListPullRequests returns the pull requests of the given repository matching
+// the criteria.
+func (c *Client) ListPullRequests(ctx context.Context, args OrgProjectRepoArgs, criteria PullRequestCriteria) ([]*PullRequest, error) {
+	q := make(url.Values)
+	if criteria.SourceRefName != "" {
+		q.Set("searchCriteria.sourceRefName", criteria.SourceRefName)
+	}
+	if criteria.TargetRefName != "" {
+		q.Set("searchCriteria.targetRefName", criteria.TargetRefName)
+	}
+	if criteria.Status != "" {
+		q.Set("searchCriteria.status", string(criteria.Status))
+	}
+
+	req, err := http.NewRequest("GET", args.path("/pullrequests?"+q.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp listResponse[*PullRequest]
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+// UpdatePullRequest updates the given pull request. Abandoning, reactivating
+// and completing pull requests are updates of their status.
+func (c *Client) UpdatePullRequest(ctx context.Context, args OrgProjectRepoArgs, id int, in UpdatePullRequestInput) (*PullRequest, error) {
+	req, err := newJSONRequest("PATCH", args.path("/pullrequests/"+strconv.Itoa(id)), in)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// AbandonPullRequest abandons the given pull request.
+func (c *Client) AbandonPullRequest(ctx context.Context, args OrgProjectRepoArgs, id int) (*PullRequest, error) {
+	status := PullRequestStatusAbandoned
+	return c.UpdatePullRequest(ctx, args, id, UpdatePullRequestInput{Status: &status})
+}
+
+// ReactivatePullRequest reactivates the given abandoned pull request.
+func (c *Client) ReactivatePullRequest(ctx context.Context, args OrgProjectRepoArgs, id int) (*PullRequest, error) {
+	status := PullRequestStatusActive
+	return c.UpdatePullRequest(ctx, args, id, UpdatePullRequestInput{Status: &status})
+}
+
+// CompletePullRequest completes, which means merges, the given pull request.
+// The merge fails if the source branch has changed since the pull request was
+// loaded. Merges can be asynchronous, so the returned pull request may still
+// be active with a queued merge.
+func (c *Client) CompletePullRequest(ctx context.Context, args OrgProjectRepoArgs, pr *PullRequest, strategy MergeStrategy) (*PullRequest, error) {
+	status := PullRequestStatusCompleted
+	return c.UpdatePullRequest(ctx, args, pr.ID, UpdatePullRequestInput{
+		Status:                &status,
+		LastMergeSourceCommit: pr.LastMergeSourceCommit,
+		CompletionOptions:     &CompletionOptions{MergeStrategy: strategy},
+	})
+}
+
+// CreatePullRequestCommentThread posts a comment in a new thread on the given
+// pull request.
+func (c *Client) CreatePullRequestCommentThread(ctx context.Context, args OrgProjectRepoArgs, id int, content string) error {
+	type comment struct {
+		Content     string `json:"content"`
+		CommentType string `json:"commentType"`
+	}
+	type thread struct {
+		Comments []comment `json:"comments"`
+		Status   string    `json:"status"`
+	}
+
+	req, err := newJSONRequest("POST", args.path("/pullrequests/"+strconv.Itoa(id)+"/threads"), thread{
+		Comments: []comment{{Content: content, CommentType: "text"}},
+		Status:   "active",
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// GetPullRequestStatuses returns all statuses posted to the given pull
+// request.
+func (c *Client) GetPullRequestStatuses(ctx context.Context, args OrgProjectRepoArgs, id int) ([]*PullRequestBuildStatus, error) {
+	req, err := http.NewRequest("GET", args.path("/pullrequests/"+strconv.Itoa(id)+"/statuses"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp listResponse[*PullRequestBuildStatus]
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
@@ -0,0 +1,144 @@
+package azuredevops
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Project is an Azure DevOps project, which contains the repositories of an
+// organization.
+type Project struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+	State       string `json:"state,omitempty"`
+	Visibility  string `json:"visibility,omitempty"`
+}
+
+// Repository is an Azure DevOps Git repository.
+type Repository struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	URL           string  `json:"url"`
+	Project       Project `json:"project"`
+	DefaultBranch string  `json:"defaultBranch,omitempty"`
+	Size          int64   `json:"size,omitempty"`
+	RemoteURL     string  `json:"remoteUrl"`
+	SSHURL        string  `json:"sshUrl"`
+	WebURL        string  `json:"webUrl"`
+	IsDisabled    bool    `json:"isDisabled,omitempty"`
+	IsFork        bool    `json:"isFork,omitempty"`
+}
+
+// Org returns the name of the organization (or the collection, on Azure DevOps
+// Server) of the repository, which is the path segment preceding the project
+// in the API URL of the repository.
+func (r *Repository) Org() (string, error) {
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return "", err
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, s := range segments {
+		if s == "_apis" && i >= 2 {
+			return segments[i-2], nil
+		}
+	}
+	return "", errors.Errorf("unable to determine organization of repository %q from URL %q", r.Name, r.URL)
+}
+
+// NameWithOwner returns the name of the repository qualified with the names
+// of its organization and project, e.g. "org/project/repo".
+func (r *Repository) NameWithOwner() (string, error) {
+	org, err := r.Org()
+	if err != nil {
+		return "", err
+	}
+	return org + "/" + r.Project.Name + "/" + r.Name, nil
+}
+
+// ListProjectsArgs defines options to be set on ListProjects method calls.
+type ListProjectsArgs struct {
+	Org string
+	// ContinuationToken is the token returned by the previous call, if any.
+	ContinuationToken string
+	// Top is the maximum number of projects returned.
+	Top int
+}
+
+// ListProjects returns a page of the projects of the given organization, and
+// the continuation token to get the next page. The token is empty if there
+// are no more pages.
+func (c *Client) ListProjects(ctx context.Context, args ListProjectsArgs) ([]*Project, string, error) {
+	q := make(url.Values)
+	if args.Top > 0 {
+		q.Set("$top", strconv.Itoa(args.Top))
+	}
+	if args.ContinuationToken != "" {
+		q.Set("continuationToken", args.ContinuationToken)
+	}
+	req, err := http.NewRequest("GET", url.PathEscape(args.Org)+"/_apis/projects?"+q.Encode(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var resp listResponse[*Project]
+	httpResp, err := c.do(ctx, req, &resp)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Value, httpResp.Header.Get("X-Ms-Continuationtoken"), nil
+}
+
+// ListRepositoriesByProjectOrOrg returns the repositories of the given
+// project, or of all projects of the organization if project is empty.
+func (c *Client) ListRepositoriesByProjectOrOrg(ctx context.Context, org, project string) ([]*Repository, error) {
+	path := url.PathEscape(org)
+	if project != "" {
+		path += "/" + url.PathEscape(project)
+	}
+	req, err := http.NewRequest("GET", path+"/_apis/git/repositories", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp listResponse[*Repository]
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+// GetRepository returns the repository with the given name or ID in the given
+// project.
+func (c *Client) GetRepository(ctx context.Context, args OrgProjectRepoArgs) (*Repository, error) {
+	req, err := http.NewRequest("GET", args.path(""), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo Repository
+	if _, err := c.do(ctx, req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// OrgProjectRepoArgs identifies a repository in the API.
+type OrgProjectRepoArgs struct {
+	Org          string
+	Project      string
+	RepoNameOrID string
+}
+
+// path returns the API path of the repository, followed by the given suffix.
+func (a OrgProjectRepoArgs) path(suffix string) string {
+	return url.PathEscape(a.Org) + "/" + url.PathEscape(a.Project) + "/_apis/git/repositories/" + url.PathEscape(a.RepoNameOrID) + suffix
+}
@@ -0,0 +1,61 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// GetExternalAccountData returns the deserialized user and token from the external account data
+// JSON blob in a typesafe way.
+func GetExternalAccountData(ctx context.Context, data *extsvc.AccountData) (usr *User, tok *oauth2.Token, err error) {
+	if data.Data != nil {
+		var u User
+		if err := encryption.DecryptJSON(ctx, data.Data, &u); err != nil {
+			return nil, nil, err
+		}
+
+		usr = &u
+	}
+
+	if data.AuthData != nil {
+		var t oauth2.Token
+		if err := encryption.DecryptJSON(ctx, data.AuthData, &t); err != nil {
+			return nil, nil, err
+		}
+
+		tok = &t
+	}
+
+	return usr, tok, nil
+}
+
+// SetExternalAccountData sets the user and token into the external account data blob.
+func SetExternalAccountData(data *extsvc.AccountData, user *User, token *oauth2.Token) error {
+	serializedUser, err := json.Marshal(user)
+	if err != nil {
+		return err
+	}
+	serializedToken, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	data.Data = extsvc.NewUnencryptedData(serializedUser)
+	data.AuthData = extsvc.NewUnencryptedData(serializedToken)
+	return nil
+}
+
+// OAuthEndpoint returns the OAuth 2.0 endpoint of the Bitbucket Cloud instance
+// at the given base URL, such as https://bitbucket.org.
+func OAuthEndpoint(baseURL *url.URL) oauth2.Endpoint {
+	return oauth2.Endpoint{
+		AuthURL:  baseURL.ResolveReference(&url.URL{Path: "/site/oauth2/authorize"}).String(),
+		TokenURL: baseURL.ResolveReference(&url.URL{Path: "/site/oauth2/access_token"}).String(),
+	}
+}
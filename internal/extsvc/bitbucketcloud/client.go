@@ -45,6 +45,10 @@ type Client interface {
 	ForkRepository(ctx context.Context, upstream *Repo, input ForkInput) (*Repo, error)
 
 	CurrentUser(ctx context.Context) (*User, error)
+	CurrentUserEmails(ctx context.Context) ([]*UserEmail, error)
+
+	CurrentUserRepoPermissions(ctx context.Context, pageToken *PageToken) ([]*RepoPermission, *PageToken, error)
+	RepoUserPermissions(ctx context.Context, pageToken *PageToken, workspace, slug string) ([]*RepoPermission, *PageToken, error)
 }
 
 // client access a Bitbucket Cloud via the REST API 2.0.
@@ -55,8 +59,8 @@ type client struct {
 	// URL is the base URL of Bitbucket Cloud.
 	URL *url.URL
 
-	// Auth is the authentication method used when accessing the server. Only
-	// auth.BasicAuth is currently supported.
+	// Auth is the authentication method used when accessing the server.
+	// auth.BasicAuth and auth.OAuthBearerToken are supported.
 	Auth auth.Authenticator
 
 	// RateLimit is the self-imposed rate limiter (since Bitbucket does not have a concept
@@ -112,8 +116,8 @@ func (c *client) Authenticator() auth.Authenticator {
 // the given authenticator instance.
 //
 // Note that using an unsupported Authenticator implementation may result in
-// unexpected behaviour, or (more likely) errors. At present, only BasicAuth is
-// supported.
+// unexpected behaviour, or (more likely) errors. At present, only BasicAuth and
+// OAuthBearerToken are supported.
 func (c *client) WithAuthenticator(a auth.Authenticator) Client {
 	return &client{
 		httpClient: c.httpClient,
@@ -33,6 +33,8 @@ func ParseWebhookEvent(eventKey string, payload []byte) (any, error) {
 		target = &RepoCommitStatusCreatedEvent{}
 	case "repo:commit_status_updated":
 		target = &RepoCommitStatusUpdatedEvent{}
+	case "repo:push":
+		target = &RepoPushEvent{}
 	default:
 		return nil, UnknownWebhookEventKey(eventKey)
 	}
@@ -132,6 +134,27 @@ type RepoCommitStatusUpdatedEvent struct {
 	RepoCommitStatusEvent
 }
 
+type RepoPushEvent struct {
+	RepoEvent
+	Push RepoPush `json:"push"`
+}
+
+type RepoPush struct {
+	Changes []RepoPushChange `json:"changes"`
+}
+
+// RepoPushChange is a single ref changed by a push. New is nil if the ref was
+// deleted, and Old is nil if it was created.
+type RepoPushChange struct {
+	New *RepoPushRef `json:"new"`
+	Old *RepoPushRef `json:"old"`
+}
+
+type RepoPushRef struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
 type CommitStatus struct {
 	Name        string                 `json:"name"`
 	Description string                 `json:"description"`
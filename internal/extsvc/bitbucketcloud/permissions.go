@@ -0,0 +1,54 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+)
+
+// RepoPermission is the effective permission of a user on a repository, which
+// is the highest level of permission the user has, whether granted directly or
+// through a group.
+type RepoPermission struct {
+	Permission RepoPermissionLevel `json:"permission"`
+	User       *Account            `json:"user"`
+	Repo       *Repo               `json:"repository"`
+}
+
+type RepoPermissionLevel string
+
+const (
+	RepoPermissionLevelRead  RepoPermissionLevel = "read"
+	RepoPermissionLevelWrite RepoPermissionLevel = "write"
+	RepoPermissionLevelAdmin RepoPermissionLevel = "admin"
+)
+
+// CurrentUserRepoPermissions returns the permissions of the user associated
+// with the authenticator in use on all repositories the user has been granted
+// access to. Public repositories the user has no explicit permission on are
+// not included. Pagination works the same way as in Repos.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-permissions-repositories-get
+func (c *client) CurrentUserRepoPermissions(ctx context.Context, pageToken *PageToken) ([]*RepoPermission, *PageToken, error) {
+	return c.repoPermissions(ctx, pageToken, "/2.0/user/permissions/repositories")
+}
+
+// RepoUserPermissions returns the permissions of all users that can access the
+// repository identified by the given workspace and slug. Only workspace admins
+// may call this endpoint. Pagination works the same way as in Repos.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-repo-slug-get
+func (c *client) RepoUserPermissions(ctx context.Context, pageToken *PageToken, workspace, slug string) ([]*RepoPermission, *PageToken, error) {
+	return c.repoPermissions(ctx, pageToken, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories/%s", workspace, slug))
+}
+
+func (c *client) repoPermissions(ctx context.Context, pageToken *PageToken, path string) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &perms)
+	} else {
+		next, err = c.page(ctx, path, nil, pageToken, &perms)
+	}
+	return perms, next, err
+}
@@ -22,8 +22,37 @@ func (c *client) CurrentUser(ctx context.Context) (*User, error) {
 	return &user, nil
 }
 
+// CurrentUserEmails returns all email addresses of the user associated with
+// the authenticator in use.
+func (c *client) CurrentUserEmails(ctx context.Context) ([]*UserEmail, error) {
+	var emails []*UserEmail
+	var next *PageToken
+	for {
+		var page []*UserEmail
+		var err error
+		if next.HasMore() {
+			next, err = c.reqPage(ctx, next.Next, &page)
+		} else {
+			next, err = c.page(ctx, "/2.0/user/emails", nil, nil, &page)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "sending request")
+		}
+		emails = append(emails, page...)
+		if !next.HasMore() {
+			return emails, nil
+		}
+	}
+}
+
 type User struct {
 	Account
 	IsStaff   bool   `json:"is_staff"`
 	AccountID string `json:"account_id"`
 }
+
+type UserEmail struct {
+	Email       string `json:"email"`
+	IsPrimary   bool   `json:"is_primary"`
+	IsConfirmed bool   `json:"is_confirmed"`
+}
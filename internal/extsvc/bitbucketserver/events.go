@@ -30,6 +30,9 @@ func ParseWebhookEvent(eventType string, payload []byte) (e any, err error) {
 	case "pr:participant:status":
 		e = &PullRequestParticipantStatusEvent{}
 		return e, json.Unmarshal(payload, e)
+	case "repo:refs_changed":
+		e = &RepoRefsChangedEvent{}
+		return e, json.Unmarshal(payload, e)
 	default:
 		return nil, errors.Errorf("unknown webhook event type: %q", eventType)
 	}
@@ -37,6 +40,20 @@ func ParseWebhookEvent(eventType string, payload []byte) (e any, err error) {
 
 type PingEvent struct{}
 
+// RepoRefsChangedEvent is sent when refs of a repository are pushed to.
+type RepoRefsChangedEvent struct {
+	Actor      User        `json:"actor"`
+	Repository Repo        `json:"repository"`
+	Changes    []RefChange `json:"changes"`
+}
+
+type RefChange struct {
+	RefID    string `json:"refId"`
+	FromHash string `json:"fromHash"`
+	ToHash   string `json:"toHash"`
+	Type     string `json:"type"`
+}
+
 type PullRequestActivityEvent struct {
 	Date        time.Time      `json:"date"`
 	Actor       User           `json:"actor"`
@@ -15,7 +15,7 @@ type CodeHost struct {
 
 func (c *CodeHost) IsPackageHost() bool {
 	switch c.ServiceType {
-	case TypeNpmPackages, TypeJVMPackages, TypeGoModules, TypePythonPackages, TypeRustPackages:
+	case TypeNpmPackages, TypeJVMPackages, TypeGoModules, TypeNuGetPackages, TypePythonPackages, TypeRubyPackages, TypeRustPackages:
 		return true
 	}
 	return false
@@ -38,9 +38,15 @@ var (
 	GoURL     = &url.URL{Host: "go"}
 	GoModules = NewCodeHost(GoURL, TypeGoModules)
 
+	NuGetURL      = &url.URL{Host: "nuget"}
+	NuGetPackages = NewCodeHost(NuGetURL, TypeNuGetPackages)
+
 	PythonURL      = &url.URL{Host: "python"}
 	PythonPackages = NewCodeHost(PythonURL, TypePythonPackages)
 
+	RubyURL      = &url.URL{Host: "rubygems"}
+	RubyPackages = NewCodeHost(RubyURL, TypeRubyPackages)
+
 	RustURL      = &url.URL{Host: "crates"}
 	RustPackages = NewCodeHost(RustURL, TypeRustPackages)
 
@@ -50,7 +56,9 @@ var (
 		JVMPackages,
 		NpmPackages,
 		GoModules,
+		NuGetPackages,
 		PythonPackages,
+		RubyPackages,
 		RustPackages,
 	}
 )
@@ -0,0 +1,226 @@
+//nolint:bodyclose // Body is closed in Client.Do, but the response is still returned to provide access to the headers
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChangeStatus is the status of a Gerrit change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// Well known label names used for code review and CI votes.
+const (
+	LabelCodeReview = "Code-Review"
+	LabelVerified   = "Verified"
+)
+
+// Change is a Gerrit change, as returned by the changes REST API. See
+// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#change-info.
+type Change struct {
+	ID              string               `json:"id"`
+	Project         string               `json:"project"`
+	Branch          string               `json:"branch"`
+	Topic           string               `json:"topic,omitempty"`
+	ChangeID        string               `json:"change_id"`
+	Subject         string               `json:"subject"`
+	Status          ChangeStatus         `json:"status"`
+	Created         Timestamp            `json:"created"`
+	Updated         Timestamp            `json:"updated"`
+	Submittable     bool                 `json:"submittable,omitempty"`
+	WorkInProgress  bool                 `json:"work_in_progress,omitempty"`
+	Number          int                  `json:"_number"`
+	Owner           Account              `json:"owner"`
+	Labels          map[string]LabelInfo `json:"labels,omitempty"`
+	CurrentRevision string               `json:"current_revision,omitempty"`
+	Revisions       map[string]Revision  `json:"revisions,omitempty"`
+}
+
+// Revision is a single patch set of a Change.
+type Revision struct {
+	Number int    `json:"_number"`
+	Ref    string `json:"ref"`
+	Commit Commit `json:"commit"`
+}
+
+// Commit is the commit of a Revision.
+type Commit struct {
+	Parents []CommitParent `json:"parents"`
+	Subject string         `json:"subject"`
+	Message string         `json:"message"`
+}
+
+// CommitParent identifies a parent commit of a Commit.
+type CommitParent struct {
+	Commit  string `json:"commit"`
+	Subject string `json:"subject"`
+}
+
+// LabelInfo contains the votes cast on a label of a Change. The summary fields
+// are only set if at least one vote with the respective value exists.
+type LabelInfo struct {
+	Approved    *Account       `json:"approved,omitempty"`
+	Rejected    *Account       `json:"rejected,omitempty"`
+	Recommended *Account       `json:"recommended,omitempty"`
+	Disliked    *Account       `json:"disliked,omitempty"`
+	All         []ApprovalInfo `json:"all,omitempty"`
+}
+
+// ApprovalInfo is a single vote on a label.
+type ApprovalInfo struct {
+	Account
+	Value int        `json:"value"`
+	Date  *Timestamp `json:"date,omitempty"`
+}
+
+// CurrentCommit returns the commit of the current revision of the change, or
+// nil if the change was loaded without revision details.
+func (c *Change) CurrentCommit() *Commit {
+	rev, ok := c.Revisions[c.CurrentRevision]
+	if !ok {
+		return nil
+	}
+	return &rev.Commit
+}
+
+// timestampLayout is the layout Gerrit uses for all timestamps, which are
+// always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp is a time.Time that is (un)marshalled in the format used by the
+// Gerrit REST API.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UTC().Format(timestampLayout))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// changeOptions are the additional fields requested when loading a change, so
+// that the returned Change contains enough information to compute its review
+// and check state.
+var changeOptions = []string{"LABELS", "DETAILED_LABELS", "DETAILED_ACCOUNTS", "CURRENT_REVISION", "CURRENT_COMMIT"}
+
+// GetChange returns the change with the given number (or Change-Id) in the
+// given project.
+func (c *Client) GetChange(ctx context.Context, project, changeID string) (*Change, error) {
+	qs := make(url.Values)
+	qs.Set("q", "project:"+project+" change:"+changeID)
+	qs["o"] = changeOptions
+
+	req, err := http.NewRequest("GET", (&url.URL{Path: "a/changes/", RawQuery: qs.Encode()}).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*Change
+	if _, err := c.do(ctx, req, &changes); err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, &httpError{URL: req.URL, StatusCode: http.StatusNotFound}
+	}
+	return changes[0], nil
+}
+
+// AbandonChange abandons the given change, optionally with a message.
+func (c *Client) AbandonChange(ctx context.Context, project string, number int, message string) error {
+	return c.postChangeAction(ctx, project, number, "abandon", map[string]string{"message": message})
+}
+
+// RestoreChange restores the given abandoned change.
+func (c *Client) RestoreChange(ctx context.Context, project string, number int) error {
+	return c.postChangeAction(ctx, project, number, "restore", map[string]string{})
+}
+
+// SubmitChange submits the given change. If the change cannot be submitted,
+// because it is missing votes or cannot be merged, a conflict error is
+// returned, which can be detected with IsConflict.
+func (c *Client) SubmitChange(ctx context.Context, project string, number int) error {
+	return c.postChangeAction(ctx, project, number, "submit", map[string]string{})
+}
+
+// SetCommitMessage creates a new patch set of the change with the given
+// commit message. The message must retain the Change-Id trailer of the change.
+func (c *Client) SetCommitMessage(ctx context.Context, project string, number int, message string) error {
+	req, err := newJSONRequest("PUT", changeURL(project, number, "message"), map[string]string{"message": message})
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// CreateChangeComment posts a message on the current revision of the change
+// without voting on any label.
+func (c *Client) CreateChangeComment(ctx context.Context, project string, number int, message string) error {
+	return c.postChangeAction(ctx, project, number, "revisions/current/review", map[string]string{"message": message})
+}
+
+// GetAuthenticatedUser returns the account the client is authenticated as.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequest("GET", "a/accounts/self", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if _, err := c.do(ctx, req, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// postChangeAction posts the given body to an endpoint of a change. The
+// responses of these endpoints don't contain the labels and revisions of the
+// change, so callers need to use GetChange to load its new state.
+func (c *Client) postChangeAction(ctx context.Context, project string, number int, action string, body any) error {
+	req, err := newJSONRequest("POST", changeURL(project, number, action), body)
+	if err != nil {
+		return err
+	}
+
+	var result json.RawMessage
+	_, err = c.do(ctx, req, &result)
+	return err
+}
+
+// changeURL returns the relative URL of the given change endpoint. Gerrit
+// requires slashes in project names to be escaped in change identifiers.
+func changeURL(project string, number int, endpoint string) string {
+	return "a/changes/" + url.PathEscape(project) + "~" + strconv.Itoa(number) + "/" + strings.TrimPrefix(endpoint, "/")
+}
+
+func newJSONRequest(method, urlStr string, body any) (*http.Request, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return http.NewRequest(method, urlStr, bytes.NewReader(data))
+}
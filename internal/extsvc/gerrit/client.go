@@ -3,15 +3,16 @@ package gerrit
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -26,6 +27,10 @@ type Client struct {
 	// URL is the base URL of Gerrit.
 	URL *url.URL
 
+	// auther is used to authenticate requests against the Gerrit API. It
+	// defaults to the username and password in Config.
+	auther *auth.BasicAuth
+
 	// RateLimit is the self-imposed rate limiter (since Gerrit does not have a concept
 	// of rate limiting in HTTP response headers).
 	rateLimit *ratelimit.InstrumentedLimiter
@@ -48,10 +53,40 @@ func NewClient(urn string, config *schema.GerritConnection, httpClient httpcli.D
 		httpClient: httpClient,
 		Config:     config,
 		URL:        u,
+		auther:     &auth.BasicAuth{Username: config.Username, Password: config.Password},
 		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
 	}, nil
 }
 
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTP client, and rate limiter as the current Client, except authenticated
+// with the given authenticator. Only basic authentication is supported by
+// Gerrit, using the username and HTTP password of the account.
+func (c *Client) WithAuthenticator(a auth.Authenticator) (*Client, error) {
+	var basic *auth.BasicAuth
+	switch a := a.(type) {
+	case *auth.BasicAuth:
+		basic = a
+	case *auth.BasicAuthWithSSH:
+		basic = &a.BasicAuth
+	default:
+		return nil, errors.Errorf("authenticator type unsupported for Gerrit clients: %T", a)
+	}
+
+	return &Client{
+		httpClient: c.httpClient,
+		Config:     c.Config,
+		URL:        c.URL,
+		auther:     basic,
+		rateLimit:  c.rateLimit,
+	}, nil
+}
+
+// Authenticator returns the authenticator used by the client.
+func (c *Client) Authenticator() auth.Authenticator {
+	return c.auther
+}
+
 type ListAccountsResponse []Account
 
 func (c *Client) ListAccountsByEmail(ctx context.Context, email string) (ListAccountsResponse, error) {
@@ -171,7 +206,12 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 	req.URL = c.URL.ResolveReference(req.URL)
 
 	// Add Basic Auth headers for authenticated requests.
-	req.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.Config.Username+":"+c.Config.Password)))
+	if err := c.auther.Authenticate(req); err != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	}
 
 	if err := c.rateLimit.Wait(ctx); err != nil {
 		return nil, err
@@ -199,6 +239,12 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 		}
 	}
 
+	// Some endpoints, such as the one editing a commit message, respond with
+	// 204 No Content.
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return resp, nil
+	}
+
 	// The first 4 characters of the Gerrit API responses need to be stripped, see: https://gerrit-review.googlesource.com/Documentation/rest-api.html#output .
 	if len(bs) < 4 {
 		return nil, &httpError{
@@ -265,3 +311,15 @@ func (e *httpError) Unauthorized() bool {
 func (e *httpError) NotFound() bool {
 	return e.StatusCode == http.StatusNotFound
 }
+
+// Conflict is true if the request could not be completed because of the
+// current state of the resource, e.g. a change that cannot be submitted.
+func (e *httpError) Conflict() bool {
+	return e.StatusCode == http.StatusConflict
+}
+
+// IsConflict reports whether err is a Gerrit API error with HTTP status 409.
+func IsConflict(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.Conflict()
+}
@@ -0,0 +1,194 @@
+//nolint:bodyclose // Body is closed in Client.do, but the response is still returned to provide access to the headers
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Client access a Gitea (or Forgejo) instance via the REST API.
+type Client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the base URL of Gitea, e.g. https://gitea.example.com/. API
+	// paths are relative to it, so it always ends with a slash.
+	URL *url.URL
+
+	// auther is used to authenticate requests against the Gitea API. It
+	// defaults to the token in the config.
+	auther auth.Authenticator
+
+	// RateLimit is the self-imposed rate limiter (since Gitea does not have a
+	// concept of rate limiting in HTTP response headers).
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns an authenticated Gitea API client with the provided
+// configuration. If a nil httpClient is provided, httpcli.ExternalDoer will be
+// used.
+func NewClient(urn string, config *schema.GiteaConnection, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+	// Paths are resolved relative to the base URL, so it must end with a
+	// slash to not drop its last path segment if Gitea is served from a
+	// sub-path.
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		httpClient: httpClient,
+		URL:        u,
+		auther:     &auth.OAuthBearerToken{Token: config.Token},
+		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTP client, and rate limiter as the current Client, except authenticated
+// with the given authenticator.
+func (c *Client) WithAuthenticator(a auth.Authenticator) *Client {
+	return &Client{
+		httpClient: c.httpClient,
+		URL:        c.URL,
+		auther:     a,
+		rateLimit:  c.rateLimit,
+	}
+}
+
+// Authenticator returns the authenticator used by the client.
+func (c *Client) Authenticator() auth.Authenticator {
+	return c.auther
+}
+
+// User is a Gitea user or organization.
+type User struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name,omitempty"`
+	Email    string `json:"email,omitempty"`
+}
+
+// GetAuthenticatedUser returns the user the client is authenticated as.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*User, error) {
+	req, err := http.NewRequest("GET", "api/v1/user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var u User
+	if _, err := c.do(ctx, req, &u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// GetVersion returns the version of the Gitea instance, such as "1.20.5".
+// Forgejo reports versions such as "1.20.5+0-gitea-1.20.5".
+func (c *Client) GetVersion(ctx context.Context) (string, error) {
+	req, err := http.NewRequest("GET", "api/v1/version", nil)
+	if err != nil {
+		return "", err
+	}
+
+	var v struct {
+		Version string `json:"version"`
+	}
+	if _, err := c.do(ctx, req, &v); err != nil {
+		return "", err
+	}
+	return v.Version, nil
+}
+
+// do sends the request to the API and decodes the JSON response into result.
+// Relative request URLs are resolved against the base URL of the client.
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(req.URL)
+
+	if err := c.auther.Authenticate(req); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result == nil {
+		return resp, nil
+	}
+
+	return resp, json.Unmarshal(bs, result)
+}
+
+// hasNextPage returns true if the Link header of a paginated response
+// contains a link to the next page.
+func hasNextPage(resp *http.Response) bool {
+	for _, link := range strings.Split(resp.Header.Get("Link"), ",") {
+		if strings.Contains(link, `rel="next"`) {
+			return true
+		}
+	}
+	return false
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gitea API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsNotFound reports whether err is a Gitea API error with HTTP status 404.
+func IsNotFound(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.NotFound()
+}
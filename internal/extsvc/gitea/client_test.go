@@ -0,0 +1,130 @@
+package gitea
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestClient_ListOrgRepos(t *testing.T) {
+	cli, requests := newTestClient(t, "/gitea", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("Link", `<https://gitea.example.com/api/v1/orgs/sgtest/repos?limit=1&page=2>; rel="next",<https://gitea.example.com/api/v1/orgs/sgtest/repos?limit=1&page=2>; rel="last"`)
+			writeJSON(t, w, []*Repository{{ID: 1, FullName: "sgtest/first"}})
+			return
+		}
+		writeJSON(t, w, []*Repository{{ID: 2, FullName: "sgtest/second"}})
+	}))
+	ctx := context.Background()
+
+	repos, next, err := cli.ListOrgRepos(ctx, "sgtest", 1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []*Repository{{ID: 1, FullName: "sgtest/first"}}, repos)
+	assert.True(t, next)
+
+	repos, next, err = cli.ListOrgRepos(ctx, "sgtest", 2, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []*Repository{{ID: 2, FullName: "sgtest/second"}}, repos)
+	assert.False(t, next)
+
+	req := (*requests)[1]
+	assert.Equal(t, "/gitea/api/v1/orgs/sgtest/repos", req.URL.Path)
+	assert.Equal(t, "2", req.URL.Query().Get("page"))
+	assert.Equal(t, "1", req.URL.Query().Get("limit"))
+	assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+}
+
+func TestClient_SearchRepos(t *testing.T) {
+	cli, requests := newTestClient(t, "", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]any{"ok": true, "data": []*Repository{{ID: 1, FullName: "alice/dotfiles"}}})
+	}))
+	ctx := context.Background()
+
+	repos, next, err := cli.SearchRepos(ctx, "dotfiles", 1, 50)
+	require.Nil(t, err)
+	assert.Equal(t, []*Repository{{ID: 1, FullName: "alice/dotfiles"}}, repos)
+	assert.False(t, next)
+
+	_, _, err = cli.SearchRepos(ctx, "", 1, 50)
+	require.Nil(t, err)
+
+	assert.Equal(t, "/api/v1/repos/search", (*requests)[0].URL.Path)
+	assert.Equal(t, "dotfiles", (*requests)[0].URL.Query().Get("q"))
+	assert.False(t, (*requests)[1].URL.Query().Has("q"))
+}
+
+func TestClient_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not found", func(t *testing.T) {
+		cli, _ := newTestClient(t, "", http.NotFoundHandler())
+
+		_, err := cli.GetRepo(ctx, "alice", "missing")
+		assert.True(t, IsNotFound(err))
+		assert.True(t, errcode.IsNotFound(err))
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		cli, _ := newTestClient(t, "", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+
+		_, err := cli.GetAuthenticatedUser(ctx)
+		assert.True(t, errcode.IsUnauthorized(err))
+	})
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	e, err := ParseWebhookEvent("push", []byte(`{"ref": "refs/heads/main", "after": "abc", "repository": {"id": 1, "full_name": "alice/dotfiles"}}`))
+	require.Nil(t, err)
+	assert.Equal(t, &PushEvent{Ref: "refs/heads/main", After: "abc", Repository: Repository{ID: 1, FullName: "alice/dotfiles"}}, e)
+
+	_, err = ParseWebhookEvent("issues", []byte(`{}`))
+	assert.ErrorIs(t, err, ErrUnknownEventType)
+}
+
+func TestValidateSignature(t *testing.T) {
+	payload := []byte(`{"ref": "refs/heads/main"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	assert.Nil(t, ValidateSignature(signature, payload, []byte("secret")))
+	assert.Error(t, ValidateSignature(signature, payload, []byte("other")))
+	assert.Error(t, ValidateSignature("not hex", payload, []byte("secret")))
+}
+
+// newTestClient returns a Client talking to a test server using the given
+// handler, with Gitea served from the given path, and a pointer to the
+// requests the server received.
+func newTestClient(t *testing.T, path string, handler http.Handler) (*Client, *[]*http.Request) {
+	t.Helper()
+
+	var requests []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient("urn", &schema.GiteaConnection{Url: srv.URL + path, Token: "secret"}, http.DefaultClient)
+	require.Nil(t, err)
+
+	return cli, &requests
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+	require.Nil(t, json.NewEncoder(w).Encode(v))
+}
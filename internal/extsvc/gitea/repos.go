@@ -0,0 +1,106 @@
+package gitea
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Repository is a Gitea repository.
+type Repository struct {
+	ID            int64     `json:"id"`
+	Owner         User      `json:"owner"`
+	Name          string    `json:"name"`
+	FullName      string    `json:"full_name"`
+	Description   string    `json:"description"`
+	Empty         bool      `json:"empty"`
+	Private       bool      `json:"private"`
+	Fork          bool      `json:"fork"`
+	Mirror        bool      `json:"mirror"`
+	Archived      bool      `json:"archived"`
+	HTMLURL       string    `json:"html_url"`
+	SSHURL        string    `json:"ssh_url"`
+	CloneURL      string    `json:"clone_url"`
+	DefaultBranch string    `json:"default_branch"`
+	StarsCount    int       `json:"stars_count"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ListOrgRepos returns a page of the repositories of the given organization,
+// and whether there are more pages. Pages are numbered starting at 1.
+func (c *Client) ListOrgRepos(ctx context.Context, org string, page, limit int) ([]*Repository, bool, error) {
+	return c.listRepos(ctx, "api/v1/orgs/"+url.PathEscape(org)+"/repos", page, limit)
+}
+
+// ListUserRepos returns a page of the repositories owned by the given user,
+// and whether there are more pages. Pages are numbered starting at 1.
+func (c *Client) ListUserRepos(ctx context.Context, user string, page, limit int) ([]*Repository, bool, error) {
+	return c.listRepos(ctx, "api/v1/users/"+url.PathEscape(user)+"/repos", page, limit)
+}
+
+func (c *Client) listRepos(ctx context.Context, path string, page, limit int) ([]*Repository, bool, error) {
+	u := url.URL{Path: path, RawQuery: paginationQuery(page, limit).Encode()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var repos []*Repository
+	resp, err := c.do(ctx, req, &repos)
+	if err != nil {
+		return nil, false, err
+	}
+	return repos, hasNextPage(resp), nil
+}
+
+// SearchRepos returns a page of the repositories visible to the authenticated
+// user whose names match the given keyword, and whether there are more
+// pages. An empty keyword matches all repositories.
+func (c *Client) SearchRepos(ctx context.Context, keyword string, page, limit int) ([]*Repository, bool, error) {
+	q := paginationQuery(page, limit)
+	if keyword != "" {
+		q.Set("q", keyword)
+	}
+	u := url.URL{Path: "api/v1/repos/search", RawQuery: q.Encode()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var result struct {
+		OK   bool          `json:"ok"`
+		Data []*Repository `json:"data"`
+	}
+	resp, err := c.do(ctx, req, &result)
+	if err != nil {
+		return nil, false, err
+	}
+	return result.Data, hasNextPage(resp), nil
+}
+
+// GetRepo returns the repository with the given owner and name.
+func (c *Client) GetRepo(ctx context.Context, owner, name string) (*Repository, error) {
+	req, err := http.NewRequest("GET", "api/v1/repos/"+url.PathEscape(owner)+"/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo Repository
+	if _, err := c.do(ctx, req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+func paginationQuery(page, limit int) url.Values {
+	q := make(url.Values)
+	if page > 0 {
+		q.Set("page", strconv.Itoa(page))
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	return q
+}
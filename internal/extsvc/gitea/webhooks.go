@@ -0,0 +1,64 @@
+package gitea
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// EventTypeHeader is the header containing the type of webhook events,
+	// such as "push".
+	EventTypeHeader = "X-Gitea-Event"
+
+	// SignatureHeader is the header containing the hex encoded HMAC-SHA256
+	// signature of the payload of webhook events, computed with the webhook
+	// secret. Forgejo sends it as well for compatibility.
+	SignatureHeader = "X-Gitea-Signature"
+)
+
+// PushEvent is the payload of push webhook events.
+type PushEvent struct {
+	Ref        string     `json:"ref"`
+	Before     string     `json:"before"`
+	After      string     `json:"after"`
+	Repository Repository `json:"repository"`
+	Pusher     User       `json:"pusher"`
+}
+
+// ErrUnknownEventType is returned by ParseWebhookEvent for event types that
+// aren't supported.
+var ErrUnknownEventType = errors.New("unknown webhook event type")
+
+// ParseWebhookEvent parses the payload of a webhook event of the given type.
+func ParseWebhookEvent(eventType string, payload []byte) (any, error) {
+	switch eventType {
+	case "push":
+		var e PushEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, errors.Wrap(err, "unmarshalling push event")
+		}
+		return &e, nil
+	default:
+		return nil, errors.Wrapf(ErrUnknownEventType, "%q", eventType)
+	}
+}
+
+// ValidateSignature returns an error if signature isn't the signature of the
+// payload computed with the given secret.
+func ValidateSignature(signature string, payload []byte, secret []byte) error {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return errors.Wrap(err, "decoding signature")
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("payload signature does not match")
+	}
+	return nil
+}
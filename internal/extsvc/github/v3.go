@@ -461,6 +461,57 @@ func (c *V3Client) ListRepositoryTeams(ctx context.Context, owner, repo string,
 	return teams, len(teams) > 0, nil
 }
 
+// Issue is a GitHub issue, as returned by the REST API.
+type Issue struct {
+	Number  int64  `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+}
+
+// CreateIssueInput is the input of CreateIssue.
+type CreateIssueInput struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// CreateIssue opens an issue in the repository.
+//
+// API docs: https://docs.github.com/en/rest/issues/issues#create-an-issue
+func (c *V3Client) CreateIssue(ctx context.Context, owner, repo string, in *CreateIssueInput) (*Issue, error) {
+	var issue Issue
+	if _, err := c.post(ctx, fmt.Sprintf("/repos/%s/%s/issues", owner, repo), in, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// GetIssue gets an issue of the repository by its number.
+//
+// API docs: https://docs.github.com/en/rest/issues/issues#get-an-issue
+func (c *V3Client) GetIssue(ctx context.Context, owner, repo string, number int64) (*Issue, error) {
+	var issue Issue
+	if _, err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d", owner, repo, number), &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// CreateIssueComment adds a comment to an issue of the repository.
+//
+// API docs: https://docs.github.com/en/rest/issues/comments#create-an-issue-comment
+func (c *V3Client) CreateIssueComment(ctx context.Context, owner, repo string, number int64, body string) error {
+	payload := struct {
+		Body string `json:"body"`
+	}{Body: body}
+	var comment struct {
+		ID int64 `json:"id"`
+	}
+	_, err := c.post(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d/comments", owner, repo, number), payload, &comment)
+	return err
+}
+
 // GetRepository gets a repository from GitHub by owner and repository name.
 func (c *V3Client) GetRepository(ctx context.Context, owner, name string) (*Repository, error) {
 	return c.getRepositoryFromAPI(ctx, owner, name)
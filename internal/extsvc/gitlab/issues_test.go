@@ -0,0 +1,66 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateIssue(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{ProjectCommon: ProjectCommon{ID: 1}}
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"id": 10, "iid": 2, "project_id": 1, "title": "title", "state": "opened", "web_url": "https://example.com/a/b/-/issues/2"}`,
+		}
+
+		issue, err := client.CreateIssue(ctx, project, CreateIssueOpts{Title: "title"})
+		require.NoError(t, err)
+		assert.Equal(t, &Issue{
+			ID:        10,
+			IID:       2,
+			ProjectID: 1,
+			Title:     "title",
+			State:     IssueStateOpened,
+			WebURL:    "https://example.com/a/b/-/issues/2",
+		}, issue)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusForbidden}
+
+		issue, err := client.CreateIssue(ctx, project, CreateIssueOpts{Title: "title"})
+		assert.Nil(t, issue)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetIssue(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{ProjectCommon: ProjectCommon{ID: 1}}
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"id": 10, "iid": 2, "project_id": 1, "title": "title", "state": "closed"}`,
+		}
+
+		issue, err := client.GetIssue(ctx, project, 2)
+		require.NoError(t, err)
+		assert.Equal(t, IssueStateClosed, issue.State)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusNotFound}
+
+		_, err := client.GetIssue(ctx, project, 2)
+		assert.Error(t, err)
+	})
+}
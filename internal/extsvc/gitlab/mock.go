@@ -55,3 +55,13 @@ var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Pro
 
 // MockForkProject, if non-nil, will be called instead of Client.ForkProject
 var MockForkProject func(c *Client, ctx context.Context, project *Project, namespace *string) (*Project, error)
+
+// MockCreateIssue, if non-nil, will be called instead of Client.CreateIssue
+var MockCreateIssue func(c *Client, ctx context.Context, project *Project, opts CreateIssueOpts) (*Issue, error)
+
+// MockGetIssue, if non-nil, will be called instead of Client.GetIssue
+var MockGetIssue func(c *Client, ctx context.Context, project *Project, iid ID) (*Issue, error)
+
+// MockCreateIssueNote, if non-nil, will be called instead of
+// Client.CreateIssueNote
+var MockCreateIssueNote func(c *Client, ctx context.Context, project *Project, issue *Issue, body string) error
@@ -109,4 +109,27 @@ func TestUnmarshalEvent(t *testing.T) {
 			t.Errorf("unexpected IID: have %d; want %d", pe.Pipeline.ID, want)
 		}
 	})
+
+	t.Run("valid push", func(t *testing.T) {
+		event, err := UnmarshalEvent([]byte(`
+			{
+				"object_kind": "push",
+				"ref": "refs/heads/main",
+				"project": {
+					"path_with_namespace": "sourcegraph/sourcegraph"
+				}
+			}
+		`))
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		pe := event.(*PushEvent)
+		if want := "sourcegraph/sourcegraph"; pe.Project.PathWithNamespace != want {
+			t.Errorf("unexpected project: have %s; want %s", pe.Project.PathWithNamespace, want)
+		}
+		if want := "refs/heads/main"; pe.Ref != want {
+			t.Errorf("unexpected ref: have %s; want %s", pe.Ref, want)
+		}
+	})
 }
@@ -0,0 +1,9 @@
+package mercurial
+
+// Repository contains information of a Mercurial repository.
+type Repository struct {
+	// URL is the URL of the repository, without credentials.
+	URL string `json:"url"`
+	// Path is the path of the repository relative to the URL of the server.
+	Path string `json:"path"`
+}
@@ -0,0 +1,213 @@
+// Package nuget is a client for NuGet V3 feeds, such as
+// https://api.nuget.org/v3/index.json or private feeds that implement the same
+// API.
+package nuget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// packageBaseAddressType is the service index resource type that serves
+// package contents.
+//
+// https://learn.microsoft.com/en-us/nuget/api/package-base-address-resource
+const packageBaseAddressType = "PackageBaseAddress/3.0.0"
+
+type Client struct {
+	// The URLs of the V3 service indexes of the NuGet feeds to query, in
+	// order. They may include basic auth credentials, which are only sent to
+	// the host of the respective feed.
+	urls []string
+	cli  httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+
+	mu sync.Mutex
+	// baseAddresses caches the package base address of each feed.
+	baseAddresses map[string]string
+}
+
+func NewClient(urn string, urls []string, cli httpcli.Doer) *Client {
+	return &Client{
+		urls:          urls,
+		cli:           cli,
+		limiter:       ratelimit.DefaultRegistry.Get(urn),
+		baseAddresses: make(map[string]string),
+	}
+}
+
+// GetPackageContents returns the contents of the .nupkg archive of the given
+// package, which is a zip archive. The configured feeds are tried in order
+// until one of them has the package, skipping feeds that fail. The returned
+// error is only a not found error if every feed reported that it doesn't have
+// the package, so that an unavailable feed doesn't hide a package.
+func (c *Client) GetPackageContents(ctx context.Context, dep *reposource.NuGetVersionedPackage) ([]byte, error) {
+	id := strings.ToLower(string(dep.Name))
+	version := normalizeVersion(dep.Version)
+
+	var notFoundErrs, errs error
+	for _, feed := range c.urls {
+		baseAddress, err := c.packageBaseAddress(ctx, feed)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+
+		u := fmt.Sprintf("%s/%s/%s/%s.%s.nupkg", baseAddress, url.PathEscape(id), url.PathEscape(version), url.PathEscape(id), url.PathEscape(version))
+		respBody, err := c.get(ctx, feed, u)
+		if err == nil {
+			return respBody, nil
+		}
+		if errcode.IsNotFound(err) {
+			notFoundErrs = errors.Append(notFoundErrs, err)
+		} else {
+			errs = errors.Append(errs, errors.Wrapf(err, "failed to fetch package from NuGet feed %q", redactedURL(feed)))
+		}
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+	return nil, notFoundErrs
+}
+
+// normalizeVersion returns the version as it appears in package content URLs,
+// which is lowercased and has no build metadata.
+func normalizeVersion(version string) string {
+	if i := strings.Index(version, "+"); i != -1 {
+		version = version[:i]
+	}
+	return strings.ToLower(version)
+}
+
+type serviceIndex struct {
+	Resources []struct {
+		ID   string `json:"@id"`
+		Type string `json:"@type"`
+	} `json:"resources"`
+}
+
+// packageBaseAddress returns the URL of the package content resource of the
+// given feed, without a trailing slash.
+func (c *Client) packageBaseAddress(ctx context.Context, feed string) (string, error) {
+	c.mu.Lock()
+	baseAddress, ok := c.baseAddresses[feed]
+	c.mu.Unlock()
+	if ok {
+		return baseAddress, nil
+	}
+
+	bs, err := c.get(ctx, feed, feed)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to fetch NuGet service index %q", redactedURL(feed))
+	}
+
+	var index serviceIndex
+	if err := json.Unmarshal(bs, &index); err != nil {
+		return "", errors.Wrapf(err, "invalid NuGet service index %q", redactedURL(feed))
+	}
+
+	for _, r := range index.Resources {
+		if r.Type == packageBaseAddressType {
+			baseAddress = strings.TrimSuffix(r.ID, "/")
+			break
+		}
+	}
+	if baseAddress == "" {
+		return "", errors.Errorf("NuGet service index %q has no %s resource", redactedURL(feed), packageBaseAddressType)
+	}
+
+	c.mu.Lock()
+	c.baseAddresses[feed] = baseAddress
+	c.mu.Unlock()
+
+	return baseAddress, nil
+}
+
+// get fetches the given URL, authenticating with the credentials of the feed
+// if the URL points to the same host as the feed.
+func (c *Client) get(ctx context.Context, feed, rawURL string) ([]byte, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	feedURL, err := url.Parse(feed)
+	if err != nil {
+		return nil, errors.Errorf("invalid NuGet feed URL %q", redactedURL(feed))
+	}
+	reqURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	reqURL.User = nil
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-nuget-syncer (sourcegraph.com)")
+
+	if feedURL.User != nil && strings.EqualFold(feedURL.Host, reqURL.Host) {
+		password, _ := feedURL.User.Password()
+		req.SetBasicAuth(feedURL.User.Username(), password)
+	}
+
+	return c.do(req)
+}
+
+// redactedURL returns the given URL without credentials, for use in errors.
+func redactedURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "<invalid URL>"
+	}
+	return u.Redacted()
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound || e.code == http.StatusGone
+}
+
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+
+	return bs, nil
+}
@@ -0,0 +1,126 @@
+package nuget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func newFeed(t *testing.T, packages map[string]string, user, pass string) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var paths []string
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if u, p, _ := r.BasicAuth(); u != user || p != pass {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/v3/index.json" {
+			fmt.Fprintf(w, `{"version":"3.0.0","resources":[{"@id":"%s/flat/","@type":"PackageBaseAddress/3.0.0"}]}`, srv.URL)
+			return
+		}
+		contents, ok := packages[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(contents))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &paths
+}
+
+func TestClient_GetPackageContents(t *testing.T) {
+	private, privatePaths := newFeed(t, map[string]string{
+		"/flat/acme.internal/1.0.0/acme.internal.1.0.0.nupkg": "private",
+	}, "admin", "secret")
+	public, publicPaths := newFeed(t, map[string]string{
+		"/flat/newtonsoft.json/13.0.1/newtonsoft.json.13.0.1.nupkg": "public",
+	}, "", "")
+
+	urls := []string{
+		"http://admin:secret@" + private.Listener.Addr().String() + "/v3/index.json",
+		public.URL + "/v3/index.json",
+	}
+	cli := NewClient("nuget", urls, http.DefaultClient)
+	ctx := context.Background()
+
+	contents, err := cli.GetPackageContents(ctx, reposource.NewNuGetVersionedPackage("Acme.Internal", "1.0.0"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("private"), contents)
+
+	contents, err = cli.GetPackageContents(ctx, reposource.NewNuGetVersionedPackage("Newtonsoft.Json", "13.0.1+build.5"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("public"), contents)
+
+	_, err = cli.GetPackageContents(ctx, reposource.NewNuGetVersionedPackage("Newtonsoft.Json", "0.0.0"))
+	assert.True(t, errcode.IsNotFound(err))
+
+	assert.Equal(t, []string{
+		"/v3/index.json",
+		"/flat/acme.internal/1.0.0/acme.internal.1.0.0.nupkg",
+		"/flat/newtonsoft.json/13.0.1/newtonsoft.json.13.0.1.nupkg",
+		"/flat/newtonsoft.json/0.0.0/newtonsoft.json.0.0.0.nupkg",
+	}, *privatePaths)
+	assert.Equal(t, []string{
+		"/v3/index.json",
+		"/flat/newtonsoft.json/13.0.1/newtonsoft.json.13.0.1.nupkg",
+		"/flat/newtonsoft.json/0.0.0/newtonsoft.json.0.0.0.nupkg",
+	}, *publicPaths)
+}
+
+func TestClient_CredentialsNotSentToOtherHosts(t *testing.T) {
+	other, _ := newFeed(t, map[string]string{
+		"/flat/serilog/2.12.0/serilog.2.12.0.nupkg": "serilog",
+	}, "", "")
+
+	// The service index of this feed points to the package content resource of
+	// another host, which must not receive the feed's credentials.
+	index := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, _ := r.BasicAuth(); u != "admin" || p != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"version":"3.0.0","resources":[{"@id":"%s/flat","@type":"PackageBaseAddress/3.0.0"}]}`, other.URL)
+	}))
+	t.Cleanup(index.Close)
+
+	cli := NewClient("nuget", []string{"http://admin:secret@" + index.Listener.Addr().String() + "/v3/index.json"}, http.DefaultClient)
+	contents, err := cli.GetPackageContents(context.Background(), reposource.NewNuGetVersionedPackage("Serilog", "2.12.0"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("serilog"), contents)
+}
+
+func TestClient_GetPackageContents_FailingFeed(t *testing.T) {
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(broken.Close)
+	public, _ := newFeed(t, map[string]string{
+		"/flat/newtonsoft.json/13.0.1/newtonsoft.json.13.0.1.nupkg": "public",
+	}, "", "")
+
+	cli := NewClient("nuget", []string{broken.URL + "/v3/index.json", public.URL + "/v3/index.json"}, http.DefaultClient)
+	ctx := context.Background()
+
+	contents, err := cli.GetPackageContents(ctx, reposource.NewNuGetVersionedPackage("Newtonsoft.Json", "13.0.1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("public"), contents)
+
+	// The broken feed might have the package, so it is not reported as not found.
+	_, err = cli.GetPackageContents(ctx, reposource.NewNuGetVersionedPackage("Newtonsoft.Json", "0.0.0"))
+	require.Error(t, err)
+	assert.False(t, errcode.IsNotFound(err))
+	assert.Contains(t, err.Error(), "failed to fetch NuGet service index")
+}
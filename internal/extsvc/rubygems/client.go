@@ -0,0 +1,91 @@
+// Package rubygems is a client for RubyGems repositories, such as
+// https://rubygems.org or private gem servers that implement the same
+// download API.
+package rubygems
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+)
+
+type Client struct {
+	// The URL of the RubyGems repository, e.g. https://rubygems.org. It may
+	// include basic auth credentials.
+	repository string
+	cli        httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+}
+
+func NewClient(urn string, repository string, cli httpcli.Doer) *Client {
+	return &Client{
+		repository: strings.TrimSuffix(repository, "/"),
+		cli:        cli,
+		limiter:    ratelimit.DefaultRegistry.Get(urn),
+	}
+}
+
+// GetPackageContents returns the contents of the .gem archive of the given
+// package, which is a tar archive containing the gem's metadata and a
+// data.tar.gz archive of its files.
+func (c *Client) GetPackageContents(ctx context.Context, dep *reposource.RubyVersionedPackage) ([]byte, error) {
+	u := fmt.Sprintf("%s/gems/%s-%s.gem", c.repository, url.PathEscape(string(dep.Name)), url.PathEscape(dep.Version))
+	return c.get(ctx, u)
+}
+
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-rubygems-syncer (sourcegraph.com)")
+
+	return c.do(req)
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound
+}
+
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+
+	return bs, nil
+}
@@ -0,0 +1,45 @@
+package rubygems
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestClient_GetPackageContents(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		user, pass, _ := r.BasicAuth()
+		if user != "admin" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/api/gems/gems/rails-7.0.4.gem" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("gem"))
+	}))
+	t.Cleanup(srv.Close)
+
+	repository := "http://admin:secret@" + srv.Listener.Addr().String() + "/api/gems/"
+	cli := NewClient("rubygems", repository, http.DefaultClient)
+	ctx := context.Background()
+
+	contents, err := cli.GetPackageContents(ctx, reposource.NewRubyVersionedPackage("rails", "7.0.4"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("gem"), contents)
+
+	_, err = cli.GetPackageContents(ctx, reposource.NewRubyVersionedPackage("rails", "0.0.0"))
+	assert.True(t, errcode.IsNotFound(err))
+
+	assert.Equal(t, []string{"/api/gems/gems/rails-7.0.4.gem", "/api/gems/gems/rails-0.0.0.gem"}, paths)
+}
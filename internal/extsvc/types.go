@@ -109,19 +109,25 @@ const (
 	// in preference to the Type values below.
 
 	KindAWSCodeCommit   = "AWSCODECOMMIT"
+	KindAzureDevOps     = "AZUREDEVOPS"
 	KindBitbucketServer = "BITBUCKETSERVER"
 	KindBitbucketCloud  = "BITBUCKETCLOUD"
 	KindGerrit          = "GERRIT"
+	KindGitea           = "GITEA"
 	KindGitHub          = "GITHUB"
 	KindGitLab          = "GITLAB"
 	KindGitolite        = "GITOLITE"
+	KindMercurial       = "MERCURIAL"
 	KindPerforce        = "PERFORCE"
+	KindSubversion      = "SUBVERSION"
 	KindPhabricator     = "PHABRICATOR"
 	KindGoPackages      = "GOMODULES"
 	KindJVMPackages     = "JVMPACKAGES"
 	KindPythonPackages  = "PYTHONPACKAGES"
+	KindRubyPackages    = "RUBYPACKAGES"
 	KindRustPackages    = "RUSTPACKAGES"
 	KindNpmPackages     = "NPMPACKAGES"
+	KindNuGetPackages   = "NUGETPACKAGES"
 	KindPagure          = "PAGURE"
 	KindOther           = "OTHER"
 )
@@ -134,6 +140,11 @@ const (
 	// suffix (e.g., "arn:aws:codecommit:us-west-1:123456789:").
 	TypeAWSCodeCommit = "awscodecommit"
 
+	// TypeAzureDevOps is the (api.ExternalRepoSpec).ServiceType value for Azure DevOps repositories. The
+	// ServiceID value is the base URL to the Azure DevOps instance (https://dev.azure.com or the Azure
+	// DevOps Server URL).
+	TypeAzureDevOps = "azuredevops"
+
 	// TypeBitbucketServer is the (api.ExternalRepoSpec).ServiceType value for Bitbucket Server projects. The
 	// ServiceID value is the base URL to the Bitbucket Server instance.
 	TypeBitbucketServer = "bitbucketServer"
@@ -145,6 +156,10 @@ const (
 	// TypeGerrit is the (api.ExternalRepoSpec).ServiceType value for Gerrit projects.
 	TypeGerrit = "gerrit"
 
+	// TypeGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea (and Forgejo) repositories. The
+	// ServiceID value is the base URL to the Gitea instance.
+	TypeGitea = "gitea"
+
 	// TypeGitHub is the (api.ExternalRepoSpec).ServiceType value for GitHub repositories. The ServiceID value
 	// is the base URL to the GitHub instance (https://github.com or the GitHub Enterprise URL).
 	TypeGitHub = "github"
@@ -159,9 +174,17 @@ const (
 	// TypeGitolite is the (api.ExternalRepoSpec).ServiceType value for Gitolite projects.
 	TypeGitolite = "gitolite"
 
+	// TypeMercurial is the (api.ExternalRepoSpec).ServiceType value for Mercurial repositories. The
+	// ServiceID value is the URL of the Mercurial server.
+	TypeMercurial = "mercurial"
+
 	// TypePerforce is the (api.ExternalRepoSpec).ServiceType value for Perforce projects.
 	TypePerforce = "perforce"
 
+	// TypeSubversion is the (api.ExternalRepoSpec).ServiceType value for Subversion repositories. The
+	// ServiceID value is the URL of the Subversion server.
+	TypeSubversion = "subversion"
+
 	// TypePhabricator is the (api.ExternalRepoSpec).ServiceType value for Phabricator projects.
 	TypePhabricator = "phabricator"
 
@@ -174,12 +197,18 @@ const (
 	// TypeNpmPackages is the (api.ExternalRepoSpec).ServiceType value for Npm packages (JavaScript/TypeScript ecosystem libraries).
 	TypeNpmPackages = "npmPackages"
 
+	// TypeNuGetPackages is the (api.ExternalRepoSpec).ServiceType value for NuGet packages (.NET ecosystem libraries).
+	TypeNuGetPackages = "nugetPackages"
+
 	// TypeGoModules is the (api.ExternalRepoSpec).ServiceType value for Go modules.
 	TypeGoModules = "goModules"
 
 	// TypePythonPackages is the (api.ExternalRepoSpec).ServiceType value for Python packages.
 	TypePythonPackages = "pythonPackages"
 
+	// TypeRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby packages.
+	TypeRubyPackages = "rubyPackages"
+
 	// TypeRustPackages is the (api.ExternalRepoSpec).ServiceType value for Python packages.
 	TypeRustPackages = "rustPackages"
 
@@ -193,30 +222,42 @@ func KindToType(kind string) string {
 	switch kind {
 	case KindAWSCodeCommit:
 		return TypeAWSCodeCommit
+	case KindAzureDevOps:
+		return TypeAzureDevOps
 	case KindBitbucketServer:
 		return TypeBitbucketServer
 	case KindBitbucketCloud:
 		return TypeBitbucketCloud
 	case KindGerrit:
 		return TypeGerrit
+	case KindGitea:
+		return TypeGitea
 	case KindGitHub:
 		return TypeGitHub
 	case KindGitLab:
 		return TypeGitLab
 	case KindGitolite:
 		return TypeGitolite
+	case KindMercurial:
+		return TypeMercurial
 	case KindPhabricator:
 		return TypePhabricator
 	case KindPerforce:
 		return TypePerforce
+	case KindSubversion:
+		return TypeSubversion
 	case KindJVMPackages:
 		return TypeJVMPackages
 	case KindPythonPackages:
 		return TypePythonPackages
+	case KindRubyPackages:
+		return TypeRubyPackages
 	case KindRustPackages:
 		return TypeRustPackages
 	case KindNpmPackages:
 		return TypeNpmPackages
+	case KindNuGetPackages:
+		return TypeNuGetPackages
 	case KindGoPackages:
 		return TypeGoModules
 	case KindPagure:
@@ -234,28 +275,40 @@ func TypeToKind(t string) string {
 	switch t {
 	case TypeAWSCodeCommit:
 		return KindAWSCodeCommit
+	case TypeAzureDevOps:
+		return KindAzureDevOps
 	case TypeBitbucketServer:
 		return KindBitbucketServer
 	case TypeBitbucketCloud:
 		return KindBitbucketCloud
 	case TypeGerrit:
 		return KindGerrit
+	case TypeGitea:
+		return KindGitea
 	case TypeGitHub:
 		return KindGitHub
 	case TypeGitLab:
 		return KindGitLab
 	case TypeGitolite:
 		return KindGitolite
+	case TypeMercurial:
+		return KindMercurial
 	case TypePerforce:
 		return KindPerforce
+	case TypeSubversion:
+		return KindSubversion
 	case TypePhabricator:
 		return KindPhabricator
 	case TypeNpmPackages:
 		return KindNpmPackages
+	case TypeNuGetPackages:
+		return KindNuGetPackages
 	case TypeJVMPackages:
 		return KindJVMPackages
 	case TypePythonPackages:
 		return KindPythonPackages
+	case TypeRubyPackages:
+		return KindRubyPackages
 	case TypeRustPackages:
 		return KindRustPackages
 	case TypeGoModules:
@@ -275,8 +328,10 @@ var (
 	bbcLower    = strings.ToLower(TypeBitbucketCloud)
 	jvmLower    = strings.ToLower(TypeJVMPackages)
 	npmLower    = strings.ToLower(TypeNpmPackages)
+	nugetLower  = strings.ToLower(TypeNuGetPackages)
 	goLower     = strings.ToLower(TypeGoModules)
 	pythonLower = strings.ToLower(TypePythonPackages)
+	rubyLower   = strings.ToLower(TypeRubyPackages)
 	rustLower   = strings.ToLower(TypeRustPackages)
 )
 
@@ -286,20 +341,28 @@ func ParseServiceType(s string) (string, bool) {
 	switch strings.ToLower(s) {
 	case TypeAWSCodeCommit:
 		return TypeAWSCodeCommit, true
+	case TypeAzureDevOps:
+		return TypeAzureDevOps, true
 	case bbsLower:
 		return TypeBitbucketServer, true
 	case bbcLower:
 		return TypeBitbucketCloud, true
 	case TypeGerrit:
 		return TypeGerrit, true
+	case TypeGitea:
+		return TypeGitea, true
 	case TypeGitHub:
 		return TypeGitHub, true
 	case TypeGitLab:
 		return TypeGitLab, true
 	case TypeGitolite:
 		return TypeGitolite, true
+	case TypeMercurial:
+		return TypeMercurial, true
 	case TypePerforce:
 		return TypePerforce, true
+	case TypeSubversion:
+		return TypeSubversion, true
 	case TypePhabricator:
 		return TypePhabricator, true
 	case goLower:
@@ -308,8 +371,12 @@ func ParseServiceType(s string) (string, bool) {
 		return TypeJVMPackages, true
 	case npmLower:
 		return TypeNpmPackages, true
+	case nugetLower:
+		return TypeNuGetPackages, true
 	case pythonLower:
 		return TypePythonPackages, true
+	case rubyLower:
+		return TypeRubyPackages, true
 	case rustLower:
 		return TypeRustPackages, true
 	case TypePagure:
@@ -327,20 +394,28 @@ func ParseServiceKind(s string) (string, bool) {
 	switch strings.ToUpper(s) {
 	case KindAWSCodeCommit:
 		return KindAWSCodeCommit, true
+	case KindAzureDevOps:
+		return KindAzureDevOps, true
 	case KindBitbucketServer:
 		return KindBitbucketServer, true
 	case KindBitbucketCloud:
 		return KindBitbucketCloud, true
 	case KindGerrit:
 		return KindGerrit, true
+	case KindGitea:
+		return KindGitea, true
 	case KindGitHub:
 		return KindGitHub, true
 	case KindGitLab:
 		return KindGitLab, true
 	case KindGitolite:
 		return KindGitolite, true
+	case KindMercurial:
+		return KindMercurial, true
 	case KindPerforce:
 		return KindPerforce, true
+	case KindSubversion:
+		return KindSubversion, true
 	case KindPhabricator:
 		return KindPhabricator, true
 	case KindGoPackages:
@@ -349,6 +424,8 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindJVMPackages, true
 	case KindPythonPackages:
 		return KindPythonPackages, true
+	case KindRubyPackages:
+		return KindRubyPackages, true
 	case KindRustPackages:
 		return KindRustPackages, true
 	case KindPagure:
@@ -404,20 +481,28 @@ func getConfigPrototype(kind string) (any, error) {
 	switch strings.ToUpper(kind) {
 	case KindAWSCodeCommit:
 		return &schema.AWSCodeCommitConnection{}, nil
+	case KindAzureDevOps:
+		return &schema.AzureDevOpsConnection{}, nil
 	case KindBitbucketServer:
 		return &schema.BitbucketServerConnection{}, nil
 	case KindBitbucketCloud:
 		return &schema.BitbucketCloudConnection{}, nil
 	case KindGerrit:
 		return &schema.GerritConnection{}, nil
+	case KindGitea:
+		return &schema.GiteaConnection{}, nil
 	case KindGitHub:
 		return &schema.GitHubConnection{}, nil
 	case KindGitLab:
 		return &schema.GitLabConnection{}, nil
 	case KindGitolite:
 		return &schema.GitoliteConnection{}, nil
+	case KindMercurial:
+		return &schema.MercurialConnection{}, nil
 	case KindPerforce:
 		return &schema.PerforceConnection{}, nil
+	case KindSubversion:
+		return &schema.SubversionConnection{}, nil
 	case KindPhabricator:
 		return &schema.PhabricatorConnection{}, nil
 	case KindGoPackages:
@@ -428,8 +513,12 @@ func getConfigPrototype(kind string) (any, error) {
 		return &schema.PagureConnection{}, nil
 	case KindNpmPackages:
 		return &schema.NpmPackagesConnection{}, nil
+	case KindNuGetPackages:
+		return &schema.NuGetPackagesConnection{}, nil
 	case KindPythonPackages:
 		return &schema.PythonPackagesConnection{}, nil
+	case KindRubyPackages:
+		return &schema.RubyPackagesConnection{}, nil
 	case KindRustPackages:
 		return &schema.RustPackagesConnection{}, nil
 	case KindOther:
@@ -452,6 +541,8 @@ func WebhookURL(kind string, externalServiceID int64, cfg any, externalURL strin
 		path = "bitbucket-server-webhooks"
 	case KindGitLab:
 		path = "gitlab-webhooks"
+	case KindGitea:
+		path = "gitea-webhooks"
 	case KindBitbucketCloud:
 		path = "bitbucket-cloud-webhooks"
 
@@ -503,6 +594,8 @@ func extractToken(parsed any, kind string) (string, error) {
 		return c.Token, nil
 	case *schema.PagureConnection:
 		return c.Token, nil
+	case *schema.GiteaConnection:
+		return c.Token, nil
 	default:
 		return "", errors.Errorf("unable to extract token for service kind %q", kind)
 	}
@@ -586,6 +679,12 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.RateLimit != nil {
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.GiteaConnection:
+		// 8/s is the default limit we enforce
+		limit = rate.Limit(8)
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.NpmPackagesConnection:
 		limit = rate.Limit(6000 / 3600.0) // Same as the default in npm-packages.schema.json
 		if c != nil && c.RateLimit != nil {
@@ -606,6 +705,20 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.RateLimit != nil {
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.NuGetPackagesConnection:
+		// nuget.org does not document a limit for package downloads, which are
+		// served by a CDN.
+		limit = rate.Limit(28800.0 / 3600.0) // 8/second same as default in nuget-packages.schema.json
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
+	case *schema.RubyPackagesConnection:
+		// rubygems.org allows 10 requests per second to the API, but gem
+		// downloads are served by a CDN without a documented limit.
+		limit = rate.Limit(28800.0 / 3600.0) // 8/second same as default in ruby-packages.schema.json
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.RustPackagesConnection:
 		// The crates.io CDN has no rate limits https://www.pietroalbini.org/blog/downloading-crates-io/
 		limit = rate.Limit(100)
@@ -635,10 +748,11 @@ func (e ErrRateLimitUnsupported) Error() string {
 }
 
 const (
-	URNGitHubApp   = "GitHubApp"
-	URNGitHubOAuth = "GitHubOAuth"
-	URNGitLabOAuth = "GitLabOAuth"
-	URNCodeIntel   = "CodeIntel"
+	URNGitHubApp           = "GitHubApp"
+	URNGitHubOAuth         = "GitHubOAuth"
+	URNGitLabOAuth         = "GitLabOAuth"
+	URNBitbucketCloudOAuth = "BitbucketCloudOAuth"
+	URNCodeIntel           = "CodeIntel"
 )
 
 // URN returns a unique resource identifier of an external service by given kind and ID.
@@ -709,6 +823,10 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.GerritConnection:
 		rawURL = c.Url
+	case *schema.GiteaConnection:
+		rawURL = c.Url
+	case *schema.AzureDevOpsConnection:
+		rawURL = c.Url
 	case *schema.PhabricatorConnection:
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
@@ -722,14 +840,22 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 	case *schema.PerforceConnection:
 		// Perforce uses the P4PORT to specify the instance, so we use that
 		return c.P4Port, nil
+	case *schema.SubversionConnection:
+		rawURL = c.Url
+	case *schema.MercurialConnection:
+		rawURL = c.Url
 	case *schema.GoModulesConnection:
 		return KindGoPackages, nil
 	case *schema.JVMPackagesConnection:
 		return KindJVMPackages, nil
 	case *schema.NpmPackagesConnection:
 		return KindNpmPackages, nil
+	case *schema.NuGetPackagesConnection:
+		return KindNuGetPackages, nil
 	case *schema.PythonPackagesConnection:
 		return KindPythonPackages, nil
+	case *schema.RubyPackagesConnection:
+		return KindRubyPackages, nil
 	case *schema.RustPackagesConnection:
 		return KindRustPackages, nil
 	case *schema.PagureConnection:
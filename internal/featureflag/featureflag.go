@@ -13,21 +13,24 @@ type FeatureFlag struct {
 	// Exactly one of the following will be set.
 	Bool    *FeatureFlagBool
 	Rollout *FeatureFlagRollout
+	Rules   *FeatureFlagRules
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
 }
 
-// EvaluateForUser evaluates the feature flag for a userID.
-func (f *FeatureFlag) EvaluateForUser(userID int32) bool {
+// EvaluateForUser evaluates the feature flag for a user.
+func (f *FeatureFlag) EvaluateForUser(user *UserAttributes) bool {
 	switch {
 	case f.Bool != nil:
 		return f.Bool.Value
 	case f.Rollout != nil:
-		return hashUserAndFlag(userID, f.Name)%10000 < uint32(f.Rollout.Rollout)
+		return hashUserAndFlag(user.UserID, f.Name)%10000 < uint32(f.Rollout.Rollout)
+	case f.Rules != nil:
+		return f.Rules.evaluate(func(r *TargetingRule) bool { return r.matchesUser(user) })
 	}
-	panic("one of Bool or Rollout must be set")
+	panic("one of Bool, Rollout or Rules must be set")
 }
 
 func hashUserAndFlag(userID int32, flagName string) uint32 {
@@ -44,8 +47,10 @@ func (f *FeatureFlag) EvaluateForAnonymousUser(anonymousUID string) bool {
 		return f.Bool.Value
 	case f.Rollout != nil:
 		return hashAnonymousUserAndFlag(anonymousUID, f.Name)%10000 < uint32(f.Rollout.Rollout)
+	case f.Rules != nil:
+		return f.Rules.evaluate(func(r *TargetingRule) bool { return r.Type == TargetingRuleAnonymous })
 	}
-	panic("one of Bool or Rollout must be set")
+	panic("one of Bool, Rollout or Rules must be set")
 }
 
 func hashAnonymousUserAndFlag(anonymousUID, flagName string) uint32 {
@@ -55,9 +60,27 @@ func hashAnonymousUserAndFlag(anonymousUID, flagName string) uint32 {
 	return h.Sum32()
 }
 
+// EvaluateForOrg evaluates the feature flag for an organization. Rollouts
+// evaluate to false, as they are defined per user, and of the targeting rules
+// only the org_members rules listing the organization match it.
+func (f *FeatureFlag) EvaluateForOrg(orgID int32) bool {
+	switch {
+	case f.Bool != nil:
+		return f.Bool.Value
+	case f.Rollout != nil:
+		return false
+	case f.Rules != nil:
+		return f.Rules.evaluate(func(r *TargetingRule) bool {
+			return r.Type == TargetingRuleOrgMembers && containsInt32(r.OrgIDs, orgID)
+		})
+	}
+	panic("one of Bool, Rollout or Rules must be set")
+}
+
 // EvaluateGlobal returns the evaluated feature flag for a global context (no user
 // is associated with the request). If the flag is not evaluatable in the global context
-// (i.e. the flag type is a rollout), then the second parameter will return false.
+// (i.e. the flag type is a rollout or has targeting rules), then the second parameter
+// will return false.
 func (f *FeatureFlag) EvaluateGlobal() (res bool, ok bool) {
 	switch {
 	case f.Bool != nil:
@@ -78,6 +101,23 @@ type FeatureFlagRollout struct {
 	Rollout int32
 }
 
+// FeatureFlagRules is a feature flag that evaluates to the value of the first
+// of its targeting rules that matches the user, or to Default if none of them
+// match.
+type FeatureFlagRules struct {
+	Rules   []*TargetingRule `json:"rules"`
+	Default bool             `json:"default"`
+}
+
+func (r *FeatureFlagRules) evaluate(matches func(*TargetingRule) bool) bool {
+	for _, rule := range r.Rules {
+		if matches(rule) {
+			return rule.Value
+		}
+	}
+	return r.Default
+}
+
 type Override struct {
 	UserID   *int32
 	OrgID    *int32
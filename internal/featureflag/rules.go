@@ -0,0 +1,120 @@
+package featureflag
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// TargetingRuleType is the kind of users a targeting rule matches.
+type TargetingRuleType string
+
+const (
+	// TargetingRuleUsers matches the users listed in UserIDs.
+	TargetingRuleUsers TargetingRuleType = "users"
+	// TargetingRuleOrgMembers matches members of any of the orgs listed in OrgIDs.
+	TargetingRuleOrgMembers TargetingRuleType = "org_members"
+	// TargetingRuleSiteAdmins matches site admins.
+	TargetingRuleSiteAdmins TargetingRuleType = "site_admins"
+	// TargetingRuleEmailDomains matches users with a verified email address in
+	// any of the domains listed in EmailDomains.
+	TargetingRuleEmailDomains TargetingRuleType = "email_domains"
+	// TargetingRuleAnonymous matches users that are not signed in.
+	TargetingRuleAnonymous TargetingRuleType = "anonymous"
+)
+
+// TargetingRule is a rule of a FeatureFlagRules flag. If a rule matches a
+// user, the flag evaluates to the value of the rule.
+type TargetingRule struct {
+	Type TargetingRuleType `json:"type"`
+
+	// Only the field corresponding to Type is set.
+	UserIDs      []int32  `json:"userIDs,omitempty"`
+	OrgIDs       []int32  `json:"orgIDs,omitempty"`
+	EmailDomains []string `json:"emailDomains,omitempty"`
+
+	Value bool `json:"value"`
+}
+
+// UserAttributes are the attributes of a signed in user that targeting rules
+// are evaluated against.
+type UserAttributes struct {
+	UserID    int32
+	SiteAdmin bool
+	// OrgIDs are the IDs of the orgs the user is a member of.
+	OrgIDs []int32
+	// EmailDomains are the domains of the verified email addresses of the user.
+	EmailDomains []string
+}
+
+func (r *TargetingRule) matchesUser(user *UserAttributes) bool {
+	switch r.Type {
+	case TargetingRuleUsers:
+		return containsInt32(r.UserIDs, user.UserID)
+	case TargetingRuleOrgMembers:
+		for _, orgID := range user.OrgIDs {
+			if containsInt32(r.OrgIDs, orgID) {
+				return true
+			}
+		}
+	case TargetingRuleSiteAdmins:
+		return user.SiteAdmin
+	case TargetingRuleEmailDomains:
+		for _, domain := range user.EmailDomains {
+			for _, ruleDomain := range r.EmailDomains {
+				if strings.EqualFold(domain, ruleDomain) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func containsInt32(ids []int32, id int32) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error if any of the targeting rules is of an unknown
+// type or lacks the users, orgs or email domains it applies to.
+func (r *FeatureFlagRules) Validate() error {
+	for i, rule := range r.Rules {
+		var missing string
+		switch rule.Type {
+		case TargetingRuleUsers:
+			if len(rule.UserIDs) == 0 {
+				missing = "users"
+			}
+		case TargetingRuleOrgMembers:
+			if len(rule.OrgIDs) == 0 {
+				missing = "orgs"
+			}
+		case TargetingRuleEmailDomains:
+			if len(rule.EmailDomains) == 0 {
+				missing = "email domains"
+			}
+		case TargetingRuleSiteAdmins, TargetingRuleAnonymous:
+		default:
+			return errors.Errorf("rule %d: unknown type %q", i+1, rule.Type)
+		}
+		if missing != "" {
+			return errors.Errorf("rule %d: %s rule must specify at least one of the %s it applies to", i+1, rule.Type, missing)
+		}
+	}
+	return nil
+}
+
+// EmailDomain returns the lowercased domain of the given email address, or an
+// empty string if the address has no domain.
+func EmailDomain(email string) string {
+	i := strings.LastIndexByte(email, '@')
+	if i < 0 {
+		return ""
+	}
+	return strings.ToLower(email[i+1:])
+}
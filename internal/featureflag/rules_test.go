@@ -0,0 +1,96 @@
+package featureflag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureFlagRules(t *testing.T) {
+	flag := &FeatureFlag{
+		Name: "rules",
+		Rules: &FeatureFlagRules{
+			Rules: []*TargetingRule{
+				{Type: TargetingRuleUsers, UserIDs: []int32{1}, Value: false},
+				{Type: TargetingRuleOrgMembers, OrgIDs: []int32{10, 11}, Value: true},
+				{Type: TargetingRuleEmailDomains, EmailDomains: []string{"Example.com"}, Value: true},
+				{Type: TargetingRuleSiteAdmins, Value: true},
+				{Type: TargetingRuleAnonymous, Value: true},
+			},
+			Default: false,
+		},
+	}
+
+	tests := []struct {
+		name string
+		user *UserAttributes
+		want bool
+	}{
+		{
+			name: "no matching rule",
+			user: &UserAttributes{UserID: 2, OrgIDs: []int32{12}, EmailDomains: []string{"example.org"}},
+			want: false,
+		},
+		{
+			name: "org member",
+			user: &UserAttributes{UserID: 2, OrgIDs: []int32{12, 11}},
+			want: true,
+		},
+		{
+			name: "email domain",
+			user: &UserAttributes{UserID: 2, EmailDomains: []string{"example.com"}},
+			want: true,
+		},
+		{
+			name: "site admin",
+			user: &UserAttributes{UserID: 2, SiteAdmin: true},
+			want: true,
+		},
+		{
+			name: "first matching rule wins",
+			user: &UserAttributes{UserID: 1, SiteAdmin: true, OrgIDs: []int32{10}},
+			want: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, flag.EvaluateForUser(tc.user))
+		})
+	}
+
+	t.Run("anonymous user", func(t *testing.T) {
+		assert.True(t, flag.EvaluateForAnonymousUser("anon"))
+	})
+
+	t.Run("global", func(t *testing.T) {
+		_, ok := flag.EvaluateGlobal()
+		assert.False(t, ok)
+	})
+
+	t.Run("org", func(t *testing.T) {
+		assert.True(t, flag.EvaluateForOrg(11))
+		assert.False(t, flag.EvaluateForOrg(12))
+	})
+}
+
+func TestFeatureFlagRules_Validate(t *testing.T) {
+	valid := &FeatureFlagRules{Rules: []*TargetingRule{
+		{Type: TargetingRuleUsers, UserIDs: []int32{1}},
+		{Type: TargetingRuleSiteAdmins},
+		{Type: TargetingRuleAnonymous, Value: true},
+	}}
+	assert.NoError(t, valid.Validate())
+
+	for _, rules := range []*FeatureFlagRules{
+		{Rules: []*TargetingRule{{Type: "admins"}}},
+		{Rules: []*TargetingRule{{Type: TargetingRuleOrgMembers}}},
+		{Rules: []*TargetingRule{{Type: TargetingRuleEmailDomains, OrgIDs: []int32{1}}}},
+	} {
+		assert.Error(t, rules.Validate())
+	}
+}
+
+func TestEmailDomain(t *testing.T) {
+	assert.Equal(t, "example.com", EmailDomain("alice@Example.COM"))
+	assert.Equal(t, "", EmailDomain("alice"))
+}
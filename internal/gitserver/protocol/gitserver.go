@@ -246,6 +246,10 @@ type CreateCommitFromPatchRequest struct {
 	Patch string
 	// TargetRef is the ref that will be created for this patch
 	TargetRef string
+	// PushRef is the ref on the code host the commit is pushed to. If nil,
+	// TargetRef is used. This is required for code hosts that don't create
+	// reviews from branches, such as Gerrit's refs/for/<branch>.
+	PushRef *string
 	// If set to true and the TargetRef already exists, an unique number will be appended to the end (ie TargetRef-{#}). The generated ref will be returned.
 	UniqueRef bool
 	// CommitInfo is the information that will be used when creating the commit from a patch
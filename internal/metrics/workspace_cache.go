@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// WorkspaceCacheMetrics are the metrics of the on-disk cache of repositories from which
+// executors create job workspaces.
+type WorkspaceCacheMetrics struct {
+	Hits      prometheus.Counter
+	Misses    prometheus.Counter
+	Evictions prometheus.Counter
+	Errors    prometheus.Counter
+	SizeBytes prometheus.Gauge
+}
+
+func NewWorkspaceCacheMetrics(r prometheus.Registerer) *WorkspaceCacheMetrics {
+	counter := func(name, help string) prometheus.Counter {
+		counter := prometheus.NewCounter(prometheus.CounterOpts{
+			Name: name,
+			Help: help,
+		})
+
+		r.MustRegister(counter)
+		return counter
+	}
+
+	sizeBytes := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "src_executor_workspace_cache_size_bytes",
+		Help: "The total size of the repositories in the workspace cache.",
+	})
+	r.MustRegister(sizeBytes)
+
+	return &WorkspaceCacheMetrics{
+		Hits: counter(
+			"src_executor_workspace_cache_hits_total",
+			"The number of workspaces created from a repository that was already cached.",
+		),
+		Misses: counter(
+			"src_executor_workspace_cache_misses_total",
+			"The number of workspaces created from a repository that was not cached yet.",
+		),
+		Evictions: counter(
+			"src_executor_workspace_cache_evictions_total",
+			"The number of repositories evicted from the workspace cache.",
+		),
+		Errors: counter(
+			"src_executor_workspace_cache_errors_total",
+			"The number of errors that occur while maintaining the workspace cache.",
+		),
+		SizeBytes: sizeBytes,
+	}
+}
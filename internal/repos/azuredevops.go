@@ -0,0 +1,176 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// An AzureDevOpsSource yields repositories from a single Azure DevOps
+// connection configured in Sourcegraph via the external services
+// configuration.
+type AzureDevOpsSource struct {
+	svc       *types.ExternalService
+	config    *schema.AzureDevOpsConnection
+	cli       *azuredevops.Client
+	exclude   excludeFunc
+	serviceID string
+	logger    log.Logger
+}
+
+// NewAzureDevOpsSource returns a new AzureDevOpsSource from the given external
+// service.
+func NewAzureDevOpsSource(ctx context.Context, logger log.Logger, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Exact(r.Id)
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := azuredevops.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureDevOpsSource{
+		svc:       svc,
+		config:    &c,
+		cli:       cli,
+		exclude:   exclude,
+		serviceID: extsvc.NormalizeBaseURL(cli.URL).String(),
+		logger:    logger,
+	}, nil
+}
+
+// ListRepos returns all Azure DevOps repositories of the organizations and
+// projects configured with this AzureDevOpsSource's config.
+func (s *AzureDevOpsSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	type target struct{ org, project string }
+
+	var targets []target
+	for _, org := range s.config.Orgs {
+		targets = append(targets, target{org: org})
+	}
+	for _, p := range s.config.Projects {
+		org, project, ok := strings.Cut(p, "/")
+		if !ok {
+			results <- SourceResult{Source: s, Err: errors.Errorf("invalid Azure DevOps project %q, expected org/project", p)}
+			continue
+		}
+		targets = append(targets, target{org: org, project: project})
+	}
+
+	seen := make(map[string]bool)
+	for _, t := range targets {
+		repos, err := s.cli.ListRepositoriesByProjectOrOrg(ctx, t.org, t.project)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "azuredevops.repos: org=%q, project=%q", t.org, t.project)}
+			continue
+		}
+
+		for _, r := range repos {
+			if seen[r.ID] || r.IsDisabled || s.excludes(r) {
+				continue
+			}
+			seen[r.ID] = true
+
+			repo, err := s.makeRepo(r)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: err}
+				continue
+			}
+			results <- SourceResult{Source: s, Repo: repo}
+		}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *AzureDevOpsSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *AzureDevOpsSource) makeRepo(r *azuredevops.Repository) (*types.Repo, error) {
+	nameWithOwner, err := r.NameWithOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	host := s.cli.URL.Hostname()
+	urn := s.svc.URN()
+	return &types.Repo{
+		Name: reposource.AzureDevOpsRepoName(s.config.RepositoryPathPattern, host, nameWithOwner),
+		URI:  string(reposource.AzureDevOpsRepoName("", host, nameWithOwner)),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          r.ID,
+			ServiceType: extsvc.TypeAzureDevOps,
+			ServiceID:   s.serviceID,
+		},
+		Fork:    r.IsFork,
+		Private: r.Project.Visibility != "public",
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.remoteURL(r),
+			},
+		},
+		Metadata: r,
+	}, nil
+}
+
+// remoteURL returns the repository's Git remote URL without credentials. Use
+// repos.CloneURL to get an authenticated clone URL.
+func (s *AzureDevOpsSource) remoteURL(r *azuredevops.Repository) string {
+	if s.config.GitURLType == "ssh" {
+		return r.SSHURL
+	}
+
+	// The remote URL contains the name of the organization as username, which
+	// we don't want to store.
+	u, err := url.Parse(r.RemoteURL)
+	if err != nil {
+		s.logger.Warn("Error parsing Azure DevOps repository Git remote URL.", log.String("url", r.RemoteURL), log.Error(err))
+		return r.RemoteURL
+	}
+	u.User = nil
+	return u.String()
+}
+
+func (s *AzureDevOpsSource) excludes(r *azuredevops.Repository) bool {
+	nameWithOwner, _ := r.NameWithOwner()
+	return s.exclude(nameWithOwner) || s.exclude(r.ID)
+}
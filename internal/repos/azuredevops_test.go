@@ -0,0 +1,102 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAzureDevOpsSource_ListRepos(t *testing.T) {
+	var srvURL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		repo := func(org, project, visibility, id, name string) *azuredevops.Repository {
+			return &azuredevops.Repository{
+				ID:        id,
+				Name:      name,
+				URL:       fmt.Sprintf("%s/%s/%s-id/_apis/git/repositories/%s", srvURL, org, project, id),
+				Project:   azuredevops.Project{ID: project + "-id", Name: project, Visibility: visibility},
+				RemoteURL: fmt.Sprintf("https://%s@dev.azure.com/%s/%s/_git/%s", org, org, project, name),
+				SSHURL:    fmt.Sprintf("git@ssh.dev.azure.com:v3/%s/%s/%s", org, project, name),
+			}
+		}
+
+		var repos []*azuredevops.Repository
+		switch r.URL.Path {
+		case "/sgtest/_apis/git/repositories":
+			repos = []*azuredevops.Repository{
+				repo("sgtest", "public", "public", "1", "src-cli"),
+				repo("sgtest", "private", "private", "2", "secret"),
+				repo("sgtest", "private", "private", "3", "excluded"),
+			}
+			disabled := repo("sgtest", "private", "private", "4", "disabled")
+			disabled.IsDisabled = true
+			repos = append(repos, disabled)
+		case "/sgtest/private/_apis/git/repositories":
+			// Repositories of configured projects and orgs overlap.
+			repos = []*azuredevops.Repository{repo("sgtest", "private", "private", "2", "secret")}
+		case "/other/project/_apis/git/repositories":
+			repos = []*azuredevops.Repository{repo("other", "project", "private", "5", "repo")}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		require.Nil(t, json.NewEncoder(w).Encode(map[string]any{"count": len(repos), "value": repos}))
+	}))
+	t.Cleanup(srv.Close)
+	srvURL = srv.URL
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindAzureDevOps,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.AzureDevOpsConnection{
+			Url:      srv.URL,
+			Username: "admin",
+			Token:    "secret",
+			Orgs:     []string{"sgtest"},
+			Projects: []string{"sgtest/private", "other/project"},
+			Exclude:  []*schema.ExcludedAzureDevOpsRepo{{Name: "sgtest/private/excluded"}},
+		})),
+	}
+
+	ctx := context.Background()
+	src, err := NewAzureDevOpsSource(ctx, logtest.Scoped(t), svc, httpcli.NewFactory(nil))
+	require.Nil(t, err)
+
+	repos, err := listAll(ctx, src)
+	require.Nil(t, err)
+
+	sort.Slice(repos, func(i, j int) bool { return repos[i].ExternalRepo.ID < repos[j].ExternalRepo.ID })
+
+	var names []api.RepoName
+	for _, r := range repos {
+		names = append(names, r.Name)
+	}
+	assert.Equal(t, []api.RepoName{
+		"127.0.0.1/sgtest/public/src-cli",
+		"127.0.0.1/sgtest/private/secret",
+		"127.0.0.1/other/project/repo",
+	}, names)
+
+	assert.False(t, repos[0].Private)
+	assert.True(t, repos[1].Private)
+	assert.Equal(t, api.ExternalRepoSpec{
+		ID:          "1",
+		ServiceType: extsvc.TypeAzureDevOps,
+		ServiceID:   srv.URL + "/",
+	}, repos[0].ExternalRepo)
+	assert.Equal(t, "https://dev.azure.com/sgtest/public/_git/src-cli", repos[0].Sources[svc.URN()].CloneURL)
+}
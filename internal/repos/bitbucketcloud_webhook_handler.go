@@ -0,0 +1,71 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// BitbucketCloudWebhookHandler enqueues an update of repositories pushed to on
+// Bitbucket Cloud.
+type BitbucketCloudWebhookHandler struct {
+	logger log.Logger
+}
+
+func (b *BitbucketCloudWebhookHandler) Register(router *webhooks.BitbucketCloudWebhook) {
+	b.logger = log.Scoped("repos.BitbucketCloudWebhookHandler", "bitbucket cloud webhook handler")
+	router.Register(b.handleBitbucketCloudWebhook, "repo:push")
+}
+
+func (b *BitbucketCloudWebhookHandler) handleBitbucketCloudWebhook(ctx context.Context, extSvc *types.ExternalService, payload any) error {
+	event, ok := payload.(*bitbucketcloud.RepoPushEvent)
+	if !ok {
+		return errors.Newf("expected bitbucketcloud.RepoPushEvent, got %T", payload)
+	}
+
+	repoName, err := bitbucketCloudRepoNameFromEvent(ctx, extSvc, event)
+	if err != nil {
+		return errors.Wrap(err, "handleBitbucketCloudWebhook: get name failed")
+	}
+
+	resp, err := repoupdater.DefaultClient.EnqueueRepoUpdate(ctx, repoName)
+	if err != nil {
+		return errors.Wrap(err, "handleBitbucketCloudWebhook: EnqueueRepoUpdate failed")
+	}
+
+	b.logger.Info("successfully updated", log.String("name", resp.Name))
+	return nil
+}
+
+// bitbucketCloudRepoNameFromEvent returns the Sourcegraph name of the
+// repository of the event, which depends on the repositoryPathPattern of the
+// external service.
+func bitbucketCloudRepoNameFromEvent(ctx context.Context, extSvc *types.ExternalService, event *bitbucketcloud.RepoPushEvent) (api.RepoName, error) {
+	c, err := extSvc.Configuration(ctx)
+	if err != nil {
+		return "", err
+	}
+	bc, ok := c.(*schema.BitbucketCloudConnection)
+	if !ok {
+		return "", errors.Errorf("expected Bitbucket Cloud configuration, got %T", c)
+	}
+
+	baseURL, err := url.Parse(bc.Url)
+	if err != nil {
+		return "", err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	return reposource.BitbucketCloudRepoName(bc.RepositoryPathPattern, baseURL.Hostname(), event.Repository.FullName), nil
+}
@@ -0,0 +1,46 @@
+package repos_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestBitbucketCloudWebhookHandle(t *testing.T) {
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultReturn(&types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindBitbucketCloud,
+		Config: extsvc.NewUnencryptedConfig(`{"url": "https://bitbucket.org", "username": "admin", "appPassword": "abc", "repositoryPathPattern": "bb/{nameWithOwner}", "webhookSecret": "secret"}`),
+	}, nil)
+
+	handler := repos.BitbucketCloudWebhookHandler{}
+	router := &webhooks.BitbucketCloudWebhook{
+		ExternalServices: externalServices,
+		Next:             http.NotFoundHandler(),
+	}
+	handler.Register(router)
+
+	updated := fakeRepoUpdater(t)
+
+	payload := []byte(`{"repository": {"full_name": "alice/dotfiles"}, "push": {"changes": [{"new": {"type": "branch", "name": "main"}}]}}`)
+	req, err := http.NewRequest("POST", "/.api/bitbucket-cloud-webhooks?externalServiceID=1&secret=secret", bytes.NewReader(payload))
+	require.Nil(t, err)
+	req.Header.Set("X-Event-Key", "repo:push")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"bb/alice/dotfiles"}, *updated)
+}
@@ -0,0 +1,75 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// BitbucketServerWebhookHandler enqueues an update of repositories pushed to
+// on Bitbucket Server.
+type BitbucketServerWebhookHandler struct {
+	logger log.Logger
+}
+
+func (b *BitbucketServerWebhookHandler) Register(router *webhooks.BitbucketServerWebhook) {
+	b.logger = log.Scoped("repos.BitbucketServerWebhookHandler", "bitbucket server webhook handler")
+	router.Register(b.handleBitbucketServerWebhook, "repo:refs_changed")
+}
+
+func (b *BitbucketServerWebhookHandler) handleBitbucketServerWebhook(ctx context.Context, extSvc *types.ExternalService, payload any) error {
+	event, ok := payload.(*bitbucketserver.RepoRefsChangedEvent)
+	if !ok {
+		return errors.Newf("expected bitbucketserver.RepoRefsChangedEvent, got %T", payload)
+	}
+
+	repoName, err := bitbucketServerRepoNameFromEvent(ctx, extSvc, event)
+	if err != nil {
+		return errors.Wrap(err, "handleBitbucketServerWebhook: get name failed")
+	}
+
+	resp, err := repoupdater.DefaultClient.EnqueueRepoUpdate(ctx, repoName)
+	if err != nil {
+		return errors.Wrap(err, "handleBitbucketServerWebhook: EnqueueRepoUpdate failed")
+	}
+
+	b.logger.Info("successfully updated", log.String("name", resp.Name))
+	return nil
+}
+
+// bitbucketServerRepoNameFromEvent returns the Sourcegraph name of the
+// repository of the event, which depends on the repositoryPathPattern of the
+// external service.
+func bitbucketServerRepoNameFromEvent(ctx context.Context, extSvc *types.ExternalService, event *bitbucketserver.RepoRefsChangedEvent) (api.RepoName, error) {
+	c, err := extSvc.Configuration(ctx)
+	if err != nil {
+		return "", err
+	}
+	bc, ok := c.(*schema.BitbucketServerConnection)
+	if !ok {
+		return "", errors.Errorf("expected Bitbucket Server configuration, got %T", c)
+	}
+
+	if event.Repository.Project == nil {
+		return "", errors.New("event has no repository project")
+	}
+
+	baseURL, err := url.Parse(bc.Url)
+	if err != nil {
+		return "", err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	return reposource.BitbucketServerRepoName(bc.RepositoryPathPattern, baseURL.Hostname(), event.Repository.Project.Key, event.Repository.Slug), nil
+}
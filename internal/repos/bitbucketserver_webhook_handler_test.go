@@ -0,0 +1,53 @@
+package repos_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestBitbucketServerWebhookHandle(t *testing.T) {
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultReturn(&types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindBitbucketServer,
+		Config: extsvc.NewUnencryptedConfig(`{"url": "https://bitbucket.example.com", "username": "admin", "token": "abc", "repositoryQuery": ["none"], "webhooks": {"secret": "secret"}}`),
+	}, nil)
+
+	handler := repos.BitbucketServerWebhookHandler{}
+	router := &webhooks.BitbucketServerWebhook{
+		ExternalServices: externalServices,
+		Next:             http.NotFoundHandler(),
+	}
+	handler.Register(router)
+
+	updated := fakeRepoUpdater(t)
+
+	payload := []byte(`{"repository": {"slug": "repo", "project": {"key": "PROJ"}}, "changes": [{"refId": "refs/heads/main", "type": "UPDATE"}]}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+
+	req, err := http.NewRequest("POST", "/.api/bitbucket-server-webhooks?externalServiceID=1", bytes.NewReader(payload))
+	require.Nil(t, err)
+	req.Header.Set("X-Event-Key", "repo:refs_changed")
+	req.Header.Set("X-Hub-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"bitbucket.example.com/PROJ/repo"}, *updated)
+}
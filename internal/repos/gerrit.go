@@ -112,6 +112,10 @@ func (s *GerritSource) makeRepo(projectName string, p *gerrit.Project) (*types.R
 		return nil, err
 	}
 
+	// The list projects endpoint returns the projects keyed by name, without
+	// setting the name on the projects themselves.
+	p.Name = projectName
+
 	name := path.Join(fullURL.Host, fullURL.Path)
 	return &types.Repo{
 		Name:        api.RepoName(name),
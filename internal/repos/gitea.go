@@ -0,0 +1,315 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GiteaSource yields repositories from a single Gitea (or Forgejo)
+// connection configured in Sourcegraph via the external services
+// configuration.
+type GiteaSource struct {
+	svc     *types.ExternalService
+	config  *schema.GiteaConnection
+	exclude excludeFunc
+	baseURL *url.URL // normalized URL of the Gitea instance
+	client  *gitea.Client
+	logger  log.Logger
+}
+
+var _ Source = &GiteaSource{}
+var _ UserSource = &GiteaSource{}
+var _ VersionSource = &GiteaSource{}
+
+// giteaPageSize is the number of repositories requested per page. It matches
+// the default maximum page size of Gitea, so that we never request more
+// repositories than the server returns.
+const giteaPageSize = 50
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(ctx context.Context, logger log.Logger, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newGiteaSource(logger, svc, &c, cf)
+}
+
+func newGiteaSource(logger log.Logger, svc *types.ExternalService, c *schema.GiteaConnection, cf *httpcli.Factory) (*GiteaSource, error) {
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	var opts []httpcli.Opt
+	if c.Certificate != "" {
+		opts = append(opts, httpcli.NewCertPoolOpt(c.Certificate))
+	}
+
+	cli, err := cf.Doer(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		if r.Id != 0 {
+			eb.Exact(strconv.Itoa(r.Id))
+		}
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := gitea.NewClient(svc.URN(), c, cli)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaSource{
+		svc:     svc,
+		config:  c,
+		exclude: exclude,
+		baseURL: baseURL,
+		client:  client,
+		logger:  logger,
+	}, nil
+}
+
+func (s GiteaSource) WithAuthenticator(a auth.Authenticator) (Source, error) {
+	switch a.(type) {
+	case *auth.OAuthBearerToken,
+		*auth.OAuthBearerTokenWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GiteaSource", a)
+	}
+
+	sc := s
+	sc.client = sc.client.WithAuthenticator(a)
+
+	return &sc, nil
+}
+
+func (s GiteaSource) Version(ctx context.Context) (string, error) {
+	return s.client.GetVersion(ctx)
+}
+
+func (s GiteaSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedUser(ctx)
+	return err
+}
+
+// ListRepos returns all Gitea repositories configured with this
+// GiteaSource's config.
+func (s GiteaSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	s.listAllRepos(ctx, results)
+}
+
+// GetRepo returns the Gitea repository with the given "owner/name".
+func (s GiteaSource) GetRepo(ctx context.Context, nameWithOwner string) (*types.Repo, error) {
+	owner, name, ok := strings.Cut(nameWithOwner, "/")
+	if !ok {
+		return nil, errors.Errorf("invalid Gitea repository name %q, expected \"owner/name\"", nameWithOwner)
+	}
+
+	repo, err := s.client.GetRepo(ctx, owner, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.makeRepo(repo), nil
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s GiteaSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s GiteaSource) makeRepo(repo *gitea.Repository) *types.Repo {
+	urn := s.svc.URN()
+	return &types.Repo{
+		Name: reposource.GiteaRepoName(
+			s.config.RepositoryPathPattern,
+			s.baseURL.Hostname(),
+			repo.FullName,
+		),
+		URI: string(reposource.GiteaRepoName(
+			"",
+			s.baseURL.Hostname(),
+			repo.FullName,
+		)),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          strconv.FormatInt(repo.ID, 10),
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   s.baseURL.String(),
+		},
+		Description: repo.Description,
+		Fork:        repo.Fork,
+		Archived:    repo.Archived,
+		Stars:       repo.StarsCount,
+		Private:     repo.Private,
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.remoteURL(repo),
+			},
+		},
+		Metadata: repo,
+	}
+}
+
+// remoteURL returns the Gitea repository's Git remote URL
+//
+// note: this doesn't contain credentials, if you need to get an authenticated
+// clone url use repos.CloneURL
+func (s *GiteaSource) remoteURL(repo *gitea.Repository) string {
+	if s.config.GitURLType == "ssh" {
+		return repo.SSHURL // SSH authentication must be provided out-of-band
+	}
+	return repo.CloneURL
+}
+
+func (s *GiteaSource) excludes(r *gitea.Repository) bool {
+	return s.exclude(r.FullName) || s.exclude(strconv.FormatInt(r.ID, 10))
+}
+
+// giteaListPage lists a single page of repositories and returns whether there are
+// more pages.
+type giteaListPage func(ctx context.Context, page, limit int) ([]*gitea.Repository, bool, error)
+
+func (s *GiteaSource) listAllRepos(ctx context.Context, results chan SourceResult) {
+	type batch struct {
+		repos []*gitea.Repository
+		err   error
+	}
+
+	ch := make(chan batch)
+
+	var wg sync.WaitGroup
+
+	// paginate sends all pages returned by list to ch. description is used to
+	// annotate errors.
+	paginate := func(description string, list giteaListPage) {
+		defer wg.Done()
+
+		for page := 1; ; page++ {
+			if err := ctx.Err(); err != nil {
+				ch <- batch{err: err}
+				return
+			}
+			repos, hasNextPage, err := list(ctx, page, giteaPageSize)
+			if err != nil {
+				ch <- batch{err: errors.Wrapf(err, "error listing Gitea repositories: %s", description)}
+				return
+			}
+			ch <- batch{repos: repos}
+			if !hasNextPage {
+				return
+			}
+		}
+	}
+
+	for _, org := range s.config.Orgs {
+		org := org
+		wg.Add(1)
+		go paginate("org="+org, func(ctx context.Context, page, limit int) ([]*gitea.Repository, bool, error) {
+			return s.client.ListOrgRepos(ctx, org, page, limit)
+		})
+	}
+
+	for _, user := range s.config.Users {
+		user := user
+		wg.Add(1)
+		go paginate("user="+user, func(ctx context.Context, page, limit int) ([]*gitea.Repository, bool, error) {
+			return s.client.ListUserRepos(ctx, user, page, limit)
+		})
+	}
+
+	for _, query := range s.config.RepositoryQuery {
+		var keyword string
+		switch query {
+		case "none":
+			continue
+		case "all":
+			// An empty keyword matches all repositories visible to the user.
+		default:
+			keyword = query
+		}
+
+		wg.Add(1)
+		go paginate("repositoryQuery="+query, func(ctx context.Context, page, limit int) ([]*gitea.Repository, bool, error) {
+			return s.client.SearchRepos(ctx, keyword, page, limit)
+		})
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// Admins normally add to end of lists, so end of list most likely has
+		// new repos => stream them first.
+		for i := len(s.config.Repos) - 1; i >= 0; i-- {
+			nameWithOwner := s.config.Repos[i]
+			owner, name, _ := strings.Cut(nameWithOwner, "/")
+			repo, err := s.client.GetRepo(ctx, owner, name)
+			if err != nil {
+				if gitea.IsNotFound(err) {
+					s.logger.Warn("skipping missing gitea.repos entry:", log.String("name", nameWithOwner), log.Error(err))
+					continue
+				}
+				ch <- batch{err: errors.Wrapf(err, "gitea.repos: name: %q", nameWithOwner)}
+				continue
+			}
+			ch <- batch{repos: []*gitea.Repository{repo}}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+
+	seen := make(map[int64]bool)
+	for b := range ch {
+		if b.err != nil {
+			results <- SourceResult{Source: s, Err: b.err}
+			continue
+		}
+
+		for _, repo := range b.repos {
+			if !seen[repo.ID] && !s.excludes(repo) {
+				results <- SourceResult{Source: s, Repo: s.makeRepo(repo)}
+				seen[repo.ID] = true
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGiteaSource_ListRepos(t *testing.T) {
+	repo := func(id int64, fullName string, private bool) *gitea.Repository {
+		return &gitea.Repository{
+			ID:       id,
+			FullName: fullName,
+			Private:  private,
+			CloneURL: "https://gitea.example.com/" + fullName + ".git",
+			SSHURL:   "git@gitea.example.com:" + fullName + ".git",
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var result any
+		switch r.URL.Path {
+		case "/api/v1/orgs/sgtest/repos":
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("Link", `</api/v1/orgs/sgtest/repos?page=2>; rel="next"`)
+				result = []*gitea.Repository{repo(1, "sgtest/src-cli", false), repo(2, "sgtest/secret", true)}
+			} else {
+				result = []*gitea.Repository{repo(3, "sgtest/excluded", false), repo(4, "sgtest/old-archive", false)}
+			}
+		case "/api/v1/users/alice/repos":
+			result = []*gitea.Repository{repo(5, "alice/dotfiles", false)}
+		case "/api/v1/repos/search":
+			// Search results overlap with the repositories of orgs.
+			assert.Equal(t, "src", r.URL.Query().Get("q"))
+			result = map[string]any{"ok": true, "data": []*gitea.Repository{repo(1, "sgtest/src-cli", false)}}
+		case "/api/v1/repos/bob/notes":
+			result = repo(6, "bob/notes", true)
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		require.Nil(t, json.NewEncoder(w).Encode(result))
+	}))
+	t.Cleanup(srv.Close)
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindGitea,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.GiteaConnection{
+			Url:             srv.URL,
+			Token:           "secret",
+			Orgs:            []string{"sgtest"},
+			Users:           []string{"alice"},
+			Repos:           []string{"bob/notes", "bob/missing"},
+			RepositoryQuery: []string{"none", "src"},
+			Exclude: []*schema.ExcludedGiteaRepo{
+				{Name: "sgtest/excluded"},
+				{Pattern: "-archive$"},
+			},
+		})),
+	}
+
+	ctx := context.Background()
+	src, err := NewGiteaSource(ctx, logtest.Scoped(t), svc, httpcli.NewFactory(nil))
+	require.Nil(t, err)
+
+	repos, err := listAll(ctx, src)
+	require.Nil(t, err)
+
+	sort.Slice(repos, func(i, j int) bool { return repos[i].ExternalRepo.ID < repos[j].ExternalRepo.ID })
+
+	var names []api.RepoName
+	for _, r := range repos {
+		names = append(names, r.Name)
+	}
+	assert.Equal(t, []api.RepoName{
+		"127.0.0.1/sgtest/src-cli",
+		"127.0.0.1/sgtest/secret",
+		"127.0.0.1/alice/dotfiles",
+		"127.0.0.1/bob/notes",
+	}, names)
+
+	assert.False(t, repos[0].Private)
+	assert.True(t, repos[1].Private)
+	assert.Equal(t, api.ExternalRepoSpec{
+		ID:          "1",
+		ServiceType: extsvc.TypeGitea,
+		ServiceID:   srv.URL + "/",
+	}, repos[0].ExternalRepo)
+	assert.Equal(t, "https://gitea.example.com/sgtest/src-cli.git", repos[0].Sources[svc.URN()].CloneURL)
+}
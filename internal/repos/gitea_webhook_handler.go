@@ -0,0 +1,67 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GiteaWebhookHandler enqueues an update of repositories pushed to on Gitea.
+type GiteaWebhookHandler struct {
+	logger log.Logger
+}
+
+func (g *GiteaWebhookHandler) Register(router *webhooks.GiteaWebhook) {
+	g.logger = log.Scoped("repos.GiteaWebhookHandler", "gitea webhook handler")
+	router.Register(g.handleGiteaWebhook, "push")
+}
+
+func (g *GiteaWebhookHandler) handleGiteaWebhook(ctx context.Context, extSvc *types.ExternalService, payload any) error {
+	event, ok := payload.(*gitea.PushEvent)
+	if !ok {
+		return errors.Newf("expected gitea.PushEvent, got %T", payload)
+	}
+
+	repoName, err := giteaRepoNameFromEvent(ctx, extSvc, event)
+	if err != nil {
+		return errors.Wrap(err, "handleGiteaWebhook: get name failed")
+	}
+
+	resp, err := repoupdater.DefaultClient.EnqueueRepoUpdate(ctx, repoName)
+	if err != nil {
+		return errors.Wrap(err, "handleGiteaWebhook: EnqueueRepoUpdate failed")
+	}
+
+	g.logger.Info("successfully updated", log.String("name", resp.Name))
+	return nil
+}
+
+// giteaRepoNameFromEvent returns the Sourcegraph name of the repository of the
+// event, which depends on the repositoryPathPattern of the external service.
+func giteaRepoNameFromEvent(ctx context.Context, extSvc *types.ExternalService, event *gitea.PushEvent) (api.RepoName, error) {
+	c, err := extSvc.Configuration(ctx)
+	if err != nil {
+		return "", err
+	}
+	gc, ok := c.(*schema.GiteaConnection)
+	if !ok {
+		return "", errors.Errorf("expected Gitea configuration, got %T", c)
+	}
+
+	baseURL, err := url.Parse(gc.Url)
+	if err != nil {
+		return "", err
+	}
+
+	return reposource.GiteaRepoName(gc.RepositoryPathPattern, baseURL.Hostname(), event.Repository.FullName), nil
+}
@@ -0,0 +1,82 @@
+package repos_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestGiteaWebhookHandle(t *testing.T) {
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultReturn(&types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindGitea,
+		Config: extsvc.NewUnencryptedConfig(`{"url": "https://gitea.example.com", "token": "abc", "repositoryPathPattern": "gitea/{nameWithOwner}", "webhooks": [{"secret": "secret"}]}`),
+	}, nil)
+
+	handler := repos.GiteaWebhookHandler{}
+	router := &webhooks.GiteaWebhook{
+		ExternalServices: externalServices,
+	}
+	handler.Register(router)
+
+	updated := fakeRepoUpdater(t)
+
+	payload := []byte(`{"ref": "refs/heads/main", "repository": {"id": 1, "full_name": "alice/dotfiles"}}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+
+	req, err := http.NewRequest("POST", "/.api/gitea-webhooks?externalServiceID=1", bytes.NewReader(payload))
+	require.Nil(t, err)
+	req.Header.Set(gitea.EventTypeHeader, "push")
+	req.Header.Set(gitea.SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"gitea/alice/dotfiles"}, *updated)
+}
+
+// fakeRepoUpdater replaces repoupdater.DefaultClient for the duration of the
+// test with one that records the names of the repositories it is asked to
+// update.
+func fakeRepoUpdater(t *testing.T) *[]string {
+	t.Helper()
+
+	var updated []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/enqueue-repo-update", r.URL.Path)
+
+		var req protocol.RepoUpdateRequest
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		updated = append(updated, string(req.Repo))
+
+		w.Header().Set("Content-Type", "application/json")
+		require.Nil(t, json.NewEncoder(w).Encode(&protocol.RepoUpdateResponse{ID: 1, Name: string(req.Repo)}))
+	}))
+	t.Cleanup(server.Close)
+
+	oldClient := repoupdater.DefaultClient
+	repoupdater.DefaultClient = &repoupdater.Client{URL: server.URL, HTTPClient: http.DefaultClient}
+	t.Cleanup(func() { repoupdater.DefaultClient = oldClient })
+
+	return &updated
+}
@@ -0,0 +1,75 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GitLabWebhookHandler enqueues an update of repositories pushed to on GitLab.
+type GitLabWebhookHandler struct {
+	logger log.Logger
+}
+
+func (g *GitLabWebhookHandler) Register(router *webhooks.GitLabWebhook) {
+	g.logger = log.Scoped("repos.GitLabWebhookHandler", "gitlab webhook handler")
+	router.Register(g.handleGitLabWebhook, "push")
+}
+
+func (g *GitLabWebhookHandler) handleGitLabWebhook(ctx context.Context, extSvc *types.ExternalService, payload any) error {
+	event, ok := payload.(*gitlabwebhooks.PushEvent)
+	if !ok {
+		return errors.Newf("expected gitlab.PushEvent, got %T", payload)
+	}
+
+	repoName, err := gitlabRepoNameFromEvent(ctx, extSvc, event)
+	if err != nil {
+		return errors.Wrap(err, "handleGitLabWebhook: get name failed")
+	}
+
+	resp, err := repoupdater.DefaultClient.EnqueueRepoUpdate(ctx, repoName)
+	if err != nil {
+		return errors.Wrap(err, "handleGitLabWebhook: EnqueueRepoUpdate failed")
+	}
+
+	g.logger.Info("successfully updated", log.String("name", resp.Name))
+	return nil
+}
+
+// gitlabRepoNameFromEvent returns the Sourcegraph name of the project of the
+// event, which depends on the repositoryPathPattern and nameTransformations of
+// the external service.
+func gitlabRepoNameFromEvent(ctx context.Context, extSvc *types.ExternalService, event *gitlabwebhooks.PushEvent) (api.RepoName, error) {
+	c, err := extSvc.Configuration(ctx)
+	if err != nil {
+		return "", err
+	}
+	gc, ok := c.(*schema.GitLabConnection)
+	if !ok {
+		return "", errors.Errorf("expected GitLab configuration, got %T", c)
+	}
+
+	baseURL, err := url.Parse(gc.Url)
+	if err != nil {
+		return "", err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	nts, err := reposource.CompileGitLabNameTransformations(gc.NameTransformations)
+	if err != nil {
+		return "", err
+	}
+
+	return reposource.GitLabRepoName(gc.RepositoryPathPattern, baseURL.Hostname(), event.Project.PathWithNamespace, nts), nil
+}
@@ -0,0 +1,53 @@
+package repos_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestGitLabWebhookHandle(t *testing.T) {
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultReturn(&types.ExternalService{
+		ID:   1,
+		Kind: extsvc.KindGitLab,
+		Config: extsvc.NewUnencryptedConfig(`{
+			"url": "https://gitlab.example.com",
+			"token": "abc",
+			"projectQuery": ["none"],
+			"nameTransformations": [{"regex": "\\.", "replacement": "-"}],
+			"webhooks": [{"secret": "secret"}]
+		}`),
+	}, nil)
+
+	handler := repos.GitLabWebhookHandler{}
+	router := &webhooks.GitLabWebhook{
+		ExternalServices: externalServices,
+		Next:             http.NotFoundHandler(),
+	}
+	handler.Register(router)
+
+	updated := fakeRepoUpdater(t)
+
+	payload := []byte(`{"object_kind": "push", "ref": "refs/heads/main", "project": {"id": 1, "path_with_namespace": "group/my.project"}}`)
+	req, err := http.NewRequest("POST", "/.api/gitlab-webhooks?externalServiceID=1", bytes.NewReader(payload))
+	require.Nil(t, err)
+	req.Header.Set("X-Gitlab-Event", "Push Hook")
+	req.Header.Set("X-Gitlab-Token", "secret")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"gitlab-example-com/group/my-project"}, *updated)
+}
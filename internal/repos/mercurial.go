@@ -0,0 +1,109 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/mercurial"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A MercurialSource yields repositories from a single Mercurial connection
+// configured in Sourcegraph via the external services configuration.
+type MercurialSource struct {
+	svc     *types.ExternalService
+	config  *schema.MercurialConnection
+	baseURL *url.URL
+}
+
+// NewMercurialSource returns a new MercurialSource from the given external
+// service.
+func NewMercurialSource(ctx context.Context, svc *types.ExternalService) (*MercurialSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.MercurialConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newMercurialSource(svc, &c)
+}
+
+func newMercurialSource(svc *types.ExternalService, c *schema.MercurialConnection) (*MercurialSource, error) {
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse Mercurial URL")
+	}
+	// Passwords are configured separately and added to the clone URL, but the
+	// username is kept, since it is required by ssh:// URLs.
+	if baseURL.User != nil {
+		baseURL.User = url.User(baseURL.User.Username())
+	}
+
+	return &MercurialSource{
+		svc:     svc,
+		config:  c,
+		baseURL: extsvc.NormalizeBaseURL(baseURL),
+	}, nil
+}
+
+// ListRepos returns all Mercurial repositories configured in the connection.
+func (s MercurialSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	for _, p := range s.config.Repos {
+		results <- SourceResult{Source: s, Repo: s.makeRepo(p)}
+	}
+}
+
+func (s MercurialSource) makeRepo(repoPath string) *types.Repo {
+	repoPath = strings.Trim(repoPath, "/")
+	urn := s.svc.URN()
+
+	repoURL := *s.baseURL
+	repoURL.Path = path.Join("/", s.baseURL.Path, repoPath)
+
+	serviceID := *s.baseURL
+	serviceID.User = nil
+
+	return &types.Repo{
+		Name: reposource.MercurialRepoName(
+			s.config.RepositoryPathPattern,
+			s.baseURL.Host,
+			repoPath,
+		),
+		URI: string(reposource.MercurialRepoName(
+			"",
+			s.baseURL.Host,
+			repoPath,
+		)),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          repoPath,
+			ServiceType: extsvc.TypeMercurial,
+			ServiceID:   serviceID.String(),
+		},
+		Private: true,
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: repoURL.String(),
+			},
+		},
+		Metadata: &mercurial.Repository{
+			URL:  repoURL.String(),
+			Path: repoPath,
+		},
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s MercurialSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
@@ -0,0 +1,59 @@
+package repos
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMercurialSource_makeRepo(t *testing.T) {
+	paths := []string{
+		"project1",
+		"/libraries/project2/",
+	}
+
+	svc := types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindMercurial,
+		Config: extsvc.NewEmptyConfig(),
+	}
+
+	tests := []struct {
+		name   string
+		schema *schema.MercurialConnection
+	}{
+		{
+			name: "simple",
+			schema: &schema.MercurialConnection{
+				Url:      "https://hg.example.com/repos/",
+				Username: "admin",
+				Password: "pa$$word",
+			},
+		}, {
+			name: "path-pattern",
+			schema: &schema.MercurialConnection{
+				Url:                   "ssh://hg@hg.example.com",
+				RepositoryPathPattern: "hg/{path}",
+			},
+		},
+	}
+	for _, test := range tests {
+		test.name = "MercurialSource_makeRepo_" + test.name
+		t.Run(test.name, func(t *testing.T) {
+			s, err := newMercurialSource(&svc, test.schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []*types.Repo
+			for _, p := range paths {
+				got = append(got, s.makeRepo(p))
+			}
+
+			testutil.AssertGolden(t, "testdata/golden/"+test.name, update(test.name), got)
+		})
+	}
+}
@@ -51,6 +51,10 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewGitLabSource(ctx, logger.Scoped("GitLabSource", ""), db, svc, cf)
 	case extsvc.KindGerrit:
 		return NewGerritSource(ctx, svc, cf)
+	case extsvc.KindGitea:
+		return NewGiteaSource(ctx, logger.Scoped("GiteaSource", ""), svc, cf)
+	case extsvc.KindAzureDevOps:
+		return NewAzureDevOpsSource(ctx, logger.Scoped("AzureDevOpsSource", ""), svc, cf)
 	case extsvc.KindBitbucketServer:
 		return NewBitbucketServerSource(ctx, logger.Scoped("BitbucketServerSource", ""), svc, cf)
 	case extsvc.KindBitbucketCloud:
@@ -63,6 +67,10 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewAWSCodeCommitSource(ctx, svc, cf)
 	case extsvc.KindPerforce:
 		return NewPerforceSource(ctx, svc)
+	case extsvc.KindSubversion:
+		return NewSubversionSource(ctx, svc)
+	case extsvc.KindMercurial:
+		return NewMercurialSource(ctx, svc)
 	case extsvc.KindGoPackages:
 		return NewGoPackagesSource(ctx, svc, cf)
 	case extsvc.KindJVMPackages:
@@ -72,8 +80,12 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewPagureSource(ctx, svc, cf)
 	case extsvc.KindNpmPackages:
 		return NewNpmPackagesSource(ctx, svc, cf)
+	case extsvc.KindNuGetPackages:
+		return NewNuGetPackagesSource(ctx, svc, cf)
 	case extsvc.KindPythonPackages:
 		return NewPythonPackagesSource(ctx, svc, cf)
+	case extsvc.KindRubyPackages:
+		return NewRubyPackagesSource(ctx, svc, cf)
 	case extsvc.KindRustPackages:
 		return NewRustPackagesSource(ctx, svc, cf)
 	case extsvc.KindOther:
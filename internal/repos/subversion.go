@@ -0,0 +1,103 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/subversion"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A SubversionSource yields repositories from a single Subversion connection
+// configured in Sourcegraph via the external services configuration.
+type SubversionSource struct {
+	svc     *types.ExternalService
+	config  *schema.SubversionConnection
+	baseURL *url.URL
+}
+
+// NewSubversionSource returns a new SubversionSource from the given external
+// service.
+func NewSubversionSource(ctx context.Context, svc *types.ExternalService) (*SubversionSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.SubversionConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newSubversionSource(svc, &c)
+}
+
+func newSubversionSource(svc *types.ExternalService, c *schema.SubversionConnection) (*SubversionSource, error) {
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse Subversion URL")
+	}
+	// Credentials are configured separately and added to the clone URL.
+	baseURL.User = nil
+
+	return &SubversionSource{
+		svc:     svc,
+		config:  c,
+		baseURL: extsvc.NormalizeBaseURL(baseURL),
+	}, nil
+}
+
+// ListRepos returns all Subversion repositories configured in the connection.
+func (s SubversionSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	for _, p := range s.config.Repos {
+		results <- SourceResult{Source: s, Repo: s.makeRepo(p)}
+	}
+}
+
+func (s SubversionSource) makeRepo(repoPath string) *types.Repo {
+	repoPath = strings.Trim(repoPath, "/")
+	urn := s.svc.URN()
+
+	repoURL := *s.baseURL
+	repoURL.Path = path.Join("/", s.baseURL.Path, repoPath)
+
+	return &types.Repo{
+		Name: reposource.SubversionRepoName(
+			s.config.RepositoryPathPattern,
+			s.baseURL.Host,
+			repoPath,
+		),
+		URI: string(reposource.SubversionRepoName(
+			"",
+			s.baseURL.Host,
+			repoPath,
+		)),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          repoPath,
+			ServiceType: extsvc.TypeSubversion,
+			ServiceID:   s.baseURL.String(),
+		},
+		Private: true,
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: repoURL.String(),
+			},
+		},
+		Metadata: &subversion.Repository{
+			URL:  repoURL.String(),
+			Path: repoPath,
+		},
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s SubversionSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
@@ -0,0 +1,188 @@
+package codeownership
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/regexp"
+	"github.com/hmarr/codeowners"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// codeownersFile is a parsed CODEOWNERS file.
+//
+// Files as supported by GitHub consist of a single unnamed section. GitLab
+// additionally allows splitting the file into named sections, which assign
+// owners independently of each other: the owners of a path are the union of
+// the owners of the last matching rule of each section.
+//
+// https://docs.gitlab.com/ee/user/project/code_owners.html#code-owners-sections
+type codeownersFile struct {
+	sections []*codeownersSection
+}
+
+type codeownersSection struct {
+	// Name is empty for rules that precede the first section header.
+	Name string
+	// Optional sections (^[Section]) don't require approval from their owners.
+	Optional bool
+	// ApprovalsRequired is the number of approvals required from the owners of
+	// the section ([Section][2]), or 0 if unspecified.
+	ApprovalsRequired int
+	// DefaultOwners are the owners of rules in the section that don't specify
+	// any owners themselves.
+	DefaultOwners Owners
+
+	rules codeowners.Ruleset
+}
+
+// sectionHeaderRegexp matches GitLab section headers, such as
+// "^[Documentation][2] @docs-team".
+var sectionHeaderRegexp = regexp.MustCompile(`^(\^)?\[([^\]]+)\](?:\[(\d+)\])?(.*)$`)
+
+func parseCodeowners(content []byte) (*codeownersFile, error) {
+	section := &codeownersSection{}
+	f := &codeownersFile{sections: []*codeownersSection{section}}
+	byName := map[string]*codeownersSection{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		// Ignore blank lines and comments
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		if m := sectionHeaderRegexp.FindStringSubmatch(line); m != nil {
+			defaultOwners, err := parseCodeownersOwners(m[4])
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d", lineNo)
+			}
+
+			// Sections with the same name are combined.
+			key := strings.ToLower(m[2])
+			if existing, ok := byName[key]; ok {
+				section = existing
+			} else {
+				section = &codeownersSection{Name: m[2]}
+				byName[key] = section
+				f.sections = append(f.sections, section)
+			}
+			section.Optional = m[1] != ""
+			if m[3] != "" {
+				section.ApprovalsRequired, _ = strconv.Atoi(m[3])
+			}
+			if len(defaultOwners) > 0 {
+				section.DefaultOwners = defaultOwners
+			}
+			continue
+		}
+
+		rule, err := parseCodeownersRule(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNo)
+		}
+		rule.LineNumber = lineNo
+		section.rules = append(section.rules, rule)
+	}
+
+	return f, scanner.Err()
+}
+
+// parseCodeownersRule parses a single rule. Only the pattern is parsed by
+// github.com/hmarr/codeowners, which doesn't support owners such as GitLab
+// subgroups.
+func parseCodeownersRule(line string) (codeowners.Rule, error) {
+	var pattern, rest string
+	escaped := false
+	for i, ch := range line {
+		if (ch == ' ' || ch == '\t') && !escaped {
+			pattern, rest = line[:i], line[i:]
+			break
+		}
+		escaped = ch == '\\' && !escaped
+	}
+	if pattern == "" {
+		pattern = line
+	}
+
+	ruleset, err := codeowners.ParseFile(strings.NewReader(pattern))
+	if err != nil {
+		return codeowners.Rule{}, err
+	}
+	if len(ruleset) != 1 {
+		return codeowners.Rule{}, errors.Errorf("invalid pattern %q", pattern)
+	}
+	rule := ruleset[0]
+
+	if i := strings.Index(rest, "#"); i != -1 {
+		rule.Comment = strings.TrimSpace(rest[i+1:])
+		rest = rest[:i]
+	}
+	rule.Owners, err = parseCodeownersOwners(rest)
+	return rule, err
+}
+
+func parseCodeownersOwners(s string) (Owners, error) {
+	var owners Owners
+	for _, field := range strings.Fields(s) {
+		owner, ok := parseOwner(field)
+		if !ok {
+			return nil, errors.Errorf("invalid owner format %q", field)
+		}
+		owners = append(owners, owner)
+	}
+	return owners, nil
+}
+
+var emailRegexp = regexp.MustCompile(`^[A-Z0-9a-z._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}$`)
+
+// parseOwner parses an email address, "@username" or "@group/team". Nested
+// GitLab groups, such as "@group/subgroup/team", are teams.
+func parseOwner(s string) (codeowners.Owner, bool) {
+	if emailRegexp.MatchString(s) {
+		return codeowners.Owner{Value: s, Type: codeowners.EmailOwner}, true
+	}
+	name := strings.TrimPrefix(s, "@")
+	if name == s || name == "" || strings.ContainsAny(name, "@#") {
+		return codeowners.Owner{}, false
+	}
+	if strings.Contains(name, "/") {
+		return codeowners.Owner{Value: name, Type: codeowners.TeamOwner}, true
+	}
+	return codeowners.Owner{Value: name, Type: codeowners.UsernameOwner}, true
+}
+
+// match returns the owners of the given path across all sections.
+func (f *codeownersFile) match(path string) (Owners, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	var owners Owners
+	for _, section := range f.sections {
+		rule, err := section.rules.Match(path)
+		if err != nil {
+			return nil, err
+		}
+		if rule == nil {
+			continue
+		}
+		if len(rule.Owners) == 0 && section.Name != "" {
+			owners = append(owners, section.DefaultOwners...)
+			continue
+		}
+		// We directly return the codeowners.Owner structs to avoid creating
+		// unnecessary copies. We also found that the longest list of owners
+		// is less than 50.
+		// c.f. https://github.com/sourcegraph/sourcegraph/pull/39250#discussion_r927942090
+		owners = append(owners, rule.Owners...)
+	}
+	return owners, nil
+}
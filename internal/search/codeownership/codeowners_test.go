@@ -0,0 +1,83 @@
+package codeownership
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ownerStrings(owners Owners) []string {
+	res := make([]string, 0, len(owners))
+	for _, o := range owners {
+		res = append(res, o.String())
+	}
+	return res
+}
+
+func TestParseCodeowners(t *testing.T) {
+	t.Run("github", func(t *testing.T) {
+		f, err := parseCodeowners([]byte(`
+# Comment
+*       @global-owner
+*.go    @gopher docs@example.com # Go code
+/docs/  @org/docs-team
+/docs/generated/
+`))
+		require.NoError(t, err)
+		require.Len(t, f.sections, 1)
+
+		for path, want := range map[string][]string{
+			"README.md":             {"@global-owner"},
+			"cmd/main.go":           {"@gopher", "docs@example.com"},
+			"docs/index.md":         {"@org/docs-team"},
+			"docs/generated/api.md": {},
+		} {
+			owners, err := f.match(path)
+			require.NoError(t, err)
+			assert.Equal(t, want, ownerStrings(owners), path)
+		}
+	})
+
+	t.Run("gitlab sections", func(t *testing.T) {
+		f, err := parseCodeowners([]byte(`
+*.md @tech-writer
+
+[Backend][2] @group/backend/leads
+*.go
+internal/auth/ @security-person
+
+^[Frontend] @frontend
+*.ts
+
+[backend]
+*.sql @dba
+`))
+		require.NoError(t, err)
+		require.Len(t, f.sections, 3)
+
+		backend := f.sections[1]
+		assert.Equal(t, "Backend", backend.Name)
+		assert.Equal(t, 2, backend.ApprovalsRequired)
+		assert.False(t, backend.Optional)
+		assert.True(t, f.sections[2].Optional)
+
+		for path, want := range map[string][]string{
+			"README.md":             {"@tech-writer"},
+			"cmd/main.go":           {"@group/backend/leads"},
+			"internal/auth/auth.go": {"@security-person"},
+			"web/app.ts":            {"@frontend"},
+			"migrations/1.sql":      {"@dba"},
+			"unowned.txt":           {},
+		} {
+			owners, err := f.match(path)
+			require.NoError(t, err)
+			assert.Equal(t, want, ownerStrings(owners), path)
+		}
+	})
+
+	t.Run("invalid owner", func(t *testing.T) {
+		_, err := parseCodeowners([]byte("*.go not-an-owner\n"))
+		assert.Error(t, err)
+	})
+}
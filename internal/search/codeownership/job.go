@@ -2,6 +2,7 @@ package codeownership
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	otlog "github.com/opentracing/opentracing-go/log"
@@ -111,7 +112,7 @@ matchesLoop:
 		}
 
 		var owners Owners
-		owners, err = ruleset.Match(mm.File.Path)
+		owners, err = ruleset.Match(ctx, mm.File.Path)
 		if err != nil {
 			errs = errors.Append(errs, err)
 		}
@@ -128,9 +129,13 @@ matchesLoop:
 	return filtered, errs
 }
 
+// containsOwner reports whether owner is one of owners. Owners are compared
+// case-insensitively and the leading "@" of usernames and teams is optional,
+// as OWNERS files list them without it.
 func containsOwner(owners Owners, owner string) bool {
+	owner = strings.TrimPrefix(owner, "@")
 	for _, o := range owners {
-		if o.String() == owner {
+		if strings.EqualFold(strings.TrimPrefix(o.String(), "@"), owner) {
 			return true
 		}
 	}
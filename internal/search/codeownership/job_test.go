@@ -98,6 +98,71 @@ func Test_applyCodeOwnershipFiltering(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "filters results based on gitlab code owners sections",
+			args: args{
+				includeOwners: []string{"@group/subgroup/docs"},
+				excludeOwners: []string{},
+				matches: []result.Match{
+					&result.FileMatch{
+						File: result.File{
+							Path: "README.md",
+						},
+					},
+					&result.FileMatch{
+						File: result.File{
+							Path: "main.go",
+						},
+					},
+				},
+				repoContent: map[string]string{
+					".gitlab/CODEOWNERS": "* @sqs\n\n[Docs] @group/subgroup/docs\n*.md\n",
+				},
+			},
+			want: autogold.Want("results matching gitlab section ownership", []result.Match{
+				&result.FileMatch{
+					File: result.File{
+						Path: "README.md",
+					},
+				},
+			}),
+		},
+		{
+			name: "filters results based on hierarchical owners files",
+			args: args{
+				includeOwners: []string{"sqs"},
+				excludeOwners: []string{},
+				matches: []result.Match{
+					&result.FileMatch{
+						File: result.File{
+							Path: "cmd/frontend/main.go",
+						},
+					},
+					&result.FileMatch{
+						File: result.File{
+							Path: "cmd/gitserver/main.go",
+						},
+					},
+					&result.FileMatch{
+						File: result.File{
+							Path: "README.md",
+						},
+					},
+				},
+				repoContent: map[string]string{
+					"OWNERS":               "approvers:\n  - keegan\n",
+					"cmd/OWNERS":           "approvers:\n  - sqs\n",
+					"cmd/gitserver/OWNERS": "approvers:\n  - eric\noptions:\n  no_parent_owners: true\n",
+				},
+			},
+			want: autogold.Want("results matching owners files", []result.Match{
+				&result.FileMatch{
+					File: result.File{
+						Path: "cmd/frontend/main.go",
+					},
+				},
+			}),
+		},
 	}
 
 	for _, tt := range tests {
@@ -113,7 +178,17 @@ func Test_applyCodeOwnershipFiltering(t *testing.T) {
 				}
 				return []byte(content), nil
 			}
-			t.Cleanup(func() { gitserver.Mocks.ReadFile = nil })
+			gitserver.Mocks.LsFiles = func(api.RepoName, api.CommitID) ([]string, error) {
+				files := make([]string, 0, len(tt.args.repoContent))
+				for file := range tt.args.repoContent {
+					files = append(files, file)
+				}
+				return files, nil
+			}
+			t.Cleanup(func() {
+				gitserver.Mocks.ReadFile = nil
+				gitserver.Mocks.LsFiles = nil
+			})
 
 			matches, _ := applyCodeOwnershipFiltering(ctx, gitserver.NewClient(db), &rules, tt.args.includeOwners, tt.args.excludeOwners, tt.args.matches)
 
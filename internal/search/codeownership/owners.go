@@ -0,0 +1,213 @@
+package codeownership
+
+import (
+	"bufio"
+	"bytes"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/grafana/regexp"
+	"github.com/hmarr/codeowners"
+	"gopkg.in/yaml.v3"
+)
+
+// ownersFile is a parsed per-directory OWNERS file, in either the Chromium or
+// the Kubernetes format. The owners of a directory also own its
+// subdirectories, unless an OWNERS file further down opts out of inheriting
+// the owners of its parents.
+//
+// https://chromium.googlesource.com/chromium/src/+/HEAD/docs/code_reviews.md#owners-files
+// https://www.kubernetes.dev/docs/guide/owners/
+type ownersFile struct {
+	owners   Owners
+	noParent bool
+
+	// rules assign additional owners to some of the files the OWNERS file
+	// applies to: Chromium "per-file" lines and Kubernetes "filters".
+	rules []ownersRule
+
+	// includes are the repository paths of other OWNERS files whose owners
+	// are included in this one (Chromium "file://" and "include" lines).
+	includes []string
+}
+
+type ownersRule struct {
+	// match reports whether the rule applies to the given path, relative to
+	// the directory of the OWNERS file.
+	match    func(relPath string) bool
+	owners   Owners
+	noParent bool
+}
+
+// match returns the owners of the given path, relative to the directory of the
+// OWNERS file, and whether the owners of parent directories are inherited.
+func (f *ownersFile) match(relPath string) (owners Owners, noParent bool) {
+	owners = append(owners, f.owners...)
+	noParent = f.noParent
+	for _, rule := range f.rules {
+		if rule.match(relPath) {
+			owners = append(owners, rule.owners...)
+			noParent = noParent || rule.noParent
+		}
+	}
+	return owners, noParent
+}
+
+// kubernetesOwners is the YAML format of Kubernetes OWNERS files.
+type kubernetesOwners struct {
+	Approvers []string `yaml:"approvers"`
+	Options   struct {
+		NoParentOwners bool `yaml:"no_parent_owners"`
+	} `yaml:"options"`
+	Filters map[string]struct {
+		Approvers []string `yaml:"approvers"`
+	} `yaml:"filters"`
+}
+
+// parseOwnersFile parses the OWNERS file at the given repository path.
+// Kubernetes OWNERS files may refer to the aliases defined in the
+// OWNERS_ALIASES file at the root of the repository.
+func parseOwnersFile(filePath string, content []byte, aliases map[string][]string) *ownersFile {
+	var k kubernetesOwners
+	if err := yaml.Unmarshal(content, &k); err == nil {
+		return parseKubernetesOwners(k, aliases)
+	}
+	return parseChromiumOwners(filePath, content)
+}
+
+func parseKubernetesOwners(k kubernetesOwners, aliases map[string][]string) *ownersFile {
+	f := &ownersFile{
+		owners:   kubernetesApprovers(k.Approvers, aliases),
+		noParent: k.Options.NoParentOwners,
+	}
+	patterns := make([]string, 0, len(k.Filters))
+	for pattern := range k.Filters {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+		f.rules = append(f.rules, ownersRule{
+			match:  re.MatchString,
+			owners: kubernetesApprovers(k.Filters[pattern].Approvers, aliases),
+		})
+	}
+	return f
+}
+
+func kubernetesApprovers(names []string, aliases map[string][]string) (owners Owners) {
+	for _, name := range names {
+		members, ok := aliases[name]
+		if !ok {
+			members = []string{name}
+		}
+		for _, member := range members {
+			owners = append(owners, parseBareOwner(member))
+		}
+	}
+	return owners
+}
+
+// parseBareOwner parses an owner that is listed without a leading "@", as is
+// the case in OWNERS files.
+func parseBareOwner(s string) codeowners.Owner {
+	if owner, ok := parseOwner(s); ok {
+		return owner
+	}
+	owner, _ := parseOwner("@" + s)
+	return owner
+}
+
+func parseChromiumOwners(filePath string, content []byte) *ownersFile {
+	f := &ownersFile{}
+	dir := path.Dir(filePath)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i != -1 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "" || line == "*":
+			// "*" allows anyone to approve, which doesn't make anyone an owner.
+		case line == "set noparent":
+			f.noParent = true
+		case strings.HasPrefix(line, "per-file "):
+			if rule, ok := parseChromiumPerFile(strings.TrimPrefix(line, "per-file ")); ok {
+				f.rules = append(f.rules, rule)
+			}
+		case strings.HasPrefix(line, "file://"):
+			f.includes = append(f.includes, path.Clean(strings.TrimPrefix(line, "file://")))
+		case strings.HasPrefix(line, "include "):
+			include := strings.TrimSpace(strings.TrimPrefix(line, "include "))
+			if strings.HasPrefix(include, "/") {
+				include = strings.TrimPrefix(include, "/")
+			} else {
+				include = path.Join(dir, include)
+			}
+			f.includes = append(f.includes, path.Clean(include))
+		default:
+			if owner, ok := parseOwner(line); ok {
+				f.owners = append(f.owners, owner)
+			}
+		}
+	}
+
+	return f
+}
+
+// parseChromiumPerFile parses the "<glob>[,<glob>...]=<owner>[,<owner>...]" part
+// of a per-file line. The globs match the names of files in the directory of
+// the OWNERS file only.
+func parseChromiumPerFile(s string) (ownersRule, bool) {
+	globs, ownerList, ok := strings.Cut(s, "=")
+	if !ok {
+		return ownersRule{}, false
+	}
+
+	rule := ownersRule{}
+	for _, o := range strings.Split(ownerList, ",") {
+		o = strings.TrimSpace(o)
+		switch o {
+		case "set noparent":
+			rule.noParent = true
+		case "", "*":
+		default:
+			if owner, ok := parseOwner(o); ok {
+				rule.owners = append(rule.owners, owner)
+			}
+		}
+	}
+
+	patterns := strings.Split(globs, ",")
+	rule.match = func(relPath string) bool {
+		if strings.Contains(relPath, "/") {
+			return false
+		}
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(strings.TrimSpace(pattern), relPath); ok {
+				return true
+			}
+		}
+		return false
+	}
+	return rule, true
+}
+
+// parseOwnersAliases parses a Kubernetes OWNERS_ALIASES file.
+func parseOwnersAliases(content []byte) map[string][]string {
+	var f struct {
+		Aliases map[string][]string `yaml:"aliases"`
+	}
+	if err := yaml.Unmarshal(content, &f); err != nil {
+		return nil
+	}
+	return f.Aliases
+}
@@ -0,0 +1,63 @@
+package codeownership
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOwnersFile(t *testing.T) {
+	t.Run("chromium", func(t *testing.T) {
+		f := parseOwnersFile("net/OWNERS", []byte(`
+# Networking owners
+set noparent
+alice@chromium.org
+bob@chromium.org  # Reviews QUIC changes
+*
+per-file *.gn=build@chromium.org
+per-file BUILD.gn,DEPS=set noparent
+file://build/OWNERS
+include quic/OWNERS
+`), nil)
+
+		assert.True(t, f.noParent)
+		assert.Equal(t, []string{"alice@chromium.org", "bob@chromium.org"}, ownerStrings(f.owners))
+		assert.Equal(t, []string{"build/OWNERS", "net/quic/OWNERS"}, f.includes)
+
+		owners, noParent := f.match("BUILD.gn")
+		assert.Equal(t, []string{"alice@chromium.org", "bob@chromium.org", "build@chromium.org"}, ownerStrings(owners))
+		assert.True(t, noParent)
+
+		// per-file rules only apply to files in the directory itself.
+		owners, _ = f.match("quic/BUILD.gn")
+		assert.Equal(t, []string{"alice@chromium.org", "bob@chromium.org"}, ownerStrings(owners))
+	})
+
+	t.Run("kubernetes", func(t *testing.T) {
+		aliases := parseOwnersAliases([]byte(`
+aliases:
+  sig-storage-approvers:
+    - carol
+    - dave
+`))
+		f := parseOwnersFile("pkg/volume/OWNERS", []byte(`
+approvers:
+  - alice
+  - sig-storage-approvers
+reviewers:
+  - erin
+options:
+  no_parent_owners: true
+filters:
+  ".*_test\\.go$":
+    approvers:
+      - tester
+`), aliases)
+
+		assert.True(t, f.noParent)
+		assert.Equal(t, []string{"@alice", "@carol", "@dave"}, ownerStrings(f.owners))
+
+		owners, _ := f.match("csi/csi_test.go")
+		assert.Equal(t, []string{"@alice", "@carol", "@dave", "@tester"}, ownerStrings(owners))
+	})
+}
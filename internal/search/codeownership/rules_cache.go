@@ -14,16 +14,16 @@ type RulesKey struct {
 }
 
 type RulesCache struct {
-	rules map[RulesKey]Ruleset
+	rules map[RulesKey]*Ruleset
 
 	mu sync.Mutex
 }
 
 func NewRulesCache() RulesCache {
-	return RulesCache{rules: make(map[RulesKey]Ruleset)}
+	return RulesCache{rules: make(map[RulesKey]*Ruleset)}
 }
 
-func (c *RulesCache) GetFromCacheOrFetch(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID) (Ruleset, error) {
+func (c *RulesCache) GetFromCacheOrFetch(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID) (*Ruleset, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -33,7 +33,7 @@ func (c *RulesCache) GetFromCacheOrFetch(ctx context.Context, gitserver gitserve
 	if !ok {
 		ruleset, err = NewRuleset(ctx, gitserver, repoName, commitID)
 		if err != nil {
-			emptyRuleset := Ruleset{}
+			emptyRuleset := &Ruleset{}
 			c.rules[key] = emptyRuleset
 			return emptyRuleset, err
 		}
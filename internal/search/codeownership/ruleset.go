@@ -1,77 +1,230 @@
 package codeownership
 
 import (
-	"bytes"
 	"context"
+	"path"
+	"strings"
+	"sync"
 
 	"github.com/hmarr/codeowners"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 )
 
+// Ruleset resolves the owners of the files of a repository at a commit. Owners
+// are taken from the CODEOWNERS file of the repository, in either GitHub or
+// GitLab syntax, and from the OWNERS files in the directories containing a
+// file, which are loaded as needed.
 type Ruleset struct {
-	codeownersRuleset codeowners.Ruleset
+	gitserver gitserver.Client
+	repoName  api.RepoName
+	commitID  api.CommitID
+
+	codeowners *codeownersFile
+
+	// ownersPaths are the paths of the OWNERS files of the repository, loaded with the
+	// first match. It is nil if the repository has a root OWNERS file or the listing
+	// failed, in which case every directory is checked for an OWNERS file.
+	ownersPaths     map[string]struct{}
+	ownersPathsOnce sync.Once
+
+	// loads deduplicates concurrent reads of the same OWNERS file.
+	loads singleflight.Group
+	mu    sync.Mutex
+	// ownersFiles caches the OWNERS files by path, nil if there is none.
+	ownersFiles map[string]*ownersFile
+
+	// aliases are the Kubernetes OWNERS_ALIASES, loaded with the first OWNERS file.
+	aliases     map[string][]string
+	aliasesOnce sync.Once
 }
+
 type Owners = []codeowners.Owner
 
-func (r *Ruleset) Match(path string) (Owners, error) {
-	if r.codeownersRuleset == nil {
+// maxOwnersIncludeDepth bounds how deep OWNERS files may include each other.
+const maxOwnersIncludeDepth = 5
+
+// Match returns the deduplicated owners of the given path.
+func (r *Ruleset) Match(ctx context.Context, filePath string) (Owners, error) {
+	if r == nil {
 		return Owners{}, nil
 	}
 
-	rule, err := r.codeownersRuleset.Match(path)
+	owners, err := r.codeowners.match(filePath)
 	if err != nil {
 		return Owners{}, err
 	}
 
-	if rule == nil {
-		return Owners{}, nil
+	if r.gitserver != nil && r.hasOwnersFiles(ctx) {
+		dir := path.Dir(filePath)
+		for {
+			if f := r.dirOwnersFile(ctx, dir); f != nil {
+				relPath := filePath
+				if dir != "." {
+					relPath = strings.TrimPrefix(filePath, dir+"/")
+				}
+				dirOwners, noParent := f.match(relPath)
+				owners = append(owners, dirOwners...)
+				owners = append(owners, r.includedOwners(ctx, f, 0)...)
+				if noParent {
+					break
+				}
+			}
+			if dir == "." || dir == "/" {
+				break
+			}
+			dir = path.Dir(dir)
+		}
 	}
 
-	// We directly return the codeowners.Owner struct to avoid creating
-	// unnecessary copies. We also found that the longest list of owners
-	// is less than 50.
-	// c.f. https://github.com/sourcegraph/sourcegraph/pull/39250#discussion_r927942090
-	return rule.Owners, nil
+	return dedupeOwners(owners), nil
 }
 
-func NewRuleset(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID) (Ruleset, error) {
-	ruleset := Ruleset{}
+// includedOwners returns the owners of the OWNERS files included by f.
+func (r *Ruleset) includedOwners(ctx context.Context, f *ownersFile, depth int) (owners Owners) {
+	if depth >= maxOwnersIncludeDepth {
+		return nil
+	}
+	for _, include := range f.includes {
+		if included := r.ownersFile(ctx, include); included != nil {
+			owners = append(owners, included.owners...)
+			owners = append(owners, r.includedOwners(ctx, included, depth+1)...)
+		}
+	}
+	return owners
+}
 
-	content, err := loadOwnershipFile(ctx, gitserver, repoName, commitID)
-	if err != nil {
-		return ruleset, err
+// hasOwnersFiles reports whether the repository may have OWNERS files. If there is no
+// root OWNERS file, the repository is listed once to find the OWNERS files in other
+// directories, so that repositories without any don't read one for every directory.
+func (r *Ruleset) hasOwnersFiles(ctx context.Context) bool {
+	r.ownersPathsOnce.Do(func() {
+		if r.ownersFile(ctx, "OWNERS") != nil {
+			return
+		}
+
+		files, err := r.gitserver.LsFiles(ctx, authz.DefaultSubRepoPermsChecker, r.repoName, r.commitID, gitdomain.PathspecSuffix("/OWNERS"))
+		if err != nil {
+			return
+		}
+		r.ownersPaths = make(map[string]struct{}, len(files))
+		for _, file := range files {
+			if path.Base(file) == "OWNERS" {
+				r.ownersPaths[file] = struct{}{}
+			}
+		}
+	})
+	return r.ownersPaths == nil || len(r.ownersPaths) > 0
+}
+
+// dirOwnersFile returns the OWNERS file of the given directory, or nil if it has none.
+func (r *Ruleset) dirOwnersFile(ctx context.Context, dir string) *ownersFile {
+	filePath := path.Join(dir, "OWNERS")
+	if r.ownersPaths != nil {
+		if _, ok := r.ownersPaths[filePath]; !ok {
+			return nil
+		}
+	}
+	return r.ownersFile(ctx, filePath)
+}
+
+// ownersFile returns the parsed OWNERS file at the given path, or nil if there is none.
+// Each file is read at most once, even if it is requested concurrently.
+func (r *Ruleset) ownersFile(ctx context.Context, filePath string) *ownersFile {
+	r.mu.Lock()
+	f, ok := r.ownersFiles[filePath]
+	r.mu.Unlock()
+	if ok {
+		return f
+	}
+
+	v, _, _ := r.loads.Do(filePath, func() (any, error) {
+		r.aliasesOnce.Do(func() {
+			if content := readFile(ctx, r.gitserver, r.repoName, r.commitID, "OWNERS_ALIASES"); content != nil {
+				r.aliases = parseOwnersAliases(content)
+			}
+		})
+
+		var f *ownersFile
+		if content := readFile(ctx, r.gitserver, r.repoName, r.commitID, filePath); content != nil {
+			f = parseOwnersFile(filePath, content, r.aliases)
+		}
+
+		r.mu.Lock()
+		if r.ownersFiles == nil {
+			r.ownersFiles = make(map[string]*ownersFile)
+		}
+		r.ownersFiles[filePath] = f
+		r.mu.Unlock()
+		return f, nil
+	})
+	return v.(*ownersFile)
+}
+
+func dedupeOwners(owners Owners) Owners {
+	deduped := owners[:0]
+	seen := make(map[string]struct{}, len(owners))
+	for _, o := range owners {
+		key := strings.ToLower(o.String())
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		deduped = append(deduped, o)
 	}
+	if deduped == nil {
+		return Owners{}
+	}
+	return deduped
+}
+
+func NewRuleset(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID) (*Ruleset, error) {
+	ruleset := &Ruleset{
+		gitserver: gitserver,
+		repoName:  repoName,
+		commitID:  commitID,
+	}
+
+	content := loadOwnershipFile(ctx, gitserver, repoName, commitID)
 	if content == nil {
 		return ruleset, nil
 	}
 
-	codeownersRuleset, err := codeowners.ParseFile(bytes.NewReader(content))
+	codeownersFile, err := parseCodeowners(content)
 	if err != nil {
 		return ruleset, err
 	}
 
-	ruleset.codeownersRuleset = codeownersRuleset
+	ruleset.codeowners = codeownersFile
 
 	return ruleset, nil
 }
 
-func loadOwnershipFile(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID) ([]byte, error) {
+func loadOwnershipFile(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID) []byte {
 	for _, path := range []string{"CODEOWNERS", ".github/CODEOWNERS", ".gitlab/CODEOWNERS", "docs/CODEOWNERS"} {
-		content, err := gitserver.ReadFile(
-			ctx,
-			repoName,
-			commitID,
-			path,
-			authz.DefaultSubRepoPermsChecker,
-		)
-
-		if err == nil && content != nil {
-			return content, nil
+		if content := readFile(ctx, gitserver, repoName, commitID, path); content != nil {
+			return content
 		}
 	}
 
-	return nil, nil
+	return nil
+}
+
+// readFile returns the content of the given file, or nil if it cannot be read.
+func readFile(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID, path string) []byte {
+	content, err := gitserver.ReadFile(
+		ctx,
+		repoName,
+		commitID,
+		path,
+		authz.DefaultSubRepoPermsChecker,
+	)
+	if err != nil {
+		return nil
+	}
+	return content
 }
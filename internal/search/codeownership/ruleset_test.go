@@ -0,0 +1,106 @@
+package codeownership
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+func TestRulesetOwnersFiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		repoContent map[string]string
+		wantOwners  []string
+		wantReads   []string
+	}{
+		{
+			name:        "no OWNERS files",
+			repoContent: map[string]string{},
+			wantOwners:  []string{},
+			wantReads:   []string{"OWNERS", "OWNERS_ALIASES"},
+		},
+		{
+			name: "nested OWNERS files only",
+			repoContent: map[string]string{
+				"cmd/OWNERS": "approvers:\n  - sqs\n",
+			},
+			wantOwners: []string{"@sqs"},
+			wantReads:  []string{"OWNERS", "OWNERS_ALIASES", "cmd/OWNERS"},
+		},
+		{
+			name: "root OWNERS file",
+			repoContent: map[string]string{
+				"OWNERS":     "approvers:\n  - keegan\n",
+				"cmd/OWNERS": "approvers:\n  - sqs\n",
+			},
+			wantOwners: []string{"@sqs", "@keegan"},
+			wantReads:  []string{"OWNERS", "OWNERS_ALIASES", "cmd/OWNERS", "cmd/gitserver/OWNERS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var reads []string
+			gitserver.Mocks.ReadFile = func(_ api.CommitID, file string) ([]byte, error) {
+				mu.Lock()
+				reads = append(reads, file)
+				mu.Unlock()
+				content, ok := tt.repoContent[file]
+				if !ok {
+					return nil, errors.New("file does not exist")
+				}
+				return []byte(content), nil
+			}
+			gitserver.Mocks.LsFiles = func(api.RepoName, api.CommitID) ([]string, error) {
+				files := make([]string, 0, len(tt.repoContent))
+				for file := range tt.repoContent {
+					files = append(files, file)
+				}
+				return files, nil
+			}
+			t.Cleanup(func() {
+				gitserver.Mocks.ReadFile = nil
+				gitserver.Mocks.LsFiles = nil
+			})
+
+			ctx := context.Background()
+			ruleset := &Ruleset{gitserver: gitserver.NewClient(database.NewMockDB()), repoName: "repo", commitID: "deadbeef"}
+
+			// Match concurrently, which reads every OWNERS file only once.
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					owners, err := ruleset.Match(ctx, "cmd/gitserver/main.go")
+					if err != nil {
+						t.Errorf("unexpected error: %s", err)
+						return
+					}
+					got := make([]string, 0, len(owners))
+					for _, o := range owners {
+						got = append(got, o.String())
+					}
+					if diff := cmp.Diff(tt.wantOwners, got); diff != "" {
+						t.Errorf("unexpected owners (-want +got):\n%s", diff)
+					}
+				}()
+			}
+			wg.Wait()
+
+			sort.Strings(reads)
+			if diff := cmp.Diff(tt.wantReads, reads); diff != "" {
+				t.Errorf("unexpected reads (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package codeownership
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewSelectOwnersJob creates a job for select:file.owners, which replaces the
+// file results of its child with the deduplicated owners of those files.
+func NewSelectOwnersJob(child job.Job) job.Job {
+	return &selectOwnersJob{child: child}
+}
+
+type selectOwnersJob struct {
+	child job.Job
+}
+
+func (s *selectOwnersJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	rules := NewRulesCache()
+	dedup := result.NewDeduper()
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var err error
+		event.Results, err = selectOwners(ctx, clients.Gitserver, &rules, &dedup, event.Results)
+		if err != nil {
+			errs = errors.Append(errs, err)
+		}
+		stream.Send(event)
+	})
+
+	alert, err = s.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (s *selectOwnersJob) Name() string {
+	return "SelectOwnersJob"
+}
+
+func (s *selectOwnersJob) Fields(job.Verbosity) []otlog.Field { return nil }
+
+func (s *selectOwnersJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *selectOwnersJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
+
+func selectOwners(
+	ctx context.Context,
+	gitserver gitserver.Client,
+	rules *RulesCache,
+	dedup *result.Deduper,
+	matches []result.Match,
+) ([]result.Match, error) {
+	var errs error
+
+	checker := authz.DefaultSubRepoPermsChecker
+	a := actor.FromContext(ctx)
+
+	var selected []result.Match
+	for _, m := range matches {
+		// Code ownership is currently only implemented for files.
+		mm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		// 🚨 SECURITY: Owner matches no longer carry the path of the file they
+		// were selected from, so sub-repo permissions must be checked here.
+		if authz.SubRepoEnabled(checker) {
+			perms, err := authz.ActorPermissions(ctx, checker, a, authz.RepoContent{Repo: mm.Repo.Name, Path: mm.Path})
+			if err != nil {
+				errs = errors.Append(errs, err)
+				continue
+			}
+			if !perms.Include(authz.Read) {
+				continue
+			}
+		}
+
+		ruleset, err := rules.GetFromCacheOrFetch(ctx, gitserver, mm.Repo.Name, mm.CommitID)
+		if err != nil {
+			errs = errors.Append(errs, err)
+		}
+
+		owners, err := ruleset.Match(ctx, mm.File.Path)
+		if err != nil {
+			errs = errors.Append(errs, err)
+		}
+
+		for _, owner := range owners {
+			om := &result.OwnerMatch{
+				Handle: owner.String(),
+				Type:   owner.Type,
+				Repo:   mm.Repo,
+			}
+			if dedup.Seen(om) {
+				continue
+			}
+			dedup.Add(om)
+			selected = append(selected, om)
+		}
+	}
+
+	return selected, errs
+}
@@ -0,0 +1,89 @@
+package codeownership
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func Test_selectOwners(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	tests := []struct {
+		name        string
+		matches     []result.Match
+		repoContent map[string]string
+		want        autogold.Value
+	}{
+		{
+			name: "no code owners file",
+			matches: []result.Match{
+				&result.FileMatch{File: result.File{Repo: repo, Path: "README.md"}},
+			},
+			want: autogold.Want("no owners", []result.Match(nil)),
+		},
+		{
+			name: "deduplicates owners across files",
+			matches: []result.Match{
+				&result.FileMatch{File: result.File{Repo: repo, Path: "README.md"}},
+				&result.FileMatch{File: result.File{Repo: repo, Path: "docs/index.md"}},
+				&result.RepoMatch{Name: repo.Name, ID: repo.ID},
+			},
+			repoContent: map[string]string{
+				"CODEOWNERS": "*.md @sqs\n/docs/ @sourcegraph/docs\n",
+			},
+			want: autogold.Want("deduplicated owners", []result.Match{
+				&result.OwnerMatch{
+					Handle: "@sqs",
+					Type:   "username",
+					Repo:   types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"},
+				},
+				&result.OwnerMatch{
+					Handle: "@sourcegraph/docs",
+					Type:   "team",
+					Repo:   types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"},
+				},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := database.NewMockDB()
+			rules := NewRulesCache()
+			dedup := result.NewDeduper()
+
+			gitserver.Mocks.ReadFile = func(_ api.CommitID, file string) ([]byte, error) {
+				content, ok := tt.repoContent[file]
+				if !ok {
+					return nil, errors.New("file does not exist")
+				}
+				return []byte(content), nil
+			}
+			gitserver.Mocks.LsFiles = func(api.RepoName, api.CommitID) ([]string, error) {
+				files := make([]string, 0, len(tt.repoContent))
+				for file := range tt.repoContent {
+					files = append(files, file)
+				}
+				return files, nil
+			}
+			t.Cleanup(func() {
+				gitserver.Mocks.ReadFile = nil
+				gitserver.Mocks.LsFiles = nil
+			})
+
+			matches, _ := selectOwners(ctx, gitserver.NewClient(db), &rules, &dedup, tt.matches)
+
+			tt.want.Equal(t, matches)
+		})
+	}
+}
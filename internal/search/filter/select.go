@@ -42,6 +42,7 @@ var validSelectors = object{
 	Content: nil,
 	File: {
 		"directory": nil,
+		"owners":    nil,
 		"path":      nil,
 	},
 	Repository: nil,
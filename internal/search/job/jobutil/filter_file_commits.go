@@ -0,0 +1,202 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewFileCommitFilterJob creates a filter job to post-filter results for the
+// file:has.commit.after() and file:modified.by() predicates.
+//
+// A file result is kept only if, for every included time reference, the file
+// was modified by a commit after that time and, for every included author, the
+// file was modified by a commit of that author. Negated predicates exclude the
+// file if it was modified after that time or by that author. Both are
+// evaluated against the history of the file up to the commit the result was
+// found at. Results that are not file results are filtered out.
+func NewFileCommitFilterJob(includeCommitAfter, excludeCommitAfter, includeAuthors, excludeAuthors []string, child job.Job) job.Job {
+	return &fileCommitFilterJob{
+		includeCommitAfter: includeCommitAfter,
+		excludeCommitAfter: excludeCommitAfter,
+		includeAuthors:     includeAuthors,
+		excludeAuthors:     excludeAuthors,
+		child:              child,
+	}
+}
+
+// maxConcurrentFileCommitChecks bounds the number of files that a
+// fileCommitFilterJob checks concurrently, and so the number of concurrent
+// gitserver requests it makes.
+const maxConcurrentFileCommitChecks = 8
+
+type fileCommitFilterJob struct {
+	// Time references specified by file:has.commit.after()
+	includeCommitAfter []string
+	excludeCommitAfter []string
+
+	// Authors specified by file:modified.by()
+	includeAuthors []string
+	excludeAuthors []string
+
+	child job.Job
+}
+
+func (j *fileCommitFilterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	// Shared by all events, because the child job may send them concurrently.
+	sem := make(chan struct{}, maxConcurrentFileCommitChecks)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var err error
+		event.Results, err = j.filterMatches(ctx, clients.Gitserver, sem, event.Results)
+		if err != nil {
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+		}
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (j *fileCommitFilterJob) filterMatches(ctx context.Context, gs gitserver.Client, sem chan struct{}, matches []result.Match) ([]result.Match, error) {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs error
+	)
+
+	keep := make([]bool, len(matches))
+	for i, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		i := i
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			ok, err := j.matchesFile(ctx, gs, fm)
+			if err != nil {
+				mu.Lock()
+				errs = errors.Append(errs, err)
+				mu.Unlock()
+				return
+			}
+			keep[i] = ok
+		}()
+	}
+	wg.Wait()
+
+	filtered := matches[:0]
+	for i, m := range matches {
+		if keep[i] {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered, errs
+}
+
+func (j *fileCommitFilterJob) matchesFile(ctx context.Context, gs gitserver.Client, fm *result.FileMatch) (bool, error) {
+	rev := string(fm.CommitID)
+	if rev == "" {
+		rev = "HEAD"
+	}
+
+	type check struct {
+		opt  gitserver.CommitsOptions
+		want bool
+	}
+	checks := make([]check, 0, len(j.includeCommitAfter)+len(j.excludeCommitAfter)+len(j.includeAuthors)+len(j.excludeAuthors))
+	for _, after := range j.includeCommitAfter {
+		checks = append(checks, check{opt: gitserver.CommitsOptions{After: after}, want: true})
+	}
+	for _, after := range j.excludeCommitAfter {
+		checks = append(checks, check{opt: gitserver.CommitsOptions{After: after}, want: false})
+	}
+	for _, author := range j.includeAuthors {
+		checks = append(checks, check{opt: gitserver.CommitsOptions{Author: author}, want: true})
+	}
+	for _, author := range j.excludeAuthors {
+		checks = append(checks, check{opt: gitserver.CommitsOptions{Author: author}, want: false})
+	}
+
+	for _, c := range checks {
+		c.opt.Range = rev
+		c.opt.Path = fm.Path
+		c.opt.N = 1
+		ok, err := hasFileCommit(ctx, gs, fm.Repo.Name, c.opt)
+		if err != nil || ok != c.want {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func hasFileCommit(ctx context.Context, gs gitserver.Client, repo api.RepoName, opt gitserver.CommitsOptions) (bool, error) {
+	commits, err := gs.Commits(ctx, repo, opt, authz.DefaultSubRepoPermsChecker)
+	if err != nil {
+		return false, err
+	}
+	return len(commits) > 0, nil
+}
+
+func (j *fileCommitFilterJob) MapChildren(f job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, f)
+	return &cp
+}
+
+func (j *fileCommitFilterJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileCommitFilterJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Strings("includeCommitAfter", j.includeCommitAfter),
+			trace.Strings("excludeCommitAfter", j.excludeCommitAfter),
+			trace.Strings("includeAuthors", j.includeAuthors),
+			trace.Strings("excludeAuthors", j.excludeAuthors),
+		)
+	}
+	return res
+}
+
+func (j *fileCommitFilterJob) Name() string {
+	return "FileCommitFilterJob"
+}
@@ -0,0 +1,163 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestFileCommitFilterJob(t *testing.T) {
+	fm := func(path string) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{Name: "github.com/sourcegraph/sourcegraph"},
+				CommitID: "deadbeef",
+				Path:     path,
+			},
+		}
+	}
+
+	// Each file was last modified by the given author at a time after the
+	// given time references.
+	history := map[string]struct {
+		author string
+		after  []string
+	}{
+		"README.md":  {author: "alice", after: []string{"1 year ago", "2 weeks ago"}},
+		"main.go":    {author: "bob", after: []string{"1 year ago"}},
+		"CHANGELOG":  {author: "alice", after: []string{}},
+		"errored.go": {},
+	}
+
+	gs := gitserver.NewMockClient()
+	gs.CommitsFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, opt gitserver.CommitsOptions, _ authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error) {
+		require.Equal(t, api.RepoName("github.com/sourcegraph/sourcegraph"), repo)
+		require.Equal(t, "deadbeef", opt.Range)
+		require.Equal(t, uint(1), opt.N)
+
+		if opt.Path == "errored.go" {
+			return nil, errors.New("boom")
+		}
+
+		h := history[opt.Path]
+		if opt.Author != "" && opt.Author != h.author {
+			return nil, nil
+		}
+		if opt.After != "" {
+			found := false
+			for _, after := range h.after {
+				found = found || after == opt.After
+			}
+			if !found {
+				return nil, nil
+			}
+		}
+		return []*gitdomain.Commit{{ID: "deadbeef"}}, nil
+	})
+
+	cases := []struct {
+		name               string
+		includeCommitAfter []string
+		excludeCommitAfter []string
+		includeAuthors     []string
+		excludeAuthors     []string
+		want               []string
+	}{{
+		name:           "modified by",
+		includeAuthors: []string{"alice"},
+		want:           []string{"README.md", "CHANGELOG"},
+	}, {
+		name:               "has commit after",
+		includeCommitAfter: []string{"1 year ago"},
+		want:               []string{"README.md", "main.go"},
+	}, {
+		name:               "both",
+		includeCommitAfter: []string{"2 weeks ago"},
+		includeAuthors:     []string{"alice"},
+		want:               []string{"README.md"},
+	}, {
+		name:           "no match",
+		includeAuthors: []string{"carol"},
+		want:           []string{},
+	}, {
+		name:           "not modified by",
+		excludeAuthors: []string{"alice"},
+		want:           []string{"main.go"},
+	}, {
+		name:               "no commit after",
+		excludeCommitAfter: []string{"2 weeks ago"},
+		want:               []string{"main.go", "CHANGELOG"},
+	}, {
+		name:               "included and excluded",
+		includeCommitAfter: []string{"1 year ago"},
+		excludeAuthors:     []string{"bob"},
+		want:               []string{"README.md"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			childJob := mockjob.NewMockJob()
+			childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{
+					Results: result.Matches{
+						fm("README.md"),
+						fm("main.go"),
+						fm("CHANGELOG"),
+						&result.RepoMatch{Name: "github.com/sourcegraph/sourcegraph"},
+					},
+				})
+				return nil, nil
+			})
+
+			var got []string
+			streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+				for _, m := range ev.Results {
+					got = append(got, m.(*result.FileMatch).Path)
+				}
+			})
+
+			j := NewFileCommitFilterJob(tc.includeCommitAfter, tc.excludeCommitAfter, tc.includeAuthors, tc.excludeAuthors, childJob)
+			alert, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gs}, streamCollector)
+			require.Nil(t, alert)
+			require.NoError(t, err)
+			if len(tc.want) == 0 {
+				require.Empty(t, got)
+			} else {
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+
+	t.Run("errors drop the file", func(t *testing.T) {
+		childJob := mockjob.NewMockJob()
+		childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: result.Matches{fm("errored.go"), fm("README.md")}})
+			return nil, nil
+		})
+
+		var got []string
+		streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+			for _, m := range ev.Results {
+				got = append(got, m.(*result.FileMatch).Path)
+			}
+		})
+
+		j := NewFileCommitFilterJob(nil, nil, []string{"alice"}, nil, childJob)
+		_, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gs}, streamCollector)
+		require.Error(t, err)
+		require.Equal(t, []string{"README.md"}, got)
+	})
+}
@@ -188,10 +188,25 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.commit.after() and file:modified.by() post-search filter
+		includeCommitAfter, excludeCommitAfter := b.FileHasCommitAfter()
+		includeAuthors, excludeAuthors := b.FileModifiedBy()
+		if len(includeCommitAfter) > 0 || len(excludeCommitAfter) > 0 || len(includeAuthors) > 0 || len(excludeAuthors) > 0 {
+			basicJob = NewFileCommitFilterJob(includeCommitAfter, excludeCommitAfter, includeAuthors, excludeAuthors, basicJob)
+		}
+	}
+
 	{ // Apply selectors
 		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
-			basicJob = NewSelectJob(sp, basicJob)
+			if isSelectOwners(sp) {
+				if !inputs.Features.CodeOwnershipFilters {
+					return nil, errors.New("select:file.owners requires the code-ownership search feature to be enabled")
+				}
+				basicJob = codeownershipjob.NewSelectOwnersJob(basicJob)
+			} else {
+				basicJob = NewSelectJob(sp, basicJob)
+			}
 		}
 	}
 
@@ -233,6 +248,10 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 	return basicJob, nil
 }
 
+func isSelectOwners(sp filter.SelectPath) bool {
+	return sp.Root() == filter.File && len(sp) > 1 && sp[1] == "owners"
+}
+
 // orderSearcherJob ensures that, if a searcher job exists, then it is only ever
 // run sequentially after a Zoekt search has returned all its results.
 func orderSearcherJob(j job.Job) job.Job {
@@ -624,6 +624,32 @@ func TestNewPlanJob(t *testing.T) {
           (repoOpts.hasKVPs[0].key . tag))
         (REPOSEARCH
           (repoOpts.hasKVPs[0].key . tag))))))`),
+		}, {
+			query:      `foo file:modified.by(alice) file:has.commit.after(2 weeks ago)`,
+			protocol:   search.Streaming,
+			searchType: query.SearchTypeLiteral,
+			want: autogold.Want("file modified by and has commit after", `
+(ALERT
+  (query . )
+  (originalQuery . )
+  (patternType . literal)
+  (TIMEOUT
+    (timeout . 20s)
+    (LIMIT
+      (limit . 500)
+      (FILECOMMITFILTER
+        (includeCommitAfter.0 . 2 weeks ago)
+
+        (includeAuthors.0 . alice)
+
+        (PARALLEL
+          (ZOEKTGLOBALTEXTSEARCH
+            (query . substr:"foo")
+            (type . text)
+            )
+          (REPOSCOMPUTEEXCLUDED
+            )
+          NoopJob)))))`),
 		},
 	}
 
@@ -648,6 +674,32 @@ func TestNewPlanJob(t *testing.T) {
 	}
 }
 
+func TestNewPlanJob_SelectOwners(t *testing.T) {
+	plan, err := query.Pipeline(query.Init(`foo select:file.owners`, query.SearchTypeLiteral))
+	require.NoError(t, err)
+
+	newPlanJob := func(features *search.Features) (job.Job, error) {
+		return NewPlanJob(&search.Inputs{
+			UserSettings:        &schema.Settings{},
+			PatternType:         query.SearchTypeLiteral,
+			Protocol:            search.Streaming,
+			Features:            features,
+			OnSourcegraphDotCom: true,
+		}, plan)
+	}
+
+	t.Run("code ownership disabled", func(t *testing.T) {
+		_, err := newPlanJob(&search.Features{})
+		require.Error(t, err)
+	})
+
+	t.Run("code ownership enabled", func(t *testing.T) {
+		j, err := newPlanJob(&search.Features{CodeOwnershipFilters: true})
+		require.NoError(t, err)
+		require.Contains(t, printer.SexpPretty(j), "SELECTOWNERS")
+	})
+}
+
 func TestToEvaluateJob(t *testing.T) {
 	test := func(input string, protocol search.Protocol) string {
 		q, _ := query.ParseLiteral(input)
@@ -117,6 +117,10 @@ func applySubRepoFiltering(ctx context.Context, logger log.Logger, checker authz
 		case *result.RepoMatch:
 			// Repo filtering is taking care of by our usual repo filtering logic
 			filtered = append(filtered, m)
+		case *result.OwnerMatch:
+			// Owners are only selected from files the actor can read, cf.
+			// codeownership.NewSelectOwnersJob.
+			filtered = append(filtered, m)
 		}
 
 	}
@@ -441,7 +441,7 @@ func ScanPredicateName(buf []byte, lookup PredicateTable) (string, int, bool) {
 			break
 		}
 
-		if !(unicode.IsLetter(r) || r == '.') {
+		if !(unicode.IsLetter(r) || r == '.' || r == '-') {
 			predicateName = string(buf[:advance])
 			break
 		}
@@ -256,6 +256,36 @@ func TestScanPredicate(t *testing.T) {
 		Result:       `{"field":"r","value":"has.tag(tag)","negated":false}`,
 		ResultLabels: "IsPredicate",
 	}).Equal(t, test(`r:has.tag(tag)`))
+
+	autogold.Want("Repo has tag with hyphen", value{
+		Result:       `{"field":"r","value":"has.tag(my-tag)","negated":false}`,
+		ResultLabels: "IsPredicate",
+	}).Equal(t, test(`r:has.tag(my-tag)`))
+
+	autogold.Want("Repo contains file with hyphenated path", value{
+		Result:       `{"field":"repo","value":"contains.file(path:my-file.go)","negated":false}`,
+		ResultLabels: "IsPredicate",
+	}).Equal(t, test(`repo:contains.file(path:my-file.go)`))
+
+	autogold.Want("Hyphenated repo that looks like predicate", value{
+		Result:       `{"field":"repo","value":"has-tag(tag)","negated":false}`,
+		ResultLabels: "None",
+	}).Equal(t, test(`repo:has-tag(tag)`))
+
+	autogold.Want("Predicate name followed by hyphen", value{
+		Result:       `{"field":"repo","value":"contains.file-foo(bar)","negated":false}`,
+		ResultLabels: "None",
+	}).Equal(t, test(`repo:contains.file-foo(bar)`))
+
+	autogold.Want("Hyphenated repo", value{
+		Result:       `{"field":"repo","value":"sourcegraph/go-diff","negated":false}`,
+		ResultLabels: "None",
+	}).Equal(t, test(`repo:sourcegraph/go-diff`))
+
+	autogold.Want("Hyphenated pattern that looks like predicate", value{
+		Result:       `{"value":"foo-bar(baz)","negated":false}`,
+		ResultLabels: "Regexp",
+	}).Equal(t, test(`foo-bar(baz)`))
 }
 
 func TestScanField(t *testing.T) {
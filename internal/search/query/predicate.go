@@ -36,6 +36,8 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
 		"contains":         func() Predicate { return &FileContainsContentPredicate{} },
 		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
+		"has.commit.after": func() Predicate { return &FileHasCommitAfterPredicate{} },
+		"modified.by":      func() Predicate { return &FileModifiedByPredicate{} },
 	},
 }
 
@@ -60,7 +62,7 @@ func (pr PredicateRegistry) Get(field, name string) Predicate {
 }
 
 var (
-	predicateRegexp = regexp.MustCompile(`^(?P<name>[a-z\.]+)\((?s:(?P<params>.*))\)$`)
+	predicateRegexp = regexp.MustCompile(`^(?P<name>[a-z\.-]+)\((?s:(?P<params>.*))\)$`)
 	nameIndex       = predicateRegexp.SubexpIndex("name")
 	paramsIndex     = predicateRegexp.SubexpIndex("params")
 )
@@ -302,3 +304,37 @@ func (f *FileHasOwnerPredicate) ParseParams(params string) error {
 
 func (f FileHasOwnerPredicate) Field() string { return FieldFile }
 func (f FileHasOwnerPredicate) Name() string  { return "has.owner" }
+
+/* file:has.commit.after(...) */
+
+type FileHasCommitAfterPredicate struct {
+	TimeRef string
+}
+
+func (f *FileHasCommitAfterPredicate) ParseParams(params string) error {
+	if params == "" {
+		return errors.Errorf("file:has.commit.after argument should not be empty")
+	}
+	f.TimeRef = params
+	return nil
+}
+
+func (f FileHasCommitAfterPredicate) Field() string { return FieldFile }
+func (f FileHasCommitAfterPredicate) Name() string  { return "has.commit.after" }
+
+/* file:modified.by(author) */
+
+type FileModifiedByPredicate struct {
+	Author string
+}
+
+func (f *FileModifiedByPredicate) ParseParams(params string) error {
+	if params == "" {
+		return errors.Errorf("file:modified.by argument should not be empty")
+	}
+	f.Author = params
+	return nil
+}
+
+func (f FileModifiedByPredicate) Field() string { return FieldFile }
+func (f FileModifiedByPredicate) Name() string  { return "modified.by" }
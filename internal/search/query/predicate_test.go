@@ -64,6 +64,8 @@ func TestParseAsPredicate(t *testing.T) {
 	}{
 		{`a()`, "a", ""},
 		{`a(b)`, "a", "b"},
+		{`a.b(c-d)`, "a.b", "c-d"},
+		{`a-b(c)`, "a-b", "c"},
 	}
 
 	for _, tc := range tests {
@@ -168,3 +170,35 @@ func TestFileHasOwnerPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestFileHasCommitAfterPredicate(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		p := &FileHasCommitAfterPredicate{}
+		if err := p.ParseParams("2 weeks ago"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := (&FileHasCommitAfterPredicate{TimeRef: "2 weeks ago"}); !reflect.DeepEqual(want, p) {
+			t.Fatalf("expected %#v, got %#v", want, p)
+		}
+
+		if err := (&FileHasCommitAfterPredicate{}).ParseParams(""); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
+
+func TestFileModifiedByPredicate(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		p := &FileModifiedByPredicate{}
+		if err := p.ParseParams("alice@example.com"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := (&FileModifiedByPredicate{Author: "alice@example.com"}); !reflect.DeepEqual(want, p) {
+			t.Fatalf("expected %#v, got %#v", want, p)
+		}
+
+		if err := (&FileModifiedByPredicate{}).ParseParams(""); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
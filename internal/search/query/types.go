@@ -447,6 +447,28 @@ func (p Parameters) FileHasOwner() (include, exclude []string) {
 	return include, exclude
 }
 
+func (p Parameters) FileHasCommitAfter() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasCommitAfterPredicate, negated bool) {
+		if negated {
+			exclude = append(exclude, pred.TimeRef)
+		} else {
+			include = append(include, pred.TimeRef)
+		}
+	})
+	return include, exclude
+}
+
+func (p Parameters) FileModifiedBy() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileModifiedByPredicate, negated bool) {
+		if negated {
+			exclude = append(exclude, pred.Author)
+		} else {
+			include = append(include, pred.Author)
+		}
+	})
+	return include, exclude
+}
+
 // Exists returns whether a parameter exists in the query (whether negated or not).
 func (p Parameters) Exists(field string) bool {
 	found := false
@@ -78,6 +78,10 @@ func (fm *FileMatch) Select(selectPath filter.SelectPath) Match {
 			ID:   fm.Repo.ID,
 		}
 	case filter.File:
+		if len(selectPath) > 1 && selectPath[1] == "owners" {
+			// Owners are resolved by a dedicated job, cf. codeownership.NewSelectOwnersJob.
+			return nil
+		}
 		fm.ChunkMatches = nil
 		fm.Symbols = nil
 		if len(selectPath) > 1 && selectPath[1] == "directory" {
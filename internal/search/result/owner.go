@@ -0,0 +1,49 @@
+package result
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is a code owner of files matching a search, as selected by
+// select:file.owners. Owner matches are deduplicated across repositories.
+type OwnerMatch struct {
+	// Handle is the owner as it is written in ownership files, e.g.
+	// "@username", "@org/team" or an email address.
+	Handle string
+
+	// Type is one of "username", "team" or "email".
+	Type string
+
+	// Repo is the repository of the first file the owner was found for.
+	Repo types.MinimalRepo
+}
+
+func (o *OwnerMatch) RepoName() types.MinimalRepo {
+	return o.Repo
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	if path.Root() == filter.File && len(path) > 1 && path[1] == "owners" {
+		return o
+	}
+	return nil
+}
+
+func (o *OwnerMatch) Key() Key {
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Owner:    o.Handle,
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
@@ -47,6 +47,10 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.TokenOauthRefresh, "token.oauth.refresh")
 	case *schema.GerritConnection:
 		es.redactString(c.Password, "password")
+	case *schema.AzureDevOpsConnection:
+		es.redactString(c.Token, "token")
+	case *schema.GiteaConnection:
+		es.redactString(c.Token, "token")
 	case *schema.BitbucketServerConnection:
 		es.redactString(c.Password, "password")
 		es.redactString(c.Token, "token")
@@ -59,6 +63,10 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.Token, "token")
 	case *schema.PerforceConnection:
 		es.redactString(c.P4Passwd, "p4.passwd")
+	case *schema.SubversionConnection:
+		es.redactString(c.Password, "password")
+	case *schema.MercurialConnection:
+		es.redactString(c.Password, "password")
 	case *schema.GitoliteConnection:
 		// Nothing to redact
 	case *schema.GoModulesConnection:
@@ -75,6 +83,18 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 				return "", err
 			}
 		}
+	case *schema.NuGetPackagesConnection:
+		for i := range c.Urls {
+			err = es.redactURL(c.Urls[i], "urls", i)
+			if err != nil {
+				return "", err
+			}
+		}
+	case *schema.RubyPackagesConnection:
+		err = es.redactURL(c.Repository, "repository")
+		if err != nil {
+			return "", err
+		}
 	case *schema.RustPackagesConnection:
 		// Nothing to redact
 	case *schema.JVMPackagesConnection:
@@ -147,6 +167,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 		o := oldCfg.(*schema.BitbucketServerConnection)
 		es.unredactString(c.Password, o.Password, "password")
 		es.unredactString(c.Token, o.Token, "token")
+	case *schema.AzureDevOpsConnection:
+		o := oldCfg.(*schema.AzureDevOpsConnection)
+		es.unredactString(c.Token, o.Token, "token")
+	case *schema.GiteaConnection:
+		o := oldCfg.(*schema.GiteaConnection)
+		es.unredactString(c.Token, o.Token, "token")
 	case *schema.BitbucketCloudConnection:
 		o := oldCfg.(*schema.BitbucketCloudConnection)
 		es.unredactString(c.AppPassword, o.AppPassword, "appPassword")
@@ -160,6 +186,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 	case *schema.PerforceConnection:
 		o := oldCfg.(*schema.PerforceConnection)
 		es.unredactString(c.P4Passwd, o.P4Passwd, "p4.passwd")
+	case *schema.SubversionConnection:
+		o := oldCfg.(*schema.SubversionConnection)
+		es.unredactString(c.Password, o.Password, "password")
+	case *schema.MercurialConnection:
+		o := oldCfg.(*schema.MercurialConnection)
+		es.unredactString(c.Password, o.Password, "password")
 	case *schema.GitoliteConnection:
 		// Nothing to redact
 	case *schema.GoModulesConnection:
@@ -172,6 +204,16 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 		if err != nil {
 			return err
 		}
+	case *schema.NuGetPackagesConnection:
+		err = es.unredactURLs(c.Urls, oldCfg.(*schema.NuGetPackagesConnection).Urls)
+		if err != nil {
+			return err
+		}
+	case *schema.RubyPackagesConnection:
+		err = es.unredactURL(c.Repository, oldCfg.(*schema.RubyPackagesConnection).Repository, "repository")
+		if err != nil {
+			return err
+		}
 	case *schema.RustPackagesConnection:
 		// Nothing to unredact
 	case *schema.JVMPackagesConnection:
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS cm_content_snapshots;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS cm_content_snapshots (
+    monitor_id BIGINT NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    repo_id INTEGER NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    revision TEXT NOT NULL,
+    commit_oid TEXT NOT NULL,
+    matches JSONB NOT NULL,
+    PRIMARY KEY (monitor_id, repo_id, revision)
+);
+
+COMMENT ON TABLE cm_content_snapshots
+    IS 'The lines and symbols matched by a content code monitor in a repository at a revision the last time it was searched';
+COMMENT ON COLUMN cm_content_snapshots.revision
+    IS 'The revision as requested by the query, or the empty string for the default branch';
+COMMENT ON COLUMN cm_content_snapshots.commit_oid
+    IS 'The commit the revision resolved to when the matches were recorded';
+COMMENT ON COLUMN cm_content_snapshots.matches
+    IS 'The matched lines and symbols, which are compared with the matches of the next run to find new and removed matches';
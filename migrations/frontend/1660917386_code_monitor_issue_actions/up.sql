@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS cm_issue_actions (
+    id BIGSERIAL PRIMARY KEY,
+    monitor BIGINT NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    repo_id INTEGER NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL,
+    include_results BOOLEAN NOT NULL DEFAULT FALSE,
+    issue_number BIGINT,
+    created_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    changed_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS cm_issue_actions_monitor ON cm_issue_actions (monitor);
+
+COMMENT ON TABLE cm_issue_actions IS 'Code host issue actions configured on code monitors';
+COMMENT ON COLUMN cm_issue_actions.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_issue_actions.repo_id IS 'The GitHub or GitLab repository the issue is opened in';
+COMMENT ON COLUMN cm_issue_actions.issue_number IS 'The number of the last issue opened by this action. While that issue is open, new events are added to it as comments';
+
+ALTER TABLE cm_action_jobs ADD COLUMN IF NOT EXISTS issue_action BIGINT REFERENCES cm_issue_actions(id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN cm_action_jobs.issue_action IS 'The ID of the cm_issue_actions action to execute if this is an issue job. Mutually exclusive with email, webhook, slack_webhook and teams_webhook';
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((
+    CASE WHEN email IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN teams_webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN issue_action IS NULL THEN 0 ELSE 1 END
+) = 1);
+
+COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
@@ -0,0 +1,17 @@
+ALTER TYPE feature_flag_type ADD VALUE IF NOT EXISTS 'rules';
+
+ALTER TABLE feature_flags ADD COLUMN IF NOT EXISTS rules jsonb;
+
+COMMENT ON COLUMN feature_flags.rules IS 'Ordered targeting rules and default value only defined when flag_type is rules';
+
+-- The new enum value cannot be used before this transaction commits, so the
+-- constraint compares the type as text.
+ALTER TABLE feature_flags DROP CONSTRAINT IF EXISTS required_rules_fields;
+ALTER TABLE feature_flags ADD CONSTRAINT required_rules_fields CHECK (1 =
+CASE
+    WHEN flag_type::text = 'rules' AND rules IS NULL THEN 0
+    WHEN flag_type::text <> 'rules' AND rules IS NOT NULL THEN 0
+    ELSE 1
+END);
+
+COMMENT ON CONSTRAINT required_rules_fields ON feature_flags IS 'Checks that rules is set IFF flag_type = rules';
@@ -6,6 +6,10 @@ import _ "embed"
 //go:embed aws_codecommit.schema.json
 var AWSCodeCommitSchemaJSON string
 
+// AzureDevOpsSchemaJSON is the content of the file "azuredevops.schema.json".
+//go:embed azuredevops.schema.json
+var AzureDevOpsSchemaJSON string
+
 // BatchSpecSchemaJSON is the content of the file "batch_spec.schema.json".
 //go:embed batch_spec.schema.json
 var BatchSpecSchemaJSON string
@@ -26,6 +30,10 @@ var ChangesetSpecSchemaJSON string
 //go:embed gerrit.schema.json
 var GerritSchemaJSON string
 
+// GiteaSchemaJSON is the content of the file "gitea.schema.json".
+//go:embed gitea.schema.json
+var GiteaSchemaJSON string
+
 // GitHubSchemaJSON is the content of the file "github.schema.json".
 //go:embed github.schema.json
 var GitHubSchemaJSON string
@@ -46,14 +54,26 @@ var GoModulesSchemaJSON string
 //go:embed jvm-packages.schema.json
 var JVMPackagesSchemaJSON string
 
+// MercurialSchemaJSON is the content of the file "mercurial.schema.json".
+//go:embed mercurial.schema.json
+var MercurialSchemaJSON string
+
 // NpmPackagesSchemaJSON is the content of the file "npm-packages.schema.json".
 //go:embed npm-packages.schema.json
 var NpmPackagesSchemaJSON string
 
+// NuGetPackagesSchemaJSON is the content of the file "nuget-packages.schema.json".
+//go:embed nuget-packages.schema.json
+var NuGetPackagesSchemaJSON string
+
 // PythonPackagesSchemaJSON is the content of the file "python-packages.schema.json".
 //go:embed python-packages.schema.json
 var PythonPackagesSchemaJSON string
 
+// RubyPackagesSchemaJSON is the content of the file "ruby-packages.schema.json".
+//go:embed ruby-packages.schema.json
+var RubyPackagesSchemaJSON string
+
 // RustPackagesSchemaJSON is the content of the file "python-packages.schema.json".
 //go:embed rust-packages.schema.json
 var RustPackagesSchemaJSON string
@@ -81,3 +101,7 @@ var SettingsSchemaJSON string
 // SiteSchemaJSON is the content of the file "site.schema.json".
 //go:embed site.schema.json
 var SiteSchemaJSON string
+
+// SubversionSchemaJSON is the content of the file "subversion.schema.json".
+//go:embed subversion.schema.json
+var SubversionSchemaJSON string